@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var historyReplayDeviceID string
+
+// historyReplayCmd re-ingests the history-*.json dumps written by history sync into chat storage
+var historyReplayCmd = &cobra.Command{
+	Use:   "history-replay",
+	Short: "Replay stored history sync dumps into chat storage",
+	Long:  `Re-ingest the history sync dumps stored under storages/ (history-*.json and history-*.json.gz) into chat storage for one device. Replaying is idempotent, so it is safe to run after a failed sync or a chat storage reset.`,
+	Run:   historyReplay,
+}
+
+func init() {
+	rootCmd.AddCommand(historyReplayCmd)
+	historyReplayCmd.Flags().StringVar(&historyReplayDeviceID, "device", "", "device ID to replay dumps for (defaults to the only/default device)")
+}
+
+func historyReplay(_ *cobra.Command, _ []string) {
+	result, err := deviceUsecase.ReplayHistory(context.Background(), historyReplayDeviceID)
+	if err != nil {
+		logrus.Fatalf("Failed to replay history dumps: %v", err)
+	}
+
+	for _, replayErr := range result.Errors {
+		logrus.Warnf("Replay error: %s", replayErr)
+	}
+	fmt.Printf("Device %s: replayed %d of %d history dump(s), %d failed\n", result.DeviceID, result.Replayed, result.Files, result.Failed)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
@@ -33,6 +34,11 @@ func mcpServer(_ *cobra.Command, _ []string) {
 	// Set auto reconnect checking with a valid client reference
 	startAutoReconnectCheckerIfClientAvailable()
 
+	// Dispatch scheduled messages when they become due, resume running campaigns and process participant jobs
+	go scheduleUsecase.Run(context.Background())
+	go campaignUsecase.Run(context.Background())
+	go groupJobUsecase.Run(context.Background())
+
 	// Create MCP server with capabilities
 	mcpServer := server.NewMCPServer(
 		"WhatsApp Web Multidevice MCP Server",
@@ -54,6 +60,24 @@ func mcpServer(_ *cobra.Command, _ []string) {
 	groupHandler := mcp.InitMcpGroup(groupUsecase)
 	groupHandler.AddGroupTools(mcpServer)
 
+	groupJobHandler := mcp.InitMcpGroupParticipantJob(groupJobUsecase)
+	groupJobHandler.AddGroupParticipantJobTools(mcpServer)
+
+	scheduleHandler := mcp.InitMcpSchedule(scheduleUsecase)
+	scheduleHandler.AddScheduleTools(mcpServer)
+
+	campaignHandler := mcp.InitMcpCampaign(campaignUsecase)
+	campaignHandler.AddCampaignTools(mcpServer)
+
+	templateHandler := mcp.InitMcpTemplate(templateUsecase)
+	templateHandler.AddTemplateTools(mcpServer)
+
+	statusHandler := mcp.InitMcpStatus(statusUsecase)
+	statusHandler.AddStatusTools(mcpServer)
+
+	newsletterHandler := mcp.InitMcpNewsletter(newsletterUsecase)
+	newsletterHandler.AddNewsletterTools(mcpServer)
+
 	// Create SSE server
 	sseServer := server.NewSSEServer(
 		mcpServer,
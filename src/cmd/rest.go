@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
@@ -42,7 +44,11 @@ func restServer(_ *cobra.Command, _ []string) {
 		Views:                   engine,
 		EnableTrustedProxyCheck: true,
 		BodyLimit:               int(config.WhatsappSettingMaxVideoSize),
-		Network:                 "tcp",
+		// Stream request bodies and parse multipart forms from the stream, so uploaded files are spooled to disk
+		// instead of held in memory. Streamed bodies are not capped by BodyLimit, middleware.BodyLimit does that.
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+		Network:                      "tcp",
 	}
 
 	// Configure proxy settings if trusted proxies are specified
@@ -66,6 +72,7 @@ func restServer(_ *cobra.Command, _ []string) {
 	}))
 
 	app.Use(middleware.Recovery())
+	app.Use(middleware.BodyLimit(int64(fiberConfig.BodyLimit)))
 	app.Use(middleware.RequestTimeout(middleware.DefaultRequestTimeout))
 	app.Use(middleware.BasicAuth())
 	if config.AppDebug {
@@ -111,20 +118,37 @@ func restServer(_ *cobra.Command, _ []string) {
 		apiGroup = app.Group(config.AppBasePath)
 	}
 
+	// Idempotency-Key replay for sends and message actions, keyed per device
+	idempotency := middleware.Idempotency(middleware.NewIdempotencyStore(time.Duration(config.AppIdempotencyWindow) * time.Minute))
+
 	registerDeviceScopedRoutes := func(r fiber.Router) {
+		r.Use("/send", idempotency)
+		r.Use("/message", idempotency)
 		rest.InitRestApp(r, appUsecase)
 		rest.InitRestChat(r, chatUsecase)
-		rest.InitRestSend(r, sendUsecase)
+		rest.InitRestSend(r, sendUsecase, jobUsecase)
+		rest.InitRestJob(r, jobUsecase)
 		rest.InitRestUser(r, userUsecase)
 		rest.InitRestMessage(r, messageUsecase)
 		rest.InitRestGroup(r, groupUsecase)
+		rest.InitRestGroupParticipantJob(r, groupJobUsecase)
+		rest.InitRestCommunity(r, groupUsecase)
 		rest.InitRestNewsletter(r, newsletterUsecase)
+		rest.InitRestStatus(r, statusUsecase)
+		rest.InitRestSchedule(r, scheduleUsecase)
+		rest.InitRestCampaign(r, campaignUsecase)
 		websocket.RegisterRoutes(r, appUsecase)
 	}
 
 	// Device management routes (no device_id required)
 	rest.InitRestDevice(apiGroup, deviceUsecase)
 
+	// Message templates are shared across devices
+	rest.InitRestTemplate(apiGroup, templateUsecase)
+
+	// Media transcoding is shared across devices too
+	rest.InitRestAppStats(apiGroup, appUsecase)
+
 	// Device-scoped operations (header-based)
 	headerDeviceGroup := apiGroup.Group("", middleware.DeviceMiddleware(dm))
 	registerDeviceScopedRoutes(headerDeviceGroup)
@@ -149,6 +173,13 @@ func restServer(_ *cobra.Command, _ []string) {
 
 	go websocket.RunHub()
 
+	// Dispatch scheduled messages when they become due, resume running campaigns and process async sends and
+	// participant jobs
+	go scheduleUsecase.Run(context.Background())
+	go campaignUsecase.Run(context.Background())
+	go jobUsecase.Run(context.Background())
+	go groupJobUsecase.Run(context.Background())
+
 	// Set auto reconnect to whatsapp server after booting
 	go helpers.SetAutoConnectAfterBooting(appUsecase)
 
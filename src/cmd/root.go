@@ -13,13 +13,18 @@ import (
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	domainApp "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/app"
+	domainCampaign "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/campaign"
 	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
 	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainDevice "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/device"
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	domainJob "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/job"
 	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
+	domainSchedule "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/schedule"
 	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	domainTemplate "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/template"
 	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/chatstorage"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
@@ -51,8 +56,14 @@ var (
 	userUsecase       domainUser.IUserUsecase
 	messageUsecase    domainMessage.IMessageUsecase
 	groupUsecase      domainGroup.IGroupUsecase
+	groupJobUsecase   domainGroup.IGroupParticipantJobUsecase
 	newsletterUsecase domainNewsletter.INewsletterUsecase
 	deviceUsecase     domainDevice.IDeviceUsecase
+	scheduleUsecase   domainSchedule.IScheduleUsecase
+	campaignUsecase   domainCampaign.ICampaignUsecase
+	templateUsecase   domainTemplate.ITemplateUsecase
+	jobUsecase        domainJob.IJobUsecase
+	statusUsecase     domainStatus.IStatusUsecase
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -104,6 +115,9 @@ func initEnvConfig() {
 		proxies := strings.Split(envTrustedProxies, ",")
 		config.AppTrustedProxies = proxies
 	}
+	if viper.IsSet("app_idempotency_window") {
+		config.AppIdempotencyWindow = viper.GetInt("app_idempotency_window")
+	}
 
 	// Database settings
 	if envDBURI := viper.GetString("db_uri"); envDBURI != "" {
@@ -146,6 +160,42 @@ func initEnvConfig() {
 	if envPresenceOnConnect := viper.GetString("whatsapp_presence_on_connect"); envPresenceOnConnect != "" {
 		config.WhatsappPresenceOnConnect = envPresenceOnConnect
 	}
+	if viper.IsSet("whatsapp_history_dump_retention_days") {
+		config.WhatsappHistoryDumpRetentionDays = viper.GetInt("whatsapp_history_dump_retention_days")
+	}
+	if viper.IsSet("whatsapp_history_dump_compress") {
+		config.WhatsappHistoryDumpCompress = viper.GetBool("whatsapp_history_dump_compress")
+	}
+	if viper.IsSet("whatsapp_rate_limit_per_minute") {
+		config.WhatsappRateLimitPerMinute = viper.GetInt("whatsapp_rate_limit_per_minute")
+	}
+	if viper.IsSet("whatsapp_rate_limit_burst") {
+		config.WhatsappRateLimitBurst = viper.GetInt("whatsapp_rate_limit_burst")
+	}
+	if viper.IsSet("whatsapp_rate_limit_per_recipient") {
+		config.WhatsappRateLimitPerRecipient = viper.GetInt("whatsapp_rate_limit_per_recipient")
+	}
+	if viper.IsSet("whatsapp_typing_simulation") {
+		config.WhatsappTypingSimulation = viper.GetBool("whatsapp_typing_simulation")
+	}
+	if viper.IsSet("whatsapp_typing_max_delay") {
+		config.WhatsappTypingMaxDelay = viper.GetInt("whatsapp_typing_max_delay")
+	}
+	if viper.IsSet("whatsapp_async_send_workers") {
+		config.WhatsappAsyncSendWorkers = viper.GetInt("whatsapp_async_send_workers")
+	}
+	if viper.IsSet("whatsapp_async_queue_size") {
+		config.WhatsappAsyncQueueSize = viper.GetInt("whatsapp_async_queue_size")
+	}
+	if viper.IsSet("whatsapp_transcode_workers") {
+		config.WhatsappTranscodeWorkers = viper.GetInt("whatsapp_transcode_workers")
+	}
+	if viper.IsSet("whatsapp_transcode_queue_size") {
+		config.WhatsappTranscodeQueueSize = viper.GetInt("whatsapp_transcode_queue_size")
+	}
+	if viper.IsSet("whatsapp_transcode_timeout") {
+		config.WhatsappTranscodeTimeout = viper.GetInt("whatsapp_transcode_timeout")
+	}
 
 	// Chatwoot settings
 	if viper.IsSet("chatwoot_enabled") {
@@ -221,6 +271,12 @@ func initFlags() {
 		config.AppTrustedProxies,
 		`trusted proxy IP ranges for reverse proxy deployments --trusted-proxies <string> | example: --trusted-proxies="0.0.0.0/0" or --trusted-proxies="10.0.0.0/8,172.16.0.0/12"`,
 	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.AppIdempotencyWindow,
+		"idempotency-window", "",
+		config.AppIdempotencyWindow,
+		`minutes a response sent with an Idempotency-Key header is replayed for retries, 0 disables it --idempotency-window <int> | example: --idempotency-window=60`,
+	)
 
 	// Database flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -297,6 +353,78 @@ func initFlags() {
 		config.WhatsappPresenceOnConnect,
 		`presence to send on connect: "available", "unavailable", or "none" --presence-on-connect <string> | example: --presence-on-connect="unavailable"`,
 	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappHistoryDumpRetentionDays,
+		"history-dump-retention-days", "",
+		config.WhatsappHistoryDumpRetentionDays,
+		`days to keep history sync dumps in storages, 0 keeps them forever --history-dump-retention-days <int> | example: --history-dump-retention-days=30`,
+	)
+	rootCmd.PersistentFlags().BoolVarP(
+		&config.WhatsappHistoryDumpCompress,
+		"history-dump-compress", "",
+		config.WhatsappHistoryDumpCompress,
+		`gzip history sync dumps --history-dump-compress <true/false> | example: --history-dump-compress=true`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappRateLimitPerMinute,
+		"rate-limit-per-minute", "",
+		config.WhatsappRateLimitPerMinute,
+		`max outbound messages per minute per device, 0 disables the limit --rate-limit-per-minute <int> | example: --rate-limit-per-minute=30`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappRateLimitBurst,
+		"rate-limit-burst", "",
+		config.WhatsappRateLimitBurst,
+		`messages a device may send back-to-back before the per-minute limit applies --rate-limit-burst <int> | example: --rate-limit-burst=10`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappRateLimitPerRecipient,
+		"rate-limit-per-recipient", "",
+		config.WhatsappRateLimitPerRecipient,
+		`max outbound messages per minute to a single recipient, 0 disables the limit --rate-limit-per-recipient <int> | example: --rate-limit-per-recipient=5`,
+	)
+	rootCmd.PersistentFlags().BoolVarP(
+		&config.WhatsappTypingSimulation,
+		"typing-simulation", "",
+		config.WhatsappTypingSimulation,
+		`show "typing..." for a length-proportional delay before sending text messages --typing-simulation <true/false> | example: --typing-simulation=true`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappTypingMaxDelay,
+		"typing-max-delay", "",
+		config.WhatsappTypingMaxDelay,
+		`maximum simulated typing delay in seconds --typing-max-delay <int> | example: --typing-max-delay=5`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappAsyncSendWorkers,
+		"async-send-workers", "",
+		config.WhatsappAsyncSendWorkers,
+		`number of workers processing sends queued with async=true --async-send-workers <int> | example: --async-send-workers=4`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappAsyncQueueSize,
+		"async-queue-size", "",
+		config.WhatsappAsyncQueueSize,
+		`max async sends waiting for a worker before new ones are rejected --async-queue-size <int> | example: --async-queue-size=100`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappTranscodeWorkers,
+		"transcode-workers", "",
+		config.WhatsappTranscodeWorkers,
+		`media conversions (ffmpeg, cwebp, webpmux) allowed to run at once --transcode-workers <int> | example: --transcode-workers=2`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappTranscodeQueueSize,
+		"transcode-queue-size", "",
+		config.WhatsappTranscodeQueueSize,
+		`max media conversions waiting for a worker before new ones are rejected --transcode-queue-size <int> | example: --transcode-queue-size=20`,
+	)
+	rootCmd.PersistentFlags().IntVarP(
+		&config.WhatsappTranscodeTimeout,
+		"transcode-timeout", "",
+		config.WhatsappTranscodeTimeout,
+		`seconds one media conversion may run before it is killed --transcode-timeout <int> | example: --transcode-timeout=300`,
+	)
 
 	// Chatwoot flags
 	rootCmd.PersistentFlags().BoolVarP(
@@ -360,6 +488,7 @@ func initApp() {
 	if err != nil {
 		logrus.Errorln(err)
 	}
+	whatsapp.PruneHistoryDumps()
 
 	ctx := context.Background()
 
@@ -389,12 +518,19 @@ func initApp() {
 	// Usecase
 	appUsecase = usecase.NewAppService(chatStorageRepo, dm)
 	chatUsecase = usecase.NewChatService(chatStorageRepo)
-	sendUsecase = usecase.NewSendService(appUsecase, chatStorageRepo)
+	templateRepo := chatstorage.NewTemplateRepository(chatStorageDB)
+	sendUsecase = usecase.NewSendService(appUsecase, chatStorageRepo, templateRepo)
 	userUsecase = usecase.NewUserService(chatStorageRepo)
 	messageUsecase = usecase.NewMessageService(chatStorageRepo)
-	groupUsecase = usecase.NewGroupService()
+	groupUsecase = usecase.NewGroupService(chatStorageRepo)
+	groupJobUsecase = usecase.NewGroupParticipantJobService(chatStorageRepo)
 	newsletterUsecase = usecase.NewNewsletterService()
 	deviceUsecase = usecase.NewDeviceService(dm)
+	scheduleUsecase = usecase.NewScheduleService(chatstorage.NewScheduleRepository(chatStorageDB), sendUsecase, dm)
+	campaignUsecase = usecase.NewCampaignService(chatstorage.NewCampaignRepository(chatStorageDB), sendUsecase, dm)
+	templateUsecase = usecase.NewTemplateService(templateRepo)
+	jobUsecase = usecase.NewJobService()
+	statusUsecase = usecase.NewStatusService(chatStorageRepo)
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -13,7 +13,8 @@ var (
 	AppPlatform            = waCompanionReg.DeviceProps_PlatformType(1)
 	AppBasicAuthCredential []string
 	AppBasePath            = ""
-	AppTrustedProxies      []string // Trusted proxy IP ranges (e.g., "0.0.0.0/0" for all, or specific CIDRs)
+	AppTrustedProxies      []string  // Trusted proxy IP ranges (e.g., "0.0.0.0/0" for all, or specific CIDRs)
+	AppIdempotencyWindow   = 24 * 60 // Minutes a response stored under an Idempotency-Key is replayed (0 = disabled)
 
 	McpPort = "8080"
 	McpHost = "localhost"
@@ -44,6 +45,18 @@ var (
 	WhatsappTypeLid                            = "@lid"
 	WhatsappAccountValidation                  = true
 	WhatsappPresenceOnConnect                  = "unavailable" // Presence to send on connect: "available", "unavailable", or "none"
+	WhatsappHistoryDumpRetentionDays           = 0             // Days to keep storages/history-*.json dumps (0 = keep forever)
+	WhatsappHistoryDumpCompress                = false         // Gzip history sync dumps (history-*.json.gz)
+	WhatsappRateLimitPerMinute                 = 0             // Max outbound messages per minute per device (0 = unlimited)
+	WhatsappRateLimitBurst                     = 10            // Messages a device may send back-to-back before the per-minute rate applies
+	WhatsappRateLimitPerRecipient              = 0             // Max outbound messages per minute to one recipient per device (0 = unlimited)
+	WhatsappTypingSimulation                   = false         // Show "typing..." for a length-proportional delay before text messages
+	WhatsappTypingMaxDelay                     = 5             // Upper bound in seconds for the simulated typing delay
+	WhatsappAsyncSendWorkers                   = 4             // Workers processing sends queued with async=true
+	WhatsappAsyncQueueSize                     = 100           // Queued async sends waiting for a worker before new ones are rejected
+	WhatsappTranscodeWorkers                   = 2             // ffmpeg/cwebp/webpmux processes allowed to run at once
+	WhatsappTranscodeQueueSize                 = 20            // Conversions waiting for a worker before new ones are rejected
+	WhatsappTranscodeTimeout                   = 300           // Seconds one conversion may run before it is killed
 
 	ChatStorageURI               = "file:storages/chatstorage.db"
 	ChatStorageEnableForeignKeys = true
@@ -13,6 +13,7 @@ type IAppUsecase interface {
 	Status(ctx context.Context, deviceID string) (isConnected bool, isLoggedIn bool, err error)
 	FirstDevice(ctx context.Context) (response DevicesResponse, err error)
 	FetchDevices(ctx context.Context) (response []DevicesResponse, err error)
+	TranscoderStats(ctx context.Context) (response TranscoderStatsResponse, err error)
 }
 
 type DevicesResponse struct {
@@ -25,3 +26,19 @@ type LoginResponse struct {
 	Duration  time.Duration `json:"duration"`
 	Code      string        `json:"code"`
 }
+
+// TranscoderStatsResponse describes the shared media transcoding pool; counters run since the process started
+type TranscoderStatsResponse struct {
+	Workers      int    `json:"workers"`
+	QueueSize    int    `json:"queue_size"`
+	Running      int    `json:"running"`
+	Queued       int    `json:"queued"`
+	Started      uint64 `json:"started"`
+	Completed    uint64 `json:"completed"`
+	Failed       uint64 `json:"failed"`
+	TimedOut     uint64 `json:"timed_out"`
+	Canceled     uint64 `json:"canceled"`
+	Rejected     uint64 `json:"rejected"`
+	AverageRunMs int64  `json:"average_run_ms"`
+	MaxRunMs     int64  `json:"max_run_ms"`
+}
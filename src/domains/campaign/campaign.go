@@ -0,0 +1,128 @@
+package campaign
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Campaign lifecycle
+const (
+	StatusRunning   = "running"
+	StatusPaused    = "paused"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+)
+
+// Recipient lifecycle
+const (
+	RecipientPending   = "pending"
+	RecipientSending   = "sending"
+	RecipientSent      = "sent"
+	RecipientFailed    = "failed"
+	RecipientSkipped   = "skipped"
+	RecipientCancelled = "cancelled"
+)
+
+// Campaign sends one /send/* payload to many recipients, rendering {{variables}} per recipient
+type Campaign struct {
+	ID              string          `json:"id"`
+	DeviceID        string          `json:"device_id"`
+	Name            string          `json:"name"`
+	Type            string          `json:"type"`
+	Payload         json.RawMessage `json:"payload"`
+	Status          string          `json:"status"`
+	RatePerMinute   int             `json:"rate_per_minute"`
+	JitterSeconds   int             `json:"jitter_seconds"`
+	QuietHoursStart string          `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   string          `json:"quiet_hours_end,omitempty"`
+	Timezone        string          `json:"timezone,omitempty"`
+	Progress        Progress        `json:"progress"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	CompletedAt     *time.Time      `json:"completed_at,omitempty"`
+}
+
+// Progress counts recipients per status
+type Progress struct {
+	Total     int     `json:"total"`
+	Pending   int     `json:"pending"`
+	Sent      int     `json:"sent"`
+	Failed    int     `json:"failed"`
+	Skipped   int     `json:"skipped"`
+	Cancelled int     `json:"cancelled"`
+	Percent   float64 `json:"percent"`
+}
+
+// Recipient is one destination of a campaign
+type Recipient struct {
+	ID         int64             `json:"id"`
+	CampaignID string            `json:"campaign_id"`
+	Phone      string            `json:"phone"`
+	Variables  map[string]string `json:"variables,omitempty"`
+	Status     string            `json:"status"`
+	MessageID  string            `json:"message_id,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	SentAt     *time.Time        `json:"sent_at,omitempty"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+type CampaignFilter struct {
+	DeviceID string
+	Status   string
+	Limit    int
+	Offset   int
+}
+
+type RecipientFilter struct {
+	CampaignID string
+	Status     string
+	Limit      int
+	Offset     int
+}
+
+type RecipientInput struct {
+	Phone     string            `json:"phone"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// CreateCampaignRequest holds a /send/* JSON body in Payload whose string values may use {{variables}}.
+// Recipients come from Recipients or RecipientsCSV (header row with a phone column, other columns become variables).
+type CreateCampaignRequest struct {
+	Name            string           `json:"name"`
+	Type            string           `json:"type"`
+	Payload         json.RawMessage  `json:"payload"`
+	Recipients      []RecipientInput `json:"recipients"`
+	RecipientsCSV   string           `json:"recipients_csv"`
+	RatePerMinute   int              `json:"rate_per_minute"`
+	JitterSeconds   int              `json:"jitter_seconds"`
+	QuietHoursStart string           `json:"quiet_hours_start"`
+	QuietHoursEnd   string           `json:"quiet_hours_end"`
+	Timezone        string           `json:"timezone"`
+}
+
+type ListCampaignsRequest struct {
+	Status string `json:"status" query:"status"`
+	Limit  int    `json:"limit" query:"limit"`
+	Offset int    `json:"offset" query:"offset"`
+}
+
+type ListCampaignsResponse struct {
+	Data  []Campaign `json:"data"`
+	Total int        `json:"total"`
+}
+
+type ListRecipientsRequest struct {
+	CampaignID string `json:"campaign_id" uri:"id"`
+	Status     string `json:"status" query:"status"`
+	Limit      int    `json:"limit" query:"limit"`
+	Offset     int    `json:"offset" query:"offset"`
+}
+
+type ListRecipientsResponse struct {
+	Data  []Recipient `json:"data"`
+	Total int         `json:"total"`
+}
+
+type CampaignActionRequest struct {
+	ID string `json:"id" uri:"id"`
+}
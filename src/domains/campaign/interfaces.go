@@ -0,0 +1,35 @@
+package campaign
+
+import (
+	"context"
+	"time"
+)
+
+// ICampaignUsecase manages broadcast campaigns and runs their senders
+type ICampaignUsecase interface {
+	CreateCampaign(ctx context.Context, request CreateCampaignRequest) (response Campaign, err error)
+	ListCampaigns(ctx context.Context, request ListCampaignsRequest) (response ListCampaignsResponse, err error)
+	GetCampaign(ctx context.Context, id string) (response Campaign, err error)
+	ListRecipients(ctx context.Context, request ListRecipientsRequest) (response ListRecipientsResponse, err error)
+	PauseCampaign(ctx context.Context, request CampaignActionRequest) (response Campaign, err error)
+	ResumeCampaign(ctx context.Context, request CampaignActionRequest) (response Campaign, err error)
+	CancelCampaign(ctx context.Context, request CampaignActionRequest) (response Campaign, err error)
+	// Run resumes running campaigns and keeps their senders alive until ctx is cancelled
+	Run(ctx context.Context)
+}
+
+// ICampaignRepository persists campaigns and their recipients
+type ICampaignRepository interface {
+	Create(campaign *Campaign, recipients []*Recipient) error
+	Get(deviceID, id string) (*Campaign, error)
+	List(filter *CampaignFilter) ([]*Campaign, int, error)
+	// SetStatus moves a campaign to status if it is currently in one of from, reporting whether a row changed
+	SetStatus(deviceID, id string, from []string, status string) (bool, error)
+	ListRecipients(filter *RecipientFilter) ([]*Recipient, int, error)
+	// ClaimNextRecipient marks the next pending recipient as sending and returns it, or nil when none are left
+	ClaimNextRecipient(campaignID string) (*Recipient, error)
+	FinishRecipient(id int64, status, messageID, errMsg string, finishedAt time.Time) error
+	CancelPendingRecipients(campaignID string) error
+	// FailInterrupted fails recipients left in sending state by a previous process
+	FailInterrupted(reason string) (int, error)
+}
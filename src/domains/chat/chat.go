@@ -99,3 +99,19 @@ type ArchiveChatResponse struct {
 	ChatJID  string `json:"chat_jid"`
 	Archived bool   `json:"archived"`
 }
+
+// History backfill operations
+type RequestHistoryRequest struct {
+	ChatJID   string `json:"chat_jid" uri:"chat_jid"`
+	MessageID string `json:"message_id"`
+	Count     int    `json:"count"`
+}
+
+type RequestHistoryResponse struct {
+	Status          string `json:"status"`
+	Message         string `json:"message"`
+	ChatJID         string `json:"chat_jid"`
+	OldestMessageID string `json:"oldest_message_id"`
+	Count           int    `json:"count"`
+	RequestID       string `json:"request_id"`
+}
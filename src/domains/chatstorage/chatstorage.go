@@ -1,6 +1,10 @@
 package chatstorage
 
-import "time"
+import (
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
 
 // Chat represents a WhatsApp chat/conversation
 type Chat struct {
@@ -29,6 +33,7 @@ type Message struct {
 	FileSHA256    []byte    `db:"file_sha256"`
 	FileEncSHA256 []byte    `db:"file_enc_sha256"`
 	FileLength    uint64    `db:"file_length"`
+	MediaMessage  []byte    `db:"media_message"` // Serialized media part of the message, kept so it can be forwarded as-is
 	CreatedAt     time.Time `db:"created_at"`
 	UpdatedAt     time.Time `db:"updated_at"`
 }
@@ -75,3 +80,93 @@ type ChatFilter struct {
 	SearchName string
 	HasMedia   bool
 }
+
+// Status represents a WhatsApp status (story) posted to status@broadcast, either by a contact or by us
+type Status struct {
+	ID              string     `db:"id"`
+	DeviceID        string     `db:"device_id"`
+	Sender          string     `db:"sender"`
+	PushName        string     `db:"push_name"`
+	IsFromMe        bool       `db:"is_from_me"`
+	Type            string     `db:"type"`
+	Text            string     `db:"text"`
+	BackgroundColor string     `db:"background_color"`
+	TextColor       string     `db:"text_color"`
+	Font            string     `db:"font"`
+	MediaType       string     `db:"media_type"`
+	Timestamp       time.Time  `db:"timestamp"`
+	ExpiresAt       time.Time  `db:"expires_at"`
+	ViewedAt        *time.Time `db:"viewed_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+// Poll is a poll created in a chat, kept so decrypted votes can be mapped back to option names
+type Poll struct {
+	ID              string    `db:"id"`
+	DeviceID        string    `db:"device_id"`
+	ChatJID         string    `db:"chat_jid"`
+	Creator         string    `db:"creator"`
+	Question        string    `db:"question"`
+	Options         []string  `db:"options"`
+	SelectableCount int       `db:"selectable_count"`
+	Timestamp       time.Time `db:"timestamp"`
+	CreatedAt       time.Time `db:"created_at"`
+}
+
+// PollVote is the current selection of one voter. A newer vote replaces the previous one and an empty
+// selection means the vote was withdrawn.
+type PollVote struct {
+	PollID    string    `db:"poll_id"`
+	DeviceID  string    `db:"device_id"`
+	Voter     string    `db:"voter"`
+	VoterName string    `db:"voter_name"`
+	Options   []string  `db:"options"`
+	Timestamp time.Time `db:"timestamp"`
+}
+
+// Group is the last known metadata of a group the device belongs to. It is kept current by group events so
+// group lookups do not have to ask WhatsApp every time.
+type Group struct {
+	JID       string          `db:"jid"`
+	DeviceID  string          `db:"device_id"`
+	Name      string          `db:"name"`
+	Info      types.GroupInfo `db:"info"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+// GroupEvent is one recorded change of a group: a membership change, a name or topic change or a setting change
+type GroupEvent struct {
+	ID          int64     `db:"id"`
+	DeviceID    string    `db:"device_id"`
+	GroupJID    string    `db:"group_jid"`
+	Type        string    `db:"type"`
+	Participant string    `db:"participant"`
+	Actor       string    `db:"actor"`
+	Setting     string    `db:"setting"`
+	Value       string    `db:"value"`
+	Timestamp   time.Time `db:"timestamp"`
+}
+
+// GroupEventFilter represents query filters for the events of one group, newest first
+type GroupEventFilter struct {
+	DeviceID    string
+	GroupJID    string
+	Types       []string
+	Participant string
+	Actor       string
+	StartTime   *time.Time
+	EndTime     *time.Time
+	Limit       int
+	Offset      int
+}
+
+// StatusFilter represents query filters for statuses; expired statuses are never returned
+type StatusFilter struct {
+	DeviceID     string
+	Sender       string
+	IsFromMe     *bool
+	UnviewedOnly bool
+	Now          time.Time
+	Limit        int
+	Offset       int
+}
@@ -22,7 +22,9 @@ type IChatStorageRepository interface {
 	StoreMessage(message *Message) error
 	StoreMessagesBatch(messages []*Message) error
 	GetMessageByID(id string) (*Message, error) // New method for efficient ID-only search
+	GetMessageByIDByDevice(deviceID, id string) (*Message, error)
 	GetMessages(filter *MessageFilter) ([]*Message, error)
+	GetOldestMessageByDevice(deviceID, chatJID string) (*Message, error)                // Anchor for on-demand history backfill
 	SearchMessages(deviceID, chatJID, searchText string, limit int) ([]*Message, error) // Database-level search with device isolation
 	DeleteMessage(id, chatJID string) error
 	DeleteMessageByDevice(deviceID, id, chatJID string) error
@@ -42,6 +44,32 @@ type IChatStorageRepository interface {
 	TruncateAllDataWithLogging(logPrefix string) error
 	DeleteDeviceData(deviceID string) error
 
+	// Status operations
+	StoreStatus(status *Status) error
+	GetStatus(deviceID, id string) (*Status, error)
+	GetStatuses(filter *StatusFilter) ([]*Status, error)
+	MarkStatusViewed(deviceID, id string, viewedAt time.Time) error
+	DeleteStatus(deviceID, id string) error
+	DeleteExpiredStatuses(now time.Time) (int64, error)
+
+	// Poll operations
+	StorePoll(poll *Poll) error
+	GetPoll(deviceID, id string) (*Poll, error)
+	StorePollVote(vote *PollVote) error
+	GetPollVotes(deviceID, pollID string) ([]*PollVote, error)
+
+	// Group metadata operations
+	StoreGroup(group *Group) error
+	GetGroup(deviceID, jid string) (*Group, error)
+	GetGroups(deviceID string) ([]*Group, error)
+	DeleteGroup(deviceID, jid string) error
+	ReplaceGroups(deviceID string, groups []*Group) error  // Replaces every stored group of the device after a full listing
+	GetGroupsSyncedAt(deviceID string) (*time.Time, error) // When the device's groups were last listed in full, nil if never
+
+	// Group history operations
+	StoreGroupEvents(events []*GroupEvent) error
+	GetGroupEvents(filter *GroupEventFilter) ([]*GroupEvent, int, error)
+
 	// Device registry operations
 	SaveDeviceRecord(record *DeviceRecord) error
 	ListDeviceRecords() ([]*DeviceRecord, error)
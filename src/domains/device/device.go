@@ -22,3 +22,12 @@ type Device struct {
 	JID         string      `json:"jid,omitempty"`
 	CreatedAt   time.Time   `json:"created_at"`
 }
+
+// HistoryReplayResult reports how many stored history sync dumps were re-ingested for a device.
+type HistoryReplayResult struct {
+	DeviceID string   `json:"device_id"`
+	Files    int      `json:"files"`
+	Replayed int      `json:"replayed"`
+	Failed   int      `json:"failed"`
+	Errors   []string `json:"errors,omitempty"`
+}
@@ -13,4 +13,5 @@ type IDeviceUsecase interface {
 	LogoutDevice(ctx context.Context, deviceID string) error
 	ReconnectDevice(ctx context.Context, deviceID string) error
 	GetStatus(ctx context.Context, deviceID string) (isConnected bool, isLoggedIn bool, err error)
+	ReplayHistory(ctx context.Context, deviceID string) (HistoryReplayResult, error)
 }
@@ -0,0 +1,62 @@
+package group
+
+import "time"
+
+type CreateCommunityRequest struct {
+	Name        string `json:"name" form:"name"`
+	Description string `json:"description" form:"description"`
+}
+
+type CreateCommunityResponse struct {
+	CommunityID string `json:"community_id"`
+	// AnnouncementGroupID is created by WhatsApp along with the community; it is empty if it was not ready yet
+	AnnouncementGroupID string `json:"announcement_group_id,omitempty"`
+}
+
+type CommunityRequest struct {
+	CommunityID string `json:"community_id" query:"community_id"`
+}
+
+type CommunityGroupsRequest struct {
+	CommunityID string   `json:"community_id" form:"community_id"`
+	GroupIDs    []string `json:"group_ids" form:"group_ids"`
+}
+
+type CommunityGroupStatus struct {
+	GroupID string `json:"group_id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type CreateCommunityGroupRequest struct {
+	CommunityID  string   `json:"community_id" form:"community_id"`
+	Title        string   `json:"title" form:"title"`
+	Participants []string `json:"participants" form:"participants"`
+}
+
+// CommunityGroup is a group linked to a community. The announcement group is the one every member is in and
+// only admins post to.
+type CommunityGroup struct {
+	GroupID        string `json:"group_id"`
+	Name           string `json:"name"`
+	IsAnnouncement bool   `json:"is_announcement"`
+}
+
+type CommunitySubGroupsResponse struct {
+	CommunityID       string           `json:"community_id"`
+	AnnouncementGroup *CommunityGroup  `json:"announcement_group"`
+	Groups            []CommunityGroup `json:"groups"`
+}
+
+type CommunityInfoResponse struct {
+	CommunityID            string           `json:"community_id"`
+	Name                   string           `json:"name"`
+	Description            string           `json:"description"`
+	OwnerJID               string           `json:"owner_jid"`
+	CreatedAt              time.Time        `json:"created_at"`
+	IsLocked               bool             `json:"is_locked"`
+	MembershipApprovalMode string           `json:"membership_approval_mode"`
+	AnnouncementGroup      *CommunityGroup  `json:"announcement_group"`
+	Groups                 []CommunityGroup `json:"groups"`
+	MemberCount            int              `json:"member_count"`
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
 )
 
 // NOTE: IGroupUsecase is now defined in interfaces.go with proper segregation
@@ -36,6 +37,8 @@ type ParticipantStatus struct {
 
 type GetGroupParticipantsRequest struct {
 	GroupID string `json:"group_id" query:"group_id"`
+	// Refresh asks WhatsApp instead of using the stored group metadata
+	Refresh bool `json:"refresh" query:"refresh"`
 }
 
 type GroupParticipant struct {
@@ -100,6 +103,40 @@ type SetGroupTopicRequest struct {
 	Topic   string `json:"topic" form:"topic"`
 }
 
+type SetGroupJoinApprovalRequest struct {
+	GroupID string `json:"group_id" form:"group_id"`
+	Enabled bool   `json:"enabled" form:"enabled"`
+}
+
+type SetGroupMemberAddModeRequest struct {
+	GroupID string                   `json:"group_id" form:"group_id"`
+	Mode    types.GroupMemberAddMode `json:"mode" form:"mode"`
+}
+
+type SetGroupDisappearingRequest struct {
+	GroupID      string `json:"group_id" form:"group_id"`
+	TimerSeconds uint32 `json:"timer_seconds" form:"timer_seconds"`
+}
+
+// SetGroupDescriptionRequest changes the description the way the phone app does: PreviousID names the description
+// being replaced, so an edit based on an outdated description is rejected instead of overwriting a newer one.
+// When empty, the current description is replaced.
+type SetGroupDescriptionRequest struct {
+	GroupID     string `json:"group_id" form:"group_id"`
+	Description string `json:"description" form:"description"`
+	PreviousID  string `json:"previous_id" form:"previous_id"`
+}
+
+type SetGroupDescriptionResponse struct {
+	GroupID       string `json:"group_id"`
+	DescriptionID string `json:"description_id"`
+	PreviousID    string `json:"previous_id,omitempty"`
+}
+
+type RevokeGroupInviteLinkRequest struct {
+	GroupID string `json:"group_id" form:"group_id"`
+}
+
 type GetGroupInfoFromLinkRequest struct {
 	Link string `json:"link" form:"link"`
 }
@@ -118,6 +155,8 @@ type GetGroupInfoFromLinkResponse struct {
 
 type GroupInfoRequest struct {
 	GroupID string `json:"group_id" query:"group_id"`
+	// Refresh asks WhatsApp instead of using the stored group metadata
+	Refresh bool `json:"refresh" query:"refresh"`
 }
 
 type GetGroupInviteLinkRequest struct {
@@ -133,3 +172,19 @@ type GetGroupInviteLinkResponse struct {
 type GroupInfoResponse struct {
 	Data any `json:"data"`
 }
+
+// GroupInfo is the group info as WhatsApp reports it, plus its settings gathered under one key
+type GroupInfo struct {
+	types.GroupInfo
+	Settings GroupSettings `json:"settings"`
+}
+
+type GroupSettings struct {
+	IsLocked               bool                     `json:"is_locked"`
+	IsAnnounce             bool                     `json:"is_announce"`
+	IsJoinApprovalRequired bool                     `json:"is_join_approval_required"`
+	MemberAddMode          types.GroupMemberAddMode `json:"member_add_mode"`
+	DisappearingTimer      uint32                   `json:"disappearing_timer"`
+	Description            string                   `json:"description"`
+	DescriptionID          string                   `json:"description_id"`
+}
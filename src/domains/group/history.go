@@ -0,0 +1,67 @@
+package group
+
+import "time"
+
+// Group history event types
+const (
+	HistoryJoined          = "joined"
+	HistoryAdded           = "added"
+	HistoryLeft            = "left"
+	HistoryRemoved         = "removed"
+	HistoryPromoted        = "promoted"
+	HistoryDemoted         = "demoted"
+	HistoryNameChanged     = "name_changed"
+	HistoryTopicChanged    = "topic_changed"
+	HistorySettingChanged  = "setting_changed"
+	HistoryLinked          = "linked"
+	HistoryUnlinked        = "unlinked"
+	HistoryInviteLinkReset = "invite_link_reset"
+	HistoryDeleted         = "deleted"
+)
+
+// HistoryTypes lists every group history event type
+var HistoryTypes = []string{
+	HistoryJoined, HistoryAdded, HistoryLeft, HistoryRemoved, HistoryPromoted, HistoryDemoted,
+	HistoryNameChanged, HistoryTopicChanged, HistorySettingChanged, HistoryLinked, HistoryUnlinked,
+	HistoryInviteLinkReset, HistoryDeleted,
+}
+
+// Settings reported by setting_changed events
+const (
+	SettingLocked       = "locked"
+	SettingAnnounce     = "announce"
+	SettingDisappearing = "disappearing"
+	SettingJoinApproval = "join_approval"
+	SettingSuspended    = "suspended"
+)
+
+// GroupHistoryRequest filters the recorded events of a group. Types takes a comma separated list and the time
+// bounds are RFC3339.
+type GroupHistoryRequest struct {
+	GroupID     string `json:"group_id" query:"group_id"`
+	Types       string `json:"types" query:"types"`
+	Participant string `json:"participant" query:"participant"`
+	Actor       string `json:"actor" query:"actor"`
+	StartTime   string `json:"start_time" query:"start_time"`
+	EndTime     string `json:"end_time" query:"end_time"`
+	Limit       int    `json:"limit" query:"limit"`
+	Offset      int    `json:"offset" query:"offset"`
+}
+
+type GroupHistoryResponse struct {
+	GroupID string              `json:"group_id"`
+	Data    []GroupHistoryEvent `json:"data"`
+	Total   int                 `json:"total"`
+}
+
+// GroupHistoryEvent is one recorded group change. Participant is set for membership changes, Setting and Value
+// for name, topic and settings changes.
+type GroupHistoryEvent struct {
+	ID          int64     `json:"id"`
+	Type        string    `json:"type"`
+	Participant string    `json:"participant,omitempty"`
+	Actor       string    `json:"actor,omitempty"`
+	Setting     string    `json:"setting,omitempty"`
+	Value       string    `json:"value,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
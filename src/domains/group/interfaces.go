@@ -29,6 +29,26 @@ type IGroupSettings interface {
 	SetGroupLocked(ctx context.Context, request SetGroupLockedRequest) (err error)
 	SetGroupAnnounce(ctx context.Context, request SetGroupAnnounceRequest) (err error)
 	SetGroupTopic(ctx context.Context, request SetGroupTopicRequest) (err error)
+	SetGroupJoinApproval(ctx context.Context, request SetGroupJoinApprovalRequest) (err error)
+	SetGroupMemberAddMode(ctx context.Context, request SetGroupMemberAddModeRequest) (err error)
+	SetGroupDisappearing(ctx context.Context, request SetGroupDisappearingRequest) (err error)
+	SetGroupDescription(ctx context.Context, request SetGroupDescriptionRequest) (response SetGroupDescriptionResponse, err error)
+	RevokeGroupInviteLink(ctx context.Context, request RevokeGroupInviteLinkRequest) (response GetGroupInviteLinkResponse, err error)
+}
+
+// IGroupCommunity handles communities and the groups linked to them
+type IGroupCommunity interface {
+	CreateCommunity(ctx context.Context, request CreateCommunityRequest) (response CreateCommunityResponse, err error)
+	CommunityInfo(ctx context.Context, request CommunityRequest) (response CommunityInfoResponse, err error)
+	GetCommunitySubGroups(ctx context.Context, request CommunityRequest) (response CommunitySubGroupsResponse, err error)
+	LinkCommunityGroups(ctx context.Context, request CommunityGroupsRequest) (result []CommunityGroupStatus, err error)
+	UnlinkCommunityGroups(ctx context.Context, request CommunityGroupsRequest) (result []CommunityGroupStatus, err error)
+	CreateCommunityGroup(ctx context.Context, request CreateCommunityGroupRequest) (groupID string, err error)
+}
+
+// IGroupHistory reads the recorded membership and settings changes of groups
+type IGroupHistory interface {
+	GroupHistory(ctx context.Context, request GroupHistoryRequest) (response GroupHistoryResponse, err error)
 }
 
 // IGroupUsecase combines all group interfaces for backward compatibility
@@ -36,4 +56,6 @@ type IGroupUsecase interface {
 	IGroupManagement
 	IGroupParticipants
 	IGroupSettings
+	IGroupCommunity
+	IGroupHistory
 }
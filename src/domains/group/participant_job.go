@@ -0,0 +1,88 @@
+package group
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+)
+
+// Participant job lifecycle
+const (
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusCancelled = "cancelled"
+)
+
+// Participant result of a job
+const (
+	ParticipantPending   = "pending"
+	ParticipantSuccess   = "success"
+	ParticipantInvited   = "invited"
+	ParticipantSkipped   = "skipped"
+	ParticipantFailed    = "failed"
+	ParticipantCancelled = "cancelled"
+)
+
+// IGroupParticipantJobUsecase applies one participant action to long lists in paced batches
+type IGroupParticipantJobUsecase interface {
+	CreateParticipantJob(ctx context.Context, request CreateParticipantJobRequest) (response ParticipantJob, err error)
+	GetParticipantJob(ctx context.Context, request ParticipantJobRequest) (response ParticipantJob, err error)
+	CancelParticipantJob(ctx context.Context, request ParticipantJobRequest) (response ParticipantJob, err error)
+	// Run keeps job workers alive and forgets old jobs until ctx is cancelled
+	Run(ctx context.Context)
+}
+
+// CreateParticipantJobRequest adds, removes, promotes or demotes Participants and the phone column of
+// ParticipantsCSV. When InviteFallback is on (the default for add), people whose privacy settings keep us from
+// adding them get a group invite message instead.
+type CreateParticipantJobRequest struct {
+	GroupID           string                      `json:"group_id" form:"group_id"`
+	Action            whatsmeow.ParticipantChange `json:"action" form:"action"`
+	Participants      []string                    `json:"participants" form:"participants"`
+	ParticipantsCSV   string                      `json:"participants_csv" form:"participants_csv"`
+	BatchSize         int                         `json:"batch_size" form:"batch_size"`
+	BatchDelaySeconds int                         `json:"batch_delay_seconds" form:"batch_delay_seconds"`
+	InviteFallback    *bool                       `json:"invite_fallback" form:"invite_fallback"`
+	InviteMessage     string                      `json:"invite_message" form:"invite_message"`
+}
+
+type ParticipantJobRequest struct {
+	ID string `json:"id" uri:"id"`
+}
+
+type ParticipantJob struct {
+	ID                string                      `json:"id"`
+	DeviceID          string                      `json:"device_id"`
+	GroupID           string                      `json:"group_id"`
+	Action            whatsmeow.ParticipantChange `json:"action"`
+	Status            string                      `json:"status"`
+	BatchSize         int                         `json:"batch_size"`
+	BatchDelaySeconds int                         `json:"batch_delay_seconds"`
+	InviteFallback    bool                        `json:"invite_fallback"`
+	Progress          ParticipantJobProgress      `json:"progress"`
+	Results           []ParticipantJobResult      `json:"results"`
+	CreatedAt         time.Time                   `json:"created_at"`
+	UpdatedAt         time.Time                   `json:"updated_at"`
+	FinishedAt        *time.Time                  `json:"finished_at,omitempty"`
+}
+
+// ParticipantJobProgress counts participants per result
+type ParticipantJobProgress struct {
+	Total     int     `json:"total"`
+	Pending   int     `json:"pending"`
+	Success   int     `json:"success"`
+	Invited   int     `json:"invited"`
+	Skipped   int     `json:"skipped"`
+	Failed    int     `json:"failed"`
+	Cancelled int     `json:"cancelled"`
+	Percent   float64 `json:"percent"`
+}
+
+type ParticipantJobResult struct {
+	Participant string `json:"participant"`
+	Status      string `json:"status"`
+	Message     string `json:"message,omitempty"`
+	// ErrorCode is the code WhatsApp answered for this participant, e.g. 403 when privacy settings block adding
+	ErrorCode int `json:"error_code,omitempty"`
+}
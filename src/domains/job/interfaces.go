@@ -0,0 +1,11 @@
+package job
+
+import "context"
+
+// IJobUsecase queues sends for the worker pool and reports their progress
+type IJobUsecase interface {
+	EnqueueSend(ctx context.Context, request EnqueueSendRequest) (response Job, err error)
+	GetJob(ctx context.Context, id string) (response Job, err error)
+	// Run starts the workers and blocks until ctx is cancelled
+	Run(ctx context.Context)
+}
@@ -0,0 +1,49 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+// Send job lifecycle
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// Job is a send request accepted with async=true and processed by the worker pool
+type Job struct {
+	ID         string     `json:"id"`
+	DeviceID   string     `json:"device_id"`
+	Type       string     `json:"type"`
+	Phone      string     `json:"phone"`
+	Status     string     `json:"status"`
+	Result     any        `json:"result,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// SendResult is what a queued send produced. Response is the endpoint's full response (e.g. every message ID of an
+// album) and becomes the job result; MessageID is the one message ID reported in the send webhook.
+type SendResult struct {
+	MessageID string
+	Response  any
+}
+
+// SendFunc performs the queued send with a context that carries the device of the original request
+type SendFunc func(ctx context.Context) (SendResult, error)
+
+// EnqueueSendRequest describes a send to run in the background. Validate, when set, runs before the job is
+// queued so an invalid send is rejected right away. Cleanup, when set, runs once the job has finished and
+// releases anything the send needed to outlive the HTTP request (e.g. uploads).
+type EnqueueSendRequest struct {
+	Type     string
+	Phone    string
+	Validate func(ctx context.Context) error
+	Send     SendFunc
+	Cleanup  func()
+}
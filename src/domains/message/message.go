@@ -51,3 +51,49 @@ type DownloadMediaResponse struct {
 	FilePath  string `json:"file_path"`
 	FileSize  int64  `json:"file_size"`
 }
+
+type ForwardRequest struct {
+	MessageID string `json:"message_id" uri:"message_id"`
+	// Phone is the chat the message belongs to
+	Phone      string   `json:"phone" form:"phone"`
+	Recipients []string `json:"recipients" form:"recipients"`
+}
+
+type ForwardResult struct {
+	Phone     string `json:"phone"`
+	MessageID string `json:"message_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ForwardResponse struct {
+	MessageID string          `json:"message_id"`
+	Status    string          `json:"status"`
+	Results   []ForwardResult `json:"results"`
+}
+
+type PollResultsRequest struct {
+	MessageID string `json:"message_id" uri:"message_id"`
+}
+
+type PollVoter struct {
+	JID       string   `json:"jid"`
+	Name      string   `json:"name,omitempty"`
+	Options   []string `json:"options"`
+	Timestamp string   `json:"timestamp"`
+}
+
+type PollOptionResult struct {
+	Name   string   `json:"name"`
+	Votes  int      `json:"votes"`
+	Voters []string `json:"voters"`
+}
+
+type PollResultsResponse struct {
+	MessageID       string             `json:"message_id"`
+	ChatJID         string             `json:"chat_jid"`
+	Question        string             `json:"question"`
+	SelectableCount int                `json:"selectable_count"`
+	TotalVoters     int                `json:"total_voters"`
+	Options         []PollOptionResult `json:"options"`
+	Voters          []PollVoter        `json:"voters"`
+}
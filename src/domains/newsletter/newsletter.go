@@ -1,11 +1,108 @@
 package newsletter
 
-import "context"
+import (
+	"context"
+	"mime/multipart"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// MessagesMaxCount caps how many channel posts one history or updates call returns
+const MessagesMaxCount = 100
 
 type INewsletterUsecase interface {
+	Create(ctx context.Context, request CreateRequest) (response *types.NewsletterMetadata, err error)
+	Follow(ctx context.Context, request FollowRequest) (response *types.NewsletterMetadata, err error)
 	Unfollow(ctx context.Context, request UnfollowRequest) (err error)
+	Info(ctx context.Context, request InfoRequest) (response *types.NewsletterMetadata, err error)
+	Messages(ctx context.Context, request MessagesRequest) (response MessagesResponse, err error)
+	MessageUpdates(ctx context.Context, request MessageUpdatesRequest) (response MessagesResponse, err error)
+	Post(ctx context.Context, request PostRequest) (response PostResponse, err error)
+	React(ctx context.Context, request ReactRequest) (err error)
+	Mute(ctx context.Context, request MuteRequest) (err error)
+}
+
+type CreateRequest struct {
+	Name        string                `json:"name" form:"name"`
+	Description string                `json:"description" form:"description"`
+	Picture     *multipart.FileHeader `json:"picture" form:"picture"`
+}
+
+// FollowRequest identifies the channel either by JID or by its invite link
+type FollowRequest struct {
+	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
+	InviteLink   string `json:"invite_link" form:"invite_link"`
 }
 
 type UnfollowRequest struct {
 	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
 }
+
+// InfoRequest identifies the channel either by JID or by its invite link
+type InfoRequest struct {
+	NewsletterID string `json:"newsletter_id" query:"newsletter_id"`
+	InviteLink   string `json:"invite_link" query:"invite_link"`
+}
+
+type MessagesRequest struct {
+	NewsletterID string `json:"newsletter_id" query:"newsletter_id"`
+	Count        int    `json:"count" query:"count"`
+	// Before pages back through history: only posts with a lower server ID are returned
+	Before int `json:"before" query:"before"`
+}
+
+// MessageUpdatesRequest asks for the view and reaction counts that changed on recent posts
+type MessageUpdatesRequest struct {
+	NewsletterID string `json:"newsletter_id" query:"newsletter_id"`
+	Count        int    `json:"count" query:"count"`
+	// Since is a unix timestamp; only updates after it are returned
+	Since int64 `json:"since" query:"since"`
+	// After only returns posts with a higher server ID
+	After int `json:"after" query:"after"`
+}
+
+type MessagesResponse struct {
+	NewsletterID string        `json:"newsletter_id"`
+	Data         []MessageItem `json:"data"`
+}
+
+// MessageItem is a channel post. Reactions address a post by its ServerID.
+type MessageItem struct {
+	ServerID  int            `json:"server_id"`
+	MessageID string         `json:"message_id"`
+	Type      string         `json:"type"`
+	MediaType string         `json:"media_type,omitempty"`
+	Text      string         `json:"text,omitempty"`
+	Timestamp time.Time      `json:"timestamp"`
+	Views     int            `json:"views"`
+	Reactions map[string]int `json:"reactions,omitempty"`
+}
+
+// PostRequest publishes a text post, or an image or video with Message as caption, to a channel we administer
+type PostRequest struct {
+	NewsletterID string                `json:"newsletter_id" form:"newsletter_id"`
+	Message      string                `json:"message" form:"message"`
+	Image        *multipart.FileHeader `json:"image" form:"image"`
+	ImageURL     *string               `json:"image_url" form:"image_url"`
+	Video        *multipart.FileHeader `json:"video" form:"video"`
+	VideoURL     *string               `json:"video_url" form:"video_url"`
+}
+
+type PostResponse struct {
+	MessageID string `json:"message_id"`
+	ServerID  int    `json:"server_id"`
+	Status    string `json:"status"`
+}
+
+// ReactRequest reacts to a channel post; an empty Reaction removes ours
+type ReactRequest struct {
+	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
+	ServerID     int    `json:"server_id" form:"server_id"`
+	Reaction     string `json:"reaction" form:"reaction"`
+}
+
+type MuteRequest struct {
+	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
+	Mute         bool   `json:"mute" form:"mute"`
+}
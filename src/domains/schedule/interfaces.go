@@ -0,0 +1,32 @@
+package schedule
+
+import (
+	"context"
+	"time"
+)
+
+// IScheduleUsecase manages scheduled messages and runs the dispatcher that sends them
+type IScheduleUsecase interface {
+	CreateSchedule(ctx context.Context, request CreateScheduleRequest) (response ScheduledMessage, err error)
+	ListSchedules(ctx context.Context, request ListScheduleRequest) (response ListScheduleResponse, err error)
+	GetSchedule(ctx context.Context, id string) (response ScheduledMessage, err error)
+	Reschedule(ctx context.Context, request RescheduleRequest) (response ScheduledMessage, err error)
+	CancelSchedule(ctx context.Context, request CancelScheduleRequest) (response ScheduledMessage, err error)
+	// Run dispatches due messages until ctx is cancelled
+	Run(ctx context.Context)
+}
+
+// IScheduleRepository persists scheduled messages
+type IScheduleRepository interface {
+	Create(message *ScheduledMessage) error
+	Get(deviceID, id string) (*ScheduledMessage, error)
+	List(filter *ScheduleFilter) ([]*ScheduledMessage, int, error)
+	// Reschedule and Cancel only touch pending messages and report whether a row changed
+	Reschedule(deviceID, id string, sendAt time.Time, timezone string) (bool, error)
+	Cancel(deviceID, id string) (bool, error)
+	// ClaimDue marks up to limit pending messages due at now as sending and returns them
+	ClaimDue(now time.Time, limit int) ([]*ScheduledMessage, error)
+	Finish(id, status, messageID, errMsg string, finishedAt time.Time) error
+	// FailInterrupted fails messages left in sending state by a previous process
+	FailInterrupted(reason string) (int, error)
+}
@@ -0,0 +1,84 @@
+package schedule
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Supported scheduled message types; each maps to the matching ISendUsecase request
+const (
+	TypeText     = "text"
+	TypeImage    = "image"
+	TypeFile     = "file"
+	TypeVideo    = "video"
+	TypeAudio    = "audio"
+	TypeSticker  = "sticker"
+	TypeContact  = "contact"
+	TypeLink     = "link"
+	TypeLocation = "location"
+	TypePoll     = "poll"
+)
+
+// Scheduled message lifecycle
+const (
+	StatusPending   = "pending"
+	StatusSending   = "sending"
+	StatusSent      = "sent"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+// ScheduledMessage is a send request persisted until its send_at time
+type ScheduledMessage struct {
+	ID        string          `json:"id"`
+	DeviceID  string          `json:"device_id"`
+	Type      string          `json:"type"`
+	Phone     string          `json:"phone"`
+	Payload   json.RawMessage `json:"payload"`
+	SendAt    time.Time       `json:"send_at"`
+	Timezone  string          `json:"timezone,omitempty"`
+	Status    string          `json:"status"`
+	MessageID string          `json:"message_id,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	SentAt    *time.Time      `json:"sent_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ScheduleFilter represents query filters for scheduled messages
+type ScheduleFilter struct {
+	DeviceID string
+	Status   string
+	Limit    int
+	Offset   int
+}
+
+// CreateScheduleRequest holds any /send/* JSON body in Payload plus the time to send it.
+// SendAt is RFC3339, or a local time interpreted in Timezone (e.g. "2026-03-01T09:00:00" + "Asia/Jakarta").
+type CreateScheduleRequest struct {
+	Type     string          `json:"type"`
+	SendAt   string          `json:"send_at"`
+	Timezone string          `json:"timezone"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+type ListScheduleRequest struct {
+	Status string `json:"status" query:"status"`
+	Limit  int    `json:"limit" query:"limit"`
+	Offset int    `json:"offset" query:"offset"`
+}
+
+type ListScheduleResponse struct {
+	Data  []ScheduledMessage `json:"data"`
+	Total int                `json:"total"`
+}
+
+type RescheduleRequest struct {
+	ID       string `json:"id" uri:"id"`
+	SendAt   string `json:"send_at"`
+	Timezone string `json:"timezone"`
+}
+
+type CancelScheduleRequest struct {
+	ID string `json:"id" uri:"id"`
+}
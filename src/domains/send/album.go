@@ -0,0 +1,33 @@
+package send
+
+import "mime/multipart"
+
+const (
+	AlbumItemImage = "image"
+	AlbumItemVideo = "video"
+
+	// AlbumMinItems and AlbumMaxItems bound an album the way the phone app does when picking media
+	AlbumMinItems = 2
+	AlbumMaxItems = 30
+)
+
+type AlbumItem struct {
+	Type    string                `json:"type"`
+	URL     *string               `json:"url,omitempty"`
+	Caption string                `json:"caption,omitempty"`
+	File    *multipart.FileHeader `json:"-"`
+	DetachedUpload
+}
+
+type AlbumRequest struct {
+	BaseRequest
+	Items    []AlbumItem `json:"items" form:"-"`
+	Compress bool        `json:"compress" form:"compress"`
+}
+
+type AlbumResponse struct {
+	// MessageID is the album message that groups the media messages in MessageIDs
+	MessageID  string   `json:"message_id"`
+	MessageIDs []string `json:"message_ids"`
+	Status     string   `json:"status"`
+}
@@ -4,7 +4,9 @@ import "mime/multipart"
 
 type AudioRequest struct {
 	BaseRequest
+	DetachedUpload
 	Audio    *multipart.FileHeader `json:"audio" form:"audio"`
 	AudioURL *string               `json:"audio_url" form:"audio_url"`
 	PTT      bool                  `json:"ptt" form:"ptt"`
+	ViewOnce bool                  `json:"view_once" form:"view_once"`
 }
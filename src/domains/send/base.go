@@ -4,4 +4,20 @@ type BaseRequest struct {
 	Phone       string `json:"phone" form:"phone"`
 	Duration    *int   `json:"duration,omitempty" form:"duration"`
 	IsForwarded bool   `json:"is_forwarded,omitempty" form:"is_forwarded"`
+	// ReplyMessageID quotes a stored message; the quoted sender and content come from chat storage
+	ReplyMessageID *string `json:"reply_message_id,omitempty" form:"reply_message_id"`
+	// Mentions are phone numbers/JIDs to mention without @phone in the text (ghost mentions), or @everyone in groups
+	Mentions []string `json:"mentions,omitempty" form:"mentions"`
+	// TemplateID renders a stored message template into the request; Variables fill its {{placeholders}}
+	TemplateID string            `json:"template_id,omitempty" form:"template_id"`
+	Variables  map[string]string `json:"variables,omitempty" form:"-"`
+	// Async queues the send and returns a job ID instead of waiting for WhatsApp (REST only)
+	Async bool `json:"async,omitempty" form:"async"`
+}
+
+// DetachedUpload is where an async send keeps its upload on disk, since the multipart form of the request is
+// released when the handler returns. It is set by the server only; the upload's name, type and size stay on
+// the file header for validation.
+type DetachedUpload struct {
+	UploadPath string `json:"-" form:"-"`
 }
@@ -2,6 +2,47 @@ package send
 
 type ContactRequest struct {
 	BaseRequest
+	// ContactName and ContactPhone send a single contact with one mobile number
 	ContactName  string `json:"contact_name" form:"contact_name"`
 	ContactPhone string `json:"contact_phone" form:"contact_phone"`
+	// Contact and Contacts carry full contact cards; more than one card is sent as a single contacts-array message
+	Contact  *ContactCard  `json:"contact,omitempty"`
+	Contacts []ContactCard `json:"contacts,omitempty"`
+}
+
+// ContactCard is a shared contact. Incoming contact messages are parsed into the same structure.
+type ContactCard struct {
+	Name         string          `json:"name"`
+	Phones       []ContactPhone  `json:"phones,omitempty"`
+	Emails       []ContactEmail  `json:"emails,omitempty"`
+	Organization string          `json:"organization,omitempty"`
+	Title        string          `json:"title,omitempty"`
+	Address      *ContactAddress `json:"address,omitempty"`
+	URL          string          `json:"url,omitempty"`
+	// VCard is sent as-is when set, instead of the structured fields
+	VCard string `json:"vcard,omitempty"`
+}
+
+type ContactPhone struct {
+	Number string `json:"number"`
+	// Type is the vCard TEL type, e.g. CELL, HOME, WORK or MAIN (default CELL)
+	Type string `json:"type,omitempty"`
+	// WaID is the WhatsApp number the phone belongs to; derived from Number when empty
+	WaID string `json:"wa_id,omitempty"`
+}
+
+type ContactEmail struct {
+	Address string `json:"address"`
+	// Type is the vCard EMAIL type, e.g. HOME or WORK
+	Type string `json:"type,omitempty"`
+}
+
+type ContactAddress struct {
+	Street     string `json:"street,omitempty"`
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+	// Type is the vCard ADR type, e.g. HOME or WORK
+	Type string `json:"type,omitempty"`
 }
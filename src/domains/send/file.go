@@ -4,6 +4,7 @@ import "mime/multipart"
 
 type FileRequest struct {
 	BaseRequest
+	DetachedUpload
 	File    *multipart.FileHeader `json:"file" form:"file"`
 	FileURL *string               `json:"file_url" form:"file_url"`
 	Caption string                `json:"caption" form:"caption"`
@@ -16,6 +16,8 @@ type IMediaSender interface {
 	SendVideo(ctx context.Context, request VideoRequest) (response GenericResponse, err error)
 	SendAudio(ctx context.Context, request AudioRequest) (response GenericResponse, err error)
 	SendSticker(ctx context.Context, request StickerRequest) (response GenericResponse, err error)
+	SendStickerPack(ctx context.Context, request StickerPackRequest) (response StickerPackResponse, err error)
+	SendAlbum(ctx context.Context, request AlbumRequest) (response AlbumResponse, err error)
 }
 
 // IInteractionSender handles interaction message sending operations
@@ -26,16 +28,31 @@ type IInteractionSender interface {
 	SendPoll(ctx context.Context, request PollRequest) (response GenericResponse, err error)
 }
 
+// ILiveLocationSender handles live location shares
+type ILiveLocationSender interface {
+	StartLiveLocation(ctx context.Context, request LiveLocationRequest) (response LiveLocationResponse, err error)
+	UpdateLiveLocation(ctx context.Context, request LiveLocationUpdateRequest) (response LiveLocationResponse, err error)
+	StopLiveLocation(ctx context.Context, shareID string) (response LiveLocationResponse, err error)
+}
+
 // IPresenceSender handles presence-related operations
 type IPresenceSender interface {
 	SendPresence(ctx context.Context, request PresenceRequest) (response GenericResponse, err error)
 	SendChatPresence(ctx context.Context, request ChatPresenceRequest) (response GenericResponse, err error)
 }
 
+// ISendValidator checks send requests without sending them
+type ISendValidator interface {
+	// ValidateSend validates any of the send requests above as its Send method would, template applied
+	ValidateSend(ctx context.Context, request any) error
+}
+
 // ISendUsecase combines all sender interfaces for backward compatibility
 type ISendUsecase interface {
 	ITextSender
 	IMediaSender
 	IInteractionSender
+	ILiveLocationSender
 	IPresenceSender
+	ISendValidator
 }
@@ -1,7 +1,59 @@
 package send
 
+// Live location limits in seconds; the phone app offers 15 minutes, 1 hour and 8 hours
+const (
+	LiveLocationDefaultDuration     = 15 * 60
+	LiveLocationMinDuration         = 60
+	LiveLocationMaxDuration         = 8 * 60 * 60
+	LiveLocationDefaultFeedInterval = 30
+	LiveLocationMinFeedInterval     = 5
+)
+
 type LocationRequest struct {
 	BaseRequest
 	Latitude  string `json:"latitude" form:"latitude"`
 	Longitude string `json:"longitude" form:"longitude"`
+	// Name and Address label the pin, e.g. a place name and its street address
+	Name    string `json:"name" form:"name"`
+	Address string `json:"address" form:"address"`
+}
+
+// LiveLocationRequest starts sharing a live location. Positions are pushed with UpdateLiveLocation, or pulled from
+// FeedURL every FeedInterval seconds, until the share is stopped or ShareDuration runs out.
+type LiveLocationRequest struct {
+	BaseRequest
+	LiveLocationPosition
+	Caption string `json:"caption" form:"caption"`
+	// ShareDuration is how long the location is shared, in seconds
+	ShareDuration int `json:"share_duration" form:"share_duration"`
+	// FeedURL answers GET with a JSON position ({"latitude": ..., "longitude": ...}) and is polled while sharing
+	FeedURL      string `json:"feed_url" form:"feed_url"`
+	FeedInterval int    `json:"feed_interval" form:"feed_interval"`
+}
+
+// LiveLocationPosition is one position of a live location share
+type LiveLocationPosition struct {
+	Latitude         float64 `json:"latitude" form:"latitude"`
+	Longitude        float64 `json:"longitude" form:"longitude"`
+	AccuracyInMeters uint32  `json:"accuracy_in_meters,omitempty" form:"accuracy_in_meters"`
+	SpeedInMps       float32 `json:"speed_in_mps,omitempty" form:"speed_in_mps"`
+	// Heading is in degrees clockwise from magnetic north
+	Heading uint32 `json:"heading,omitempty" form:"heading"`
+}
+
+type LiveLocationUpdateRequest struct {
+	ShareID string `json:"share_id" uri:"share_id"`
+	LiveLocationPosition
+}
+
+type LiveLocationResponse struct {
+	ShareID        string  `json:"share_id"`
+	Phone          string  `json:"phone"`
+	Status         string  `json:"status"`
+	SequenceNumber int64   `json:"sequence_number"`
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	FeedURL        string  `json:"feed_url,omitempty"`
+	StartedAt      string  `json:"started_at"`
+	ExpiresAt      string  `json:"expires_at"`
 }
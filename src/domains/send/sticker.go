@@ -2,8 +2,49 @@ package send
 
 import "mime/multipart"
 
+const (
+	// StickerMaxEmojis is how many emoji tags WhatsApp keeps per sticker
+	StickerMaxEmojis = 3
+	// StickerPackMaxStickers bounds a pack the way WhatsApp sticker packs are bounded
+	StickerPackMaxStickers = 30
+)
+
+// StickerMetadata is embedded into the sticker so WhatsApp shows its pack, publisher and emojis. Stickers with
+// the same pack name and publisher are grouped as one pack.
+type StickerMetadata struct {
+	PackName      string   `json:"pack_name,omitempty" form:"pack_name"`
+	PackPublisher string   `json:"pack_publisher,omitempty" form:"pack_publisher"`
+	Emojis        []string `json:"emojis,omitempty" form:"emojis"`
+}
+
 type StickerRequest struct {
 	BaseRequest
+	StickerMetadata
+	DetachedUpload
 	Sticker    *multipart.FileHeader `json:"sticker" form:"sticker"`
 	StickerURL *string               `json:"sticker_url" form:"sticker_url"`
 }
+
+// StickerPackRequest sends every image of a zip archive as a sticker of the same pack
+type StickerPackRequest struct {
+	BaseRequest
+	StickerMetadata
+	DetachedUpload
+	Pack    *multipart.FileHeader `json:"pack" form:"pack"`
+	PackURL *string               `json:"pack_url" form:"pack_url"`
+}
+
+type StickerPackItem struct {
+	File      string `json:"file"`
+	MessageID string `json:"message_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type StickerPackResponse struct {
+	PackID   string            `json:"pack_id"`
+	PackName string            `json:"pack_name"`
+	Sent     int               `json:"sent"`
+	Failed   int               `json:"failed"`
+	Stickers []StickerPackItem `json:"stickers"`
+	Status   string            `json:"status"`
+}
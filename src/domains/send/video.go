@@ -4,9 +4,14 @@ import "mime/multipart"
 
 type VideoRequest struct {
 	BaseRequest
+	DetachedUpload
 	Caption  string                `json:"caption" form:"caption"`
 	Video    *multipart.FileHeader `json:"video" form:"video"`
 	ViewOnce bool                  `json:"view_once" form:"view_once"`
 	Compress bool                  `json:"compress"`
 	VideoURL *string               `json:"video_url" form:"video_url"`
+	// GifPlayback sends the video as a muted, looping GIF; uploaded .gif files always are
+	GifPlayback bool `json:"gif_playback" form:"gif_playback"`
+	// PTV sends the video as a round video note, cropped to a square
+	PTV bool `json:"ptv" form:"ptv"`
 }
@@ -0,0 +1,14 @@
+package status
+
+import "context"
+
+type IStatusUsecase interface {
+	PostText(ctx context.Context, request PostTextRequest) (response PostResponse, err error)
+	PostImage(ctx context.Context, request PostImageRequest) (response PostResponse, err error)
+	PostVideo(ctx context.Context, request PostVideoRequest) (response PostResponse, err error)
+	DeleteStatus(ctx context.Context, statusID string) (response StatusActionResponse, err error)
+	ListStatuses(ctx context.Context, request ListStatusesRequest) (response ListStatusesResponse, err error)
+	GetStatus(ctx context.Context, statusID string) (response StatusInfo, err error)
+	MarkViewed(ctx context.Context, statusID string) (response StatusActionResponse, err error)
+	GetPrivacy(ctx context.Context) (response PrivacyResponse, err error)
+}
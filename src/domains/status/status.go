@@ -0,0 +1,90 @@
+package status
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+const (
+	TypeText  = "text"
+	TypeImage = "image"
+	TypeVideo = "video"
+	TypeAudio = "audio"
+	TypeOther = "other"
+
+	// Lifetime is how long WhatsApp shows a status after it was posted
+	Lifetime = 24 * time.Hour
+)
+
+type PostTextRequest struct {
+	Text string `json:"text" form:"text"`
+	// BackgroundColor and TextColor are hex colours, #RRGGBB or #AARRGGBB
+	BackgroundColor string `json:"background_color" form:"background_color"`
+	TextColor       string `json:"text_color" form:"text_color"`
+	// Font is one of the WhatsApp status fonts, e.g. SYSTEM, SYSTEM_BOLD, FB_SCRIPT
+	Font string `json:"font" form:"font"`
+}
+
+type PostImageRequest struct {
+	Caption  string                `json:"caption" form:"caption"`
+	Image    *multipart.FileHeader `json:"image" form:"image"`
+	ImageURL *string               `json:"image_url" form:"image_url"`
+	Compress bool                  `json:"compress" form:"compress"`
+}
+
+type PostVideoRequest struct {
+	Caption  string                `json:"caption" form:"caption"`
+	Video    *multipart.FileHeader `json:"video" form:"video"`
+	VideoURL *string               `json:"video_url" form:"video_url"`
+	Compress bool                  `json:"compress" form:"compress"`
+}
+
+type PostResponse struct {
+	StatusID  string `json:"status_id"`
+	ExpiresAt string `json:"expires_at"`
+	Status    string `json:"status"`
+}
+
+type ListStatusesRequest struct {
+	// Sender limits the list to one contact's statuses
+	Sender       string `json:"sender" query:"sender"`
+	Mine         bool   `json:"mine" query:"mine"`
+	UnviewedOnly bool   `json:"unviewed_only" query:"unviewed_only"`
+	Limit        int    `json:"limit" query:"limit"`
+	Offset       int    `json:"offset" query:"offset"`
+}
+
+type ListStatusesResponse struct {
+	Data []StatusInfo `json:"data"`
+}
+
+type StatusInfo struct {
+	ID              string `json:"id"`
+	Sender          string `json:"sender"`
+	SenderName      string `json:"sender_name,omitempty"`
+	IsFromMe        bool   `json:"is_from_me"`
+	Type            string `json:"type"`
+	Text            string `json:"text,omitempty"`
+	BackgroundColor string `json:"background_color,omitempty"`
+	TextColor       string `json:"text_color,omitempty"`
+	Font            string `json:"font,omitempty"`
+	// MediaType is set for media statuses, which can be downloaded via
+	// GET /message/{id}/download?phone=status@broadcast
+	MediaType string  `json:"media_type,omitempty"`
+	Viewed    bool    `json:"viewed"`
+	ViewedAt  *string `json:"viewed_at,omitempty"`
+	Timestamp string  `json:"timestamp"`
+	ExpiresAt string  `json:"expires_at"`
+}
+
+type StatusActionResponse struct {
+	StatusID string `json:"status_id"`
+	Status   string `json:"status"`
+}
+
+// PrivacyResponse describes who receives the statuses we post, as configured in the phone's status privacy
+type PrivacyResponse struct {
+	// Type is contacts (all contacts), blacklist (contacts except List) or whitelist (only List)
+	Type string   `json:"type"`
+	List []string `json:"list"`
+}
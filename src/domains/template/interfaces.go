@@ -0,0 +1,22 @@
+package template
+
+import "context"
+
+// ITemplateUsecase manages message templates and renders them for the send endpoints
+type ITemplateUsecase interface {
+	CreateTemplate(ctx context.Context, request CreateTemplateRequest) (response Template, err error)
+	ListTemplates(ctx context.Context, request ListTemplatesRequest) (response ListTemplatesResponse, err error)
+	GetTemplate(ctx context.Context, id string) (response Template, err error)
+	UpdateTemplate(ctx context.Context, request UpdateTemplateRequest) (response Template, err error)
+	DeleteTemplate(ctx context.Context, id string) error
+}
+
+// ITemplateRepository persists message templates
+type ITemplateRepository interface {
+	Create(template *Template) error
+	Get(id string) (*Template, error)
+	List(filter *TemplateFilter) ([]*Template, int, error)
+	// Update and Delete report whether a row changed
+	Update(template *Template) (bool, error)
+	Delete(id string) (bool, error)
+}
@@ -0,0 +1,70 @@
+package template
+
+import "time"
+
+// Media types a template attachment can carry; each maps to the matching /send/* endpoint
+const (
+	MediaImage   = "image"
+	MediaVideo   = "video"
+	MediaFile    = "file"
+	MediaAudio   = "audio"
+	MediaSticker = "sticker"
+)
+
+// Template is reusable message content. Body and Caption may contain {{variable}} placeholders
+// that are filled in from the send request before the message goes out.
+type Template struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Body      string    `json:"body"`
+	MediaType string    `json:"media_type,omitempty"`
+	MediaURL  string    `json:"media_url,omitempty"`
+	Caption   string    `json:"caption,omitempty"`
+	Variables []string  `json:"variables"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TemplateFilter represents query filters for templates
+type TemplateFilter struct {
+	Search string
+	Limit  int
+	Offset int
+}
+
+type CreateTemplateRequest struct {
+	Name      string `json:"name"`
+	Body      string `json:"body"`
+	MediaType string `json:"media_type"`
+	MediaURL  string `json:"media_url"`
+	Caption   string `json:"caption"`
+}
+
+// UpdateTemplateRequest replaces every editable field of the template
+type UpdateTemplateRequest struct {
+	ID        string `json:"id" uri:"id"`
+	Name      string `json:"name"`
+	Body      string `json:"body"`
+	MediaType string `json:"media_type"`
+	MediaURL  string `json:"media_url"`
+	Caption   string `json:"caption"`
+}
+
+type ListTemplatesRequest struct {
+	Search string `json:"search" query:"search"`
+	Limit  int    `json:"limit" query:"limit"`
+	Offset int    `json:"offset" query:"offset"`
+}
+
+type ListTemplatesResponse struct {
+	Data  []Template `json:"data"`
+	Total int        `json:"total"`
+}
+
+// RenderedTemplate is a template with all placeholders substituted
+type RenderedTemplate struct {
+	Body      string
+	Caption   string
+	MediaType string
+	MediaURL  string
+}
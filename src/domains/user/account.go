@@ -52,6 +52,11 @@ type MyPrivacySettingResponse struct {
 	ReadReceipts string `json:"read_receipts"`
 }
 
+type MyListGroupsRequest struct {
+	// Refresh lists the groups from WhatsApp and replaces the stored ones
+	Refresh bool `json:"refresh" query:"refresh"`
+}
+
 type MyListGroupsResponse struct {
 	Data []types.GroupInfo `json:"data"`
 }
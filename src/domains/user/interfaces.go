@@ -20,7 +20,7 @@ type IUserProfile interface {
 
 // IUserListing handles user listing operations
 type IUserListing interface {
-	MyListGroups(ctx context.Context) (response MyListGroupsResponse, err error)
+	MyListGroups(ctx context.Context, request MyListGroupsRequest) (response MyListGroupsResponse, err error)
 	MyListNewsletter(ctx context.Context) (response MyListNewsletterResponse, err error)
 	MyListContacts(ctx context.Context) (response MyListContactsResponse, err error)
 }
@@ -0,0 +1,404 @@
+package chatstorage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	domainCampaign "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/campaign"
+)
+
+// CampaignRepository stores broadcast campaigns and their recipients in the chat storage database
+type CampaignRepository struct {
+	db *sql.DB
+}
+
+func NewCampaignRepository(db *sql.DB) domainCampaign.ICampaignRepository {
+	return &CampaignRepository{db: db}
+}
+
+const campaignColumns = `id, device_id, name, type, payload, status, rate_per_minute, jitter_seconds,
+	quiet_hours_start, quiet_hours_end, timezone, completed_at, created_at, updated_at`
+
+const recipientColumns = `id, campaign_id, phone, variables, status, message_id, error, sent_at, updated_at`
+
+// Create inserts a campaign and all of its recipients in one transaction
+func (r *CampaignRepository) Create(campaign *domainCampaign.Campaign, recipients []*domainCampaign.Recipient) error {
+	if campaign == nil || strings.TrimSpace(campaign.ID) == "" {
+		return fmt.Errorf("campaign with id is required")
+	}
+
+	now := time.Now().UTC()
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+	if campaign.Status == "" {
+		campaign.Status = domainCampaign.StatusRunning
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO campaigns (
+			id, device_id, name, type, payload, status, rate_per_minute, jitter_seconds,
+			quiet_hours_start, quiet_hours_end, timezone, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, campaign.ID, campaign.DeviceID, campaign.Name, campaign.Type, string(campaign.Payload), campaign.Status,
+		campaign.RatePerMinute, campaign.JitterSeconds, campaign.QuietHoursStart, campaign.QuietHoursEnd,
+		campaign.Timezone, now, now)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO campaign_recipients (campaign_id, phone, variables, status, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, recipient := range recipients {
+		variables, err := json.Marshal(recipient.Variables)
+		if err != nil {
+			return err
+		}
+		if recipient.Status == "" {
+			recipient.Status = domainCampaign.RecipientPending
+		}
+		result, err := stmt.Exec(campaign.ID, recipient.Phone, string(variables), recipient.Status, now)
+		if err != nil {
+			return err
+		}
+		recipient.ID, _ = result.LastInsertId()
+		recipient.CampaignID = campaign.ID
+		recipient.UpdatedAt = now
+	}
+
+	return tx.Commit()
+}
+
+// Get returns a campaign with its progress, scoped to deviceID when it is not empty
+func (r *CampaignRepository) Get(deviceID, id string) (*domainCampaign.Campaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM campaigns WHERE id = ?`
+	args := []any{id}
+	if deviceID != "" {
+		query += " AND device_id = ?"
+		args = append(args, deviceID)
+	}
+
+	campaign, err := r.scanCampaign(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if campaign.Progress, err = r.progress(campaign.ID); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// List returns campaigns, newest first, along with the total matching count
+func (r *CampaignRepository) List(filter *domainCampaign.CampaignFilter) ([]*domainCampaign.Campaign, int, error) {
+	var conditions []string
+	var args []any
+
+	if filter.DeviceID != "" {
+		conditions = append(conditions, "device_id = ?")
+		args = append(args, filter.DeviceID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM campaigns"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + campaignColumns + ` FROM campaigns` + where + ` ORDER BY created_at DESC`
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+		if filter.Offset > 0 {
+			query += " OFFSET ?"
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var campaigns []*domainCampaign.Campaign
+	for rows.Next() {
+		campaign, err := r.scanCampaign(rows)
+		if err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		campaigns = append(campaigns, campaign)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	for _, campaign := range campaigns {
+		if campaign.Progress, err = r.progress(campaign.ID); err != nil {
+			return nil, 0, err
+		}
+	}
+	return campaigns, total, nil
+}
+
+// SetStatus changes the campaign status only when the current status is one of from
+func (r *CampaignRepository) SetStatus(deviceID, id string, from []string, status string) (bool, error) {
+	if len(from) == 0 {
+		return false, fmt.Errorf("at least one source status is required")
+	}
+
+	now := time.Now().UTC()
+	var completedAt any
+	if status == domainCampaign.StatusCompleted || status == domainCampaign.StatusCancelled {
+		completedAt = now
+	}
+
+	query := `UPDATE campaigns SET status = ?, completed_at = ?, updated_at = ?
+		WHERE id = ? AND status IN (?` + strings.Repeat(", ?", len(from)-1) + `)`
+	args := []any{status, completedAt, now, id}
+	for _, s := range from {
+		args = append(args, s)
+	}
+	if deviceID != "" {
+		query += " AND device_id = ?"
+		args = append(args, deviceID)
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// ListRecipients returns recipients in send order along with the total matching count
+func (r *CampaignRepository) ListRecipients(filter *domainCampaign.RecipientFilter) ([]*domainCampaign.Recipient, int, error) {
+	where := " WHERE campaign_id = ?"
+	args := []any{filter.CampaignID}
+	if filter.Status != "" {
+		where += " AND status = ?"
+		args = append(args, filter.Status)
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM campaign_recipients"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + recipientColumns + ` FROM campaign_recipients` + where + ` ORDER BY id ASC`
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+		if filter.Offset > 0 {
+			query += " OFFSET ?"
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var recipients []*domainCampaign.Recipient
+	for rows.Next() {
+		recipient, err := r.scanRecipient(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, total, rows.Err()
+}
+
+// ClaimNextRecipient moves the oldest pending recipient to sending. The status guard on the update
+// keeps a recipient from being sent twice if two senders ever race on the same campaign.
+func (r *CampaignRepository) ClaimNextRecipient(campaignID string) (*domainCampaign.Recipient, error) {
+	for {
+		recipient, err := r.scanRecipient(r.db.QueryRow(`SELECT `+recipientColumns+` FROM campaign_recipients
+			WHERE campaign_id = ? AND status = ?
+			ORDER BY id ASC
+			LIMIT 1
+		`, campaignID, domainCampaign.RecipientPending))
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := r.db.Exec(`
+			UPDATE campaign_recipients SET status = ?, updated_at = ?
+			WHERE id = ? AND status = ?
+		`, domainCampaign.RecipientSending, time.Now().UTC(), recipient.ID, domainCampaign.RecipientPending)
+		if err != nil {
+			return nil, err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+			recipient.Status = domainCampaign.RecipientSending
+			return recipient, nil
+		}
+	}
+}
+
+// FinishRecipient records the outcome of a send attempt
+func (r *CampaignRepository) FinishRecipient(id int64, status, messageID, errMsg string, finishedAt time.Time) error {
+	var sentAt any
+	if status == domainCampaign.RecipientSent {
+		sentAt = finishedAt.UTC()
+	}
+	_, err := r.db.Exec(`
+		UPDATE campaign_recipients SET status = ?, message_id = ?, error = ?, sent_at = ?, updated_at = ?
+		WHERE id = ?
+	`, status, messageID, errMsg, sentAt, finishedAt.UTC(), id)
+	return err
+}
+
+// CancelPendingRecipients marks every recipient that has not been attempted yet as cancelled
+func (r *CampaignRepository) CancelPendingRecipients(campaignID string) error {
+	_, err := r.db.Exec(`
+		UPDATE campaign_recipients SET status = ?, updated_at = ?
+		WHERE campaign_id = ? AND status = ?
+	`, domainCampaign.RecipientCancelled, time.Now().UTC(), campaignID, domainCampaign.RecipientPending)
+	return err
+}
+
+// FailInterrupted fails recipients a previous process claimed but never finished. They are not
+// retried because the message may already have reached WhatsApp.
+func (r *CampaignRepository) FailInterrupted(reason string) (int, error) {
+	result, err := r.db.Exec(`
+		UPDATE campaign_recipients SET status = ?, error = ?, updated_at = ?
+		WHERE status = ?
+	`, domainCampaign.RecipientFailed, reason, time.Now().UTC(), domainCampaign.RecipientSending)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+func (r *CampaignRepository) progress(campaignID string) (domainCampaign.Progress, error) {
+	var progress domainCampaign.Progress
+
+	rows, err := r.db.Query(`SELECT status, COUNT(*) FROM campaign_recipients WHERE campaign_id = ? GROUP BY status`, campaignID)
+	if err != nil {
+		return progress, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return progress, err
+		}
+		progress.Total += count
+		switch status {
+		case domainCampaign.RecipientPending, domainCampaign.RecipientSending:
+			progress.Pending += count
+		case domainCampaign.RecipientSent:
+			progress.Sent += count
+		case domainCampaign.RecipientFailed:
+			progress.Failed += count
+		case domainCampaign.RecipientSkipped:
+			progress.Skipped += count
+		case domainCampaign.RecipientCancelled:
+			progress.Cancelled += count
+		}
+	}
+	if progress.Total > 0 {
+		done := progress.Total - progress.Pending
+		progress.Percent = float64(done*10000/progress.Total) / 100
+	}
+	return progress, rows.Err()
+}
+
+func (r *CampaignRepository) scanCampaign(scanner interface{ Scan(...any) error }) (*domainCampaign.Campaign, error) {
+	var (
+		campaign        domainCampaign.Campaign
+		payload         string
+		quietHoursStart sql.NullString
+		quietHoursEnd   sql.NullString
+		timezone        sql.NullString
+		completedAt     sql.NullTime
+	)
+
+	err := scanner.Scan(
+		&campaign.ID, &campaign.DeviceID, &campaign.Name, &campaign.Type, &payload, &campaign.Status,
+		&campaign.RatePerMinute, &campaign.JitterSeconds, &quietHoursStart, &quietHoursEnd, &timezone,
+		&completedAt, &campaign.CreatedAt, &campaign.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign.Payload = []byte(payload)
+	campaign.QuietHoursStart = quietHoursStart.String
+	campaign.QuietHoursEnd = quietHoursEnd.String
+	campaign.Timezone = timezone.String
+	if completedAt.Valid {
+		t := completedAt.Time
+		campaign.CompletedAt = &t
+	}
+	return &campaign, nil
+}
+
+func (r *CampaignRepository) scanRecipient(scanner interface{ Scan(...any) error }) (*domainCampaign.Recipient, error) {
+	var (
+		recipient domainCampaign.Recipient
+		variables sql.NullString
+		messageID sql.NullString
+		errMsg    sql.NullString
+		sentAt    sql.NullTime
+	)
+
+	err := scanner.Scan(
+		&recipient.ID, &recipient.CampaignID, &recipient.Phone, &variables, &recipient.Status,
+		&messageID, &errMsg, &sentAt, &recipient.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if variables.Valid && variables.String != "" && variables.String != "null" {
+		if err := json.Unmarshal([]byte(variables.String), &recipient.Variables); err != nil {
+			return nil, fmt.Errorf("decode variables of recipient %d: %w", recipient.ID, err)
+		}
+	}
+	recipient.MessageID = messageID.String
+	recipient.Error = errMsg.String
+	if sentAt.Valid {
+		t := sentAt.Time
+		recipient.SentAt = &t
+	}
+	return &recipient, nil
+}
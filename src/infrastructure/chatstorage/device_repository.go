@@ -77,6 +77,14 @@ func (r *DeviceRepository) GetMessageByID(id string) (*domainChatStorage.Message
 	return r.base.GetMessageByID(id)
 }
 
+func (r *DeviceRepository) GetMessageByIDByDevice(deviceID, id string) (*domainChatStorage.Message, error) {
+	targetDeviceID := deviceID
+	if targetDeviceID == "" {
+		targetDeviceID = r.deviceID
+	}
+	return r.base.GetMessageByIDByDevice(targetDeviceID, id)
+}
+
 func (r *DeviceRepository) GetMessages(filter *domainChatStorage.MessageFilter) ([]*domainChatStorage.Message, error) {
 	if filter != nil && filter.DeviceID == "" {
 		filter.DeviceID = r.deviceID
@@ -84,6 +92,14 @@ func (r *DeviceRepository) GetMessages(filter *domainChatStorage.MessageFilter)
 	return r.base.GetMessages(filter)
 }
 
+func (r *DeviceRepository) GetOldestMessageByDevice(deviceID, chatJID string) (*domainChatStorage.Message, error) {
+	targetDeviceID := deviceID
+	if targetDeviceID == "" {
+		targetDeviceID = r.deviceID
+	}
+	return r.base.GetOldestMessageByDevice(targetDeviceID, chatJID)
+}
+
 func (r *DeviceRepository) SearchMessages(deviceID, chatJID, searchText string, limit int) ([]*domainChatStorage.Message, error) {
 	targetDeviceID := deviceID
 	if targetDeviceID == "" {
@@ -152,6 +168,108 @@ func (r *DeviceRepository) DeleteDeviceData(deviceID string) error {
 	return r.base.DeleteDeviceData(target)
 }
 
+func (r *DeviceRepository) StoreStatus(status *domainChatStorage.Status) error {
+	if status != nil && status.DeviceID == "" {
+		status.DeviceID = r.deviceID
+	}
+	return r.base.StoreStatus(status)
+}
+
+func (r *DeviceRepository) GetStatus(deviceID, id string) (*domainChatStorage.Status, error) {
+	return r.base.GetStatus(r.statusDeviceID(deviceID), id)
+}
+
+func (r *DeviceRepository) GetStatuses(filter *domainChatStorage.StatusFilter) ([]*domainChatStorage.Status, error) {
+	if filter != nil && filter.DeviceID == "" {
+		filter.DeviceID = r.deviceID
+	}
+	return r.base.GetStatuses(filter)
+}
+
+func (r *DeviceRepository) MarkStatusViewed(deviceID, id string, viewedAt time.Time) error {
+	return r.base.MarkStatusViewed(r.statusDeviceID(deviceID), id, viewedAt)
+}
+
+func (r *DeviceRepository) DeleteStatus(deviceID, id string) error {
+	return r.base.DeleteStatus(r.statusDeviceID(deviceID), id)
+}
+
+func (r *DeviceRepository) DeleteExpiredStatuses(now time.Time) (int64, error) {
+	return r.base.DeleteExpiredStatuses(now)
+}
+
+func (r *DeviceRepository) statusDeviceID(deviceID string) string {
+	if deviceID == "" {
+		return r.deviceID
+	}
+	return deviceID
+}
+
+func (r *DeviceRepository) StorePoll(poll *domainChatStorage.Poll) error {
+	if poll != nil && poll.DeviceID == "" {
+		poll.DeviceID = r.deviceID
+	}
+	return r.base.StorePoll(poll)
+}
+
+func (r *DeviceRepository) GetPoll(deviceID, id string) (*domainChatStorage.Poll, error) {
+	return r.base.GetPoll(r.statusDeviceID(deviceID), id)
+}
+
+func (r *DeviceRepository) StorePollVote(vote *domainChatStorage.PollVote) error {
+	if vote != nil && vote.DeviceID == "" {
+		vote.DeviceID = r.deviceID
+	}
+	return r.base.StorePollVote(vote)
+}
+
+func (r *DeviceRepository) GetPollVotes(deviceID, pollID string) ([]*domainChatStorage.PollVote, error) {
+	return r.base.GetPollVotes(r.statusDeviceID(deviceID), pollID)
+}
+
+func (r *DeviceRepository) StoreGroup(group *domainChatStorage.Group) error {
+	if group != nil && group.DeviceID == "" {
+		group.DeviceID = r.deviceID
+	}
+	return r.base.StoreGroup(group)
+}
+
+func (r *DeviceRepository) GetGroup(deviceID, jid string) (*domainChatStorage.Group, error) {
+	return r.base.GetGroup(r.statusDeviceID(deviceID), jid)
+}
+
+func (r *DeviceRepository) GetGroups(deviceID string) ([]*domainChatStorage.Group, error) {
+	return r.base.GetGroups(r.statusDeviceID(deviceID))
+}
+
+func (r *DeviceRepository) DeleteGroup(deviceID, jid string) error {
+	return r.base.DeleteGroup(r.statusDeviceID(deviceID), jid)
+}
+
+func (r *DeviceRepository) ReplaceGroups(deviceID string, groups []*domainChatStorage.Group) error {
+	return r.base.ReplaceGroups(r.statusDeviceID(deviceID), groups)
+}
+
+func (r *DeviceRepository) GetGroupsSyncedAt(deviceID string) (*time.Time, error) {
+	return r.base.GetGroupsSyncedAt(r.statusDeviceID(deviceID))
+}
+
+func (r *DeviceRepository) StoreGroupEvents(events []*domainChatStorage.GroupEvent) error {
+	for _, event := range events {
+		if event != nil && event.DeviceID == "" {
+			event.DeviceID = r.deviceID
+		}
+	}
+	return r.base.StoreGroupEvents(events)
+}
+
+func (r *DeviceRepository) GetGroupEvents(filter *domainChatStorage.GroupEventFilter) ([]*domainChatStorage.GroupEvent, int, error) {
+	if filter != nil && filter.DeviceID == "" {
+		filter.DeviceID = r.deviceID
+	}
+	return r.base.GetGroupEvents(filter)
+}
+
 func (r *DeviceRepository) SaveDeviceRecord(record *domainChatStorage.DeviceRecord) error {
 	return r.base.SaveDeviceRecord(record)
 }
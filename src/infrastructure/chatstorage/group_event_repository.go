@@ -0,0 +1,109 @@
+package chatstorage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+const groupEventColumns = `id, device_id, group_jid, type, participant, actor, setting, value, timestamp`
+
+// StoreGroupEvents records the changes carried by one group notification in a single transaction
+func (r *SQLiteRepository) StoreGroupEvents(events []*domainChatStorage.GroupEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, event := range events {
+		if event == nil || event.DeviceID == "" || strings.TrimSpace(event.GroupJID) == "" || event.Type == "" {
+			return fmt.Errorf("group event with device id, group jid and type is required")
+		}
+		result, err := tx.Exec(`
+			INSERT INTO group_events (device_id, group_jid, type, participant, actor, setting, value, timestamp)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, event.DeviceID, event.GroupJID, event.Type, event.Participant, event.Actor, event.Setting, event.Value,
+			event.Timestamp)
+		if err != nil {
+			return err
+		}
+		event.ID, _ = result.LastInsertId()
+	}
+
+	return tx.Commit()
+}
+
+// GetGroupEvents lists the recorded events of a group, newest first, along with the total matching count
+func (r *SQLiteRepository) GetGroupEvents(filter *domainChatStorage.GroupEventFilter) ([]*domainChatStorage.GroupEvent, int, error) {
+	if filter == nil || filter.DeviceID == "" {
+		return nil, 0, fmt.Errorf("device_id is required for group event queries (data isolation)")
+	}
+
+	conditions := []string{"device_id = ?", "group_jid = ?"}
+	args := []any{filter.DeviceID, filter.GroupJID}
+
+	if len(filter.Types) > 0 {
+		conditions = append(conditions, "type IN (?"+strings.Repeat(", ?", len(filter.Types)-1)+")")
+		for _, eventType := range filter.Types {
+			args = append(args, eventType)
+		}
+	}
+	if filter.Participant != "" {
+		conditions = append(conditions, "participant = ?")
+		args = append(args, filter.Participant)
+	}
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+	if filter.StartTime != nil {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		conditions = append(conditions, "timestamp <= ?")
+		args = append(args, *filter.EndTime)
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM group_events"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := "SELECT " + groupEventColumns + " FROM group_events" + where + " ORDER BY timestamp DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+		if filter.Offset > 0 {
+			query += " OFFSET ?"
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var events []*domainChatStorage.GroupEvent
+	for rows.Next() {
+		event := &domainChatStorage.GroupEvent{}
+		var value sql.NullString
+		if err := rows.Scan(&event.ID, &event.DeviceID, &event.GroupJID, &event.Type, &event.Participant, &event.Actor,
+			&event.Setting, &value, &event.Timestamp); err != nil {
+			return nil, 0, err
+		}
+		event.Value = value.String
+		events = append(events, event)
+	}
+	return events, total, rows.Err()
+}
@@ -0,0 +1,172 @@
+package chatstorage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+const groupColumns = `jid, device_id, name, info, updated_at`
+
+// StoreGroup creates or updates the metadata of a group
+func (r *SQLiteRepository) StoreGroup(group *domainChatStorage.Group) error {
+	if group == nil || strings.TrimSpace(group.JID) == "" || group.DeviceID == "" {
+		return fmt.Errorf("group with jid and device id is required")
+	}
+	return storeGroup(r.db, group)
+}
+
+// GetGroup returns the stored metadata of a group, or nil when it is not known
+func (r *SQLiteRepository) GetGroup(deviceID, jid string) (*domainChatStorage.Group, error) {
+	if deviceID == "" {
+		return nil, fmt.Errorf("device_id is required for group queries (data isolation)")
+	}
+
+	group, err := scanGroup(r.db.QueryRow(`
+		SELECT `+groupColumns+`
+		FROM group_metadata
+		WHERE device_id = ? AND jid = ?
+		LIMIT 1
+	`, deviceID, jid))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return group, err
+}
+
+// GetGroups lists the stored groups of a device ordered by name
+func (r *SQLiteRepository) GetGroups(deviceID string) ([]*domainChatStorage.Group, error) {
+	if deviceID == "" {
+		return nil, fmt.Errorf("device_id is required for group queries (data isolation)")
+	}
+
+	rows, err := r.db.Query(`
+		SELECT `+groupColumns+`
+		FROM group_metadata
+		WHERE device_id = ?
+		ORDER BY name, jid
+	`, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*domainChatStorage.Group
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// DeleteGroup forgets a group, e.g. after the device left it
+func (r *SQLiteRepository) DeleteGroup(deviceID, jid string) error {
+	_, err := r.db.Exec(`DELETE FROM group_metadata WHERE device_id = ? AND jid = ?`, deviceID, jid)
+	return err
+}
+
+// ReplaceGroups stores the full group list of a device, dropping groups that are no longer in it, and records
+// when the list was taken
+func (r *SQLiteRepository) ReplaceGroups(deviceID string, groups []*domainChatStorage.Group) error {
+	if deviceID == "" {
+		return fmt.Errorf("device id is required")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM group_metadata WHERE device_id = ?`, deviceID); err != nil {
+		return fmt.Errorf("failed to clear device groups: %w", err)
+	}
+	for _, group := range groups {
+		if group == nil || strings.TrimSpace(group.JID) == "" {
+			continue
+		}
+		group.DeviceID = deviceID
+		if err := storeGroup(tx, group); err != nil {
+			return fmt.Errorf("failed to store group %s: %w", group.JID, err)
+		}
+	}
+
+	now := time.Now()
+	result, err := tx.Exec(`UPDATE group_metadata_syncs SET synced_at = ? WHERE device_id = ?`, now, deviceID)
+	if err != nil {
+		return err
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		if _, err := tx.Exec(`INSERT INTO group_metadata_syncs (device_id, synced_at) VALUES (?, ?)`, deviceID, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetGroupsSyncedAt returns when the group list of a device was last stored in full, or nil if it never was
+func (r *SQLiteRepository) GetGroupsSyncedAt(deviceID string) (*time.Time, error) {
+	var syncedAt time.Time
+	err := r.db.QueryRow(`SELECT synced_at FROM group_metadata_syncs WHERE device_id = ?`, deviceID).Scan(&syncedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &syncedAt, nil
+}
+
+// groupExecer is satisfied by both *sql.DB and *sql.Tx
+type groupExecer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func storeGroup(db groupExecer, group *domainChatStorage.Group) error {
+	info, err := json.Marshal(group.Info)
+	if err != nil {
+		return err
+	}
+	if group.Name == "" {
+		group.Name = group.Info.Name
+	}
+	group.UpdatedAt = time.Now()
+
+	// Try update first, then insert if no rows affected (cross-db compatible)
+	result, err := db.Exec(`
+		UPDATE group_metadata SET name = ?, info = ?, updated_at = ?
+		WHERE jid = ? AND device_id = ?
+	`, group.Name, string(info), group.UpdatedAt, group.JID, group.DeviceID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		_, err = db.Exec(`
+			INSERT INTO group_metadata (`+groupColumns+`)
+			VALUES (?, ?, ?, ?, ?)
+		`, group.JID, group.DeviceID, group.Name, string(info), group.UpdatedAt)
+	}
+	return err
+}
+
+func scanGroup(row interface{ Scan(dest ...any) error }) (*domainChatStorage.Group, error) {
+	group := &domainChatStorage.Group{}
+	var info string
+	if err := row.Scan(&group.JID, &group.DeviceID, &group.Name, &info, &group.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(info), &group.Info); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata of group %s: %w", group.JID, err)
+	}
+	return group, nil
+}
@@ -0,0 +1,144 @@
+package chatstorage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+// StorePoll creates or updates a poll definition
+func (r *SQLiteRepository) StorePoll(poll *domainChatStorage.Poll) error {
+	if poll == nil || strings.TrimSpace(poll.ID) == "" || poll.DeviceID == "" {
+		return fmt.Errorf("poll with id and device id is required")
+	}
+	if poll.CreatedAt.IsZero() {
+		poll.CreatedAt = time.Now()
+	}
+
+	options, err := json.Marshal(poll.Options)
+	if err != nil {
+		return err
+	}
+
+	// Try update first, then insert if no rows affected (cross-db compatible)
+	result, err := r.db.Exec(`
+		UPDATE polls SET chat_jid = ?, creator = ?, question = ?, options = ?, selectable_count = ?, timestamp = ?
+		WHERE id = ? AND device_id = ?
+	`, poll.ChatJID, poll.Creator, poll.Question, string(options), poll.SelectableCount, poll.Timestamp,
+		poll.ID, poll.DeviceID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		_, err = r.db.Exec(`
+			INSERT INTO polls (id, device_id, chat_jid, creator, question, options, selectable_count, timestamp, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, poll.ID, poll.DeviceID, poll.ChatJID, poll.Creator, poll.Question, string(options), poll.SelectableCount,
+			poll.Timestamp, poll.CreatedAt)
+	}
+	return err
+}
+
+// GetPoll returns a poll of the device, or nil when it does not exist
+func (r *SQLiteRepository) GetPoll(deviceID, id string) (*domainChatStorage.Poll, error) {
+	if deviceID == "" {
+		return nil, fmt.Errorf("device_id is required for poll queries (data isolation)")
+	}
+
+	poll := &domainChatStorage.Poll{}
+	var options string
+	err := r.db.QueryRow(`
+		SELECT id, device_id, chat_jid, creator, question, options, selectable_count, timestamp, created_at
+		FROM polls
+		WHERE device_id = ? AND id = ?
+		LIMIT 1
+	`, deviceID, id).Scan(&poll.ID, &poll.DeviceID, &poll.ChatJID, &poll.Creator, &poll.Question, &options,
+		&poll.SelectableCount, &poll.Timestamp, &poll.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(options), &poll.Options); err != nil {
+		return nil, fmt.Errorf("failed to decode options of poll %s: %w", id, err)
+	}
+	return poll, nil
+}
+
+// StorePollVote records the selection of a voter. Votes can arrive out of order, so an older vote never
+// replaces a newer one.
+func (r *SQLiteRepository) StorePollVote(vote *domainChatStorage.PollVote) error {
+	if vote == nil || strings.TrimSpace(vote.PollID) == "" || vote.DeviceID == "" || vote.Voter == "" {
+		return fmt.Errorf("poll vote with poll id, device id and voter is required")
+	}
+
+	options, err := json.Marshal(vote.Options)
+	if err != nil {
+		return err
+	}
+	if vote.Options == nil {
+		options = []byte("[]")
+	}
+
+	var existing time.Time
+	err = r.db.QueryRow(`
+		SELECT timestamp FROM poll_votes WHERE poll_id = ? AND device_id = ? AND voter = ?
+	`, vote.PollID, vote.DeviceID, vote.Voter).Scan(&existing)
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = r.db.Exec(`
+			INSERT INTO poll_votes (poll_id, device_id, voter, voter_name, options, timestamp)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, vote.PollID, vote.DeviceID, vote.Voter, vote.VoterName, string(options), vote.Timestamp)
+		return err
+	case err != nil:
+		return err
+	case existing.After(vote.Timestamp):
+		return nil
+	}
+
+	_, err = r.db.Exec(`
+		UPDATE poll_votes SET voter_name = ?, options = ?, timestamp = ?
+		WHERE poll_id = ? AND device_id = ? AND voter = ?
+	`, vote.VoterName, string(options), vote.Timestamp, vote.PollID, vote.DeviceID, vote.Voter)
+	return err
+}
+
+// GetPollVotes lists the current selection of every voter of a poll, oldest vote first
+func (r *SQLiteRepository) GetPollVotes(deviceID, pollID string) ([]*domainChatStorage.PollVote, error) {
+	if deviceID == "" {
+		return nil, fmt.Errorf("device_id is required for poll queries (data isolation)")
+	}
+
+	rows, err := r.db.Query(`
+		SELECT poll_id, device_id, voter, voter_name, options, timestamp
+		FROM poll_votes
+		WHERE device_id = ? AND poll_id = ?
+		ORDER BY timestamp ASC
+	`, deviceID, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var votes []*domainChatStorage.PollVote
+	for rows.Next() {
+		vote := &domainChatStorage.PollVote{}
+		var options string
+		if err := rows.Scan(&vote.PollID, &vote.DeviceID, &vote.Voter, &vote.VoterName, &options, &vote.Timestamp); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(options), &vote.Options); err != nil {
+			return nil, fmt.Errorf("failed to decode vote of %s on poll %s: %w", vote.Voter, pollID, err)
+		}
+		votes = append(votes, vote)
+	}
+	return votes, rows.Err()
+}
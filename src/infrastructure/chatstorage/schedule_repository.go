@@ -0,0 +1,238 @@
+package chatstorage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	domainSchedule "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/schedule"
+)
+
+// ScheduleRepository stores scheduled messages in the chat storage database
+type ScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewScheduleRepository(db *sql.DB) domainSchedule.IScheduleRepository {
+	return &ScheduleRepository{db: db}
+}
+
+const scheduleColumns = `id, device_id, type, phone, payload, send_at, timezone, status,
+	message_id, error, sent_at, created_at, updated_at`
+
+// Create inserts a new scheduled message. Times are stored in UTC so send_at comparisons stay ordered.
+func (r *ScheduleRepository) Create(message *domainSchedule.ScheduledMessage) error {
+	if message == nil || strings.TrimSpace(message.ID) == "" {
+		return fmt.Errorf("scheduled message with id is required")
+	}
+
+	now := time.Now().UTC()
+	message.SendAt = message.SendAt.UTC()
+	message.CreatedAt = now
+	message.UpdatedAt = now
+	if message.Status == "" {
+		message.Status = domainSchedule.StatusPending
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO scheduled_messages (
+			id, device_id, type, phone, payload, send_at, timezone, status,
+			message_id, error, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, message.ID, message.DeviceID, message.Type, message.Phone, string(message.Payload), message.SendAt,
+		message.Timezone, message.Status, message.MessageID, message.Error, message.CreatedAt, message.UpdatedAt)
+	return err
+}
+
+// Get returns a scheduled message, scoped to deviceID when it is not empty
+func (r *ScheduleRepository) Get(deviceID, id string) (*domainSchedule.ScheduledMessage, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM scheduled_messages WHERE id = ?`
+	args := []any{id}
+	if deviceID != "" {
+		query += " AND device_id = ?"
+		args = append(args, deviceID)
+	}
+
+	message, err := r.scanScheduledMessage(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return message, err
+}
+
+// List returns scheduled messages ordered by send time along with the total matching count
+func (r *ScheduleRepository) List(filter *domainSchedule.ScheduleFilter) ([]*domainSchedule.ScheduledMessage, int, error) {
+	var conditions []string
+	var args []any
+
+	if filter.DeviceID != "" {
+		conditions = append(conditions, "device_id = ?")
+		args = append(args, filter.DeviceID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM scheduled_messages"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + scheduleColumns + ` FROM scheduled_messages` + where + ` ORDER BY send_at ASC`
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+		if filter.Offset > 0 {
+			query += " OFFSET ?"
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var messages []*domainSchedule.ScheduledMessage
+	for rows.Next() {
+		message, err := r.scanScheduledMessage(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, total, rows.Err()
+}
+
+// Reschedule moves a pending message to a new send time
+func (r *ScheduleRepository) Reschedule(deviceID, id string, sendAt time.Time, timezone string) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE scheduled_messages SET send_at = ?, timezone = ?, updated_at = ?
+		WHERE id = ? AND device_id = ? AND status = ?
+	`, sendAt.UTC(), timezone, time.Now().UTC(), id, deviceID, domainSchedule.StatusPending)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// Cancel marks a pending message as cancelled
+func (r *ScheduleRepository) Cancel(deviceID, id string) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE scheduled_messages SET status = ?, updated_at = ?
+		WHERE id = ? AND device_id = ? AND status = ?
+	`, domainSchedule.StatusCancelled, time.Now().UTC(), id, deviceID, domainSchedule.StatusPending)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// ClaimDue moves due pending messages to sending. The status guard on the update keeps a
+// message from being claimed twice even if a cancel races with the dispatcher.
+func (r *ScheduleRepository) ClaimDue(now time.Time, limit int) ([]*domainSchedule.ScheduledMessage, error) {
+	rows, err := r.db.Query(`SELECT `+scheduleColumns+` FROM scheduled_messages
+		WHERE status = ? AND send_at <= ?
+		ORDER BY send_at ASC
+		LIMIT ?
+	`, domainSchedule.StatusPending, now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var due []*domainSchedule.ScheduledMessage
+	for rows.Next() {
+		message, err := r.scanScheduledMessage(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		due = append(due, message)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var claimed []*domainSchedule.ScheduledMessage
+	for _, message := range due {
+		result, err := r.db.Exec(`
+			UPDATE scheduled_messages SET status = ?, updated_at = ?
+			WHERE id = ? AND status = ?
+		`, domainSchedule.StatusSending, now.UTC(), message.ID, domainSchedule.StatusPending)
+		if err != nil {
+			return claimed, err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+			message.Status = domainSchedule.StatusSending
+			claimed = append(claimed, message)
+		}
+	}
+	return claimed, nil
+}
+
+// Finish records the outcome of a send attempt
+func (r *ScheduleRepository) Finish(id, status, messageID, errMsg string, finishedAt time.Time) error {
+	var sentAt any
+	if status == domainSchedule.StatusSent {
+		sentAt = finishedAt.UTC()
+	}
+	_, err := r.db.Exec(`
+		UPDATE scheduled_messages SET status = ?, message_id = ?, error = ?, sent_at = ?, updated_at = ?
+		WHERE id = ?
+	`, status, messageID, errMsg, sentAt, finishedAt.UTC(), id)
+	return err
+}
+
+// FailInterrupted fails messages a previous process claimed but never finished. They are not
+// retried because the send may already have reached WhatsApp.
+func (r *ScheduleRepository) FailInterrupted(reason string) (int, error) {
+	result, err := r.db.Exec(`
+		UPDATE scheduled_messages SET status = ?, error = ?, updated_at = ?
+		WHERE status = ?
+	`, domainSchedule.StatusFailed, reason, time.Now().UTC(), domainSchedule.StatusSending)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return int(rowsAffected), nil
+}
+
+func (r *ScheduleRepository) scanScheduledMessage(scanner interface{ Scan(...any) error }) (*domainSchedule.ScheduledMessage, error) {
+	var (
+		message   domainSchedule.ScheduledMessage
+		payload   string
+		timezone  sql.NullString
+		messageID sql.NullString
+		errMsg    sql.NullString
+		sentAt    sql.NullTime
+	)
+
+	err := scanner.Scan(
+		&message.ID, &message.DeviceID, &message.Type, &message.Phone, &payload, &message.SendAt,
+		&timezone, &message.Status, &messageID, &errMsg, &sentAt, &message.CreatedAt, &message.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	message.Payload = []byte(payload)
+	message.Timezone = timezone.String
+	message.MessageID = messageID.String
+	message.Error = errMsg.String
+	if sentAt.Valid {
+		t := sentAt.Time
+		message.SentAt = &t
+	}
+	return &message, nil
+}
@@ -87,7 +87,7 @@ func (r *SQLiteRepository) GetMessageByID(id string) (*domainChatStorage.Message
 	query := `
 		SELECT id, chat_jid, device_id, sender, content, timestamp, is_from_me,
 			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
+			file_enc_sha256, file_length, media_message, created_at, updated_at
 		FROM messages
 		WHERE id = ?
 		LIMIT 1
@@ -101,6 +101,24 @@ func (r *SQLiteRepository) GetMessageByID(id string) (*domainChatStorage.Message
 	return message, err
 }
 
+// GetMessageByIDByDevice retrieves a message by its ID from any chat of one device
+func (r *SQLiteRepository) GetMessageByIDByDevice(deviceID, id string) (*domainChatStorage.Message, error) {
+	query := `
+		SELECT id, chat_jid, device_id, sender, content, timestamp, is_from_me,
+			media_type, filename, url, media_key, file_sha256,
+			file_enc_sha256, file_length, media_message, created_at, updated_at
+		FROM messages
+		WHERE id = ? AND device_id = ?
+		LIMIT 1
+	`
+
+	message, err := r.scanMessage(r.db.QueryRow(query, id, deviceID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return message, err
+}
+
 // GetChats retrieves chats with filtering
 func (r *SQLiteRepository) GetChats(filter *domainChatStorage.ChatFilter) ([]*domainChatStorage.Chat, error) {
 	var conditions []string
@@ -226,11 +244,11 @@ func (r *SQLiteRepository) StoreMessage(message *domainChatStorage.Message) erro
 	result, err := r.db.Exec(`
 		UPDATE messages SET sender = ?, content = ?, timestamp = ?, is_from_me = ?,
 			media_type = ?, filename = ?, url = ?, media_key = ?, file_sha256 = ?,
-			file_enc_sha256 = ?, file_length = ?, updated_at = ?
+			file_enc_sha256 = ?, file_length = ?, media_message = ?, updated_at = ?
 		WHERE id = ? AND chat_jid = ? AND device_id = ?
 	`, message.Sender, message.Content, message.Timestamp, message.IsFromMe,
 		message.MediaType, message.Filename, message.URL, message.MediaKey, message.FileSHA256,
-		message.FileEncSHA256, message.FileLength, message.UpdatedAt,
+		message.FileEncSHA256, message.FileLength, message.MediaMessage, message.UpdatedAt,
 		message.ID, message.ChatJID, message.DeviceID)
 	if err != nil {
 		return err
@@ -242,12 +260,12 @@ func (r *SQLiteRepository) StoreMessage(message *domainChatStorage.Message) erro
 			INSERT INTO messages (
 				id, chat_jid, device_id, sender, content, timestamp, is_from_me,
 				media_type, filename, url, media_key, file_sha256,
-				file_enc_sha256, file_length, created_at, updated_at
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				file_enc_sha256, file_length, media_message, created_at, updated_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`, message.ID, message.ChatJID, message.DeviceID, message.Sender, message.Content,
 			message.Timestamp, message.IsFromMe, message.MediaType, message.Filename,
 			message.URL, message.MediaKey, message.FileSHA256, message.FileEncSHA256,
-			message.FileLength, message.CreatedAt, message.UpdatedAt)
+			message.FileLength, message.MediaMessage, message.CreatedAt, message.UpdatedAt)
 	}
 	return err
 }
@@ -268,7 +286,7 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 	updateStmt, err := tx.Prepare(`
 		UPDATE messages SET sender = ?, content = ?, timestamp = ?, is_from_me = ?,
 			media_type = ?, filename = ?, url = ?, media_key = ?, file_sha256 = ?,
-			file_enc_sha256 = ?, file_length = ?, updated_at = ?
+			file_enc_sha256 = ?, file_length = ?, media_message = ?, updated_at = ?
 		WHERE id = ? AND chat_jid = ? AND device_id = ?
 	`)
 	if err != nil {
@@ -280,8 +298,8 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 		INSERT INTO messages (
 			id, chat_jid, device_id, sender, content, timestamp, is_from_me,
 			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			file_enc_sha256, file_length, media_message, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare insert statement: %w", err)
@@ -300,7 +318,7 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 		result, err := updateStmt.Exec(
 			message.Sender, message.Content, message.Timestamp, message.IsFromMe,
 			message.MediaType, message.Filename, message.URL, message.MediaKey, message.FileSHA256,
-			message.FileEncSHA256, message.FileLength, message.UpdatedAt,
+			message.FileEncSHA256, message.FileLength, message.MediaMessage, message.UpdatedAt,
 			message.ID, message.ChatJID, message.DeviceID,
 		)
 		if err != nil {
@@ -313,7 +331,7 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 				message.ID, message.ChatJID, message.DeviceID, message.Sender, message.Content,
 				message.Timestamp, message.IsFromMe, message.MediaType, message.Filename,
 				message.URL, message.MediaKey, message.FileSHA256, message.FileEncSHA256,
-				message.FileLength, message.CreatedAt, message.UpdatedAt,
+				message.FileLength, message.MediaMessage, message.CreatedAt, message.UpdatedAt,
 			)
 			if err != nil {
 				return fmt.Errorf("failed to insert message %s: %w", message.ID, err)
@@ -363,7 +381,7 @@ func (r *SQLiteRepository) GetMessages(filter *domainChatStorage.MessageFilter)
 	query := `
 		SELECT id, chat_jid, device_id, sender, content, timestamp, is_from_me,
 			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
+			file_enc_sha256, file_length, media_message, created_at, updated_at
 		FROM messages
 		WHERE ` + strings.Join(conditions, " AND ") + `
 		ORDER BY timestamp DESC
@@ -402,6 +420,26 @@ func (r *SQLiteRepository) GetMessages(filter *domainChatStorage.MessageFilter)
 	return messages, rows.Err()
 }
 
+// GetOldestMessageByDevice retrieves the oldest stored message of a chat for a specific device
+func (r *SQLiteRepository) GetOldestMessageByDevice(deviceID, chatJID string) (*domainChatStorage.Message, error) {
+	query := `
+		SELECT id, chat_jid, device_id, sender, content, timestamp, is_from_me,
+			media_type, filename, url, media_key, file_sha256,
+			file_enc_sha256, file_length, media_message, created_at, updated_at
+		FROM messages
+		WHERE chat_jid = ? AND device_id = ?
+		ORDER BY timestamp ASC
+		LIMIT 1
+	`
+
+	message, err := r.scanMessage(r.db.QueryRow(query, chatJID, deviceID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return message, err
+}
+
 // SearchMessages performs database-level search for messages containing specific text
 func (r *SQLiteRepository) SearchMessages(deviceID, chatJID, searchText string, limit int) ([]*domainChatStorage.Message, error) {
 	// Require device_id for data isolation - fail fast if missing
@@ -429,7 +467,7 @@ func (r *SQLiteRepository) SearchMessages(deviceID, chatJID, searchText string,
 	query := `
 		SELECT id, chat_jid, device_id, sender, content, timestamp, is_from_me,
 			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
+			file_enc_sha256, file_length, media_message, created_at, updated_at
 		FROM messages
 		WHERE ` + strings.Join(conditions, " AND ") + `
 		ORDER BY timestamp DESC
@@ -493,7 +531,7 @@ func (r *SQLiteRepository) scanMessage(scanner interface{ Scan(...any) error })
 		&message.ID, &message.ChatJID, &message.DeviceID, &message.Sender, &message.Content,
 		&message.Timestamp, &message.IsFromMe, &message.MediaType, &message.Filename,
 		&message.URL, &message.MediaKey, &message.FileSHA256, &message.FileEncSHA256,
-		&message.FileLength, &message.CreatedAt, &message.UpdatedAt,
+		&message.FileLength, &message.MediaMessage, &message.CreatedAt, &message.UpdatedAt,
 	)
 	return message, err
 }
@@ -549,6 +587,42 @@ func (r *SQLiteRepository) TruncateAllChats() error {
 		return fmt.Errorf("failed to delete chats: %w", err)
 	}
 
+	if _, err = tx.Exec("DELETE FROM statuses"); err != nil {
+		return fmt.Errorf("failed to delete statuses: %w", err)
+	}
+
+	if _, err = tx.Exec("DELETE FROM poll_votes"); err != nil {
+		return fmt.Errorf("failed to delete poll votes: %w", err)
+	}
+
+	if _, err = tx.Exec("DELETE FROM polls"); err != nil {
+		return fmt.Errorf("failed to delete polls: %w", err)
+	}
+
+	if _, err = tx.Exec("DELETE FROM group_metadata"); err != nil {
+		return fmt.Errorf("failed to delete group metadata: %w", err)
+	}
+
+	if _, err = tx.Exec("DELETE FROM group_metadata_syncs"); err != nil {
+		return fmt.Errorf("failed to delete group metadata syncs: %w", err)
+	}
+
+	if _, err = tx.Exec("DELETE FROM group_events"); err != nil {
+		return fmt.Errorf("failed to delete group events: %w", err)
+	}
+
+	if _, err = tx.Exec("DELETE FROM scheduled_messages"); err != nil {
+		return fmt.Errorf("failed to delete scheduled messages: %w", err)
+	}
+
+	if _, err = tx.Exec("DELETE FROM campaign_recipients"); err != nil {
+		return fmt.Errorf("failed to delete campaign recipients: %w", err)
+	}
+
+	if _, err = tx.Exec("DELETE FROM campaigns"); err != nil {
+		return fmt.Errorf("failed to delete campaigns: %w", err)
+	}
+
 	return tx.Commit()
 }
 
@@ -574,6 +648,43 @@ func (r *SQLiteRepository) DeleteDeviceData(deviceID string) error {
 		return fmt.Errorf("failed to delete device chats: %w", err)
 	}
 
+	if _, err := tx.Exec("DELETE FROM statuses WHERE device_id = ?", deviceID); err != nil {
+		return fmt.Errorf("failed to delete device statuses: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM poll_votes WHERE device_id = ?", deviceID); err != nil {
+		return fmt.Errorf("failed to delete device poll votes: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM polls WHERE device_id = ?", deviceID); err != nil {
+		return fmt.Errorf("failed to delete device polls: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM group_metadata WHERE device_id = ?", deviceID); err != nil {
+		return fmt.Errorf("failed to delete device groups: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM group_metadata_syncs WHERE device_id = ?", deviceID); err != nil {
+		return fmt.Errorf("failed to delete device group syncs: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM group_events WHERE device_id = ?", deviceID); err != nil {
+		return fmt.Errorf("failed to delete device group events: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM scheduled_messages WHERE device_id = ?", deviceID); err != nil {
+		return fmt.Errorf("failed to delete device scheduled messages: %w", err)
+	}
+
+	// Recipients carry no device_id, they go with their campaign
+	if _, err := tx.Exec("DELETE FROM campaign_recipients WHERE campaign_id IN (SELECT id FROM campaigns WHERE device_id = ?)", deviceID); err != nil {
+		return fmt.Errorf("failed to delete device campaign recipients: %w", err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM campaigns WHERE device_id = ?", deviceID); err != nil {
+		return fmt.Errorf("failed to delete device campaigns: %w", err)
+	}
+
 	return tx.Commit()
 }
 
@@ -806,6 +917,7 @@ func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Messag
 	// Extract message content and media info
 	content := utils.ExtractMessageTextFromProto(evt.Message)
 	mediaType, filename, url, mediaKey, fileSHA256, fileEncSHA256, fileLength := utils.ExtractMediaInfo(evt.Message)
+	mediaMessage := utils.ExtractMediaMessage(evt.Message)
 
 	// Skip if there's no content and no media
 	if content == "" && mediaType == "" {
@@ -829,6 +941,7 @@ func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Messag
 		FileSHA256:    fileSHA256,
 		FileEncSHA256: fileEncSHA256,
 		FileLength:    fileLength,
+		MediaMessage:  mediaMessage,
 	}
 
 	// Store the message
@@ -1101,5 +1214,161 @@ func (r *SQLiteRepository) getMigrations() []string {
 
 		// Migration 12: Create index for devices
 		`CREATE INDEX IF NOT EXISTS idx_devices_created_at ON devices(created_at)`,
+
+		// Migration 13: Create scheduled messages table
+		`CREATE TABLE IF NOT EXISTS scheduled_messages (
+			id VARCHAR(64) PRIMARY KEY,
+			device_id VARCHAR(255) NOT NULL DEFAULT '',
+			type VARCHAR(32) NOT NULL,
+			phone VARCHAR(255) NOT NULL,
+			payload TEXT NOT NULL,
+			send_at TIMESTAMP NOT NULL,
+			timezone VARCHAR(64) DEFAULT '',
+			status VARCHAR(16) NOT NULL DEFAULT 'pending',
+			message_id VARCHAR(255) DEFAULT '',
+			error TEXT,
+			sent_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+
+		// Migration 14
+		`CREATE INDEX IF NOT EXISTS idx_scheduled_messages_due ON scheduled_messages(status, send_at)`,
+
+		// Migration 15
+		`CREATE INDEX IF NOT EXISTS idx_scheduled_messages_device ON scheduled_messages(device_id)`,
+
+		// Migration 16: Create broadcast campaign tables
+		`CREATE TABLE IF NOT EXISTS campaigns (
+			id VARCHAR(64) PRIMARY KEY,
+			device_id VARCHAR(255) NOT NULL DEFAULT '',
+			name VARCHAR(255) NOT NULL DEFAULT '',
+			type VARCHAR(32) NOT NULL,
+			payload TEXT NOT NULL,
+			status VARCHAR(16) NOT NULL DEFAULT 'running',
+			rate_per_minute INTEGER NOT NULL DEFAULT 10,
+			jitter_seconds INTEGER NOT NULL DEFAULT 0,
+			quiet_hours_start VARCHAR(5) DEFAULT '',
+			quiet_hours_end VARCHAR(5) DEFAULT '',
+			timezone VARCHAR(64) DEFAULT '',
+			completed_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+
+		// Migration 17
+		`CREATE INDEX IF NOT EXISTS idx_campaigns_device ON campaigns(device_id, status)`,
+
+		// Migration 18
+		`CREATE TABLE IF NOT EXISTS campaign_recipients (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			campaign_id VARCHAR(64) NOT NULL,
+			phone VARCHAR(255) NOT NULL,
+			variables TEXT,
+			status VARCHAR(16) NOT NULL DEFAULT 'pending',
+			message_id VARCHAR(255) DEFAULT '',
+			error TEXT,
+			sent_at TIMESTAMP NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+		)`,
+
+		// Migration 19
+		`CREATE INDEX IF NOT EXISTS idx_campaign_recipients_status ON campaign_recipients(campaign_id, status, id)`,
+
+		// Migration 20
+		`CREATE TABLE IF NOT EXISTS message_templates (
+			id VARCHAR(64) PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			body TEXT NOT NULL DEFAULT '',
+			media_type VARCHAR(16) DEFAULT '',
+			media_url TEXT DEFAULT '',
+			caption TEXT DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+
+		// Migration 21
+		`CREATE TABLE IF NOT EXISTS statuses (
+			id VARCHAR(255) NOT NULL,
+			device_id VARCHAR(255) NOT NULL DEFAULT '',
+			sender VARCHAR(255) NOT NULL,
+			push_name VARCHAR(255) DEFAULT '',
+			is_from_me BOOLEAN DEFAULT FALSE,
+			type VARCHAR(16) NOT NULL DEFAULT 'text',
+			text TEXT,
+			background_color VARCHAR(16) DEFAULT '',
+			text_color VARCHAR(16) DEFAULT '',
+			font VARCHAR(32) DEFAULT '',
+			media_type VARCHAR(50) DEFAULT '',
+			timestamp TIMESTAMP NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			viewed_at TIMESTAMP NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (id, device_id)
+		)`,
+
+		// Migration 22
+		`CREATE INDEX IF NOT EXISTS idx_statuses_device_expires ON statuses(device_id, expires_at)`,
+
+		// Migration 23
+		`CREATE TABLE IF NOT EXISTS polls (
+			id VARCHAR(255) NOT NULL,
+			device_id VARCHAR(255) NOT NULL DEFAULT '',
+			chat_jid VARCHAR(255) NOT NULL,
+			creator VARCHAR(255) DEFAULT '',
+			question TEXT NOT NULL,
+			options TEXT NOT NULL,
+			selectable_count INTEGER DEFAULT 0,
+			timestamp TIMESTAMP NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (id, device_id)
+		)`,
+
+		// Migration 24
+		`CREATE TABLE IF NOT EXISTS poll_votes (
+			poll_id VARCHAR(255) NOT NULL,
+			device_id VARCHAR(255) NOT NULL DEFAULT '',
+			voter VARCHAR(255) NOT NULL,
+			voter_name VARCHAR(255) DEFAULT '',
+			options TEXT NOT NULL,
+			timestamp TIMESTAMP NOT NULL,
+			PRIMARY KEY (poll_id, device_id, voter)
+		)`,
+
+		// Migration 25
+		`CREATE TABLE IF NOT EXISTS group_metadata (
+			jid VARCHAR(255) NOT NULL,
+			device_id VARCHAR(255) NOT NULL DEFAULT '',
+			name VARCHAR(255) DEFAULT '',
+			info TEXT NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (jid, device_id)
+		)`,
+
+		// Migration 26
+		`CREATE TABLE IF NOT EXISTS group_metadata_syncs (
+			device_id VARCHAR(255) NOT NULL PRIMARY KEY,
+			synced_at TIMESTAMP NOT NULL
+		)`,
+
+		// Migration 27
+		`CREATE TABLE IF NOT EXISTS group_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			device_id VARCHAR(255) NOT NULL DEFAULT '',
+			group_jid VARCHAR(255) NOT NULL,
+			type VARCHAR(32) NOT NULL,
+			participant VARCHAR(255) DEFAULT '',
+			actor VARCHAR(255) DEFAULT '',
+			setting VARCHAR(32) DEFAULT '',
+			value TEXT,
+			timestamp TIMESTAMP NOT NULL
+		)`,
+
+		// Migration 28
+		`CREATE INDEX IF NOT EXISTS idx_group_events_device_group ON group_events(device_id, group_jid, timestamp)`,
+
+		// Migration 29
+		`ALTER TABLE messages ADD COLUMN media_message BLOB`,
 	}
 }
@@ -0,0 +1,165 @@
+package chatstorage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+const statusColumns = `id, device_id, sender, push_name, is_from_me, type, text, background_color,
+	text_color, font, media_type, timestamp, expires_at, viewed_at, created_at`
+
+// StoreStatus creates or updates a status. The viewed state of an existing status is kept.
+func (r *SQLiteRepository) StoreStatus(status *domainChatStorage.Status) error {
+	if status == nil || strings.TrimSpace(status.ID) == "" || status.DeviceID == "" {
+		return fmt.Errorf("status with id and device id is required")
+	}
+	if status.CreatedAt.IsZero() {
+		status.CreatedAt = time.Now()
+	}
+
+	// Try update first, then insert if no rows affected (cross-db compatible)
+	result, err := r.db.Exec(`
+		UPDATE statuses SET sender = ?, push_name = ?, is_from_me = ?, type = ?, text = ?,
+			background_color = ?, text_color = ?, font = ?, media_type = ?, timestamp = ?, expires_at = ?
+		WHERE id = ? AND device_id = ?
+	`, status.Sender, status.PushName, status.IsFromMe, status.Type, status.Text,
+		status.BackgroundColor, status.TextColor, status.Font, status.MediaType, status.Timestamp, status.ExpiresAt,
+		status.ID, status.DeviceID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		_, err = r.db.Exec(`
+			INSERT INTO statuses (`+statusColumns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, status.ID, status.DeviceID, status.Sender, status.PushName, status.IsFromMe, status.Type, status.Text,
+			status.BackgroundColor, status.TextColor, status.Font, status.MediaType, status.Timestamp, status.ExpiresAt,
+			status.ViewedAt, status.CreatedAt)
+	}
+	return err
+}
+
+// GetStatus returns a status of the device, or nil when it does not exist
+func (r *SQLiteRepository) GetStatus(deviceID, id string) (*domainChatStorage.Status, error) {
+	if deviceID == "" {
+		return nil, fmt.Errorf("device_id is required for status queries (data isolation)")
+	}
+
+	status, err := scanStatus(r.db.QueryRow(`
+		SELECT `+statusColumns+`
+		FROM statuses
+		WHERE device_id = ? AND id = ?
+		LIMIT 1
+	`, deviceID, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return status, err
+}
+
+// GetStatuses lists the unexpired statuses of a device, newest first
+func (r *SQLiteRepository) GetStatuses(filter *domainChatStorage.StatusFilter) ([]*domainChatStorage.Status, error) {
+	if filter == nil || filter.DeviceID == "" {
+		return nil, fmt.Errorf("device_id is required for status queries (data isolation)")
+	}
+
+	now := filter.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	conditions := []string{"device_id = ?", "expires_at > ?"}
+	args := []any{filter.DeviceID, now}
+
+	if filter.Sender != "" {
+		conditions = append(conditions, "sender = ?")
+		args = append(args, filter.Sender)
+	}
+	if filter.IsFromMe != nil {
+		conditions = append(conditions, "is_from_me = ?")
+		args = append(args, *filter.IsFromMe)
+	}
+	if filter.UnviewedOnly {
+		conditions = append(conditions, "viewed_at IS NULL")
+	}
+
+	query := `
+		SELECT ` + statusColumns + `
+		FROM statuses
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY timestamp DESC
+	`
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+		if filter.Offset > 0 {
+			query += " OFFSET ?"
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []*domainChatStorage.Status
+	for rows.Next() {
+		status, err := scanStatus(rows)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, rows.Err()
+}
+
+// MarkStatusViewed records when the status was viewed; the first view wins
+func (r *SQLiteRepository) MarkStatusViewed(deviceID, id string, viewedAt time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE statuses SET viewed_at = ?
+		WHERE device_id = ? AND id = ? AND viewed_at IS NULL
+	`, viewedAt, deviceID, id)
+	return err
+}
+
+// DeleteStatus removes a status of the device
+func (r *SQLiteRepository) DeleteStatus(deviceID, id string) error {
+	_, err := r.db.Exec(`DELETE FROM statuses WHERE device_id = ? AND id = ?`, deviceID, id)
+	return err
+}
+
+// DeleteExpiredStatuses removes statuses of every device that WhatsApp no longer shows
+func (r *SQLiteRepository) DeleteExpiredStatuses(now time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM statuses WHERE expires_at <= ?`, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func scanStatus(row interface{ Scan(dest ...any) error }) (*domainChatStorage.Status, error) {
+	status := &domainChatStorage.Status{}
+	var (
+		text     sql.NullString
+		viewedAt sql.NullTime
+	)
+	err := row.Scan(&status.ID, &status.DeviceID, &status.Sender, &status.PushName, &status.IsFromMe, &status.Type,
+		&text, &status.BackgroundColor, &status.TextColor, &status.Font, &status.MediaType, &status.Timestamp,
+		&status.ExpiresAt, &viewedAt, &status.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	status.Text = text.String
+	if viewedAt.Valid {
+		status.ViewedAt = &viewedAt.Time
+	}
+	return status, nil
+}
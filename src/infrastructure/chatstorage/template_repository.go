@@ -0,0 +1,135 @@
+package chatstorage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	domainTemplate "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/template"
+)
+
+// TemplateRepository stores message templates in the chat storage database
+type TemplateRepository struct {
+	db *sql.DB
+}
+
+func NewTemplateRepository(db *sql.DB) domainTemplate.ITemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+const templateColumns = `id, name, body, media_type, media_url, caption, created_at, updated_at`
+
+// Create inserts a new template
+func (r *TemplateRepository) Create(template *domainTemplate.Template) error {
+	if template == nil || strings.TrimSpace(template.ID) == "" {
+		return fmt.Errorf("template with id is required")
+	}
+
+	now := time.Now().UTC()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+
+	_, err := r.db.Exec(`
+		INSERT INTO message_templates (`+templateColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, template.ID, template.Name, template.Body, template.MediaType, template.MediaURL, template.Caption,
+		template.CreatedAt, template.UpdatedAt)
+	return err
+}
+
+// Get returns a template by ID, or nil when it does not exist
+func (r *TemplateRepository) Get(id string) (*domainTemplate.Template, error) {
+	template, err := r.scanTemplate(r.db.QueryRow(`SELECT `+templateColumns+` FROM message_templates WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return template, err
+}
+
+// List returns templates ordered by name along with the total matching count
+func (r *TemplateRepository) List(filter *domainTemplate.TemplateFilter) ([]*domainTemplate.Template, int, error) {
+	where := ""
+	var args []any
+	if filter.Search != "" {
+		where = " WHERE name LIKE ?"
+		args = append(args, "%"+filter.Search+"%")
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM message_templates"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + templateColumns + ` FROM message_templates` + where + ` ORDER BY name ASC, created_at ASC`
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+		if filter.Offset > 0 {
+			query += " OFFSET ?"
+			args = append(args, filter.Offset)
+		}
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var templates []*domainTemplate.Template
+	for rows.Next() {
+		template, err := r.scanTemplate(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, total, rows.Err()
+}
+
+// Update overwrites the editable fields of an existing template
+func (r *TemplateRepository) Update(template *domainTemplate.Template) (bool, error) {
+	template.UpdatedAt = time.Now().UTC()
+	result, err := r.db.Exec(`
+		UPDATE message_templates SET name = ?, body = ?, media_type = ?, media_url = ?, caption = ?, updated_at = ?
+		WHERE id = ?
+	`, template.Name, template.Body, template.MediaType, template.MediaURL, template.Caption, template.UpdatedAt, template.ID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// Delete removes a template
+func (r *TemplateRepository) Delete(id string) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM message_templates WHERE id = ?`, id)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (r *TemplateRepository) scanTemplate(scanner interface{ Scan(...any) error }) (*domainTemplate.Template, error) {
+	var (
+		template  domainTemplate.Template
+		mediaType sql.NullString
+		mediaURL  sql.NullString
+		caption   sql.NullString
+	)
+
+	err := scanner.Scan(
+		&template.ID, &template.Name, &template.Body, &mediaType, &mediaURL, &caption,
+		&template.CreatedAt, &template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	template.MediaType = mediaType.String
+	template.MediaURL = mediaURL.String
+	template.Caption = caption.String
+	return &template, nil
+}
@@ -79,6 +79,14 @@ func (r *deviceChatStorage) GetMessageByID(id string) (*domainChatStorage.Messag
 	return r.base.GetMessageByID(id)
 }
 
+func (r *deviceChatStorage) GetMessageByIDByDevice(deviceID, id string) (*domainChatStorage.Message, error) {
+	targetDeviceID := deviceID
+	if targetDeviceID == "" {
+		targetDeviceID = r.deviceID
+	}
+	return r.base.GetMessageByIDByDevice(targetDeviceID, id)
+}
+
 func (r *deviceChatStorage) GetMessages(filter *domainChatStorage.MessageFilter) ([]*domainChatStorage.Message, error) {
 	if filter != nil && filter.DeviceID == "" {
 		filter.DeviceID = r.deviceID
@@ -86,6 +94,14 @@ func (r *deviceChatStorage) GetMessages(filter *domainChatStorage.MessageFilter)
 	return r.base.GetMessages(filter)
 }
 
+func (r *deviceChatStorage) GetOldestMessageByDevice(deviceID, chatJID string) (*domainChatStorage.Message, error) {
+	targetDeviceID := deviceID
+	if targetDeviceID == "" {
+		targetDeviceID = r.deviceID
+	}
+	return r.base.GetOldestMessageByDevice(targetDeviceID, chatJID)
+}
+
 func (r *deviceChatStorage) SearchMessages(deviceID, chatJID, searchText string, limit int) ([]*domainChatStorage.Message, error) {
 	targetDeviceID := deviceID
 	if targetDeviceID == "" {
@@ -157,6 +173,108 @@ func (r *deviceChatStorage) DeleteDeviceData(deviceID string) error {
 	return r.base.DeleteDeviceData(target)
 }
 
+func (r *deviceChatStorage) StoreStatus(status *domainChatStorage.Status) error {
+	if status != nil && status.DeviceID == "" {
+		status.DeviceID = r.deviceID
+	}
+	return r.base.StoreStatus(status)
+}
+
+func (r *deviceChatStorage) GetStatus(deviceID, id string) (*domainChatStorage.Status, error) {
+	return r.base.GetStatus(r.statusDeviceID(deviceID), id)
+}
+
+func (r *deviceChatStorage) GetStatuses(filter *domainChatStorage.StatusFilter) ([]*domainChatStorage.Status, error) {
+	if filter != nil && filter.DeviceID == "" {
+		filter.DeviceID = r.deviceID
+	}
+	return r.base.GetStatuses(filter)
+}
+
+func (r *deviceChatStorage) MarkStatusViewed(deviceID, id string, viewedAt time.Time) error {
+	return r.base.MarkStatusViewed(r.statusDeviceID(deviceID), id, viewedAt)
+}
+
+func (r *deviceChatStorage) DeleteStatus(deviceID, id string) error {
+	return r.base.DeleteStatus(r.statusDeviceID(deviceID), id)
+}
+
+func (r *deviceChatStorage) DeleteExpiredStatuses(now time.Time) (int64, error) {
+	return r.base.DeleteExpiredStatuses(now)
+}
+
+func (r *deviceChatStorage) statusDeviceID(deviceID string) string {
+	if deviceID == "" {
+		return r.deviceID
+	}
+	return deviceID
+}
+
+func (r *deviceChatStorage) StorePoll(poll *domainChatStorage.Poll) error {
+	if poll != nil && poll.DeviceID == "" {
+		poll.DeviceID = r.deviceID
+	}
+	return r.base.StorePoll(poll)
+}
+
+func (r *deviceChatStorage) GetPoll(deviceID, id string) (*domainChatStorage.Poll, error) {
+	return r.base.GetPoll(r.statusDeviceID(deviceID), id)
+}
+
+func (r *deviceChatStorage) StorePollVote(vote *domainChatStorage.PollVote) error {
+	if vote != nil && vote.DeviceID == "" {
+		vote.DeviceID = r.deviceID
+	}
+	return r.base.StorePollVote(vote)
+}
+
+func (r *deviceChatStorage) GetPollVotes(deviceID, pollID string) ([]*domainChatStorage.PollVote, error) {
+	return r.base.GetPollVotes(r.statusDeviceID(deviceID), pollID)
+}
+
+func (r *deviceChatStorage) StoreGroup(group *domainChatStorage.Group) error {
+	if group != nil && group.DeviceID == "" {
+		group.DeviceID = r.deviceID
+	}
+	return r.base.StoreGroup(group)
+}
+
+func (r *deviceChatStorage) GetGroup(deviceID, jid string) (*domainChatStorage.Group, error) {
+	return r.base.GetGroup(r.statusDeviceID(deviceID), jid)
+}
+
+func (r *deviceChatStorage) GetGroups(deviceID string) ([]*domainChatStorage.Group, error) {
+	return r.base.GetGroups(r.statusDeviceID(deviceID))
+}
+
+func (r *deviceChatStorage) DeleteGroup(deviceID, jid string) error {
+	return r.base.DeleteGroup(r.statusDeviceID(deviceID), jid)
+}
+
+func (r *deviceChatStorage) ReplaceGroups(deviceID string, groups []*domainChatStorage.Group) error {
+	return r.base.ReplaceGroups(r.statusDeviceID(deviceID), groups)
+}
+
+func (r *deviceChatStorage) GetGroupsSyncedAt(deviceID string) (*time.Time, error) {
+	return r.base.GetGroupsSyncedAt(r.statusDeviceID(deviceID))
+}
+
+func (r *deviceChatStorage) StoreGroupEvents(events []*domainChatStorage.GroupEvent) error {
+	for _, event := range events {
+		if event != nil && event.DeviceID == "" {
+			event.DeviceID = r.deviceID
+		}
+	}
+	return r.base.StoreGroupEvents(events)
+}
+
+func (r *deviceChatStorage) GetGroupEvents(filter *domainChatStorage.GroupEventFilter) ([]*domainChatStorage.GroupEvent, int, error) {
+	if filter != nil && filter.DeviceID == "" {
+		filter.DeviceID = r.deviceID
+	}
+	return r.base.GetGroupEvents(filter)
+}
+
 func (r *deviceChatStorage) SaveDeviceRecord(record *domainChatStorage.DeviceRecord) error {
 	return r.base.SaveDeviceRecord(record)
 }
@@ -3,9 +3,11 @@ package whatsapp
 import (
 	"context"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	"github.com/sirupsen/logrus"
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/types"
@@ -13,7 +15,7 @@ import (
 )
 
 // createGroupInfoPayload creates a webhook payload for group information events
-func createGroupInfoPayload(ctx context.Context, evt *events.GroupInfo, actionType string, jids []types.JID, deviceID string, client *whatsmeow.Client) map[string]any {
+func createGroupInfoPayload(ctx context.Context, evt *events.GroupInfo, actionType string, jids []types.JID, community map[string]any, deviceID string, client *whatsmeow.Client) map[string]any {
 	body := make(map[string]any)
 
 	// Create payload structure matching the expected format
@@ -25,6 +27,7 @@ func createGroupInfoPayload(ctx context.Context, evt *events.GroupInfo, actionTy
 	// Add action type and affected users (with LID resolution)
 	payload["type"] = actionType
 	payload["jids"] = jidsToStrings(ctx, jids, client)
+	maps.Copy(payload, community)
 
 	// Wrap in payload structure
 	body["payload"] = payload
@@ -54,8 +57,9 @@ func jidsToStrings(ctx context.Context, jids []types.JID, client *whatsmeow.Clie
 	return result
 }
 
-// forwardGroupInfoToWebhook forwards group information events to the configured webhook URLs
-func forwardGroupInfoToWebhook(ctx context.Context, evt *events.GroupInfo, deviceID string, client *whatsmeow.Client) error {
+// forwardGroupInfoToWebhook forwards group information events to the configured webhook URLs. The community fields
+// come from the group stored under storeDeviceID in repo.
+func forwardGroupInfoToWebhook(ctx context.Context, evt *events.GroupInfo, deviceID string, client *whatsmeow.Client, repo domainChatStorage.IChatStorageRepository, storeDeviceID string) error {
 	// Send separate webhook events for each action type
 	actions := []struct {
 		actionType string
@@ -67,9 +71,13 @@ func forwardGroupInfoToWebhook(ctx context.Context, evt *events.GroupInfo, devic
 		{"demote", evt.Demote},
 	}
 
+	var community map[string]any
 	for _, action := range actions {
 		if len(action.jids) > 0 {
-			payload := createGroupInfoPayload(ctx, evt, action.actionType, action.jids, deviceID, client)
+			if community == nil {
+				community = lookupGroupCommunity(ctx, evt.JID, client, repo, storeDeviceID)
+			}
+			payload := createGroupInfoPayload(ctx, evt, action.actionType, action.jids, community, deviceID, client)
 
 			if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "group.participants"); err != nil {
 				logrus.Warnf("Failed to forward group %s event to webhook: %v", action.actionType, err)
@@ -77,12 +85,89 @@ func forwardGroupInfoToWebhook(ctx context.Context, evt *events.GroupInfo, devic
 		}
 	}
 
+	for _, change := range []struct {
+		actionType string
+		link       *types.GroupLinkChange
+	}{
+		{"link", evt.Link},
+		{"unlink", evt.Unlink},
+	} {
+		if change.link == nil {
+			continue
+		}
+		payload := createGroupLinkPayload(evt, change.actionType, change.link, deviceID)
+		if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "group.community"); err != nil {
+			logrus.Warnf("Failed to forward group %s event to webhook: %v", change.actionType, err)
+		}
+	}
+
 	return nil
 }
 
+// createGroupLinkPayload creates the webhook payload for a group being linked to or unlinked from a community.
+// chat_id is the group that received the notification; group_id is the other side of the link.
+func createGroupLinkPayload(evt *events.GroupInfo, actionType string, change *types.GroupLinkChange, deviceID string) map[string]any {
+	payload := map[string]any{
+		"chat_id":         evt.JID.ToNonAD().String(),
+		"type":            actionType,
+		"link_type":       string(change.Type),
+		"group_id":        change.Group.JID.ToNonAD().String(),
+		"is_announcement": change.Group.IsDefaultSubGroup,
+	}
+	if change.Group.Name != "" {
+		payload["group_name"] = change.Group.Name
+	}
+	if change.UnlinkReason != "" {
+		payload["unlink_reason"] = string(change.UnlinkReason)
+	}
+
+	body := map[string]any{
+		"event":     "group.community",
+		"payload":   payload,
+		"timestamp": evt.Timestamp.Format(time.RFC3339),
+	}
+	if deviceID != "" {
+		body["device_id"] = deviceID
+	}
+	return body
+}
+
+// lookupGroupCommunity reads the group's community fields for a webhook payload from the stored group, asking
+// WhatsApp only when it is not stored; lookup failures leave them out
+func lookupGroupCommunity(ctx context.Context, jid types.JID, client *whatsmeow.Client, repo domainChatStorage.IChatStorageRepository, deviceID string) map[string]any {
+	if client == nil {
+		return map[string]any{}
+	}
+	info, err := StoredGroupInfo(ctx, client, repo, deviceID, jid, false)
+	if err != nil {
+		logrus.Debugf("Could not look up community of group %s: %v", jid, err)
+		return map[string]any{}
+	}
+	return groupCommunityFields(info)
+}
+
+// groupCommunityFields describes how a group relates to a community: a community itself, a group linked to one
+// (with community_id), or neither (no fields)
+func groupCommunityFields(info *types.GroupInfo) map[string]any {
+	fields := map[string]any{}
+	if info == nil {
+		return fields
+	}
+	if info.IsParent {
+		fields["is_community"] = true
+	}
+	if !info.LinkedParentJID.IsEmpty() {
+		fields["community_id"] = info.LinkedParentJID.ToNonAD().String()
+		fields["is_community_announcement"] = info.IsDefaultSubGroup
+	}
+	return fields
+}
+
 // handleJoinedGroup handles the event when the connected device is added to a new group
-func handleJoinedGroup(ctx context.Context, evt *events.JoinedGroup, deviceID string, client *whatsmeow.Client) {
+func handleJoinedGroup(ctx context.Context, evt *events.JoinedGroup, chatStorageRepo domainChatStorage.IChatStorageRepository, deviceID string, client *whatsmeow.Client) {
 	log.Infof("Joined group %s (reason: %s, type: %s)", evt.JID, evt.Reason, evt.Type)
+	storeGroupInfo(chatStorageRepo, statusDeviceID(ctx), &evt.GroupInfo)
+	recordJoinedGroupHistory(ctx, evt, chatStorageRepo, statusDeviceID(ctx), client)
 
 	if len(config.WhatsappWebhook) > 0 {
 		go func(e *events.JoinedGroup, c *whatsmeow.Client) {
@@ -114,6 +199,7 @@ func forwardJoinedGroupToWebhook(ctx context.Context, evt *events.JoinedGroup, d
 	if evt.GroupName.Name != "" {
 		payload["group_name"] = evt.GroupName.Name
 	}
+	maps.Copy(payload, groupCommunityFields(&evt.GroupInfo))
 
 	body := map[string]any{
 		"event":     "group.joined",
@@ -0,0 +1,69 @@
+package whatsapp
+
+import (
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestGroupCommunityFields(t *testing.T) {
+	community := types.NewJID("120363000000000001", types.GroupServer)
+
+	if fields := groupCommunityFields(&types.GroupInfo{}); len(fields) != 0 {
+		t.Fatalf("a plain group must not carry community fields, got %v", fields)
+	}
+	if fields := groupCommunityFields(nil); len(fields) != 0 {
+		t.Fatalf("unknown group info must not carry community fields, got %v", fields)
+	}
+
+	fields := groupCommunityFields(&types.GroupInfo{GroupParent: types.GroupParent{IsParent: true}})
+	if fields["is_community"] != true || fields["community_id"] != nil {
+		t.Fatalf("unexpected fields for a community: %v", fields)
+	}
+
+	fields = groupCommunityFields(&types.GroupInfo{
+		GroupLinkedParent: types.GroupLinkedParent{LinkedParentJID: community},
+		GroupIsDefaultSub: types.GroupIsDefaultSub{IsDefaultSubGroup: true},
+	})
+	if fields["community_id"] != "120363000000000001@g.us" || fields["is_community_announcement"] != true {
+		t.Fatalf("unexpected fields for an announcement group: %v", fields)
+	}
+}
+
+func TestCreateGroupLinkPayload(t *testing.T) {
+	evt := &events.GroupInfo{
+		JID:       types.NewJID("120363000000000001", types.GroupServer),
+		Timestamp: time.Date(2026, time.March, 1, 8, 0, 0, 0, time.UTC),
+	}
+	change := &types.GroupLinkChange{
+		Type:         types.GroupLinkChangeTypeSub,
+		UnlinkReason: types.GroupUnlinkReasonDefault,
+		Group: types.GroupLinkTarget{
+			JID:       types.NewJID("120363000000000002", types.GroupServer),
+			GroupName: types.GroupName{Name: "Parents"},
+		},
+	}
+
+	body := createGroupLinkPayload(evt, "unlink", change, "628123456789@s.whatsapp.net")
+	if body["event"] != "group.community" || body["device_id"] != "628123456789@s.whatsapp.net" || body["timestamp"] != "2026-03-01T08:00:00Z" {
+		t.Fatalf("unexpected envelope: %v", body)
+	}
+
+	payload := body["payload"].(map[string]any)
+	expected := map[string]any{
+		"chat_id":         "120363000000000001@g.us",
+		"type":            "unlink",
+		"link_type":       "sub_group",
+		"group_id":        "120363000000000002@g.us",
+		"group_name":      "Parents",
+		"is_announcement": false,
+		"unlink_reason":   "unlink_group",
+	}
+	for key, value := range expected {
+		if payload[key] != value {
+			t.Fatalf("payload[%q] = %v, want %v", key, payload[key], value)
+		}
+	}
+}
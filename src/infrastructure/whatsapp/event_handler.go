@@ -54,9 +54,9 @@ func handler(ctx context.Context, instance *DeviceInstance, rawEvt any) {
 	case *events.AppState:
 		handleAppState(ctx, evt)
 	case *events.GroupInfo:
-		handleGroupInfo(ctx, evt, instance.JID(), client)
+		handleGroupInfo(ctx, evt, chatStorageRepo, instance.JID(), client)
 	case *events.JoinedGroup:
-		handleJoinedGroup(ctx, evt, instance.JID(), client)
+		handleJoinedGroup(ctx, evt, chatStorageRepo, instance.JID(), client)
 	case *events.NewsletterJoin:
 		handleNewsletterJoin(ctx, evt, instance.JID(), client)
 	case *events.NewsletterLeave:
@@ -250,10 +250,14 @@ func handleAppState(_ context.Context, evt *events.AppState) {
 	log.Debugf("App state event: %+v / %+v", evt.Index, evt.SyncActionValue)
 }
 
-func handleGroupInfo(ctx context.Context, evt *events.GroupInfo, deviceID string, client *whatsmeow.Client) {
+func handleGroupInfo(ctx context.Context, evt *events.GroupInfo, chatStorageRepo domainChatStorage.IChatStorageRepository, deviceID string, client *whatsmeow.Client) {
+	updateStoredGroup(ctx, evt, chatStorageRepo, statusDeviceID(ctx), client)
+	recordGroupHistory(ctx, evt, chatStorageRepo, statusDeviceID(ctx), client)
+
 	// Only process events that have actual changes
 	hasChanges := len(evt.Join) > 0 || len(evt.Leave) > 0 || len(evt.Promote) > 0 || len(evt.Demote) > 0 ||
-		evt.Name != nil || evt.Topic != nil || evt.Locked != nil || evt.Announce != nil
+		evt.Name != nil || evt.Topic != nil || evt.Locked != nil || evt.Announce != nil ||
+		evt.Link != nil || evt.Unlink != nil
 
 	if !hasChanges {
 		return
@@ -275,10 +279,11 @@ func handleGroupInfo(ctx context.Context, evt *events.GroupInfo, deviceID string
 
 	// Forward group info event to webhook if configured
 	if len(config.WhatsappWebhook) > 0 {
+		storeDeviceID := statusDeviceID(ctx)
 		go func(e *events.GroupInfo, c *whatsmeow.Client) {
 			webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
-			if err := forwardGroupInfoToWebhook(webhookCtx, e, deviceID, c); err != nil {
+			if err := forwardGroupInfoToWebhook(webhookCtx, e, deviceID, c, chatStorageRepo, storeDeviceID); err != nil {
 				logrus.Errorf("Failed to forward group info event to webhook: %v", err)
 			}
 		}(evt, client)
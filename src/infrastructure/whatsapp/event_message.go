@@ -12,6 +12,7 @@ import (
 	"go.mau.fi/whatsmeow/types"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/sirupsen/logrus"
@@ -209,7 +210,7 @@ func buildMessageBody(ctx context.Context, client *whatsmeow.Client, evt *events
 }
 
 func buildOptionalFields(ctx context.Context, client *whatsmeow.Client, evt *events.Message, msg *waE2E.Message, payload map[string]any) error {
-	if evt.IsViewOnce {
+	if evt.IsViewOnce || isViewOnceMedia(msg) {
 		payload["view_once"] = true
 	}
 
@@ -228,6 +229,9 @@ func buildOptionalFields(ctx context.Context, client *whatsmeow.Client, evt *eve
 
 func buildMediaFields(ctx context.Context, client *whatsmeow.Client, msg *waE2E.Message, payload map[string]any) error {
 	if audioMedia := msg.GetAudioMessage(); audioMedia != nil {
+		if audioMedia.GetPTT() {
+			payload["ptt"] = true
+		}
 		if config.WhatsappAutoDownloadMedia {
 			extracted, err := utils.ExtractMedia(ctx, client, config.PathMedia, audioMedia)
 			if err != nil {
@@ -290,6 +294,9 @@ func buildMediaFields(ctx context.Context, client *whatsmeow.Client, msg *waE2E.
 	}
 
 	if videoMedia := msg.GetVideoMessage(); videoMedia != nil {
+		if videoMedia.GetGifPlayback() {
+			payload["gif_playback"] = true
+		}
 		if config.WhatsappAutoDownloadMedia {
 			extracted, err := utils.ExtractMedia(ctx, client, config.PathMedia, videoMedia)
 			if err != nil {
@@ -324,6 +331,14 @@ func buildMediaFields(ctx context.Context, client *whatsmeow.Client, msg *waE2E.
 	return nil
 }
 
+// isViewOnceMedia reports media flagged view-once on the message itself rather than through a view-once wrapper
+func isViewOnceMedia(msg *waE2E.Message) bool {
+	return msg.GetImageMessage().GetViewOnce() ||
+		msg.GetVideoMessage().GetViewOnce() ||
+		msg.GetPtvMessage().GetViewOnce() ||
+		msg.GetAudioMessage().GetViewOnce()
+}
+
 // buildAutoDownloadPayload builds the media payload for auto-downloaded media.
 // Returns just the path string if no caption (backward compatible), or a map with path+caption.
 func buildAutoDownloadPayload(extracted utils.ExtractedMedia) any {
@@ -339,10 +354,16 @@ func buildAutoDownloadPayload(extracted utils.ExtractedMedia) any {
 func buildOtherMessageTypes(msg *waE2E.Message, payload map[string]any) {
 	if contactMessage := msg.GetContactMessage(); contactMessage != nil {
 		payload["contact"] = contactMessage
+		payload["contacts"] = []domainSend.ContactCard{parseContactMessage(contactMessage)}
 	}
 
 	if contactsArrayMessage := msg.GetContactsArrayMessage(); contactsArrayMessage != nil {
 		payload["contacts_array"] = contactsArrayMessage.GetContacts()
+		cards := make([]domainSend.ContactCard, 0, len(contactsArrayMessage.GetContacts()))
+		for _, contact := range contactsArrayMessage.GetContacts() {
+			cards = append(cards, parseContactMessage(contact))
+		}
+		payload["contacts"] = cards
 	}
 
 	if listMessage := msg.GetListMessage(); listMessage != nil {
@@ -351,13 +372,70 @@ func buildOtherMessageTypes(msg *waE2E.Message, payload map[string]any) {
 
 	if liveLocationMessage := msg.GetLiveLocationMessage(); liveLocationMessage != nil {
 		payload["live_location"] = liveLocationMessage
+		payload["coordinates"] = parseLiveLocationMessage(liveLocationMessage)
 	}
 
 	if locationMessage := msg.GetLocationMessage(); locationMessage != nil {
 		payload["location"] = locationMessage
+		payload["coordinates"] = parseLocationMessage(locationMessage)
 	}
 
 	if orderMessage := msg.GetOrderMessage(); orderMessage != nil {
 		payload["order"] = orderMessage
 	}
 }
+
+// parseContactMessage turns a shared contact into the structure /send/contact accepts
+func parseContactMessage(contact *waE2E.ContactMessage) domainSend.ContactCard {
+	card := ParseVCard(contact.GetVcard())
+	if card.Name == "" {
+		card.Name = contact.GetDisplayName()
+	}
+	return card
+}
+
+// LocationCoordinates is the flat form of a static or live location sent to webhooks
+type LocationCoordinates struct {
+	Latitude         float64 `json:"latitude"`
+	Longitude        float64 `json:"longitude"`
+	AccuracyInMeters uint32  `json:"accuracy_in_meters,omitempty"`
+	SpeedInMps       float32 `json:"speed_in_mps,omitempty"`
+	Heading          uint32  `json:"heading,omitempty"`
+	Name             string  `json:"name,omitempty"`
+	Address          string  `json:"address,omitempty"`
+	URL              string  `json:"url,omitempty"`
+	Caption          string  `json:"caption,omitempty"`
+	IsLive           bool    `json:"is_live"`
+	// SequenceNumber and TimeOffset (seconds since the share started) order the updates of a live location
+	SequenceNumber int64  `json:"sequence_number,omitempty"`
+	TimeOffset     uint32 `json:"time_offset,omitempty"`
+}
+
+func parseLocationMessage(location *waE2E.LocationMessage) LocationCoordinates {
+	return LocationCoordinates{
+		Latitude:         location.GetDegreesLatitude(),
+		Longitude:        location.GetDegreesLongitude(),
+		AccuracyInMeters: location.GetAccuracyInMeters(),
+		SpeedInMps:       location.GetSpeedInMps(),
+		Heading:          location.GetDegreesClockwiseFromMagneticNorth(),
+		Name:             location.GetName(),
+		Address:          location.GetAddress(),
+		URL:              location.GetURL(),
+		Caption:          location.GetComment(),
+		IsLive:           location.GetIsLive(),
+	}
+}
+
+func parseLiveLocationMessage(location *waE2E.LiveLocationMessage) LocationCoordinates {
+	return LocationCoordinates{
+		Latitude:         location.GetDegreesLatitude(),
+		Longitude:        location.GetDegreesLongitude(),
+		AccuracyInMeters: location.GetAccuracyInMeters(),
+		SpeedInMps:       location.GetSpeedInMps(),
+		Heading:          location.GetDegreesClockwiseFromMagneticNorth(),
+		Caption:          location.GetCaption(),
+		IsLive:           true,
+		SequenceNumber:   location.GetSequenceNumber(),
+		TimeOffset:       location.GetTimeOffset(),
+	}
+}
@@ -30,6 +30,12 @@ func handleMessage(ctx context.Context, evt *events.Message, chatStorageRepo dom
 		log.Errorf("Failed to store incoming message %s: %v", evt.Info.ID, err)
 	}
 
+	// Record statuses posted to status@broadcast
+	handleStatusMessage(ctx, evt, chatStorageRepo, client)
+
+	// Keep polls and decrypt votes cast on them
+	handlePollMessage(ctx, evt, chatStorageRepo, client)
+
 	// Handle image message if present
 	handleImageMessage(ctx, evt, client)
 
@@ -114,6 +120,11 @@ func handleWebhookForward(ctx context.Context, evt *events.Message, client *what
 		}
 	}
 
+	// Poll votes are encrypted; handlePollMessage forwards them decrypted as poll.vote
+	if evt.Message.GetPollUpdateMessage() != nil {
+		return
+	}
+
 	if (len(config.WhatsappWebhook) > 0 || config.ChatwootEnabled) &&
 		!strings.Contains(evt.Info.SourceString(), "broadcast") {
 		go func(e *events.Message, c *whatsmeow.Client) {
@@ -225,3 +225,141 @@ func TestBuildEventPayloadDocumentWithCaption(t *testing.T) {
 		t.Fatalf("expected body='Important document', got %v", body)
 	}
 }
+
+func TestBuildEventPayloadLocationCoordinates(t *testing.T) {
+	lat, lng := -6.2, 106.816666
+	name, address := "Monas", "Gambir, Central Jakarta"
+	evt := &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{
+				Chat:   types.NewJID("123", types.DefaultUserServer),
+				Sender: types.NewJID("456", types.DefaultUserServer),
+			},
+			ID:        "MSG300",
+			Timestamp: time.Date(2026, time.February, 8, 10, 0, 0, 0, time.UTC),
+		},
+		Message: &waE2E.Message{
+			LocationMessage: &waE2E.LocationMessage{
+				DegreesLatitude:  &lat,
+				DegreesLongitude: &lng,
+				Name:             &name,
+				Address:          &address,
+			},
+		},
+	}
+
+	_, payload, err := buildEventPayload(context.Background(), nil, evt)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	coordinates, ok := payload["coordinates"].(LocationCoordinates)
+	if !ok {
+		t.Fatalf("expected coordinates in payload, got %T", payload["coordinates"])
+	}
+	if coordinates.Latitude != lat || coordinates.Longitude != lng {
+		t.Fatalf("expected %f, %f, got %f, %f", lat, lng, coordinates.Latitude, coordinates.Longitude)
+	}
+	if coordinates.Name != name || coordinates.Address != address || coordinates.IsLive {
+		t.Fatalf("unexpected coordinates %+v", coordinates)
+	}
+	if _, ok := payload["location"]; !ok {
+		t.Fatal("expected the raw location to be kept")
+	}
+}
+
+func TestBuildEventPayloadLiveLocationCoordinates(t *testing.T) {
+	lat, lng := 51.5007, -0.1246
+	var sequence int64 = 3
+	var offset, heading uint32 = 120, 90
+	evt := &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{
+				Chat:   types.NewJID("123", types.DefaultUserServer),
+				Sender: types.NewJID("456", types.DefaultUserServer),
+			},
+			ID:        "MSG301",
+			Timestamp: time.Date(2026, time.February, 8, 10, 0, 0, 0, time.UTC),
+		},
+		Message: &waE2E.Message{
+			LiveLocationMessage: &waE2E.LiveLocationMessage{
+				DegreesLatitude:                   &lat,
+				DegreesLongitude:                  &lng,
+				DegreesClockwiseFromMagneticNorth: &heading,
+				SequenceNumber:                    &sequence,
+				TimeOffset:                        &offset,
+			},
+		},
+	}
+
+	_, payload, err := buildEventPayload(context.Background(), nil, evt)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	coordinates, ok := payload["coordinates"].(LocationCoordinates)
+	if !ok {
+		t.Fatalf("expected coordinates in payload, got %T", payload["coordinates"])
+	}
+	if !coordinates.IsLive || coordinates.SequenceNumber != sequence || coordinates.TimeOffset != offset || coordinates.Heading != heading {
+		t.Fatalf("unexpected coordinates %+v", coordinates)
+	}
+	if coordinates.Latitude != lat || coordinates.Longitude != lng {
+		t.Fatalf("expected %f, %f, got %f, %f", lat, lng, coordinates.Latitude, coordinates.Longitude)
+	}
+}
+
+func TestBuildEventPayloadMediaVariants(t *testing.T) {
+	config.WhatsappAutoDownloadMedia = false
+	viewOnce, gif, ptt := true, true, true
+	tests := []struct {
+		name    string
+		message *waE2E.Message
+		want    map[string]bool
+	}{
+		{
+			name:    "gif",
+			message: &waE2E.Message{VideoMessage: &waE2E.VideoMessage{GifPlayback: &gif}},
+			want:    map[string]bool{"gif_playback": true, "video": true},
+		},
+		{
+			name:    "video note",
+			message: &waE2E.Message{PtvMessage: &waE2E.VideoMessage{}},
+			want:    map[string]bool{"video_note": true},
+		},
+		{
+			name:    "view once voice note",
+			message: &waE2E.Message{AudioMessage: &waE2E.AudioMessage{PTT: &ptt, ViewOnce: &viewOnce}},
+			want:    map[string]bool{"ptt": true, "view_once": true, "audio": true},
+		},
+		{
+			name:    "plain video",
+			message: &waE2E.Message{VideoMessage: &waE2E.VideoMessage{}},
+			want:    map[string]bool{"video": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evt := &events.Message{
+				Info: types.MessageInfo{
+					MessageSource: types.MessageSource{
+						Chat:   types.NewJID("123", types.DefaultUserServer),
+						Sender: types.NewJID("456", types.DefaultUserServer),
+					},
+					ID:        "MSG301",
+					Timestamp: time.Date(2026, time.February, 8, 10, 0, 0, 0, time.UTC),
+				},
+				Message: tt.message,
+			}
+
+			_, payload, err := buildEventPayload(context.Background(), nil, evt)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			for _, key := range []string{"gif_playback", "ptt", "view_once", "video", "video_note", "audio"} {
+				if _, ok := payload[key]; ok != tt.want[key] {
+					t.Fatalf("expected %s present=%v, payload %v", key, tt.want[key], payload)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// EventTypePollVote is sent when someone votes on, changes or withdraws a vote on a poll
+const EventTypePollVote = "poll.vote"
+
+// handlePollMessage keeps poll definitions so votes can be named, and decrypts incoming votes. WhatsApp
+// encrypts votes with the poll's message secret, which whatsmeow stores when the poll is sent or received.
+func handlePollMessage(ctx context.Context, evt *events.Message, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
+	if chatStorageRepo == nil {
+		return
+	}
+
+	msg := utils.UnwrapMessage(evt.Message)
+	if poll := utils.ExtractPollCreation(msg); poll != nil {
+		record := buildPollRecord(ctx, client, evt, poll)
+		record.DeviceID = statusDeviceID(ctx)
+		if err := chatStorageRepo.StorePoll(record); err != nil {
+			log.Errorf("Failed to store poll %s: %v", evt.Info.ID, err)
+		}
+		return
+	}
+
+	update := msg.GetPollUpdateMessage()
+	if update == nil || client == nil {
+		return
+	}
+
+	pollID := update.GetPollCreationMessageKey().GetID()
+	poll, err := chatStorageRepo.GetPoll(statusDeviceID(ctx), pollID)
+	if err != nil {
+		log.Errorf("Failed to load poll %s: %v", pollID, err)
+		return
+	}
+	if poll == nil {
+		log.Debugf("Ignoring vote %s on unknown poll %s", evt.Info.ID, pollID)
+		return
+	}
+
+	decrypted, err := client.DecryptPollVote(ctx, evt)
+	if err != nil {
+		log.Warnf("Failed to decrypt vote %s on poll %s: %v", evt.Info.ID, pollID, err)
+		return
+	}
+
+	vote := &domainChatStorage.PollVote{
+		PollID:    pollID,
+		DeviceID:  poll.DeviceID,
+		Voter:     NormalizeJIDFromLID(ctx, evt.Info.Sender, client).ToNonAD().String(),
+		VoterName: evt.Info.PushName,
+		Options:   pollOptionNames(poll.Options, decrypted.GetSelectedOptions()),
+		Timestamp: evt.Info.Timestamp,
+	}
+	if ms := update.GetSenderTimestampMS(); ms > 0 {
+		vote.Timestamp = time.UnixMilli(ms)
+	}
+	if err := chatStorageRepo.StorePollVote(vote); err != nil {
+		log.Errorf("Failed to store vote of %s on poll %s: %v", vote.Voter, pollID, err)
+	}
+
+	forwardPollVoteEvent(ctx, client, evt, poll, vote)
+}
+
+func buildPollRecord(ctx context.Context, client *whatsmeow.Client, evt *events.Message, poll *waE2E.PollCreationMessage) *domainChatStorage.Poll {
+	options := make([]string, 0, len(poll.GetOptions()))
+	for _, option := range poll.GetOptions() {
+		options = append(options, option.GetOptionName())
+	}
+	return &domainChatStorage.Poll{
+		ID:              evt.Info.ID,
+		ChatJID:         NormalizeJIDFromLID(ctx, evt.Info.Chat, client).ToNonAD().String(),
+		Creator:         NormalizeJIDFromLID(ctx, evt.Info.Sender, client).ToNonAD().String(),
+		Question:        poll.GetName(),
+		Options:         options,
+		SelectableCount: int(poll.GetSelectableOptionsCount()),
+		Timestamp:       evt.Info.Timestamp,
+	}
+}
+
+// pollOptionNames maps the SHA-256 hashes a vote carries back to option names, in poll order
+func pollOptionNames(options []string, selected [][]byte) []string {
+	names := []string{}
+	for i, hash := range whatsmeow.HashPollOptions(options) {
+		for _, choice := range selected {
+			if bytes.Equal(hash, choice) {
+				names = append(names, options[i])
+				break
+			}
+		}
+	}
+	return names
+}
+
+func forwardPollVoteEvent(ctx context.Context, client *whatsmeow.Client, evt *events.Message, poll *domainChatStorage.Poll, vote *domainChatStorage.PollVote) {
+	if len(config.WhatsappWebhook) == 0 {
+		return
+	}
+
+	payload := map[string]any{
+		"id":               evt.Info.ID,
+		"timestamp":        vote.Timestamp.Format(time.RFC3339),
+		"is_from_me":       evt.Info.IsFromMe,
+		"poll_id":          poll.ID,
+		"poll_question":    poll.Question,
+		"selected_options": vote.Options,
+	}
+	buildFromFields(ctx, client, evt, payload)
+	if vote.VoterName != "" {
+		payload["from_name"] = vote.VoterName
+	}
+
+	go func() {
+		webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := ForwardEventToWebhooks(webhookCtx, EventTypePollVote, poll.DeviceID, payload); err != nil {
+			logrus.Errorf("Failed to forward %s to webhook: %v", EventTypePollVote, err)
+		}
+	}()
+}
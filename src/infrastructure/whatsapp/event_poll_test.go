@@ -0,0 +1,62 @@
+package whatsapp
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPollOptionNames(t *testing.T) {
+	options := []string{"Zuko", "Aang", "Katara"}
+	selected := whatsmeow.HashPollOptions([]string{"Katara", "Zuko"})
+
+	names := pollOptionNames(options, selected)
+	if !reflect.DeepEqual(names, []string{"Zuko", "Katara"}) {
+		t.Fatalf("expected options in poll order, got %v", names)
+	}
+}
+
+func TestPollOptionNamesWithdrawnVote(t *testing.T) {
+	names := pollOptionNames([]string{"Yes", "No"}, nil)
+	if names == nil || len(names) != 0 {
+		t.Fatalf("expected an empty, non-nil selection, got %#v", names)
+	}
+}
+
+func TestBuildPollRecord(t *testing.T) {
+	group := types.NewJID("120363025246125888", types.GroupServer)
+	evt := &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{
+				Chat:    group,
+				Sender:  types.NewJID("628123456789", types.DefaultUserServer),
+				IsGroup: true,
+			},
+			ID:        "POLL1",
+			Timestamp: time.Date(2026, time.March, 1, 8, 0, 0, 0, time.UTC),
+		},
+	}
+	poll := &waE2E.PollCreationMessage{
+		Name: proto.String("Lunch?"),
+		Options: []*waE2E.PollCreationMessage_Option{
+			{OptionName: proto.String("Rice")},
+			{OptionName: proto.String("Noodles")},
+		},
+		SelectableOptionsCount: proto.Uint32(1),
+	}
+
+	record := buildPollRecord(context.Background(), nil, evt, poll)
+	if record.ID != "POLL1" || record.ChatJID != group.String() || record.Creator != "628123456789@s.whatsapp.net" {
+		t.Fatalf("unexpected poll identity: %+v", record)
+	}
+	if record.Question != "Lunch?" || record.SelectableCount != 1 || !reflect.DeepEqual(record.Options, []string{"Rice", "Noodles"}) {
+		t.Fatalf("unexpected poll content: %+v", record)
+	}
+}
@@ -0,0 +1,158 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Event types for status (story) webhooks
+const (
+	EventTypeStatusReceived = "status.received"
+	EventTypeStatusDeleted  = "status.deleted"
+)
+
+// handleStatusMessage records statuses posted to status@broadcast and forwards them to webhooks.
+// Regular message webhooks skip broadcasts, so statuses get their own events.
+func handleStatusMessage(ctx context.Context, evt *events.Message, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
+	if evt.Info.Chat != types.StatusBroadcastJID || chatStorageRepo == nil {
+		return
+	}
+
+	deviceID := statusDeviceID(ctx)
+	msg := utils.UnwrapMessage(evt.Message)
+
+	if protocolMessage := msg.GetProtocolMessage(); protocolMessage != nil {
+		if protocolMessage.GetType() != waE2E.ProtocolMessage_REVOKE || protocolMessage.GetKey() == nil {
+			return
+		}
+		statusID := protocolMessage.GetKey().GetID()
+		if err := chatStorageRepo.DeleteStatus(deviceID, statusID); err != nil {
+			log.Errorf("Failed to delete status %s: %v", statusID, err)
+		}
+		forwardStatusEvent(client, evt, EventTypeStatusDeleted, map[string]any{"status_id": statusID})
+		return
+	}
+
+	status := buildStatusRecord(ctx, client, evt, msg)
+	if status == nil {
+		return
+	}
+	status.DeviceID = deviceID
+	if err := chatStorageRepo.StoreStatus(status); err != nil {
+		log.Errorf("Failed to store status %s: %v", evt.Info.ID, err)
+	}
+
+	extra := map[string]any{
+		"status_type": status.Type,
+		"expires_at":  status.ExpiresAt.Format(time.RFC3339),
+	}
+	if status.BackgroundColor != "" {
+		extra["background_color"] = status.BackgroundColor
+	}
+	if status.TextColor != "" {
+		extra["text_color"] = status.TextColor
+	}
+	if status.Font != "" {
+		extra["font"] = status.Font
+	}
+	forwardStatusEvent(client, evt, EventTypeStatusReceived, extra)
+}
+
+// buildStatusRecord maps a status message to its stored form, or nil for messages that are not statuses themselves
+func buildStatusRecord(ctx context.Context, client *whatsmeow.Client, evt *events.Message, msg *waE2E.Message) *domainChatStorage.Status {
+	status := &domainChatStorage.Status{
+		ID:        evt.Info.ID,
+		Sender:    NormalizeJIDFromLID(ctx, evt.Info.Sender, client).ToNonAD().String(),
+		PushName:  evt.Info.PushName,
+		IsFromMe:  evt.Info.IsFromMe,
+		Timestamp: evt.Info.Timestamp,
+		ExpiresAt: evt.Info.Timestamp.Add(domainStatus.Lifetime),
+	}
+
+	switch {
+	case msg.GetExtendedTextMessage() != nil:
+		text := msg.GetExtendedTextMessage()
+		status.Type = domainStatus.TypeText
+		status.Text = text.GetText()
+		if text.BackgroundArgb != nil {
+			status.BackgroundColor = utils.FormatARGBColor(text.GetBackgroundArgb())
+		}
+		if text.TextArgb != nil {
+			status.TextColor = utils.FormatARGBColor(text.GetTextArgb())
+		}
+		if text.Font != nil {
+			status.Font = text.GetFont().String()
+		}
+	case msg.GetConversation() != "":
+		status.Type = domainStatus.TypeText
+		status.Text = msg.GetConversation()
+	case msg.GetImageMessage() != nil:
+		status.Type = domainStatus.TypeImage
+		status.MediaType = "image"
+		status.Text = msg.GetImageMessage().GetCaption()
+	case msg.GetVideoMessage() != nil:
+		status.Type = domainStatus.TypeVideo
+		status.MediaType = "video"
+		status.Text = msg.GetVideoMessage().GetCaption()
+	case msg.GetAudioMessage() != nil:
+		status.Type = domainStatus.TypeAudio
+		status.MediaType = "audio"
+	case msg.GetReactionMessage() != nil:
+		return nil
+	default:
+		// Sender key distributions and other bookkeeping messages carry nothing to show
+		text := utils.ExtractMessageTextFromProto(msg)
+		if text == "" {
+			return nil
+		}
+		status.Type = domainStatus.TypeOther
+		status.Text = text
+	}
+
+	return status
+}
+
+func forwardStatusEvent(client *whatsmeow.Client, evt *events.Message, eventName string, extra map[string]any) {
+	if len(config.WhatsappWebhook) == 0 {
+		return
+	}
+
+	go func() {
+		webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		webhookEvent, err := createWebhookEvent(webhookCtx, client, evt)
+		if err != nil {
+			logrus.Errorf("Failed to build %s webhook: %v", eventName, err)
+			return
+		}
+		for key, value := range extra {
+			webhookEvent.Payload[key] = value
+		}
+
+		if err := ForwardEventToWebhooks(webhookCtx, eventName, webhookEvent.DeviceID, webhookEvent.Payload); err != nil {
+			logrus.Errorf("Failed to forward %s to webhook: %v", eventName, err)
+		}
+	}()
+}
+
+// statusDeviceID resolves the chat storage device ID the same way incoming messages are stored
+func statusDeviceID(ctx context.Context) string {
+	if inst, ok := DeviceFromContext(ctx); ok && inst != nil {
+		if jid := inst.JID(); jid != "" {
+			return jid
+		}
+		return inst.ID()
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+func statusEvent(msg *waE2E.Message) *events.Message {
+	return &events.Message{
+		Info: types.MessageInfo{
+			MessageSource: types.MessageSource{
+				Chat:   types.StatusBroadcastJID,
+				Sender: types.NewJID("628123456789", types.DefaultUserServer),
+			},
+			ID:        "STATUS1",
+			PushName:  "Budi",
+			Timestamp: time.Date(2026, time.March, 1, 8, 0, 0, 0, time.UTC),
+		},
+		Message: msg,
+	}
+}
+
+func TestBuildStatusRecordText(t *testing.T) {
+	msg := &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+		Text:           proto.String("Good morning"),
+		BackgroundArgb: proto.Uint32(0xFF25D366),
+		Font:           waE2E.ExtendedTextMessage_SYSTEM_BOLD.Enum(),
+	}}
+	evt := statusEvent(msg)
+
+	status := buildStatusRecord(context.Background(), nil, evt, msg)
+	if status == nil {
+		t.Fatal("expected a status record")
+	}
+	if status.Type != domainStatus.TypeText || status.Text != "Good morning" {
+		t.Fatalf("unexpected status content: %+v", status)
+	}
+	if status.BackgroundColor != "#25D366" || status.Font != "SYSTEM_BOLD" {
+		t.Fatalf("unexpected status style: background %q font %q", status.BackgroundColor, status.Font)
+	}
+	if status.Sender != "628123456789@s.whatsapp.net" {
+		t.Fatalf("unexpected sender %s", status.Sender)
+	}
+	if !status.ExpiresAt.Equal(evt.Info.Timestamp.Add(domainStatus.Lifetime)) {
+		t.Fatalf("unexpected expiry %s", status.ExpiresAt)
+	}
+}
+
+func TestBuildStatusRecordImage(t *testing.T) {
+	msg := &waE2E.Message{ImageMessage: &waE2E.ImageMessage{Caption: proto.String("New menu")}}
+
+	status := buildStatusRecord(context.Background(), nil, statusEvent(msg), msg)
+	if status == nil {
+		t.Fatal("expected a status record")
+	}
+	if status.Type != domainStatus.TypeImage || status.MediaType != "image" || status.Text != "New menu" {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestBuildStatusRecordSkipsBookkeeping(t *testing.T) {
+	msg := &waE2E.Message{SenderKeyDistributionMessage: &waE2E.SenderKeyDistributionMessage{GroupID: proto.String("status@broadcast")}}
+
+	if status := buildStatusRecord(context.Background(), nil, statusEvent(msg), msg); status != nil {
+		t.Fatalf("expected no status record, got %+v", status)
+	}
+}
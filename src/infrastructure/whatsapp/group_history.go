@@ -0,0 +1,155 @@
+package whatsapp
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// recordGroupHistory stores the changes carried by a group notification in the group's history
+func recordGroupHistory(ctx context.Context, evt *events.GroupInfo, repo domainChatStorage.IChatStorageRepository, deviceID string, client *whatsmeow.Client) {
+	storeGroupHistory(repo, deviceID, groupHistoryEvents(ctx, evt, client))
+}
+
+// recordJoinedGroupHistory stores the connected account joining, or being added to, a group
+func recordJoinedGroupHistory(ctx context.Context, evt *events.JoinedGroup, repo domainChatStorage.IChatStorageRepository, deviceID string, client *whatsmeow.Client) {
+	if client == nil || client.Store == nil || client.Store.ID == nil {
+		return
+	}
+	own := client.Store.ID.ToNonAD().String()
+	actor := groupEventActor(ctx, evt.Sender, evt.SenderPN, client)
+
+	eventType := domainGroup.HistoryAdded
+	if evt.Reason == "invite" || actor == "" || actor == own {
+		eventType = domainGroup.HistoryJoined
+	}
+	storeGroupHistory(repo, deviceID, []*domainChatStorage.GroupEvent{{
+		GroupJID:    evt.JID.ToNonAD().String(),
+		Type:        eventType,
+		Participant: own,
+		Actor:       actor,
+		Timestamp:   time.Now(),
+	}})
+}
+
+func storeGroupHistory(repo domainChatStorage.IChatStorageRepository, deviceID string, records []*domainChatStorage.GroupEvent) {
+	if repo == nil || deviceID == "" || len(records) == 0 {
+		return
+	}
+	for _, record := range records {
+		record.DeviceID = deviceID
+	}
+	if err := repo.StoreGroupEvents(records); err != nil {
+		logrus.Warnf("Failed to record history of group %s: %v", records[0].GroupJID, err)
+	}
+}
+
+// groupHistoryEvents turns a group notification into history events. Participants and actors are phone number
+// JIDs where the LID can be resolved, so filters can use either form the webhooks report.
+func groupHistoryEvents(ctx context.Context, evt *events.GroupInfo, client *whatsmeow.Client) []*domainChatStorage.GroupEvent {
+	groupJID := evt.JID.ToNonAD().String()
+	timestamp := evt.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	actor := groupEventActor(ctx, evt.Sender, evt.SenderPN, client)
+
+	var records []*domainChatStorage.GroupEvent
+	add := func(eventType, participant, setting, value, by string) {
+		records = append(records, &domainChatStorage.GroupEvent{
+			GroupJID:    groupJID,
+			Type:        eventType,
+			Participant: participant,
+			Actor:       by,
+			Setting:     setting,
+			Value:       value,
+			Timestamp:   timestamp,
+		})
+	}
+	membership := func(jids []types.JID, self, byOther string) {
+		for _, jid := range jids {
+			participant := NormalizeJIDFromLID(ctx, jid, client).ToNonAD().String()
+			eventType := byOther
+			// Nobody else acted when the participant is the actor or used an invite link
+			if actor == "" || actor == participant || (self == domainGroup.HistoryJoined && evt.JoinReason == "invite") {
+				eventType = self
+			}
+			add(eventType, participant, "", "", actor)
+		}
+	}
+	membership(evt.Join, domainGroup.HistoryJoined, domainGroup.HistoryAdded)
+	membership(evt.Leave, domainGroup.HistoryLeft, domainGroup.HistoryRemoved)
+	for _, jid := range evt.Promote {
+		add(domainGroup.HistoryPromoted, NormalizeJIDFromLID(ctx, jid, client).ToNonAD().String(), "", "", actor)
+	}
+	for _, jid := range evt.Demote {
+		add(domainGroup.HistoryDemoted, NormalizeJIDFromLID(ctx, jid, client).ToNonAD().String(), "", "", actor)
+	}
+
+	if evt.Name != nil {
+		by := actor
+		if by == "" {
+			by = groupEventActor(ctx, &evt.Name.NameSetBy, &evt.Name.NameSetByPN, client)
+		}
+		add(domainGroup.HistoryNameChanged, "", "", evt.Name.Name, by)
+	}
+	if evt.Topic != nil {
+		by := actor
+		if by == "" {
+			by = groupEventActor(ctx, &evt.Topic.TopicSetBy, &evt.Topic.TopicSetByPN, client)
+		}
+		add(domainGroup.HistoryTopicChanged, "", "", evt.Topic.Topic, by)
+	}
+	if evt.Locked != nil {
+		add(domainGroup.HistorySettingChanged, "", domainGroup.SettingLocked, strconv.FormatBool(evt.Locked.IsLocked), actor)
+	}
+	if evt.Announce != nil {
+		add(domainGroup.HistorySettingChanged, "", domainGroup.SettingAnnounce, strconv.FormatBool(evt.Announce.IsAnnounce), actor)
+	}
+	if evt.Ephemeral != nil {
+		timer := uint32(0)
+		if evt.Ephemeral.IsEphemeral {
+			timer = evt.Ephemeral.DisappearingTimer
+		}
+		add(domainGroup.HistorySettingChanged, "", domainGroup.SettingDisappearing, strconv.FormatUint(uint64(timer), 10), actor)
+	}
+	if evt.MembershipApprovalMode != nil {
+		add(domainGroup.HistorySettingChanged, "", domainGroup.SettingJoinApproval,
+			strconv.FormatBool(evt.MembershipApprovalMode.IsJoinApprovalRequired), actor)
+	}
+	if evt.Suspended || evt.Unsuspended {
+		add(domainGroup.HistorySettingChanged, "", domainGroup.SettingSuspended, strconv.FormatBool(evt.Suspended), actor)
+	}
+	if evt.Link != nil {
+		add(domainGroup.HistoryLinked, "", string(evt.Link.Type), evt.Link.Group.JID.String(), actor)
+	}
+	if evt.Unlink != nil {
+		add(domainGroup.HistoryUnlinked, "", string(evt.Unlink.Type), evt.Unlink.Group.JID.String(), actor)
+	}
+	if evt.NewInviteLink != nil {
+		add(domainGroup.HistoryInviteLinkReset, "", "", "", actor)
+	}
+	if evt.Delete != nil {
+		add(domainGroup.HistoryDeleted, "", "", evt.Delete.DeleteReason, actor)
+	}
+
+	return records
+}
+
+// groupEventActor prefers the phone number of whoever made a change and falls back to their resolved LID
+func groupEventActor(ctx context.Context, sender, senderPN *types.JID, client *whatsmeow.Client) string {
+	if senderPN != nil && !senderPN.IsEmpty() {
+		return senderPN.ToNonAD().String()
+	}
+	if sender != nil && !sender.IsEmpty() {
+		return NormalizeJIDFromLID(ctx, *sender, client).ToNonAD().String()
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestGroupHistoryEvents(t *testing.T) {
+	phone := func(user string) types.JID { return types.NewJID(user, types.DefaultUserServer) }
+	admin := phone("62801")
+	adminLID := types.NewJID("99901", types.HiddenUserServer)
+	timestamp := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+
+	records := groupHistoryEvents(context.Background(), &events.GroupInfo{
+		JID:       types.NewJID("120363000000000001", types.GroupServer),
+		Sender:    &adminLID,
+		SenderPN:  &admin,
+		Timestamp: timestamp,
+		Join:      []types.JID{phone("62802")},
+		Leave:     []types.JID{phone("62803"), admin},
+		Promote:   []types.JID{phone("62804")},
+		Announce:  &types.GroupAnnounce{IsAnnounce: true},
+		Ephemeral: &types.GroupEphemeral{IsEphemeral: true, DisappearingTimer: 86400},
+		Topic:     &types.GroupTopic{Topic: "Rules: be kind"},
+	}, nil)
+
+	expected := []struct{ eventType, participant, setting, value string }{
+		{domainGroup.HistoryAdded, "62802@s.whatsapp.net", "", ""},
+		{domainGroup.HistoryRemoved, "62803@s.whatsapp.net", "", ""},
+		{domainGroup.HistoryLeft, "62801@s.whatsapp.net", "", ""},
+		{domainGroup.HistoryPromoted, "62804@s.whatsapp.net", "", ""},
+		{domainGroup.HistoryTopicChanged, "", "", "Rules: be kind"},
+		{domainGroup.HistorySettingChanged, "", domainGroup.SettingAnnounce, "true"},
+		{domainGroup.HistorySettingChanged, "", domainGroup.SettingDisappearing, "86400"},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("expected %d events, got %d: %+v", len(expected), len(records), records)
+	}
+	for i, want := range expected {
+		got := records[i]
+		if got.Type != want.eventType || got.Participant != want.participant || got.Setting != want.setting || got.Value != want.value {
+			t.Fatalf("events[%d] = %+v, want %+v", i, got, want)
+		}
+		if got.Actor != "62801@s.whatsapp.net" || got.GroupJID != "120363000000000001@g.us" || !got.Timestamp.Equal(timestamp) {
+			t.Fatalf("events[%d] has wrong actor, group or time: %+v", i, got)
+		}
+	}
+
+	// Someone using an invite link joins on their own, whoever the notification names as sender
+	invited := phone("62805")
+	records = groupHistoryEvents(context.Background(), &events.GroupInfo{
+		JID:        types.NewJID("120363000000000001", types.GroupServer),
+		Sender:     &admin,
+		JoinReason: "invite",
+		Join:       []types.JID{invited},
+	}, nil)
+	if len(records) != 1 || records[0].Type != domainGroup.HistoryJoined || records[0].Timestamp.IsZero() {
+		t.Fatalf("unexpected invite join events %+v", records)
+	}
+}
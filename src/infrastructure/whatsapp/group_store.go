@@ -0,0 +1,240 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// StoredGroupInfo returns the stored metadata of a group. It asks WhatsApp, and stores the answer, when the group
+// is not stored yet or refresh is set. Without a repository or device ID it always asks WhatsApp.
+func StoredGroupInfo(ctx context.Context, client *whatsmeow.Client, repo domainChatStorage.IChatStorageRepository, deviceID string, jid types.JID, refresh bool) (*types.GroupInfo, error) {
+	if repo != nil && deviceID != "" && !refresh {
+		group, err := repo.GetGroup(deviceID, jid.String())
+		if err != nil {
+			logrus.Warnf("Failed to read stored group %s: %v", jid, err)
+		} else if group != nil {
+			return &group.Info, nil
+		}
+	}
+
+	info, err := client.GetGroupInfo(ctx, jid)
+	if err != nil || info == nil {
+		return info, err
+	}
+	storeGroupInfo(repo, deviceID, info)
+	return info, nil
+}
+
+// StoredJoinedGroups returns every group the device belongs to. The stored list is used once it has been taken
+// in full; the first call and refresh list the groups from WhatsApp and replace the stored ones.
+func StoredJoinedGroups(ctx context.Context, client *whatsmeow.Client, repo domainChatStorage.IChatStorageRepository, deviceID string, refresh bool) ([]*types.GroupInfo, error) {
+	if repo != nil && deviceID != "" && !refresh {
+		if groups, ok := storedJoinedGroups(repo, deviceID); ok {
+			return groups, nil
+		}
+	}
+
+	groups, err := client.GetJoinedGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if repo != nil && deviceID != "" {
+		records := make([]*domainChatStorage.Group, 0, len(groups))
+		for _, info := range groups {
+			if info != nil {
+				records = append(records, &domainChatStorage.Group{JID: info.JID.String(), Info: *info})
+			}
+		}
+		if err := repo.ReplaceGroups(deviceID, records); err != nil {
+			logrus.Warnf("Failed to store groups of device %s: %v", deviceID, err)
+		}
+	}
+	return groups, nil
+}
+
+func storedJoinedGroups(repo domainChatStorage.IChatStorageRepository, deviceID string) ([]*types.GroupInfo, bool) {
+	syncedAt, err := repo.GetGroupsSyncedAt(deviceID)
+	if err != nil || syncedAt == nil {
+		return nil, false
+	}
+	stored, err := repo.GetGroups(deviceID)
+	if err != nil {
+		logrus.Warnf("Failed to read stored groups of device %s: %v", deviceID, err)
+		return nil, false
+	}
+	groups := make([]*types.GroupInfo, 0, len(stored))
+	for _, group := range stored {
+		groups = append(groups, &group.Info)
+	}
+	return groups, true
+}
+
+func storeGroupInfo(repo domainChatStorage.IChatStorageRepository, deviceID string, info *types.GroupInfo) {
+	if repo == nil || deviceID == "" || info == nil {
+		return
+	}
+	if err := repo.StoreGroup(&domainChatStorage.Group{JID: info.JID.String(), DeviceID: deviceID, Info: *info}); err != nil {
+		logrus.Warnf("Failed to store group %s: %v", info.JID, err)
+	}
+}
+
+// updateStoredGroup applies a group change to the stored metadata. Groups that are not stored yet are left
+// alone; they are fetched in full the first time they are asked for.
+func updateStoredGroup(ctx context.Context, evt *events.GroupInfo, repo domainChatStorage.IChatStorageRepository, deviceID string, client *whatsmeow.Client) {
+	if repo == nil || deviceID == "" {
+		return
+	}
+
+	if evt.Delete != nil || leftGroup(evt.Leave, client) {
+		if err := repo.DeleteGroup(deviceID, evt.JID.String()); err != nil {
+			logrus.Warnf("Failed to forget group %s: %v", evt.JID, err)
+		}
+		return
+	}
+
+	group, err := repo.GetGroup(deviceID, evt.JID.String())
+	if err != nil || group == nil {
+		return
+	}
+	// Changes whatsmeow does not parse, such as the member add mode, cannot be patched in
+	if len(evt.UnknownChanges) > 0 {
+		go refreshStoredGroup(client, repo, deviceID, evt.JID)
+		return
+	}
+	applyGroupChange(ctx, &group.Info, evt, client)
+	group.Name = group.Info.Name
+	if err := repo.StoreGroup(group); err != nil {
+		logrus.Warnf("Failed to update stored group %s: %v", evt.JID, err)
+	}
+}
+
+// refreshStoredGroup replaces the stored group with its current metadata from WhatsApp. When that fails the group
+// is forgotten instead of kept out of date, so the next lookup fetches it again.
+func refreshStoredGroup(client *whatsmeow.Client, repo domainChatStorage.IChatStorageRepository, deviceID string, jid types.JID) {
+	if client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		info, err := client.GetGroupInfo(ctx, jid)
+		if err == nil && info != nil {
+			storeGroupInfo(repo, deviceID, info)
+			return
+		}
+		logrus.Warnf("Failed to refresh stored group %s: %v", jid, err)
+	}
+	if err := repo.DeleteGroup(deviceID, jid.String()); err != nil {
+		logrus.Warnf("Failed to forget group %s: %v", jid, err)
+	}
+}
+
+// leftGroup reports whether the connected account is among the participants who left
+func leftGroup(leave []types.JID, client *whatsmeow.Client) bool {
+	if client == nil || client.Store == nil || client.Store.ID == nil {
+		return false
+	}
+	for _, jid := range leave {
+		user := jid.ToNonAD()
+		if user == client.Store.ID.ToNonAD() || (!client.Store.LID.IsEmpty() && user == client.Store.LID.ToNonAD()) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyGroupChange updates group metadata with the fields a group change carries
+func applyGroupChange(ctx context.Context, info *types.GroupInfo, evt *events.GroupInfo, client *whatsmeow.Client) {
+	if evt.Name != nil {
+		info.GroupName = *evt.Name
+	}
+	if evt.Topic != nil {
+		info.GroupTopic = *evt.Topic
+	}
+	if evt.Locked != nil {
+		info.GroupLocked = *evt.Locked
+	}
+	if evt.Announce != nil {
+		info.GroupAnnounce = *evt.Announce
+	}
+	if evt.Ephemeral != nil {
+		info.GroupEphemeral = *evt.Ephemeral
+	}
+	if evt.MembershipApprovalMode != nil {
+		info.GroupMembershipApprovalMode = *evt.MembershipApprovalMode
+	}
+	if evt.Link != nil && evt.Link.Type == types.GroupLinkChangeTypeParent {
+		info.LinkedParentJID = evt.Link.Group.JID
+	}
+	if evt.Unlink != nil && evt.Unlink.Type == types.GroupLinkChangeTypeParent {
+		info.LinkedParentJID = types.EmptyJID
+	}
+	if evt.Suspended {
+		info.Suspended = true
+	}
+	if evt.Unsuspended {
+		info.Suspended = false
+	}
+
+	for _, jid := range evt.Join {
+		if groupParticipantIndex(info.Participants, jid) < 0 {
+			info.Participants = append(info.Participants, joinedGroupParticipant(ctx, jid, client))
+		}
+	}
+	for _, jid := range evt.Leave {
+		if i := groupParticipantIndex(info.Participants, jid); i >= 0 {
+			info.Participants = append(info.Participants[:i], info.Participants[i+1:]...)
+		}
+	}
+	for _, jid := range evt.Promote {
+		if i := groupParticipantIndex(info.Participants, jid); i >= 0 {
+			info.Participants[i].IsAdmin = true
+		}
+	}
+	for _, jid := range evt.Demote {
+		if i := groupParticipantIndex(info.Participants, jid); i >= 0 {
+			info.Participants[i].IsAdmin = false
+			info.Participants[i].IsSuperAdmin = false
+		}
+	}
+	if len(evt.Join) > 0 || len(evt.Leave) > 0 {
+		info.ParticipantCount = len(info.Participants)
+	}
+	if evt.ParticipantVersionID != "" {
+		info.ParticipantVersionID = evt.ParticipantVersionID
+	}
+}
+
+// joinedGroupParticipant describes a new member the way WhatsApp lists participants: a LID member carries its
+// phone number and a phone number member its LID, where the client knows the mapping
+func joinedGroupParticipant(ctx context.Context, jid types.JID, client *whatsmeow.Client) types.GroupParticipant {
+	participant := types.GroupParticipant{JID: jid}
+	switch jid.Server {
+	case types.HiddenUserServer:
+		participant.LID = jid
+		if pn := NormalizeJIDFromLID(ctx, jid, client); pn.Server == types.DefaultUserServer {
+			participant.PhoneNumber = pn
+		}
+	case types.DefaultUserServer:
+		participant.PhoneNumber = jid
+		participant.LID = utils.ResolvePhoneToLID(ctx, jid, client)
+	}
+	return participant
+}
+
+// groupParticipantIndex finds a participant by its JID, phone number or LID, since events may use either
+func groupParticipantIndex(participants []types.GroupParticipant, jid types.JID) int {
+	jid = jid.ToNonAD()
+	for i, participant := range participants {
+		if participant.JID.ToNonAD() == jid ||
+			(!participant.PhoneNumber.IsEmpty() && participant.PhoneNumber.ToNonAD() == jid) ||
+			(!participant.LID.IsEmpty() && participant.LID.ToNonAD() == jid) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,62 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestApplyGroupChange(t *testing.T) {
+	phone := func(user string) types.JID { return types.NewJID(user, types.DefaultUserServer) }
+	community := types.NewJID("120363000000000001", types.GroupServer)
+
+	info := &types.GroupInfo{
+		GroupName: types.GroupName{Name: "Neighbours"},
+		Participants: []types.GroupParticipant{
+			{JID: phone("62801"), IsAdmin: true, IsSuperAdmin: true},
+			// Stored by LID, while the event below names them by phone number
+			{JID: types.NewJID("99902", types.HiddenUserServer), PhoneNumber: phone("62802")},
+			{JID: phone("62803")},
+		},
+		ParticipantCount: 3,
+	}
+
+	applyGroupChange(context.Background(), info, &events.GroupInfo{
+		Name:                   &types.GroupName{Name: "Street 12"},
+		Announce:               &types.GroupAnnounce{IsAnnounce: true},
+		MembershipApprovalMode: &types.GroupMembershipApprovalMode{IsJoinApprovalRequired: true},
+		Link:                   &types.GroupLinkChange{Type: types.GroupLinkChangeTypeParent, Group: types.GroupLinkTarget{JID: community}},
+		Join:                   []types.JID{phone("62804"), phone("62803")},
+		Leave:                  []types.JID{phone("62802")},
+		Promote:                []types.JID{phone("62803")},
+		Demote:                 []types.JID{phone("62801")},
+		ParticipantVersionID:   "v2",
+	}, nil)
+
+	if info.Name != "Street 12" || !info.IsAnnounce || !info.IsJoinApprovalRequired || info.LinkedParentJID != community {
+		t.Fatalf("settings were not applied: %+v", info)
+	}
+	if info.ParticipantCount != 3 || len(info.Participants) != 3 || info.ParticipantVersionID != "v2" {
+		t.Fatalf("unexpected participants %+v (count %d)", info.Participants, info.ParticipantCount)
+	}
+	if groupParticipantIndex(info.Participants, phone("62802")) >= 0 {
+		t.Fatal("participant who left is still stored")
+	}
+	if i := groupParticipantIndex(info.Participants, phone("62804")); i < 0 || info.Participants[i].IsAdmin ||
+		info.Participants[i].PhoneNumber != phone("62804") {
+		t.Fatalf("joined participant missing, admin or without phone number: %+v", info.Participants)
+	}
+	if info.Participants[0].IsAdmin || info.Participants[0].IsSuperAdmin {
+		t.Fatalf("demoted participant is still admin: %+v", info.Participants[0])
+	}
+	if i := groupParticipantIndex(info.Participants, phone("62803")); i < 0 || !info.Participants[i].IsAdmin {
+		t.Fatalf("promoted participant is not admin: %+v", info.Participants)
+	}
+
+	applyGroupChange(context.Background(), info, &events.GroupInfo{Unlink: &types.GroupLinkChange{Type: types.GroupLinkChangeTypeParent}}, nil)
+	if !info.LinkedParentJID.IsEmpty() {
+		t.Fatalf("unlinked group still points at community %s", info.LinkedParentJID)
+	}
+}
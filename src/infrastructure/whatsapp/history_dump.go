@@ -0,0 +1,288 @@
+package whatsapp
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"go.mau.fi/whatsmeow/proto/waHistorySync"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	historyDumpPrefix = "history-"
+	historyDumpExt    = ".json"
+	historyDumpGzExt  = ".json.gz"
+)
+
+// historyDump describes a history-<startup>-<jid>-<id>-<synctype>.json[.gz] file written by handleHistorySync
+type historyDump struct {
+	path     string
+	startup  int64
+	jid      string
+	seq      int
+	syncType string
+}
+
+// HistoryReplayResult summarizes a replay of stored history sync dumps for one device
+type HistoryReplayResult struct {
+	Files    int
+	Replayed int
+	Failed   int
+	Errors   []string
+}
+
+type historyReplayKey struct{}
+
+// isHistoryReplay reports whether history sync data is being re-ingested from a dump file
+func isHistoryReplay(ctx context.Context) bool {
+	replay, _ := ctx.Value(historyReplayKey{}).(bool)
+	return replay
+}
+
+// writeHistoryDump encodes a history sync blob to path, gzipped when compress is set
+func writeHistoryDump(path string, data *waHistorySync.HistorySync, compress bool) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if !compress {
+		return encodeHistoryDump(file, data)
+	}
+
+	gz := gzip.NewWriter(file)
+	if err := encodeHistoryDump(gz, data); err != nil {
+		gz.Close()
+		return err
+	}
+	return gz.Close()
+}
+
+// encodeHistoryDump writes the blob as protojson; encoding/json cannot decode oneof fields such as interactive
+// message headers back into their interfaces
+func encodeHistoryDump(w io.Writer, data *waHistorySync.HistorySync) error {
+	encoded, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(encoded)
+	return err
+}
+
+// readHistoryDump decodes a dump written by writeHistoryDump
+func readHistoryDump(path string) (*waHistorySync.HistorySync, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(path, historyDumpGzExt) {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	encoded, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data waHistorySync.HistorySync
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(encoded, &data); err != nil {
+		// Dumps written before the switch to protojson used encoding/json
+		legacy := &waHistorySync.HistorySync{}
+		if json.Unmarshal(encoded, legacy) != nil {
+			return nil, err
+		}
+		return legacy, nil
+	}
+	return &data, nil
+}
+
+// parseHistoryDumpName splits a dump file name into its parts. The JID may itself contain
+// dashes, so the startup time is taken from the front and the sequence/sync type from the back.
+func parseHistoryDumpName(name string) (historyDump, bool) {
+	var base string
+	switch {
+	case strings.HasSuffix(name, historyDumpGzExt):
+		base = strings.TrimSuffix(name, historyDumpGzExt)
+	case strings.HasSuffix(name, historyDumpExt):
+		base = strings.TrimSuffix(name, historyDumpExt)
+	default:
+		return historyDump{}, false
+	}
+	if !strings.HasPrefix(base, historyDumpPrefix) {
+		return historyDump{}, false
+	}
+	base = strings.TrimPrefix(base, historyDumpPrefix)
+
+	first := strings.Index(base, "-")
+	last := strings.LastIndex(base, "-")
+	if first < 0 || last <= first {
+		return historyDump{}, false
+	}
+	middle := strings.LastIndex(base[:last], "-")
+	if middle <= first {
+		return historyDump{}, false
+	}
+
+	startup, err := strconv.ParseInt(base[:first], 10, 64)
+	if err != nil {
+		return historyDump{}, false
+	}
+	seq, err := strconv.Atoi(base[middle+1 : last])
+	if err != nil {
+		return historyDump{}, false
+	}
+
+	return historyDump{
+		path:     name,
+		startup:  startup,
+		jid:      base[first+1 : middle],
+		seq:      seq,
+		syncType: base[last+1:],
+	}, true
+}
+
+// listHistoryDumps returns the dumps written for deviceJID in the order they were received
+func listHistoryDumps(dir string, deviceJID types.JID) ([]historyDump, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	want := deviceJID.ToNonAD().String()
+	var dumps []historyDump
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		dump, ok := parseHistoryDumpName(entry.Name())
+		if !ok {
+			continue
+		}
+		jid, err := types.ParseJID(dump.jid)
+		if err != nil || jid.ToNonAD().String() != want {
+			continue
+		}
+		dump.path = filepath.Join(dir, entry.Name())
+		dumps = append(dumps, dump)
+	}
+
+	sort.Slice(dumps, func(i, j int) bool {
+		if dumps[i].startup != dumps[j].startup {
+			return dumps[i].startup < dumps[j].startup
+		}
+		return dumps[i].seq < dumps[j].seq
+	})
+	return dumps, nil
+}
+
+// pruneHistoryDumps deletes dumps older than the retention window. A zero or negative retention keeps everything.
+func pruneHistoryDumps(dir string, retentionDays int, now time.Time) (int, error) {
+	if retentionDays <= 0 {
+		return 0, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := now.Add(-time.Duration(retentionDays) * 24 * time.Hour)
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, ok := parseHistoryDumpName(entry.Name()); !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			log.Warnf("Failed to remove expired history dump %s: %v", entry.Name(), err)
+			continue
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// PruneHistoryDumps applies the configured dump retention to the storages folder
+func PruneHistoryDumps() {
+	removed, err := pruneHistoryDumps(config.PathStorages, config.WhatsappHistoryDumpRetentionDays, time.Now())
+	if err != nil {
+		log.Warnf("Failed to prune history dumps: %v", err)
+		return
+	}
+	if removed > 0 {
+		log.Infof("Removed %d expired history dump(s)", removed)
+	}
+}
+
+// ReplayHistoryDumps re-ingests every stored history sync dump of a device into its chat storage.
+// Messages and chats are upserted, so replaying the same files more than once is harmless.
+func ReplayHistoryDumps(ctx context.Context, instance *DeviceInstance) (HistoryReplayResult, error) {
+	var result HistoryReplayResult
+	if instance == nil {
+		return result, fmt.Errorf("device instance is required")
+	}
+
+	client := instance.GetClient()
+	if client == nil || client.Store == nil || client.Store.ID == nil {
+		return result, fmt.Errorf("device %s is not logged in", instance.ID())
+	}
+
+	repo := instance.GetChatStorage()
+	if repo == nil {
+		return result, fmt.Errorf("chat storage is not available for device %s", instance.ID())
+	}
+
+	dumps, err := listHistoryDumps(config.PathStorages, *client.Store.ID)
+	if err != nil {
+		return result, err
+	}
+	result.Files = len(dumps)
+
+	replayCtx := context.WithValue(ContextWithDevice(ctx, instance), historyReplayKey{}, true)
+	for _, dump := range dumps {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		data, err := readHistoryDump(dump.path)
+		if err == nil {
+			err = processHistorySync(replayCtx, data, repo, client)
+		}
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", filepath.Base(dump.path), err))
+			log.Warnf("Failed to replay history dump %s: %v", dump.path, err)
+			continue
+		}
+		result.Replayed++
+		log.Infof("Replayed history dump %s (%s)", filepath.Base(dump.path), dump.syncType)
+	}
+
+	return result, nil
+}
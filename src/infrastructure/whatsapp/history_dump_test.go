@@ -0,0 +1,209 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/proto/waHistorySync"
+	"go.mau.fi/whatsmeow/proto/waWeb"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseHistoryDumpName(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		ok       bool
+		jid      string
+		seq      int
+		syncType string
+	}{
+		{"plain", "history-1700000000-628123:12@s.whatsapp.net-3-RECENT.json", true, "628123:12@s.whatsapp.net", 3, "RECENT"},
+		{"gzipped", "history-1700000000-628123:12@s.whatsapp.net-1-INITIAL_BOOTSTRAP.json.gz", true, "628123:12@s.whatsapp.net", 1, "INITIAL_BOOTSTRAP"},
+		{"other json", "whatsapp.json", false, "", 0, ""},
+		{"bad sequence", "history-1700000000-628123@s.whatsapp.net-x-RECENT.json", false, "", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dump, ok := parseHistoryDumpName(tt.file)
+			if ok != tt.ok {
+				t.Fatalf("expected ok=%v, got %v", tt.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			if dump.jid != tt.jid || dump.seq != tt.seq || dump.syncType != tt.syncType {
+				t.Fatalf("unexpected dump: %+v", dump)
+			}
+		})
+	}
+}
+
+func TestHistoryDumpRoundTripAndOrdering(t *testing.T) {
+	dir := t.TempDir()
+	data := &waHistorySync.HistorySync{
+		SyncType: waHistorySync.HistorySync_RECENT.Enum(),
+		Conversations: []*waHistorySync.Conversation{
+			{ID: proto.String("628999@s.whatsapp.net")},
+		},
+	}
+
+	files := []struct {
+		name     string
+		compress bool
+	}{
+		{"history-200-628123:12@s.whatsapp.net-1-RECENT.json.gz", true},
+		{"history-100-628123:12@s.whatsapp.net-10-RECENT.json", false},
+		{"history-100-628123:12@s.whatsapp.net-2-INITIAL_BOOTSTRAP.json", false},
+		{"history-100-628777:3@s.whatsapp.net-1-RECENT.json", false},
+	}
+	for _, f := range files {
+		if err := writeHistoryDump(filepath.Join(dir, f.name), data, f.compress); err != nil {
+			t.Fatalf("write %s: %v", f.name, err)
+		}
+	}
+
+	dumps, err := listHistoryDumps(dir, types.NewJID("628123", types.DefaultUserServer))
+	if err != nil {
+		t.Fatalf("list dumps: %v", err)
+	}
+	if len(dumps) != 3 {
+		t.Fatalf("expected 3 dumps for the device, got %d", len(dumps))
+	}
+	if dumps[0].seq != 2 || dumps[1].seq != 10 || dumps[2].startup != 200 {
+		t.Fatalf("dumps are not in received order: %+v", dumps)
+	}
+
+	for _, dump := range dumps {
+		decoded, err := readHistoryDump(dump.path)
+		if err != nil {
+			t.Fatalf("read %s: %v", dump.path, err)
+		}
+		if decoded.GetSyncType() != waHistorySync.HistorySync_RECENT || len(decoded.GetConversations()) != 1 {
+			t.Fatalf("unexpected decoded dump: %v", decoded)
+		}
+		if decoded.GetConversations()[0].GetID() != "628999@s.whatsapp.net" {
+			t.Fatalf("unexpected conversation id %q", decoded.GetConversations()[0].GetID())
+		}
+	}
+}
+
+func TestHistoryDumpReplaysOneofMessages(t *testing.T) {
+	dir := t.TempDir()
+	// Interactive messages carry their header media and native flow body in oneof fields
+	interactive := &waE2E.Message{InteractiveMessage: &waE2E.InteractiveMessage{
+		Header: &waE2E.InteractiveMessage_Header{
+			Title: proto.String("Order"),
+			Media: &waE2E.InteractiveMessage_Header_ImageMessage{ImageMessage: &waE2E.ImageMessage{
+				Mimetype:      proto.String("image/jpeg"),
+				JPEGThumbnail: []byte{0xff, 0xd8, 0xff},
+			}},
+		},
+		InteractiveMessage: &waE2E.InteractiveMessage_NativeFlowMessage_{NativeFlowMessage: &waE2E.InteractiveMessage_NativeFlowMessage{
+			Buttons: []*waE2E.InteractiveMessage_NativeFlowMessage_NativeFlowButton{
+				{Name: proto.String("cta_url"), ButtonParamsJSON: proto.String(`{"url":"https://example.com"}`)},
+			},
+		}},
+	}}
+	data := &waHistorySync.HistorySync{
+		SyncType: waHistorySync.HistorySync_RECENT.Enum(),
+		Conversations: []*waHistorySync.Conversation{{
+			ID: proto.String("628999@s.whatsapp.net"),
+			Messages: []*waHistorySync.HistorySyncMsg{{
+				Message: &waWeb.WebMessageInfo{
+					Key: &waCommon.MessageKey{
+						RemoteJID: proto.String("628999@s.whatsapp.net"),
+						ID:        proto.String("3EB0INTERACTIVE"),
+					},
+					Message:          interactive,
+					MessageTimestamp: proto.Uint64(1700000000),
+				},
+			}},
+		}},
+	}
+
+	for _, name := range []string{"history-100-628123@s.whatsapp.net-1-RECENT.json", "history-100-628123@s.whatsapp.net-2-RECENT.json.gz"} {
+		path := filepath.Join(dir, name)
+		if err := writeHistoryDump(path, data, filepath.Ext(name) == ".gz"); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		decoded, err := readHistoryDump(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if !proto.Equal(decoded, data) {
+			t.Fatalf("%s did not survive the round trip: %v", name, decoded)
+		}
+		media := decoded.GetConversations()[0].GetMessages()[0].GetMessage().GetMessage().GetInteractiveMessage().GetHeader().GetImageMessage()
+		if media.GetMimetype() != "image/jpeg" {
+			t.Fatalf("header image lost in %s", name)
+		}
+	}
+
+	// Dumps written with encoding/json before the switch to protojson can still be replayed
+	legacy, err := json.Marshal(&waHistorySync.HistorySync{
+		SyncType:      waHistorySync.HistorySync_RECENT.Enum(),
+		Conversations: []*waHistorySync.Conversation{{ID: proto.String("628999@s.whatsapp.net")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyPath := filepath.Join(dir, "history-100-628123@s.whatsapp.net-3-RECENT.json")
+	if err := os.WriteFile(legacyPath, legacy, 0600); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := readHistoryDump(legacyPath)
+	if err != nil {
+		t.Fatalf("read legacy dump: %v", err)
+	}
+	if decoded.GetConversations()[0].GetID() != "628999@s.whatsapp.net" {
+		t.Fatalf("unexpected legacy dump %v", decoded)
+	}
+}
+
+func TestPruneHistoryDumps(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	oldDump := filepath.Join(dir, "history-100-628123@s.whatsapp.net-1-RECENT.json")
+	newDump := filepath.Join(dir, "history-100-628123@s.whatsapp.net-2-RECENT.json")
+	unrelated := filepath.Join(dir, "whatsapp.db")
+	for _, path := range []string{oldDump, newDump, unrelated} {
+		if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := now.Add(-10 * 24 * time.Hour)
+	for _, path := range []string{oldDump, unrelated} {
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if removed, err := pruneHistoryDumps(dir, 0, now); err != nil || removed != 0 {
+		t.Fatalf("zero retention must keep everything, removed=%d err=%v", removed, err)
+	}
+
+	removed, err := pruneHistoryDumps(dir, 7, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 removed dump, got %d", removed)
+	}
+	if _, err := os.Stat(oldDump); !os.IsNotExist(err) {
+		t.Fatal("expired dump should be removed")
+	}
+	for _, path := range []string{newDump, unrelated} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("%s should be kept: %v", path, err)
+		}
+	}
+}
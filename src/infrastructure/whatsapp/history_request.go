@@ -0,0 +1,117 @@
+package whatsapp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"go.mau.fi/whatsmeow/proto/waHistorySync"
+)
+
+// pendingHistoryRequest remembers an on-demand history request until the phone answers it
+type pendingHistoryRequest struct {
+	requestID   string
+	count       int
+	requestedAt time.Time
+}
+
+var (
+	// pendingHistoryRequests maps "<device>|<chat>" to the last on-demand request for that chat
+	pendingHistoryRequests   sync.Map
+	pendingHistoryRequestTTL = 10 * time.Minute
+)
+
+func historyRequestKey(deviceID, chatJID string) string {
+	return deviceID + "|" + chatJID
+}
+
+// TrackHistoryRequest records an on-demand history request so the matching ON_DEMAND sync
+// can be correlated with it, and notifies webhooks that the backfill was requested.
+func TrackHistoryRequest(ctx context.Context, deviceID, chatJID, requestID string, count int) {
+	now := time.Now()
+	sweepHistoryRequests(now)
+	pendingHistoryRequests.Store(historyRequestKey(deviceID, chatJID), pendingHistoryRequest{
+		requestID:   requestID,
+		count:       count,
+		requestedAt: now,
+	})
+
+	if len(config.WhatsappWebhook) == 0 {
+		return
+	}
+
+	payload := map[string]any{
+		"request_id": requestID,
+		"chat_id":    chatJID,
+		"status":     "requested",
+		"count":      count,
+	}
+	go func() {
+		webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := forwardHistorySyncEventToWebhook(webhookCtx, payload, deviceID); err != nil {
+			log.Errorf("Failed to forward history request event to webhook: %v", err)
+		}
+	}()
+}
+
+// sweepHistoryRequests forgets requests the phone never answered, so chats that are asked for once do not
+// stay in memory for good
+func sweepHistoryRequests(now time.Time) {
+	pendingHistoryRequests.Range(func(key, value any) bool {
+		if now.Sub(value.(pendingHistoryRequest).requestedAt) > pendingHistoryRequestTTL {
+			pendingHistoryRequests.Delete(key)
+		}
+		return true
+	})
+}
+
+// takeHistoryRequest returns and forgets the pending request for a chat, if it has not expired
+func takeHistoryRequest(deviceID, chatJID string) (pendingHistoryRequest, bool) {
+	value, ok := pendingHistoryRequests.LoadAndDelete(historyRequestKey(deviceID, chatJID))
+	if !ok {
+		return pendingHistoryRequest{}, false
+	}
+	pending := value.(pendingHistoryRequest)
+	if time.Since(pending.requestedAt) > pendingHistoryRequestTTL {
+		return pendingHistoryRequest{}, false
+	}
+	return pending, true
+}
+
+// forwardHistoryBackfillProgress reports the result of an ON_DEMAND history sync chunk for one chat
+func forwardHistoryBackfillProgress(ctx context.Context, deviceID, chatJID string, stored int, progress uint32, conv *waHistorySync.Conversation) {
+	pending, tracked := takeHistoryRequest(deviceID, chatJID)
+
+	if len(config.WhatsappWebhook) == 0 {
+		return
+	}
+
+	endType := conv.GetEndOfHistoryTransferType()
+	payload := map[string]any{
+		"chat_id":                      chatJID,
+		"status":                       "completed",
+		"messages":                     stored,
+		"progress":                     progress,
+		"end_of_history_transfer_type": endType.String(),
+		"has_more": endType == waHistorySync.Conversation_COMPLETE_BUT_MORE_MESSAGES_REMAIN_ON_PRIMARY ||
+			endType == waHistorySync.Conversation_COMPLETE_ON_DEMAND_SYNC_BUT_MORE_MSG_REMAIN_ON_PRIMARY,
+	}
+	if tracked {
+		payload["request_id"] = pending.requestID
+		payload["count"] = pending.count
+	}
+
+	go func() {
+		webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := forwardHistorySyncEventToWebhook(webhookCtx, payload, deviceID); err != nil {
+			log.Errorf("Failed to forward history sync progress to webhook: %v", err)
+		}
+	}()
+}
+
+func forwardHistorySyncEventToWebhook(ctx context.Context, payload map[string]any, deviceID string) error {
+	return ForwardEventToWebhooks(ctx, "chat.history_sync", deviceID, payload)
+}
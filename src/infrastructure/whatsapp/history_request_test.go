@@ -0,0 +1,61 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+)
+
+func TestTrackHistoryRequest_CorrelatesOnce(t *testing.T) {
+	originalWebhooks := config.WhatsappWebhook
+	config.WhatsappWebhook = nil
+	defer func() { config.WhatsappWebhook = originalWebhooks }()
+
+	TrackHistoryRequest(context.Background(), "device-1", "628123@s.whatsapp.net", "REQ1", 50)
+
+	if _, ok := takeHistoryRequest("device-2", "628123@s.whatsapp.net"); ok {
+		t.Fatal("request must not match another device")
+	}
+
+	pending, ok := takeHistoryRequest("device-1", "628123@s.whatsapp.net")
+	if !ok || pending.requestID != "REQ1" || pending.count != 50 {
+		t.Fatalf("unexpected pending request: %+v (found=%v)", pending, ok)
+	}
+
+	if _, ok := takeHistoryRequest("device-1", "628123@s.whatsapp.net"); ok {
+		t.Fatal("request must only be returned once")
+	}
+}
+
+func TestTakeHistoryRequest_Expired(t *testing.T) {
+	key := historyRequestKey("device-1", "628999@s.whatsapp.net")
+	pendingHistoryRequests.Store(key, pendingHistoryRequest{
+		requestID:   "OLD",
+		requestedAt: time.Now().Add(-2 * pendingHistoryRequestTTL),
+	})
+
+	if _, ok := takeHistoryRequest("device-1", "628999@s.whatsapp.net"); ok {
+		t.Fatal("expired request must not be returned")
+	}
+}
+
+func TestTrackHistoryRequest_SweepsExpired(t *testing.T) {
+	originalWebhooks := config.WhatsappWebhook
+	config.WhatsappWebhook = nil
+	defer func() { config.WhatsappWebhook = originalWebhooks }()
+
+	staleKey := historyRequestKey("device-1", "628777@s.whatsapp.net")
+	pendingHistoryRequests.Store(staleKey, pendingHistoryRequest{
+		requestID:   "STALE",
+		requestedAt: time.Now().Add(-2 * pendingHistoryRequestTTL),
+	})
+
+	TrackHistoryRequest(context.Background(), "device-1", "628778@s.whatsapp.net", "REQ2", 10)
+	defer pendingHistoryRequests.Delete(historyRequestKey("device-1", "628778@s.whatsapp.net"))
+
+	if _, ok := pendingHistoryRequests.Load(staleKey); ok {
+		t.Fatal("expired request must be swept when a new one is tracked")
+	}
+}
@@ -2,9 +2,7 @@ package whatsapp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"sync/atomic"
 	"time"
 
@@ -25,29 +23,27 @@ func handleHistorySync(ctx context.Context, evt *events.HistorySync, chatStorage
 		return
 	}
 	id := atomic.AddInt32(&historySyncID, 1)
-	fileName := fmt.Sprintf("%s/history-%d-%s-%d-%s.json",
+	ext := historyDumpExt
+	if config.WhatsappHistoryDumpCompress {
+		ext = historyDumpGzExt
+	}
+	fileName := fmt.Sprintf("%s/%s%d-%s-%d-%s%s",
 		config.PathStorages,
+		historyDumpPrefix,
 		startupTime,
 		client.Store.ID.String(),
 		id,
 		evt.Data.SyncType.String(),
+		ext,
 	)
 
-	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE, 0600)
-	if err != nil {
-		log.Errorf("Failed to open file to write history sync: %v", err)
-		return
-	}
-	defer file.Close()
-
-	enc := json.NewEncoder(file)
-	enc.SetIndent("", "  ")
-	if err = enc.Encode(evt.Data); err != nil {
+	if err := writeHistoryDump(fileName, evt.Data, config.WhatsappHistoryDumpCompress); err != nil {
 		log.Errorf("Failed to write history sync: %v", err)
 		return
 	}
 
 	log.Infof("Wrote history sync to %s", fileName)
+	PruneHistoryDumps()
 
 	// Process history sync data to database
 	if chatStorageRepo != nil {
@@ -67,8 +63,8 @@ func processHistorySync(ctx context.Context, data *waHistorySync.HistorySync, ch
 	log.Infof("Processing history sync type: %s", syncType.String())
 
 	switch syncType {
-	case waHistorySync.HistorySync_INITIAL_BOOTSTRAP, waHistorySync.HistorySync_RECENT:
-		// Process conversation messages
+	case waHistorySync.HistorySync_INITIAL_BOOTSTRAP, waHistorySync.HistorySync_RECENT, waHistorySync.HistorySync_ON_DEMAND:
+		// Process conversation messages (ON_DEMAND answers a backfill requested via /chat/:chat_jid/history)
 		return processConversationMessages(ctx, data, chatStorageRepo, client)
 	case waHistorySync.HistorySync_PUSH_NAME:
 		// Process push names to update chat names
@@ -217,6 +213,7 @@ func processConversationMessages(ctx context.Context, data *waHistorySync.Histor
 				FileSHA256:    fileSHA256,
 				FileEncSHA256: fileEncSHA256,
 				FileLength:    fileLength,
+				MediaMessage:  utils.ExtractMediaMessage(msg.GetMessage()),
 			}
 
 			messageBatch = append(messageBatch, message)
@@ -224,6 +221,11 @@ func processConversationMessages(ctx context.Context, data *waHistorySync.Histor
 
 		// Store or update the chat with latest message time
 		if len(messageBatch) > 0 {
+			// Older chunks (on-demand syncs, dump replays) must not move the last message time backwards
+			if existing, err := chatStorageRepo.GetChatByDevice(deviceID, chatJID); err == nil && existing != nil && existing.LastMessageTime.After(latestTimestamp) {
+				latestTimestamp = existing.LastMessageTime
+			}
+
 			chat := &domainChatStorage.Chat{
 				DeviceID:            deviceID,
 				JID:                 chatJID,
@@ -245,6 +247,10 @@ func processConversationMessages(ctx context.Context, data *waHistorySync.Histor
 				log.Debugf("Stored %d messages for chat %s", len(messageBatch), chatJID)
 			}
 		}
+
+		if data.GetSyncType() == waHistorySync.HistorySync_ON_DEMAND && !isHistoryReplay(ctx) {
+			forwardHistoryBackfillProgress(ctx, deviceID, chatJID, len(messageBatch), data.GetProgress(), conv)
+		}
 	}
 
 	return nil
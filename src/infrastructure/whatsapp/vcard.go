@@ -0,0 +1,231 @@
+package whatsapp
+
+import (
+	"strings"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+)
+
+// vcardEscaper escapes text values as vCard 3.0 requires
+var vcardEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// BuildVCard renders a contact card as a vCard 3.0 the way WhatsApp clients write them. A raw vCard is returned as-is.
+func BuildVCard(card domainSend.ContactCard) string {
+	if card.VCard != "" {
+		return card.VCard
+	}
+
+	name := vcardEscaper.Replace(card.Name)
+	lines := []string{"BEGIN:VCARD", "VERSION:3.0", "N:;" + name + ";;;", "FN:" + name}
+
+	if card.Organization != "" {
+		lines = append(lines, "ORG:"+vcardEscaper.Replace(card.Organization)+";")
+	}
+	if card.Title != "" {
+		lines = append(lines, "TITLE:"+vcardEscaper.Replace(card.Title))
+	}
+	for _, phone := range card.Phones {
+		digits := onlyDigits(phone.Number)
+		if digits == "" {
+			continue
+		}
+		phoneType := vcardParamToken(phone.Type)
+		if phoneType == "" {
+			phoneType = "CELL"
+		}
+		waID := phone.WaID
+		if waID == "" || onlyDigits(waID) != waID {
+			waID = digits
+		}
+		lines = append(lines, "TEL;type="+phoneType+";waid="+waID+":+"+digits)
+	}
+	for _, email := range card.Emails {
+		if email.Address == "" {
+			continue
+		}
+		param := "EMAIL;type=INTERNET"
+		if emailType := vcardParamToken(email.Type); emailType != "" {
+			param += ";type=" + emailType
+		}
+		lines = append(lines, param+":"+vcardEscaper.Replace(email.Address))
+	}
+	if address := card.Address; address != nil {
+		param := "ADR"
+		if addressType := vcardParamToken(address.Type); addressType != "" {
+			param += ";type=" + addressType
+		}
+		parts := []string{"", "", address.Street, address.City, address.Region, address.PostalCode, address.Country}
+		for i := range parts {
+			parts[i] = vcardEscaper.Replace(parts[i])
+		}
+		lines = append(lines, param+":"+strings.Join(parts, ";"))
+	}
+	if card.URL != "" {
+		lines = append(lines, "URL:"+vcardEscaper.Replace(card.URL))
+	}
+
+	lines = append(lines, "END:VCARD")
+	return strings.Join(lines, "\n")
+}
+
+// vcardParamToken upper-cases a type parameter, or returns "" when it is not a plain token that is safe to put in
+// a property's parameters
+func vcardParamToken(value string) string {
+	if !isVCardParamToken(value) {
+		return ""
+	}
+	return strings.ToUpper(value)
+}
+
+// isVCardParamToken reports whether a type parameter holds only letters, digits and dashes
+func isVCardParamToken(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseVCard reads the fields WhatsApp clients put in a shared contact. Unknown properties are ignored and the raw
+// vCard is kept in the result.
+func ParseVCard(raw string) domainSend.ContactCard {
+	card := domainSend.ContactCard{VCard: raw}
+	var structuredName string
+
+	for _, line := range unfoldVCard(raw) {
+		property, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		params := strings.Split(property, ";")
+		name := strings.ToUpper(params[0])
+		// Apple and WhatsApp group related properties as item1.TEL, item1.X-ABLabel
+		if dot := strings.LastIndex(name, "."); dot >= 0 {
+			name = name[dot+1:]
+		}
+		params = params[1:]
+
+		switch name {
+		case "FN":
+			card.Name = unescapeVCard(value)
+		case "N":
+			parts := splitVCardValue(value)
+			var given []string
+			for _, index := range []int{3, 1, 2, 0, 4} {
+				if index < len(parts) && parts[index] != "" {
+					given = append(given, parts[index])
+				}
+			}
+			structuredName = strings.Join(given, " ")
+		case "TEL":
+			phone := domainSend.ContactPhone{Number: strings.TrimSpace(value), Type: vcardType(params)}
+			for _, param := range params {
+				if key, val, ok := strings.Cut(param, "="); ok && strings.EqualFold(key, "waid") {
+					phone.WaID = val
+				}
+			}
+			card.Phones = append(card.Phones, phone)
+		case "EMAIL":
+			card.Emails = append(card.Emails, domainSend.ContactEmail{Address: unescapeVCard(value), Type: vcardType(params)})
+		case "ORG":
+			if parts := splitVCardValue(value); len(parts) > 0 {
+				card.Organization = parts[0]
+			}
+		case "TITLE":
+			card.Title = unescapeVCard(value)
+		case "URL":
+			card.URL = unescapeVCard(value)
+		case "ADR":
+			parts := splitVCardValue(value)
+			for len(parts) < 7 {
+				parts = append(parts, "")
+			}
+			card.Address = &domainSend.ContactAddress{
+				Street:     parts[2],
+				City:       parts[3],
+				Region:     parts[4],
+				PostalCode: parts[5],
+				Country:    parts[6],
+				Type:       vcardType(params),
+			}
+		}
+	}
+
+	if card.Name == "" {
+		card.Name = structuredName
+	}
+	return card
+}
+
+// unfoldVCard splits a vCard into logical lines, joining the continuation lines that start with a space or tab
+func unfoldVCard(raw string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// vcardType returns the first meaningful type of a property, from both type=X and bare X parameters
+func vcardType(params []string) string {
+	for _, param := range params {
+		key, value, ok := strings.Cut(param, "=")
+		if !ok {
+			value = key
+		} else if !strings.EqualFold(key, "type") {
+			continue
+		}
+		for _, candidate := range strings.Split(value, ",") {
+			switch candidate = strings.ToUpper(strings.TrimSpace(candidate)); candidate {
+			case "", "INTERNET", "PREF", "VOICE":
+			default:
+				return candidate
+			}
+		}
+	}
+	return ""
+}
+
+// splitVCardValue splits a structured value on unescaped semicolons and unescapes each component
+func splitVCardValue(value string) []string {
+	var parts []string
+	var current strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			current.WriteByte(value[i])
+			current.WriteByte(value[i+1])
+			i++
+		case value[i] == ';':
+			parts = append(parts, unescapeVCard(current.String()))
+			current.Reset()
+		default:
+			current.WriteByte(value[i])
+		}
+	}
+	return append(parts, unescapeVCard(current.String()))
+}
+
+func unescapeVCard(value string) string {
+	return strings.TrimSpace(strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value))
+}
+
+func onlyDigits(value string) string {
+	var digits strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return digits.String()
+}
@@ -0,0 +1,100 @@
+package whatsapp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+)
+
+func TestBuildVCardRoundTrip(t *testing.T) {
+	card := domainSend.ContactCard{
+		Name:         "Budi, Santoso",
+		Phones:       []domainSend.ContactPhone{{Number: "+62 812-3456-789"}, {Number: "62215550000", Type: "work"}},
+		Emails:       []domainSend.ContactEmail{{Address: "budi@example.com", Type: "work"}},
+		Organization: "Acme; Inc",
+		Title:        "Engineer",
+		Address:      &domainSend.ContactAddress{Street: "Jl. Sudirman 1", City: "Jakarta", Country: "Indonesia", Type: "work"},
+		URL:          "https://example.com",
+	}
+
+	vcard := BuildVCard(card)
+	if !strings.Contains(vcard, "TEL;type=CELL;waid=628123456789:+628123456789") {
+		t.Fatalf("expected a WhatsApp phone line, got:\n%s", vcard)
+	}
+	if !strings.Contains(vcard, `FN:Budi\, Santoso`) || !strings.Contains(vcard, `ORG:Acme\; Inc;`) {
+		t.Fatalf("expected escaped text values, got:\n%s", vcard)
+	}
+
+	parsed := ParseVCard(vcard)
+	if parsed.Name != card.Name || parsed.Organization != card.Organization || parsed.Title != card.Title || parsed.URL != card.URL {
+		t.Fatalf("unexpected parsed card: %+v", parsed)
+	}
+	wantPhones := []domainSend.ContactPhone{
+		{Number: "+628123456789", Type: "CELL", WaID: "628123456789"},
+		{Number: "+62215550000", Type: "WORK", WaID: "62215550000"},
+	}
+	if !reflect.DeepEqual(parsed.Phones, wantPhones) {
+		t.Fatalf("unexpected phones: %+v", parsed.Phones)
+	}
+	if len(parsed.Emails) != 1 || parsed.Emails[0] != (domainSend.ContactEmail{Address: "budi@example.com", Type: "WORK"}) {
+		t.Fatalf("unexpected emails: %+v", parsed.Emails)
+	}
+	if parsed.Address == nil || parsed.Address.Street != "Jl. Sudirman 1" || parsed.Address.City != "Jakarta" || parsed.Address.Type != "WORK" {
+		t.Fatalf("unexpected address: %+v", parsed.Address)
+	}
+	if parsed.VCard != vcard {
+		t.Fatal("expected the raw vCard to be kept")
+	}
+}
+
+func TestBuildVCardKeepsParametersAndURLSafe(t *testing.T) {
+	vcard := BuildVCard(domainSend.ContactCard{
+		Name:    "Budi",
+		Phones:  []domainSend.ContactPhone{{Number: "628123456789", Type: "CELL:+1\nFN:Evil", WaID: "62;x"}},
+		Emails:  []domainSend.ContactEmail{{Address: "budi@example.com", Type: "WORK;X=1"}},
+		Address: &domainSend.ContactAddress{City: "Jakarta", Type: "HOME:x"},
+		URL:     "https://example.com/a,b;c\nEND:VCARD",
+	})
+
+	lines := strings.Split(vcard, "\n")
+	if len(lines) != 9 || lines[len(lines)-1] != "END:VCARD" {
+		t.Fatalf("unexpected lines:\n%s", vcard)
+	}
+	for _, want := range []string{
+		"TEL;type=CELL;waid=628123456789:+628123456789",
+		"EMAIL;type=INTERNET:budi@example.com",
+		"ADR:;;;Jakarta;;;",
+		`URL:https://example.com/a\,b\;c\nEND:VCARD`,
+	} {
+		if !strings.Contains(vcard, want+"\n") {
+			t.Fatalf("expected %q in:\n%s", want, vcard)
+		}
+	}
+	if parsed := ParseVCard(vcard); parsed.URL != "https://example.com/a,b;c\nEND:VCARD" {
+		t.Fatalf("unexpected parsed url %q", parsed.URL)
+	}
+}
+
+func TestBuildVCardRaw(t *testing.T) {
+	raw := "BEGIN:VCARD\nVERSION:3.0\nFN:Raw\nEND:VCARD"
+	if got := BuildVCard(domainSend.ContactCard{Name: "Ignored", VCard: raw}); got != raw {
+		t.Fatalf("expected the raw vCard unchanged, got %q", got)
+	}
+}
+
+func TestParseVCardFromPhone(t *testing.T) {
+	raw := "BEGIN:VCARD\r\nVERSION:3.0\r\nN:Doe;Jane;;;\r\nitem1.TEL;waid=628123456789:+62 812-3456-789\r\nitem1.X-ABLabel:Mobile\r\nEMAIL;TYPE=INTERNET,HOME:jane@exam\r\n ple.com\r\nEND:VCARD"
+
+	card := ParseVCard(raw)
+	if card.Name != "Jane Doe" {
+		t.Fatalf("expected the name from N, got %q", card.Name)
+	}
+	if len(card.Phones) != 1 || card.Phones[0].WaID != "628123456789" || card.Phones[0].Number != "+62 812-3456-789" {
+		t.Fatalf("unexpected phones: %+v", card.Phones)
+	}
+	if len(card.Emails) != 1 || card.Emails[0].Address != "jane@example.com" || card.Emails[0].Type != "HOME" {
+		t.Fatalf("expected a folded email line to be joined, got %+v", card.Emails)
+	}
+}
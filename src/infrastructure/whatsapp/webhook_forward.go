@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/chatwoot"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/sirupsen/logrus"
@@ -26,40 +27,6 @@ const mutexShardCount = 64
 // This approach prevents memory leaks that would occur with a sync.Map that grows indefinitely.
 var contactMutexShards [mutexShardCount]sync.Mutex
 
-// groupNameCacheEntry holds cached group name with expiration time
-type groupNameCacheEntry struct {
-	name      string
-	expiresAt time.Time
-}
-
-var (
-	// groupNameCache provides TTL-based caching for group names to reduce WhatsApp API calls
-	groupNameCache    sync.Map
-	groupNameCacheTTL = 5 * time.Minute
-)
-
-// getCachedGroupName retrieves group name from cache if not expired.
-// Returns empty string and false if not cached or expired.
-func getCachedGroupName(groupJID string) (string, bool) {
-	if entry, ok := groupNameCache.Load(groupJID); ok {
-		cached := entry.(groupNameCacheEntry)
-		if time.Now().Before(cached.expiresAt) {
-			return cached.name, true
-		}
-		// Entry expired, delete it
-		groupNameCache.Delete(groupJID)
-	}
-	return "", false
-}
-
-// setCachedGroupName stores group name in cache with TTL.
-func setCachedGroupName(groupJID, name string) {
-	groupNameCache.Store(groupJID, groupNameCacheEntry{
-		name:      name,
-		expiresAt: time.Now().Add(groupNameCacheTTL),
-	})
-}
-
 // getContactMutex returns a mutex for the given phone number to serialize contact operations.
 // Uses FNV-1a hash to distribute phones across shards for balanced lock contention.
 func getContactMutex(phone string) *sync.Mutex {
@@ -89,6 +56,25 @@ func forwardPayloadToConfiguredWebhooks(ctx context.Context, payload map[string]
 	return err
 }
 
+// ForwardEventToWebhooks delivers an event raised outside the WhatsApp event loop (schedulers, background jobs)
+// using the same envelope as the built-in events. It is a no-op when no webhook is configured.
+func ForwardEventToWebhooks(ctx context.Context, eventName, deviceID string, payload map[string]any) error {
+	if len(config.WhatsappWebhook) == 0 {
+		return nil
+	}
+
+	body := map[string]any{
+		"event":     eventName,
+		"payload":   payload,
+		"timestamp": time.Now().Format(time.RFC3339),
+	}
+	if deviceID != "" {
+		body["device_id"] = deviceID
+	}
+
+	return forwardPayloadToConfiguredWebhooks(ctx, body, eventName)
+}
+
 func forwardToWebhooks(ctx context.Context, payload map[string]any, eventName string) error {
 	total := len(config.WhatsappWebhook)
 	logrus.Infof("Forwarding %s to %d configured webhook(s)", eventName, total)
@@ -378,15 +364,9 @@ func isEventWhitelisted(eventName string) bool {
 	return false
 }
 
-// getGroupName fetches the group name from WhatsApp using the group JID.
-// Uses a TTL cache to avoid repeated API calls for the same group.
+// getGroupName looks up the group name in the device's stored group metadata, which group events keep current,
+// and asks WhatsApp only for groups that are not stored yet.
 func getGroupName(ctx context.Context, groupJID string) string {
-	// Check cache first
-	if name, ok := getCachedGroupName(groupJID); ok {
-		logrus.Debugf("Chatwoot: Using cached group name for %s: %s", groupJID, name)
-		return name
-	}
-
 	client := ClientFromContext(ctx)
 	if client == nil {
 		logrus.Debug("Chatwoot: ClientFromContext returned nil, trying GetClient()")
@@ -403,22 +383,24 @@ func getGroupName(ctx context.Context, groupJID string) string {
 		return ""
 	}
 
+	var repo domainChatStorage.IChatStorageRepository
+	if inst, ok := DeviceFromContext(ctx); ok && inst != nil {
+		repo = inst.GetChatStorage()
+	}
+
 	// Use a fresh context with timeout since the original context may be canceled
 	// (this function is called from a goroutine)
 	freshCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	logrus.Debugf("Chatwoot: Fetching group info for %s", groupJID)
-	groupInfo, err := client.GetGroupInfo(freshCtx, jid)
+	groupInfo, err := StoredGroupInfo(freshCtx, client, repo, statusDeviceID(ctx), jid, false)
 	if err != nil {
 		logrus.Warnf("Chatwoot: Failed to get group info for %s: %v", groupJID, err)
 		return ""
 	}
 
 	if groupInfo != nil && groupInfo.Name != "" {
-		logrus.Infof("Chatwoot: Got group name: %s", groupInfo.Name)
-		// Cache the result
-		setCachedGroupName(groupJID, groupInfo.Name)
+		logrus.Debugf("Chatwoot: Got group name: %s", groupInfo.Name)
 		return groupInfo.Name
 	}
 
@@ -1,6 +1,11 @@
 package error
 
-import "net/http"
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+)
 
 // GenericError represent as the contract of generic error
 type GenericError interface {
@@ -63,6 +68,73 @@ func RequestTimeout(text string) GenericError {
 	return TimeoutError(text)
 }
 
+// NotFoundError represents a missing resource
+type NotFoundError string
+
+// Error for complying the error interface
+func (e NotFoundError) Error() string {
+	return string(e)
+}
+
+// ErrCode will return the error code based on the error data type
+func (e NotFoundError) ErrCode() string {
+	return "NOT_FOUND"
+}
+
+// StatusCode will return the HTTP status code based on the error data type
+func (e NotFoundError) StatusCode() int {
+	return http.StatusNotFound
+}
+
+// QueueFullError is returned when a background queue cannot accept more work
+type QueueFullError string
+
+// Error for complying the error interface
+func (e QueueFullError) Error() string {
+	return string(e)
+}
+
+// ErrCode will return the error code based on the error data type
+func (e QueueFullError) ErrCode() string {
+	return "QUEUE_FULL"
+}
+
+// StatusCode will return the HTTP status code based on the error data type
+func (e QueueFullError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+// RateLimitError is returned when an outbound send exceeds the configured rate limit
+type RateLimitError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+// RateLimited creates a rate limit error telling the caller when to retry
+func RateLimited(message string, retryAfter time.Duration) RateLimitError {
+	return RateLimitError{Message: message, RetryAfter: retryAfter}
+}
+
+// Error for complying the error interface
+func (e RateLimitError) Error() string {
+	return fmt.Sprintf("%s, retry after %ds", e.Message, e.RetryAfterSeconds())
+}
+
+// ErrCode will return the error code based on the error data type
+func (e RateLimitError) ErrCode() string {
+	return "RATE_LIMITED"
+}
+
+// StatusCode will return the HTTP status code based on the error data type
+func (e RateLimitError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+// RetryAfterSeconds rounds the retry delay up to whole seconds for the Retry-After header
+func (e RateLimitError) RetryAfterSeconds() int {
+	return int(math.Max(1, math.Ceil(e.RetryAfter.Seconds())))
+}
+
 var (
 	ErrInternalServerError = InternalServerError("internal server error")
 	ErrRequestTimeout      = TimeoutError("request timed out waiting for WhatsApp server response")
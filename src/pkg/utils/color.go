@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseARGBColor converts a #RRGGBB or #AARRGGBB hex colour into the ARGB value WhatsApp uses;
+// colours without alpha are fully opaque.
+func ParseARGBColor(color string) (uint32, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(color), "#")
+	if len(hex) != 6 && len(hex) != 8 {
+		return 0, fmt.Errorf("color %q must be #RRGGBB or #AARRGGBB", color)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("color %q must be #RRGGBB or #AARRGGBB", color)
+	}
+	if len(hex) == 6 {
+		value |= 0xFF000000
+	}
+	return uint32(value), nil
+}
+
+// FormatARGBColor renders an ARGB value as #RRGGBB, or #AARRGGBB when it is not fully opaque
+func FormatARGBColor(argb uint32) string {
+	if argb>>24 == 0xFF {
+		return fmt.Sprintf("#%06X", argb&0xFFFFFF)
+	}
+	return fmt.Sprintf("#%08X", argb)
+}
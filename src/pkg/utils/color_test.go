@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseARGBColor(t *testing.T) {
+	tests := []struct {
+		color   string
+		want    uint32
+		wantErr bool
+	}{
+		{color: "#25D366", want: 0xFF25D366},
+		{color: "25d366", want: 0xFF25D366},
+		{color: "#8025D366", want: 0x8025D366},
+		{color: "#FFF", wantErr: true},
+		{color: "#GGGGGG", wantErr: true},
+		{color: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			got, err := ParseARGBColor(tt.color)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatARGBColor(t *testing.T) {
+	assert.Equal(t, "#25D366", FormatARGBColor(0xFF25D366))
+	assert.Equal(t, "#8025D366", FormatARGBColor(0x8025D366))
+}
@@ -8,7 +8,6 @@ import (
 	_ "image/jpeg" // For JPEG encoding
 	_ "image/png"  // For PNG encoding
 	"io"
-	"math"
 	"net/http"
 	"net/url"
 	"os"
@@ -232,220 +231,6 @@ func ContainsMention(message string) []string {
 	return phoneNumbers
 }
 
-func DownloadImageFromURL(url string) ([]byte, string, error) {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) >= 10 {
-				return fmt.Errorf("too many redirects")
-			}
-			return nil
-		},
-	}
-	response, err := client.Get(url)
-	if err != nil {
-		return nil, "", err
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode != http.StatusOK {
-		return nil, "", fmt.Errorf("HTTP request failed with status: %s", response.Status)
-	}
-
-	contentType := response.Header.Get("Content-Type")
-	if !strings.HasPrefix(contentType, "image/") {
-		return nil, "", fmt.Errorf("invalid content type: %s", contentType)
-	}
-	// Check content length if available
-	if contentLength := response.ContentLength; contentLength > int64(config.WhatsappSettingMaxImageSize) {
-		return nil, "", fmt.Errorf("image size %d exceeds maximum allowed size %d", contentLength, config.WhatsappSettingMaxImageSize)
-	}
-	// Limit the size from config
-	reader := io.LimitReader(response.Body, int64(config.WhatsappSettingMaxImageSize))
-	// Extract the file name from the URL and remove query parameters if present
-	segments := strings.Split(url, "/")
-	fileName := segments[len(segments)-1]
-	fileName = strings.Split(fileName, "?")[0]
-	// Check if the file extension is supported
-	allowedExtensions := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".webp": true,
-	}
-	extension := strings.ToLower(filepath.Ext(fileName))
-	if !allowedExtensions[extension] {
-		return nil, "", fmt.Errorf("unsupported file type: %s", extension)
-	}
-	imageData, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, "", err
-	}
-	return imageData, fileName, nil
-}
-
-// DownloadAudioFromURL downloads an audio file from the provided URL and returns the bytes and sanitized filename.
-// It validates that the content-type returned by the server starts with "audio/" and that the size is below
-// WhatsappSettingMaxDownloadSize limit to avoid memory exhaustion. Only the MIME types defined in audio validation
-// are allowed to ensure WhatsApp compatibility.
-func DownloadAudioFromURL(audioURL string) ([]byte, string, error) {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) >= 10 {
-				return fmt.Errorf("too many redirects")
-			}
-			return nil
-		},
-	}
-
-	resp, err := client.Get(audioURL)
-	if err != nil {
-		return nil, "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, "", fmt.Errorf("HTTP request failed with status: %s", resp.Status)
-	}
-
-	// Extract only the MIME type portion (ignore parameters like charset)
-	contentType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
-
-	// Align audio MIME validation with the one used for uploaded files to ensure consistency with WhatsApp requirements.
-	allowedMimes := map[string]bool{
-		"audio/aac":          true,
-		"audio/amr":          true,
-		"audio/flac":         true,
-		"audio/m4a":          true,
-		"audio/m4r":          true,
-		"audio/mp3":          true,
-		"audio/mpeg":         true,
-		"audio/ogg":          true,
-		"audio/wma":          true,
-		"audio/x-ms-wma":     true,
-		"audio/wav":          true,
-		"audio/vnd.wav":      true,
-		"audio/vnd.wave":     true,
-		"audio/wave":         true,
-		"audio/x-pn-wav":     true,
-		"audio/x-wav":        true,
-		"video/mp4":          true, // Sometimes audio is served as mp4
-		"application/ogg":    true, // Ogg audio
-		"application/x-mpeg": true,
-		"audio/webm":         true, // WebM audio
-		"video/webm":         true, // WebM audio/video
-		"audio/mp4":          true,
-	}
-
-	// If content type is generic or not in list, just warn but allow download (let WhatsApp reject if invalid)
-	if !allowedMimes[contentType] {
-		logrus.Warnf("DownloadAudioFromURL: unexpected content type '%s', proceeding anyway", contentType)
-	}
-
-	// Validate content length when it is provided by the server.
-	maxSize := config.WhatsappSettingMaxDownloadSize
-	if resp.ContentLength > 0 && resp.ContentLength > maxSize {
-		return nil, "", fmt.Errorf("audio size %d exceeds maximum allowed size %d", resp.ContentLength, maxSize)
-	}
-
-	// Guard against servers that do not set Content-Length by reading at most (maxSize+1) bytes
-	// and erroring if the limit is exceeded.
-	limit := maxSize
-	if limit < math.MaxInt64 {
-		limit++
-	}
-
-	limitedReader := &io.LimitedReader{R: resp.Body, N: limit}
-	audioData, err := io.ReadAll(limitedReader)
-	if err != nil {
-		return nil, "", err
-	}
-	if int64(len(audioData)) > maxSize {
-		return nil, "", fmt.Errorf("downloaded audio size of %d bytes exceeds the maximum allowed size of %d bytes", len(audioData), maxSize)
-	}
-
-	// Derive filename from URL path (strip query parameters if present)
-	segments := strings.Split(audioURL, "/")
-	fileName := segments[len(segments)-1]
-	fileName = strings.Split(fileName, "?")[0]
-	if fileName == "" {
-		fileName = fmt.Sprintf("audio_%d", time.Now().Unix())
-	}
-
-	return audioData, fileName, nil
-}
-
-// DownloadVideoFromURL downloads a video file from the provided URL and returns the bytes and sanitized filename.
-// It validates that the content-type returned by the server is one of the supported WhatsApp video formats and
-// that the size does not exceed WhatsappSettingMaxDownloadSize to avoid memory exhaustion.
-func DownloadVideoFromURL(videoURL string) ([]byte, string, error) {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) >= 10 {
-				return fmt.Errorf("too many redirects")
-			}
-			return nil
-		},
-	}
-
-	resp, err := client.Get(videoURL)
-	if err != nil {
-		return nil, "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, "", fmt.Errorf("HTTP request failed with status: %s", resp.Status)
-	}
-
-	// Extract MIME type without parameters
-	contentType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
-
-	allowedMimes := map[string]bool{
-		"video/mp4":        true,
-		"video/x-matroska": true, // mkv
-		"video/avi":        true,
-		"video/x-msvideo":  true,
-	}
-
-	if !allowedMimes[contentType] {
-		return nil, "", fmt.Errorf("invalid content type: %s", contentType)
-	}
-
-	// Validate content length if provided
-	maxSize := config.WhatsappSettingMaxDownloadSize
-	if resp.ContentLength > 0 && resp.ContentLength > maxSize {
-		return nil, "", fmt.Errorf("video size %d exceeds maximum allowed size %d", resp.ContentLength, maxSize)
-	}
-
-	// Guard against unknown Content-Length by limiting reader
-	limit := maxSize
-	if limit < math.MaxInt64 {
-		limit++
-	}
-
-	limitedReader := &io.LimitedReader{R: resp.Body, N: limit}
-	videoData, err := io.ReadAll(limitedReader)
-	if err != nil {
-		return nil, "", err
-	}
-	if int64(len(videoData)) > maxSize {
-		return nil, "", fmt.Errorf("downloaded video size of %d bytes exceeds the maximum allowed size of %d bytes", len(videoData), maxSize)
-	}
-
-	// Derive filename from URL path
-	segments := strings.Split(videoURL, "/")
-	fileName := segments[len(segments)-1]
-	fileName = strings.Split(fileName, "?")[0]
-	if fileName == "" {
-		fileName = fmt.Sprintf("video_%d.mp4", time.Now().Unix())
-	}
-
-	return videoData, fileName, nil
-}
-
 // FormatBusinessHourTime converts numeric time format (e.g., 600, 1200) to HH:MM format (e.g., "06:00", "12:00")
 func FormatBusinessHourTime(timeValue any) string {
 	var timeInt int
@@ -493,57 +278,66 @@ func UniqueStrings(input []string) []string {
 	return result
 }
 
-// DownloadFileFromURL downloads a file from the provided URL and returns the bytes and sanitized filename.
-// It enforces max file size limit.
-func DownloadFileFromURL(fileURL string) ([]byte, string, error) {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) >= 10 {
-				return fmt.Errorf("too many redirects")
-			}
-			return nil
-		},
-	}
+// localTimeLayouts are accepted by ParseTimeInLocation for timestamps without a UTC offset
+var localTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
 
-	resp, err := client.Get(fileURL)
-	if err != nil {
-		return nil, "", err
+// ParseTimeInLocation parses an RFC3339 timestamp, or a local timestamp without offset that is
+// interpreted in the given IANA timezone (UTC when empty). This lets callers say "09:00 in Asia/Jakarta".
+func ParseTimeInLocation(value, timezone string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, "", fmt.Errorf("HTTP request failed with status: %s", resp.Status)
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
 	}
 
-	// Validate content length when it is provided by the server.
-	maxSize := config.WhatsappSettingMaxFileSize
-	if resp.ContentLength > 0 && resp.ContentLength > maxSize {
-		return nil, "", fmt.Errorf("file size %d exceeds maximum allowed size %d", resp.ContentLength, maxSize)
+	for _, layout := range localTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
 	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use RFC3339 or YYYY-MM-DDTHH:MM:SS", value)
+}
 
-	// Limit reader
-	limit := maxSize
-	if limit < math.MaxInt64 {
-		limit++
-	}
+// templateVariablePattern matches {{name}} placeholders, allowing spaces inside the braces
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
 
-	limitedReader := &io.LimitedReader{R: resp.Body, N: limit}
-	fileData, err := io.ReadAll(limitedReader)
-	if err != nil {
-		return nil, "", err
-	}
-	if int64(len(fileData)) > maxSize {
-		return nil, "", fmt.Errorf("downloaded file size of %d bytes exceeds the maximum allowed size of %d bytes", len(fileData), maxSize)
+// RenderTemplate replaces {{name}} placeholders with values from vars. Unknown placeholders are left as-is
+// so a missing variable is visible in the sent message instead of silently disappearing.
+func RenderTemplate(text string, vars map[string]string) string {
+	if len(vars) == 0 || !strings.Contains(text, "{{") {
+		return text
 	}
+	return templateVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVariablePattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
 
-	// Derive filename from URL path
-	segments := strings.Split(fileURL, "/")
-	fileName := segments[len(segments)-1]
-	fileName = strings.Split(fileName, "?")[0]
-	if fileName == "" {
-		fileName = fmt.Sprintf("file_%d", time.Now().Unix())
+// TemplateVariables returns the distinct placeholder names used in text, in order of first appearance
+func TemplateVariables(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range templateVariablePattern.FindAllStringSubmatch(text, -1) {
+		if name := match[1]; !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
 	}
-
-	return fileData, fileName, nil
+	return names
 }
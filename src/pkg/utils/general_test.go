@@ -194,24 +194,6 @@ func (suite *UtilsTestSuite) TestGetMetaDataFromURLEdgeCases() {
 	// Image download may fail but meta should still be extracted
 }
 
-func (suite *UtilsTestSuite) TestDownloadImageFromURL() {
-	// Use httptest.NewServer to mock HTTP server
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/image.jpg" {
-			w.Header().Set("Content-Type", "image/jpeg") // Set content type to image
-			w.Write([]byte("image data"))
-		} else {
-			http.NotFound(w, r)
-		}
-	}))
-	defer server.Close() // Ensure the server is closed when the test ends
-
-	imageData, fileName, err := utils.DownloadImageFromURL(server.URL + "/image.jpg")
-	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), []byte("image data"), imageData)
-	assert.Equal(suite.T(), "image.jpg", fileName)
-}
-
 func (suite *UtilsTestSuite) TestRemoveFileEdgeCases() {
 	// Test empty path handling
 	err := utils.RemoveFile(0, "")
@@ -332,78 +314,77 @@ func (suite *UtilsTestSuite) TestStrToFloat64EdgeCases() {
 	assert.Equal(suite.T(), 0.0, utils.StrToFloat64("abc123"))
 }
 
-func (suite *UtilsTestSuite) TestDownloadImageFromURLEdgeCases() {
-	// Test non-image content type
+func (suite *UtilsTestSuite) TestDownloadImageToFile() {
+	origMaxSize := config.WhatsappSettingMaxImageSize
+	config.WhatsappSettingMaxImageSize = 1024 // 1KB for testing
+	defer func() {
+		config.WhatsappSettingMaxImageSize = origMaxSize
+	}()
+
+	requests := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte("not an image"))
+		requests++
+		switch r.URL.Path {
+		case "/page.png":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("not an image"))
+		case "/missing.png":
+			w.WriteHeader(http.StatusNotFound)
+		case "/streamed.png":
+			// No Content-Length, the limit has to be enforced while copying
+			w.Header().Set("Content-Type", "image/png")
+			w.(http.Flusher).Flush()
+			w.Write([]byte(strings.Repeat("i", 4096)))
+		default:
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.Write([]byte("image data"))
+		}
 	}))
 	defer server.Close()
 
-	_, _, err := utils.DownloadImageFromURL(server.URL)
-	assert.Error(suite.T(), err)
-	assert.Contains(suite.T(), err.Error(), "invalid content type")
+	dir := suite.T().TempDir()
 
-	// Test HTTP error status
-	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusNotFound)
-	}))
-	defer errorServer.Close()
-
-	_, _, err = utils.DownloadImageFromURL(errorServer.URL)
-	assert.Error(suite.T(), err)
-	assert.Contains(suite.T(), err.Error(), "HTTP request failed")
-
-	// Test unsupported file extension
-	extServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/image.gif" {
-			w.Header().Set("Content-Type", "image/gif")
-			w.Write([]byte("gif data"))
-		}
-	}))
-	defer extServer.Close()
+	// Test valid download lands in a file in dir, named after the URL without its query
+	path, filename, err := utils.DownloadImageToFile(server.URL+"/photo.jpg?v=1", dir)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "photo.jpg", filename)
+	assert.Equal(suite.T(), dir, filepath.Dir(path))
+	assert.Equal(suite.T(), ".jpg", filepath.Ext(path))
+	assert.Equal(suite.T(), []byte("image data"), readDownload(suite.T(), path))
+	assert.NoError(suite.T(), os.Remove(path))
 
-	_, _, err = utils.DownloadImageFromURL(extServer.URL + "/image.gif")
+	// Test unsupported extensions are rejected without a request
+	_, _, err = utils.DownloadImageToFile(server.URL+"/animation.gif", dir)
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "unsupported file type")
+	assert.Equal(suite.T(), 1, requests)
 
-	// Test valid image extensions
-	validExtServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		path := r.URL.Path
-		if strings.HasSuffix(path, ".jpg") {
-			w.Header().Set("Content-Type", "image/jpeg")
-		} else if strings.HasSuffix(path, ".png") {
-			w.Header().Set("Content-Type", "image/png")
-		} else if strings.HasSuffix(path, ".webp") {
-			w.Header().Set("Content-Type", "image/webp")
-		}
-		w.Write([]byte("valid image data"))
-	}))
-	defer validExtServer.Close()
-
-	// Test .jpg
-	data, filename, err := utils.DownloadImageFromURL(validExtServer.URL + "/test.jpg")
+	// Test WebP is accepted too
+	path, filename, err = utils.DownloadImageToFile(server.URL+"/sticker.webp", dir)
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), "test.jpg", filename)
-	assert.Equal(suite.T(), []byte("valid image data"), data)
+	assert.Equal(suite.T(), "sticker.webp", filename)
+	assert.NoError(suite.T(), os.Remove(path))
 
-	// Test .png
-	data, filename, err = utils.DownloadImageFromURL(validExtServer.URL + "/test.png")
-	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), "test.png", filename)
+	// Test HTTP error status
+	_, _, err = utils.DownloadImageToFile(server.URL+"/missing.png", dir)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "HTTP request failed")
 
-	// Test .webp
-	data, filename, err = utils.DownloadImageFromURL(validExtServer.URL + "/test.webp")
-	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), "test.webp", filename)
+	// Test non-image content type
+	_, _, err = utils.DownloadImageToFile(server.URL+"/page.png", dir)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "invalid content type")
 
-	// Test filename extraction with query parameters
-	data, filename, err = utils.DownloadImageFromURL(validExtServer.URL + "/test.jpg?v=1&size=large")
+	// Test oversized body without Content-Length is rejected and leaves nothing behind
+	_, _, err = utils.DownloadImageToFile(server.URL+"/streamed.png", dir)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "exceeds the maximum allowed size")
+	entries, err := os.ReadDir(dir)
 	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), "test.jpg", filename)
+	assert.Empty(suite.T(), entries)
 }
 
-func (suite *UtilsTestSuite) TestDownloadAudioFromURL() {
+func (suite *UtilsTestSuite) TestDownloadAudioToFile() {
 	// Mock original config values
 	origMaxSize := config.WhatsappSettingMaxDownloadSize
 	config.WhatsappSettingMaxDownloadSize = 1024 * 1024 // 1MB for testing
@@ -440,34 +421,36 @@ func (suite *UtilsTestSuite) TestDownloadAudioFromURL() {
 	}))
 	defer server.Close()
 
+	dir := suite.T().TempDir()
+
 	// Test valid MP3 download
-	data, filename, err := utils.DownloadAudioFromURL(server.URL + "/test.mp3")
+	path, filename, err := utils.DownloadAudioToFile(server.URL+"/test.mp3", dir)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test.mp3", filename)
-	assert.Equal(suite.T(), []byte("audio data"), data)
+	assert.Equal(suite.T(), []byte("audio data"), readDownload(suite.T(), path))
 
 	// Test valid WAV download
-	data, filename, err = utils.DownloadAudioFromURL(server.URL + "/test.wav")
+	path, filename, err = utils.DownloadAudioToFile(server.URL+"/test.wav", dir)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test.wav", filename)
 
 	// Test valid OGG download
-	data, filename, err = utils.DownloadAudioFromURL(server.URL + "/test.ogg")
+	path, filename, err = utils.DownloadAudioToFile(server.URL+"/test.ogg", dir)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test.ogg", filename)
 
 	// Test valid M4A download
-	data, filename, err = utils.DownloadAudioFromURL(server.URL + "/test.m4a")
+	path, filename, err = utils.DownloadAudioToFile(server.URL+"/test.m4a", dir)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test.m4a", filename)
 
 	// Test invalid content type - should now warn but still proceed (changed behavior for Chatwoot compatibility)
-	data, filename, err = utils.DownloadAudioFromURL(server.URL + "/invalid.mp3")
+	path, filename, err = utils.DownloadAudioToFile(server.URL+"/invalid.mp3", dir)
 	assert.NoError(suite.T(), err) // Now proceeds with warning instead of error
-	assert.NotEmpty(suite.T(), data)
+	assert.NotEmpty(suite.T(), readDownload(suite.T(), path))
 
 	// Test file too large by content length
-	_, _, err = utils.DownloadAudioFromURL(server.URL + "/large.mp3")
+	_, _, err = utils.DownloadAudioToFile(server.URL+"/large.mp3", dir)
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "exceeds maximum allowed size")
 
@@ -477,23 +460,23 @@ func (suite *UtilsTestSuite) TestDownloadAudioFromURL() {
 	}))
 	defer errorServer.Close()
 
-	_, _, err = utils.DownloadAudioFromURL(errorServer.URL + "/error.mp3")
+	_, _, err = utils.DownloadAudioToFile(errorServer.URL+"/error.mp3", dir)
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "HTTP request failed")
 
 	// Test filename without extension (should generate timestamp-based name)
-	data, filename, err = utils.DownloadAudioFromURL(server.URL + "/no-filename/")
+	path, filename, err = utils.DownloadAudioToFile(server.URL+"/no-filename/", dir)
 	assert.NoError(suite.T(), err)
 	assert.Contains(suite.T(), filename, "audio_")
-	assert.Equal(suite.T(), []byte("audio without filename"), data)
+	assert.Equal(suite.T(), []byte("audio without filename"), readDownload(suite.T(), path))
 
 	// Test URL with query parameters
-	data, filename, err = utils.DownloadAudioFromURL(server.URL + "/test.mp3?v=1&quality=high")
+	path, filename, err = utils.DownloadAudioToFile(server.URL+"/test.mp3?v=1&quality=high", dir)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test.mp3", filename)
 
 	// Test invalid URL
-	_, _, err = utils.DownloadAudioFromURL("not-a-valid-url")
+	_, _, err = utils.DownloadAudioToFile("not-a-valid-url", dir)
 	assert.Error(suite.T(), err)
 
 	// Test too many redirects
@@ -502,15 +485,15 @@ func (suite *UtilsTestSuite) TestDownloadAudioFromURL() {
 	}))
 	defer redirectServer.Close()
 
-	_, _, err = utils.DownloadAudioFromURL(redirectServer.URL + "/redirect.mp3")
+	_, _, err = utils.DownloadAudioToFile(redirectServer.URL+"/redirect.mp3", dir)
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "too many redirects")
 }
 
-func (suite *UtilsTestSuite) TestDownloadVideoFromURL() {
+func (suite *UtilsTestSuite) TestDownloadVideoToFile() {
 	// Mock original config values
 	origMaxSize := config.WhatsappSettingMaxDownloadSize
-	config.WhatsappSettingMaxDownloadSize = 1024 * 1024 // 1MB for testing
+	config.WhatsappSettingMaxDownloadSize = 1024 // 1KB for testing
 	defer func() {
 		config.WhatsappSettingMaxDownloadSize = origMaxSize
 	}()
@@ -518,7 +501,12 @@ func (suite *UtilsTestSuite) TestDownloadVideoFromURL() {
 	// Test successful video download
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
-		if path == "/test.mp4" {
+		if path == "/streamed.mp4" {
+			// No Content-Length, the limit has to be enforced while copying
+			w.Header().Set("Content-Type", "video/mp4")
+			w.(http.Flusher).Flush()
+			w.Write([]byte(strings.Repeat("v", 4096)))
+		} else if path == "/test.mp4" {
 			w.Header().Set("Content-Type", "video/mp4")
 			w.Write([]byte("video data"))
 		} else if path == "/test.mkv" {
@@ -541,56 +529,69 @@ func (suite *UtilsTestSuite) TestDownloadVideoFromURL() {
 	}))
 	defer server.Close()
 
-	// Test valid MP4 download
-	data, filename, err := utils.DownloadVideoFromURL(server.URL + "/test.mp4")
+	dir := suite.T().TempDir()
+
+	// Test valid MP4 download lands in a file in dir
+	path, filename, err := utils.DownloadVideoToFile(server.URL+"/test.mp4", dir)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test.mp4", filename)
-	assert.Equal(suite.T(), []byte("video data"), data)
+	assert.Equal(suite.T(), dir, filepath.Dir(path))
+	assert.Equal(suite.T(), ".mp4", filepath.Ext(path))
+	assert.Equal(suite.T(), []byte("video data"), readDownload(suite.T(), path))
 
 	// Test valid MKV download
-	data, filename, err = utils.DownloadVideoFromURL(server.URL + "/test.mkv")
+	path, filename, err = utils.DownloadVideoToFile(server.URL+"/test.mkv", dir)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test.mkv", filename)
 
 	// Test valid AVI download
-	data, filename, err = utils.DownloadVideoFromURL(server.URL + "/test.avi")
+	path, filename, err = utils.DownloadVideoToFile(server.URL+"/test.avi", dir)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test.avi", filename)
 
 	// Test invalid content type
-	_, _, err = utils.DownloadVideoFromURL(server.URL + "/invalid.mp4")
+	_, _, err = utils.DownloadVideoToFile(server.URL+"/invalid.mp4", dir)
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "invalid content type")
 
 	// Test file too large by content length
-	_, _, err = utils.DownloadVideoFromURL(server.URL + "/large.mp4")
+	_, _, err = utils.DownloadVideoToFile(server.URL+"/large.mp4", dir)
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "exceeds maximum allowed size")
 
+	// Test oversized body without Content-Length is rejected and leaves nothing behind
+	streamDir := suite.T().TempDir()
+	_, _, err = utils.DownloadVideoToFile(server.URL+"/streamed.mp4", streamDir)
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "exceeds the maximum allowed size")
+	entries, err := os.ReadDir(streamDir)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), entries)
+
 	// Test HTTP error
 	errorServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 	}))
 	defer errorServer.Close()
 
-	_, _, err = utils.DownloadVideoFromURL(errorServer.URL + "/error.mp4")
+	_, _, err = utils.DownloadVideoToFile(errorServer.URL+"/error.mp4", dir)
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "HTTP request failed")
 
 	// Test filename without extension (should generate timestamp-based name)
-	data, filename, err = utils.DownloadVideoFromURL(server.URL + "/no-filename/")
+	path, filename, err = utils.DownloadVideoToFile(server.URL+"/no-filename/", dir)
 	assert.NoError(suite.T(), err)
 	assert.Contains(suite.T(), filename, "video_")
 	assert.True(suite.T(), strings.HasSuffix(filename, ".mp4"))
-	assert.Equal(suite.T(), []byte("video without filename"), data)
+	assert.Equal(suite.T(), []byte("video without filename"), readDownload(suite.T(), path))
 
 	// Test URL with query parameters
-	data, filename, err = utils.DownloadVideoFromURL(server.URL + "/test.mp4?v=1&quality=hd")
+	path, filename, err = utils.DownloadVideoToFile(server.URL+"/test.mp4?v=1&quality=hd", dir)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "test.mp4", filename)
 
 	// Test invalid URL
-	_, _, err = utils.DownloadVideoFromURL("not-a-valid-url")
+	_, _, err = utils.DownloadVideoToFile("not-a-valid-url", dir)
 	assert.Error(suite.T(), err)
 
 	// Test too many redirects
@@ -599,11 +600,76 @@ func (suite *UtilsTestSuite) TestDownloadVideoFromURL() {
 	}))
 	defer redirectServer.Close()
 
-	_, _, err = utils.DownloadVideoFromURL(redirectServer.URL + "/redirect.mp4")
+	_, _, err = utils.DownloadVideoToFile(redirectServer.URL+"/redirect.mp4", dir)
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "too many redirects")
 }
 
+// readDownload returns the content of a downloaded file
+func readDownload(t *testing.T, path string) []byte {
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	return data
+}
+
+func (suite *UtilsTestSuite) TestDownloadFileToFile() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4 document"))
+	}))
+	defer server.Close()
+
+	dir := suite.T().TempDir()
+
+	path, filename, err := utils.DownloadFileToFile(server.URL+"/report.pdf", dir)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "report.pdf", filename)
+	data, err := os.ReadFile(path)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []byte("%PDF-1.4 document"), data)
+
+	// Test a missing directory fails before anything is written
+	_, _, err = utils.DownloadFileToFile(server.URL+"/report.pdf", filepath.Join(dir, "missing"))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *UtilsTestSuite) TestParseTimeInLocation() {
+	// RFC3339 keeps its own offset
+	t, err := utils.ParseTimeInLocation("2026-03-01T09:00:00+07:00", "America/New_York")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2026-03-01T02:00:00Z", t.UTC().Format(time.RFC3339))
+
+	// Local time is interpreted in the timezone
+	t, err = utils.ParseTimeInLocation("2026-03-01 09:00", "Asia/Jakarta")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2026-03-01T02:00:00Z", t.UTC().Format(time.RFC3339))
+
+	// No timezone means UTC
+	t, err = utils.ParseTimeInLocation("2026-03-01T09:00:00", "")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "2026-03-01T09:00:00Z", t.UTC().Format(time.RFC3339))
+
+	_, err = utils.ParseTimeInLocation("2026-03-01T09:00:00", "Mars/Olympus")
+	assert.Error(suite.T(), err)
+
+	_, err = utils.ParseTimeInLocation("tomorrow", "")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *UtilsTestSuite) TestRenderTemplate() {
+	vars := map[string]string{"name": "Budi", "order": "INV-42"}
+
+	assert.Equal(suite.T(), "Hi Budi, order INV-42 shipped", utils.RenderTemplate("Hi {{name}}, order {{ order }} shipped", vars))
+	assert.Equal(suite.T(), "Hi Budi {{missing}}", utils.RenderTemplate("Hi {{name}} {{missing}}", vars))
+	assert.Equal(suite.T(), "no placeholders", utils.RenderTemplate("no placeholders", vars))
+	assert.Equal(suite.T(), "Hi {{name}}", utils.RenderTemplate("Hi {{name}}", nil))
+}
+
+func (suite *UtilsTestSuite) TestTemplateVariables() {
+	assert.Equal(suite.T(), []string{"name", "order"}, utils.TemplateVariables("Hi {{name}}, order {{ order }} for {{name}}"))
+	assert.Empty(suite.T(), utils.TemplateVariables("no placeholders"))
+}
+
 func TestUtilsTestSuite(t *testing.T) {
 	suite.Run(t, new(UtilsTestSuite))
 }
@@ -0,0 +1,238 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"github.com/sirupsen/logrus"
+)
+
+// allowedAudioMimes aligns URL audio with the MIME types accepted for uploaded audio, for WhatsApp compatibility
+var allowedAudioMimes = map[string]bool{
+	"audio/aac":          true,
+	"audio/amr":          true,
+	"audio/flac":         true,
+	"audio/m4a":          true,
+	"audio/m4r":          true,
+	"audio/mp3":          true,
+	"audio/mpeg":         true,
+	"audio/ogg":          true,
+	"audio/wma":          true,
+	"audio/x-ms-wma":     true,
+	"audio/wav":          true,
+	"audio/vnd.wav":      true,
+	"audio/vnd.wave":     true,
+	"audio/wave":         true,
+	"audio/x-pn-wav":     true,
+	"audio/x-wav":        true,
+	"video/mp4":          true, // Sometimes audio is served as mp4
+	"application/ogg":    true, // Ogg audio
+	"application/x-mpeg": true,
+	"audio/webm":         true, // WebM audio
+	"video/webm":         true, // WebM audio/video
+	"audio/mp4":          true,
+}
+
+var allowedVideoMimes = map[string]bool{
+	"video/mp4":        true,
+	"video/x-matroska": true, // mkv
+	"video/avi":        true,
+	"video/x-msvideo":  true,
+	"image/gif":        true, // converted to MP4 and sent as GIF
+}
+
+// allowedImageExtensions are the image formats send image accepts from a URL
+var allowedImageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".webp": true,
+}
+
+// mediaDownload is a media response whose status, content type and announced size passed validation. The body is
+// limited to maxSize+1 bytes so servers that do not send Content-Length are caught too.
+type mediaDownload struct {
+	kind     string
+	fileName string
+	maxSize  int64
+	response *http.Response
+	body     *io.LimitedReader
+}
+
+// DownloadImageToFile downloads an image from the provided URL into a new file in dir and returns its path and the
+// sanitized filename. It validates that the content-type starts with "image/", that the URL names a JPEG, PNG or
+// WebP file and that the size does not exceed WhatsappSettingMaxImageSize.
+func DownloadImageToFile(imageURL, dir string) (path string, fileName string, err error) {
+	download, err := openImageDownload(imageURL)
+	if err != nil {
+		return "", "", err
+	}
+	return download.saveTo(dir)
+}
+
+// DownloadAudioToFile downloads an audio file from the provided URL into a new file in dir and returns its path and
+// the sanitized filename. The size must not exceed WhatsappSettingMaxDownloadSize; content types outside the audio
+// validation list are only logged, for Chatwoot compatibility.
+func DownloadAudioToFile(audioURL, dir string) (path string, fileName string, err error) {
+	download, err := openAudioDownload(audioURL)
+	if err != nil {
+		return "", "", err
+	}
+	return download.saveTo(dir)
+}
+
+// DownloadVideoToFile downloads a video from the provided URL into a new file in dir and returns its path and the
+// sanitized filename. It validates that the content-type is one of the supported WhatsApp video formats and that the
+// size does not exceed WhatsappSettingMaxDownloadSize.
+func DownloadVideoToFile(videoURL, dir string) (path string, fileName string, err error) {
+	download, err := openVideoDownload(videoURL)
+	if err != nil {
+		return "", "", err
+	}
+	return download.saveTo(dir)
+}
+
+// DownloadFileToFile downloads a document from the provided URL into a new file in dir and returns its path and the
+// sanitized filename. It enforces the max file size limit.
+func DownloadFileToFile(fileURL, dir string) (path string, fileName string, err error) {
+	download, err := openFileDownload(fileURL)
+	if err != nil {
+		return "", "", err
+	}
+	return download.saveTo(dir)
+}
+
+func openImageDownload(imageURL string) (*mediaDownload, error) {
+	// Check the extension before requesting anything
+	segments := strings.Split(imageURL, "/")
+	extension := strings.ToLower(filepath.Ext(strings.Split(segments[len(segments)-1], "?")[0]))
+	if !allowedImageExtensions[extension] {
+		return nil, fmt.Errorf("unsupported file type: %s", extension)
+	}
+
+	return openMediaDownload(imageURL, "image", config.WhatsappSettingMaxImageSize, "", func(contentType string) error {
+		if !strings.HasPrefix(contentType, "image/") {
+			return fmt.Errorf("invalid content type: %s", contentType)
+		}
+		return nil
+	})
+}
+
+func openAudioDownload(audioURL string) (*mediaDownload, error) {
+	return openMediaDownload(audioURL, "audio", config.WhatsappSettingMaxDownloadSize, fmt.Sprintf("audio_%d", time.Now().Unix()), func(contentType string) error {
+		// If content type is generic or not in list, just warn but allow download (let WhatsApp reject if invalid)
+		if !allowedAudioMimes[contentType] {
+			logrus.Warnf("DownloadAudioToFile: unexpected content type '%s', proceeding anyway", contentType)
+		}
+		return nil
+	})
+}
+
+func openVideoDownload(videoURL string) (*mediaDownload, error) {
+	return openMediaDownload(videoURL, "video", config.WhatsappSettingMaxDownloadSize, fmt.Sprintf("video_%d.mp4", time.Now().Unix()), func(contentType string) error {
+		if !allowedVideoMimes[contentType] {
+			return fmt.Errorf("invalid content type: %s", contentType)
+		}
+		return nil
+	})
+}
+
+func openFileDownload(fileURL string) (*mediaDownload, error) {
+	return openMediaDownload(fileURL, "file", config.WhatsappSettingMaxFileSize, fmt.Sprintf("file_%d", time.Now().Unix()), nil)
+}
+
+// openMediaDownload requests rawURL and validates the response headers; the caller must read and close the body
+func openMediaDownload(rawURL, kind string, maxSize int64, defaultName string, checkContentType func(contentType string) error) (*mediaDownload, error) {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("too many redirects")
+			}
+			return nil
+		},
+	}
+
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("HTTP request failed with status: %s", resp.Status)
+	}
+
+	if checkContentType != nil {
+		// Extract only the MIME type portion (ignore parameters like charset)
+		contentType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+		if err := checkContentType(contentType); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	}
+
+	// Validate content length when it is provided by the server.
+	if resp.ContentLength > 0 && resp.ContentLength > maxSize {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s size %d exceeds maximum allowed size %d", kind, resp.ContentLength, maxSize)
+	}
+
+	// Derive filename from URL path (strip query parameters if present)
+	segments := strings.Split(rawURL, "/")
+	fileName := strings.Split(segments[len(segments)-1], "?")[0]
+	if fileName == "" {
+		fileName = defaultName
+	}
+
+	limit := maxSize
+	if limit < math.MaxInt64 {
+		limit++
+	}
+
+	return &mediaDownload{
+		kind:     kind,
+		fileName: fileName,
+		maxSize:  maxSize,
+		response: resp,
+		body:     &io.LimitedReader{R: resp.Body, N: limit},
+	}, nil
+}
+
+// saveTo copies the body into a new file in dir in chunks, so memory use does not depend on the media size. The
+// file is removed again when the download fails or turns out too large.
+func (download *mediaDownload) saveTo(dir string) (path string, fileName string, err error) {
+	defer download.response.Body.Close()
+
+	file, err := os.CreateTemp(dir, "download-*"+filepath.Ext(filepath.Base(download.fileName)))
+	if err != nil {
+		return "", "", err
+	}
+
+	written, err := io.Copy(file, download.body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = download.checkSize(written)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", "", err
+	}
+	return file.Name(), download.fileName, nil
+}
+
+func (download *mediaDownload) checkSize(size int64) error {
+	if size > download.maxSize {
+		return fmt.Errorf("downloaded %s size of %d bytes exceeds the maximum allowed size of %d bytes", download.kind, size, download.maxSize)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// maxIdleBuckets bounds memory; full buckets are dropped once the map grows past it
+const maxIdleBuckets = 10000
+
+// TokenBucketLimiter keeps one token bucket per key. Each bucket holds up to burst tokens and
+// refills at perMinute tokens per minute.
+type TokenBucketLimiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	burst    float64
+	buckets  map[string]*tokenBucket
+	disabled bool
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewTokenBucketLimiter creates a limiter; perMinute <= 0 disables it so every Take succeeds.
+// burst defaults to perMinute when <= 0.
+func NewTokenBucketLimiter(perMinute, burst int) *TokenBucketLimiter {
+	if burst <= 0 {
+		burst = perMinute
+	}
+	return &TokenBucketLimiter{
+		rate:     float64(perMinute) / 60,
+		burst:    float64(burst),
+		buckets:  make(map[string]*tokenBucket),
+		disabled: perMinute <= 0,
+	}
+}
+
+// Take consumes a token for key. When none is available it returns false and how long until one is.
+func (l *TokenBucketLimiter) Take(key string) (bool, time.Duration) {
+	return l.TakeAt(key, time.Now())
+}
+
+// TakeAt is Take with an explicit clock
+func (l *TokenBucketLimiter) TakeAt(key string, now time.Time) (bool, time.Duration) {
+	if l == nil || l.disabled {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket := l.refill(key, now)
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration(math.Ceil((1 - bucket.tokens) / l.rate * float64(time.Second)))
+	return false, wait
+}
+
+// Refund returns a token taken for key, used when a later check rejects the same send
+func (l *TokenBucketLimiter) Refund(key string) {
+	if l == nil || l.disabled {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if bucket, ok := l.buckets[key]; ok {
+		bucket.tokens = math.Min(l.burst, bucket.tokens+1)
+	}
+}
+
+func (l *TokenBucketLimiter) refill(key string, now time.Time) *tokenBucket {
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.pruneFull(now)
+		}
+		bucket = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets[key] = bucket
+		return bucket
+	}
+
+	if elapsed := now.Sub(bucket.updated).Seconds(); elapsed > 0 {
+		bucket.tokens = math.Min(l.burst, bucket.tokens+elapsed*l.rate)
+		bucket.updated = now
+	}
+	return bucket
+}
+
+// pruneFull drops buckets that have refilled completely; they behave exactly like new ones
+func (l *TokenBucketLimiter) pruneFull(now time.Time) {
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
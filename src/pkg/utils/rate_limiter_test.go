@@ -0,0 +1,70 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimiterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RateLimiterTestSuite) TestBurstThenRefill() {
+	limiter := utils.NewTokenBucketLimiter(60, 2) // one token per second, burst of two
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	ok, _ := limiter.TakeAt("device", now)
+	assert.True(suite.T(), ok)
+	ok, _ = limiter.TakeAt("device", now)
+	assert.True(suite.T(), ok)
+
+	ok, wait := limiter.TakeAt("device", now)
+	assert.False(suite.T(), ok)
+	assert.Equal(suite.T(), time.Second, wait)
+
+	ok, wait = limiter.TakeAt("device", now.Add(500*time.Millisecond))
+	assert.False(suite.T(), ok)
+	assert.Equal(suite.T(), 500*time.Millisecond, wait)
+
+	ok, _ = limiter.TakeAt("device", now.Add(time.Second))
+	assert.True(suite.T(), ok)
+}
+
+func (suite *RateLimiterTestSuite) TestKeysAreIndependent() {
+	limiter := utils.NewTokenBucketLimiter(1, 1)
+	now := time.Now()
+
+	ok, _ := limiter.TakeAt("a", now)
+	assert.True(suite.T(), ok)
+	ok, _ = limiter.TakeAt("a", now)
+	assert.False(suite.T(), ok)
+	ok, _ = limiter.TakeAt("b", now)
+	assert.True(suite.T(), ok)
+}
+
+func (suite *RateLimiterTestSuite) TestRefund() {
+	limiter := utils.NewTokenBucketLimiter(1, 1)
+	now := time.Now()
+
+	ok, _ := limiter.TakeAt("a", now)
+	assert.True(suite.T(), ok)
+	limiter.Refund("a")
+	ok, _ = limiter.TakeAt("a", now)
+	assert.True(suite.T(), ok)
+}
+
+func (suite *RateLimiterTestSuite) TestDisabled() {
+	limiter := utils.NewTokenBucketLimiter(0, 0)
+	for i := 0; i < 100; i++ {
+		ok, _ := limiter.Take("a")
+		assert.True(suite.T(), ok)
+	}
+}
+
+func TestRateLimiterTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimiterTestSuite))
+}
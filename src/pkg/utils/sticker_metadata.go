@@ -0,0 +1,192 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// stickerExifTag is the private EXIF tag WhatsApp reads the sticker pack JSON from
+const stickerExifTag = 0x5741
+
+const (
+	webpFlagAlpha = 0x10
+	webpFlagExif  = 0x08
+)
+
+// StickerMetadata is what WhatsApp shows for a sticker: the pack it belongs to and the emojis it is found by.
+// Stickers with the same PackID are grouped as one pack by the apps.
+type StickerMetadata struct {
+	PackID    string   `json:"sticker-pack-id"`
+	PackName  string   `json:"sticker-pack-name"`
+	Publisher string   `json:"sticker-pack-publisher"`
+	Emojis    []string `json:"emojis,omitempty"`
+}
+
+type webpChunk struct {
+	fourCC string
+	data   []byte
+}
+
+// EmbedStickerMetadata stores the metadata in the EXIF chunk of a WebP image, replacing any EXIF it had. Simple
+// (VP8/VP8L) files are turned into extended VP8X files, which is the only layout that may carry EXIF.
+func EmbedStickerMetadata(webp []byte, metadata StickerMetadata) ([]byte, error) {
+	chunks, err := parseWebP(webp)
+	if err != nil {
+		return nil, err
+	}
+
+	exif, err := stickerExif(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []webpChunk
+	switch chunks[0].fourCC {
+	case "VP8X":
+		header := append([]byte(nil), chunks[0].data...)
+		header[0] |= webpFlagExif
+		out = append(out, webpChunk{fourCC: "VP8X", data: header})
+		for _, chunk := range chunks[1:] {
+			if chunk.fourCC != "EXIF" {
+				out = append(out, chunk)
+			}
+		}
+	case "VP8 ", "VP8L":
+		width, height, alpha, err := webpCanvas(chunks[0])
+		if err != nil {
+			return nil, err
+		}
+		header := make([]byte, 10)
+		header[0] = webpFlagExif
+		if alpha {
+			header[0] |= webpFlagAlpha
+		}
+		putUint24(header[4:7], uint32(width-1))
+		putUint24(header[7:10], uint32(height-1))
+		out = append(out, webpChunk{fourCC: "VP8X", data: header}, chunks[0])
+	default:
+		return nil, fmt.Errorf("unsupported WebP chunk %q", chunks[0].fourCC)
+	}
+	out = append(out, webpChunk{fourCC: "EXIF", data: exif})
+
+	return writeWebP(out), nil
+}
+
+// ReadStickerMetadata returns the sticker pack metadata of a WebP image, if it has any
+func ReadStickerMetadata(webp []byte) (metadata StickerMetadata, ok bool) {
+	chunks, err := parseWebP(webp)
+	if err != nil {
+		return metadata, false
+	}
+	for _, chunk := range chunks {
+		if chunk.fourCC != "EXIF" {
+			continue
+		}
+		exif := chunk.data
+		// IFD with a single entry: count(2) tag(2) type(2) length(4) offset(4)
+		if len(exif) < 22 || !bytes.HasPrefix(exif, []byte("II*\x00")) {
+			return metadata, false
+		}
+		if binary.LittleEndian.Uint16(exif[10:12]) != stickerExifTag {
+			return metadata, false
+		}
+		length := binary.LittleEndian.Uint32(exif[14:18])
+		offset := binary.LittleEndian.Uint32(exif[18:22])
+		if uint64(offset)+uint64(length) > uint64(len(exif)) {
+			return metadata, false
+		}
+		if err := json.Unmarshal(exif[offset:offset+length], &metadata); err != nil {
+			return metadata, false
+		}
+		return metadata, true
+	}
+	return metadata, false
+}
+
+// stickerExif builds a little-endian TIFF header with one IFD entry holding the metadata JSON
+func stickerExif(metadata StickerMetadata) ([]byte, error) {
+	payload, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("II*\x00")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(8)) // offset of the first IFD
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(1)) // one entry
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(stickerExifTag))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(7)) // UNDEFINED
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(payload)))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(22)) // payload follows the IFD
+	buf.Write(payload)
+	return buf.Bytes(), nil
+}
+
+func parseWebP(webp []byte) ([]webpChunk, error) {
+	if len(webp) < 12 || string(webp[0:4]) != "RIFF" || string(webp[8:12]) != "WEBP" {
+		return nil, errors.New("not a WebP image")
+	}
+
+	var chunks []webpChunk
+	for rest := webp[12:]; len(rest) >= 8; {
+		size := binary.LittleEndian.Uint32(rest[4:8])
+		if uint64(size) > uint64(len(rest)-8) {
+			return nil, fmt.Errorf("truncated WebP chunk %q", rest[0:4])
+		}
+		chunks = append(chunks, webpChunk{fourCC: string(rest[0:4]), data: rest[8 : 8+size]})
+		next := 8 + int(size) + int(size&1) // chunks are padded to an even size
+		if next > len(rest) {
+			break
+		}
+		rest = rest[next:]
+	}
+	if len(chunks) == 0 {
+		return nil, errors.New("empty WebP image")
+	}
+	return chunks, nil
+}
+
+func writeWebP(chunks []webpChunk) []byte {
+	var body bytes.Buffer
+	body.WriteString("WEBP")
+	for _, chunk := range chunks {
+		body.WriteString(chunk.fourCC)
+		_ = binary.Write(&body, binary.LittleEndian, uint32(len(chunk.data)))
+		body.Write(chunk.data)
+		if len(chunk.data)%2 == 1 {
+			body.WriteByte(0)
+		}
+	}
+
+	out := make([]byte, 0, 8+body.Len())
+	out = append(out, "RIFF"...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(body.Len()))
+	return append(out, body.Bytes()...)
+}
+
+// webpCanvas reads the image size from a simple WebP bitstream, and whether a lossless image uses alpha
+func webpCanvas(chunk webpChunk) (width, height int, alpha bool, err error) {
+	data := chunk.data
+	switch chunk.fourCC {
+	case "VP8L":
+		if len(data) < 5 || data[0] != 0x2f {
+			return 0, 0, false, errors.New("invalid VP8L header")
+		}
+		bits := binary.LittleEndian.Uint32(data[1:5])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, bits>>28&1 == 1, nil
+	default:
+		if len(data) < 10 || data[3] != 0x9d || data[4] != 0x01 || data[5] != 0x2a {
+			return 0, 0, false, errors.New("invalid VP8 header")
+		}
+		return int(binary.LittleEndian.Uint16(data[6:8]) & 0x3fff), int(binary.LittleEndian.Uint16(data[8:10]) & 0x3fff), false, nil
+	}
+}
+
+func putUint24(b []byte, v uint32) {
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+}
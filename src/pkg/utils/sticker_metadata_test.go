@@ -0,0 +1,63 @@
+package utils_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/image/webp"
+)
+
+// losslessWebP is a 1x1 VP8L image without the alpha hint
+var losslessWebP = []byte("RIFF\x1a\x00\x00\x00WEBPVP8L\x0d\x00\x00\x00\x2f\x00\x00\x00\x00\x07\x10\x11\x11\x88\x88\xfe\x07\x00")
+
+type StickerMetadataTestSuite struct {
+	suite.Suite
+}
+
+func (suite *StickerMetadataTestSuite) TestEmbedIntoSimpleWebP() {
+	metadata := utils.StickerMetadata{PackID: "pack-1", PackName: "Cats", Publisher: "Kemal", Emojis: []string{"😺", "❤️"}}
+
+	out, err := utils.EmbedStickerMetadata(losslessWebP, metadata)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "VP8X", string(out[12:16]))
+
+	// The result is still a decodable image of the same size
+	img, err := webp.Decode(bytes.NewReader(out))
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, img.Bounds().Dx())
+	assert.Equal(suite.T(), 1, img.Bounds().Dy())
+
+	read, ok := utils.ReadStickerMetadata(out)
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), metadata, read)
+}
+
+func (suite *StickerMetadataTestSuite) TestEmbedReplacesExistingMetadata() {
+	first, err := utils.EmbedStickerMetadata(losslessWebP, utils.StickerMetadata{PackID: "old", PackName: "Old"})
+	require.NoError(suite.T(), err)
+
+	second, err := utils.EmbedStickerMetadata(first, utils.StickerMetadata{PackID: "new", PackName: "New"})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, strings.Count(string(second), "EXIF"))
+
+	read, ok := utils.ReadStickerMetadata(second)
+	require.True(suite.T(), ok)
+	assert.Equal(suite.T(), "New", read.PackName)
+}
+
+func (suite *StickerMetadataTestSuite) TestRejectsNonWebP() {
+	_, err := utils.EmbedStickerMetadata([]byte("\x89PNG\r\n\x1a\n"), utils.StickerMetadata{PackName: "x"})
+	assert.Error(suite.T(), err)
+
+	_, ok := utils.ReadStickerMetadata(losslessWebP)
+	assert.False(suite.T(), ok)
+}
+
+func TestStickerMetadataTestSuite(t *testing.T) {
+	suite.Run(t, new(StickerMetadataTestSuite))
+}
@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+)
+
+const (
+	// transcoderWaitDelay bounds how long a killed process may keep its output pipes open
+	transcoderWaitDelay = 5 * time.Second
+	// transcoderStderrTail is how much of a failing command's stderr ends up in its error
+	transcoderStderrTail = 512
+)
+
+// Transcoder runs external media tools (ffmpeg, ffprobe, cwebp, webpmux, ...) with at most workers processes at a
+// time. Up to queueSize callers wait for a free worker; beyond that Run fails fast with a QUEUE_FULL error.
+type Transcoder struct {
+	slots     chan struct{}
+	queueSize int
+	timeout   time.Duration
+
+	mu    sync.Mutex
+	stats TranscoderStats
+}
+
+// TranscoderStats is a snapshot of the pool and its counters since start
+type TranscoderStats struct {
+	Workers   int
+	QueueSize int
+	Running   int
+	Queued    int
+	// Started counts processes that got a worker; each ends as Completed, Failed, TimedOut or Canceled.
+	// Canceled also counts callers that gave up while queued, Rejected those turned away by a full queue.
+	Started   uint64
+	Completed uint64
+	Failed    uint64
+	TimedOut  uint64
+	Canceled  uint64
+	Rejected  uint64
+	// TotalRunTime and MaxRunTime cover processes that were started, whatever their outcome
+	TotalRunTime time.Duration
+	MaxRunTime   time.Duration
+}
+
+// NewTranscoder creates a pool; workers < 1 is treated as 1, queueSize < 0 as 0 and timeout <= 0 disables the
+// default per-job timeout.
+func NewTranscoder(workers, queueSize int, timeout time.Duration) *Transcoder {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	return &Transcoder{
+		slots:     make(chan struct{}, workers),
+		queueSize: queueSize,
+		timeout:   timeout,
+		stats:     TranscoderStats{Workers: workers, QueueSize: queueSize},
+	}
+}
+
+// Run executes name with args once a worker is free and returns its stdout. The process is killed when ctx ends or
+// when it runs longer than timeout (the pool default when timeout <= 0); time spent queued does not count against
+// the timeout. A failing command's error carries the tail of its stderr.
+func (t *Transcoder) Run(ctx context.Context, timeout time.Duration, name string, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return nil, fmt.Errorf("%s not found: %w", name, err)
+	}
+
+	if err := t.acquire(ctx, name); err != nil {
+		return nil, err
+	}
+	defer func() { <-t.slots }()
+
+	if timeout <= 0 {
+		timeout = t.timeout
+	}
+	runCtx, cancel := ctx, context.CancelFunc(func() {})
+	if timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(runCtx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = transcoderWaitDelay
+
+	started := time.Now()
+	err := cmd.Run()
+	elapsed := time.Since(started)
+
+	switch {
+	case err == nil:
+		t.finish(elapsed, &t.stats.Completed)
+		return stdout.Bytes(), nil
+	case ctx.Err() != nil:
+		t.finish(elapsed, &t.stats.Canceled)
+		return nil, fmt.Errorf("%s canceled: %w", name, ctx.Err())
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		t.finish(elapsed, &t.stats.TimedOut)
+		return nil, pkgError.RequestTimeout(fmt.Sprintf("%s did not finish within %s", name, timeout))
+	default:
+		t.finish(elapsed, &t.stats.Failed)
+		if tail := stderrTail(stderr.String()); tail != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", name, err, tail)
+		}
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+}
+
+// Stats returns the current pool state and counters
+func (t *Transcoder) Stats() TranscoderStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stats
+}
+
+// acquire takes a worker slot, waiting in the queue while there is room in it
+func (t *Transcoder) acquire(ctx context.Context, name string) error {
+	t.mu.Lock()
+	select {
+	case t.slots <- struct{}{}:
+		t.stats.Running++
+		t.stats.Started++
+		t.mu.Unlock()
+		return nil
+	default:
+	}
+	if t.stats.Queued >= t.queueSize {
+		t.stats.Rejected++
+		t.mu.Unlock()
+		return pkgError.QueueFullError(fmt.Sprintf("media transcoding is busy (%d running, %d queued), retry later", cap(t.slots), t.queueSize))
+	}
+	t.stats.Queued++
+	t.mu.Unlock()
+
+	select {
+	case t.slots <- struct{}{}:
+		t.mu.Lock()
+		t.stats.Queued--
+		t.stats.Running++
+		t.stats.Started++
+		t.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		t.mu.Lock()
+		t.stats.Queued--
+		t.stats.Canceled++
+		t.mu.Unlock()
+		return fmt.Errorf("%s canceled while queued: %w", name, ctx.Err())
+	}
+}
+
+func (t *Transcoder) finish(elapsed time.Duration, counter *uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stats.Running--
+	*counter++
+	t.stats.TotalRunTime += elapsed
+	if elapsed > t.stats.MaxRunTime {
+		t.stats.MaxRunTime = elapsed
+	}
+}
+
+func stderrTail(stderr string) string {
+	stderr = strings.TrimSpace(stderr)
+	if len(stderr) > transcoderStderrTail {
+		stderr = "..." + stderr[len(stderr)-transcoderStderrTail:]
+	}
+	return stderr
+}
@@ -0,0 +1,99 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type TranscoderTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TranscoderTestSuite) TestReturnsStdout() {
+	transcoder := utils.NewTranscoder(1, 0, time.Second)
+
+	output, err := transcoder.Run(context.Background(), 0, "sh", "-c", "printf hello")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "hello", string(output))
+
+	stats := transcoder.Stats()
+	assert.Equal(suite.T(), uint64(1), stats.Started)
+	assert.Equal(suite.T(), uint64(1), stats.Completed)
+	assert.Equal(suite.T(), 0, stats.Running)
+}
+
+func (suite *TranscoderTestSuite) TestFailureCarriesStderr() {
+	transcoder := utils.NewTranscoder(1, 0, time.Second)
+
+	_, err := transcoder.Run(context.Background(), 0, "sh", "-c", "echo broken input >&2; exit 3")
+	require.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "broken input")
+	assert.Equal(suite.T(), uint64(1), transcoder.Stats().Failed)
+}
+
+func (suite *TranscoderTestSuite) TestTimeout() {
+	transcoder := utils.NewTranscoder(1, 0, time.Minute)
+
+	_, err := transcoder.Run(context.Background(), 50*time.Millisecond, "sleep", "5")
+	var timeoutErr pkgError.TimeoutError
+	assert.True(suite.T(), errors.As(err, &timeoutErr), "expected a timeout error, got %v", err)
+	assert.Equal(suite.T(), uint64(1), transcoder.Stats().TimedOut)
+}
+
+func (suite *TranscoderTestSuite) TestRejectsWhenQueueIsFull() {
+	transcoder := utils.NewTranscoder(1, 0, time.Second)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = transcoder.Run(context.Background(), 0, "sleep", "0.3")
+	}()
+	suite.waitRunning(transcoder, 1)
+
+	_, err := transcoder.Run(context.Background(), 0, "sh", "-c", "true")
+	var queueFull pkgError.QueueFullError
+	assert.True(suite.T(), errors.As(err, &queueFull), "expected a queue full error, got %v", err)
+	assert.Equal(suite.T(), uint64(1), transcoder.Stats().Rejected)
+	<-done
+}
+
+func (suite *TranscoderTestSuite) TestQueuedCallerCanGiveUp() {
+	transcoder := utils.NewTranscoder(1, 1, time.Second)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = transcoder.Run(context.Background(), 0, "sleep", "0.3")
+	}()
+	suite.waitRunning(transcoder, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := transcoder.Run(ctx, 0, "sh", "-c", "true")
+	assert.ErrorIs(suite.T(), err, context.DeadlineExceeded)
+
+	stats := transcoder.Stats()
+	assert.Equal(suite.T(), uint64(1), stats.Canceled)
+	assert.Equal(suite.T(), 0, stats.Queued)
+	<-done
+}
+
+func (suite *TranscoderTestSuite) waitRunning(transcoder *utils.Transcoder, running int) {
+	deadline := time.Now().Add(2 * time.Second)
+	for transcoder.Stats().Running < running {
+		if time.Now().After(deadline) {
+			suite.T().Fatalf("transcoder never reached %d running jobs", running)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTranscoderTestSuite(t *testing.T) {
+	suite.Run(t, new(TranscoderTestSuite))
+}
@@ -18,6 +18,7 @@ import (
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -344,6 +345,72 @@ func ExtractMediaInfo(msg *waE2E.Message) (mediaType string, filename string, ur
 	return "", "", "", nil, nil, nil, 0
 }
 
+// ExtractMediaMessage serializes the media part of a message, with its mimetype, duration, waveform, dimensions and
+// thumbnails, so it can be forwarded later without downloading it. Of the context only the forwarding score is kept.
+// Messages without media give nil.
+func ExtractMediaMessage(msg *waE2E.Message) []byte {
+	if msg == nil {
+		return nil
+	}
+
+	media := &waE2E.Message{}
+	var contextInfo **waE2E.ContextInfo
+	switch {
+	case msg.GetImageMessage() != nil:
+		media.ImageMessage = proto.Clone(msg.GetImageMessage()).(*waE2E.ImageMessage)
+		contextInfo = &media.ImageMessage.ContextInfo
+	case msg.GetVideoMessage() != nil:
+		media.VideoMessage = proto.Clone(msg.GetVideoMessage()).(*waE2E.VideoMessage)
+		contextInfo = &media.VideoMessage.ContextInfo
+	case msg.GetPtvMessage() != nil:
+		media.PtvMessage = proto.Clone(msg.GetPtvMessage()).(*waE2E.VideoMessage)
+		contextInfo = &media.PtvMessage.ContextInfo
+	case msg.GetAudioMessage() != nil:
+		media.AudioMessage = proto.Clone(msg.GetAudioMessage()).(*waE2E.AudioMessage)
+		contextInfo = &media.AudioMessage.ContextInfo
+	case msg.GetDocumentMessage() != nil:
+		media.DocumentMessage = proto.Clone(msg.GetDocumentMessage()).(*waE2E.DocumentMessage)
+		contextInfo = &media.DocumentMessage.ContextInfo
+	case msg.GetStickerMessage() != nil:
+		media.StickerMessage = proto.Clone(msg.GetStickerMessage()).(*waE2E.StickerMessage)
+		contextInfo = &media.StickerMessage.ContextInfo
+	default:
+		return nil
+	}
+
+	score := (*contextInfo).GetForwardingScore()
+	*contextInfo = nil
+	if score > 0 {
+		*contextInfo = &waE2E.ContextInfo{IsForwarded: proto.Bool(true), ForwardingScore: proto.Uint32(score)}
+	}
+
+	data, err := proto.Marshal(media)
+	if err != nil {
+		logrus.Warnf("Failed to serialize media message: %v", err)
+		return nil
+	}
+	return data
+}
+
+// ExtractPollCreation returns the poll of a poll creation message, whichever version of the field carries it
+func ExtractPollCreation(msg *waE2E.Message) *waE2E.PollCreationMessage {
+	if msg == nil {
+		return nil
+	}
+	for _, poll := range []*waE2E.PollCreationMessage{
+		msg.GetPollCreationMessage(),
+		msg.GetPollCreationMessageV2(),
+		msg.GetPollCreationMessageV3(),
+		msg.GetPollCreationMessageV5(),
+		msg.GetPollCreationMessageV6(),
+	} {
+		if poll != nil {
+			return poll
+		}
+	}
+	return nil
+}
+
 // ExtractEphemeralExpiration extracts ephemeral expiration from a WhatsApp message
 func ExtractEphemeralExpiration(msg *waE2E.Message) uint32 {
 	logrus.Debug("ExtractEphemeralExpiration: Starting extraction process")
@@ -0,0 +1,264 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainCampaign "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/campaign"
+	mcpHelpers "github.com/aldinokemal/go-whatsapp-web-multidevice/ui/mcp/helpers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type CampaignHandler struct {
+	campaignService domainCampaign.ICampaignUsecase
+}
+
+func InitMcpCampaign(campaignService domainCampaign.ICampaignUsecase) *CampaignHandler {
+	return &CampaignHandler{campaignService: campaignService}
+}
+
+func (h *CampaignHandler) AddCampaignTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolCreateCampaign(), h.handleCreateCampaign)
+	mcpServer.AddTool(h.toolListCampaigns(), h.handleListCampaigns)
+	mcpServer.AddTool(h.toolCampaignStatus(), h.handleCampaignStatus)
+	mcpServer.AddTool(h.toolControlCampaign(), h.handleControlCampaign)
+}
+
+func (h *CampaignHandler) toolCreateCampaign() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_campaign_create",
+		mcp.WithDescription("Start a broadcast campaign that sends one message to many recipients with rate limiting, jitter and optional quiet hours. String values in payload may use {{variables}} filled per recipient; numbers not on WhatsApp are skipped."),
+		mcp.WithTitleAnnotation("Create Campaign"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("name",
+			mcp.Description("Campaign name for your own reference."),
+		),
+		mcp.WithString("type",
+			mcp.Description("Message type, matching the /send/* endpoint name (text, image, file, video, audio, sticker, contact, link, location, poll)."),
+			mcp.Required(),
+		),
+		mcp.WithObject("payload",
+			mcp.Description("The /send/* JSON body without phone, e.g. {\"message\":\"Hi {{name}}\"}."),
+			mcp.Required(),
+		),
+		mcp.WithArray("recipients",
+			mcp.Description("Recipients as objects: {\"phone\":\"628123456789\",\"variables\":{\"name\":\"Budi\"}}."),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"phone":     map[string]any{"type": "string"},
+					"variables": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+				},
+				"required": []string{"phone"},
+			}),
+		),
+		mcp.WithString("recipients_csv",
+			mcp.Description("Alternative to recipients: CSV text with a header row containing a phone column; other columns become variables."),
+		),
+		mcp.WithNumber("rate_per_minute",
+			mcp.Description("Messages per minute (default 10, max 60)."),
+			mcp.DefaultNumber(10),
+		),
+		mcp.WithNumber("jitter_seconds",
+			mcp.Description("Random extra delay of up to this many seconds between messages (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+		mcp.WithString("quiet_hours_start",
+			mcp.Description("Stop sending from this time (HH:MM), e.g. 21:00."),
+		),
+		mcp.WithString("quiet_hours_end",
+			mcp.Description("Resume sending at this time (HH:MM), e.g. 08:00."),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA timezone for quiet hours (default UTC)."),
+		),
+	)
+}
+
+func (h *CampaignHandler) handleCreateCampaign(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messageType, err := request.RequireString("type")
+	if err != nil {
+		return nil, err
+	}
+
+	req := domainCampaign.CreateCampaignRequest{
+		Name:            request.GetString("name", ""),
+		Type:            messageType,
+		RecipientsCSV:   request.GetString("recipients_csv", ""),
+		RatePerMinute:   request.GetInt("rate_per_minute", 0),
+		JitterSeconds:   request.GetInt("jitter_seconds", 0),
+		QuietHoursStart: request.GetString("quiet_hours_start", ""),
+		QuietHoursEnd:   request.GetString("quiet_hours_end", ""),
+		Timezone:        request.GetString("timezone", ""),
+	}
+
+	if args := request.GetArguments(); args != nil {
+		if raw, ok := args["payload"]; ok && raw != nil {
+			if req.Payload, err = json.Marshal(raw); err != nil {
+				return nil, fmt.Errorf("payload must be a JSON object: %w", err)
+			}
+		}
+		if raw, ok := args["recipients"]; ok && raw != nil {
+			encoded, err := json.Marshal(raw)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(encoded, &req.Recipients); err != nil {
+				return nil, fmt.Errorf("recipients must be an array of {phone, variables}: %w", err)
+			}
+		}
+	}
+
+	resp, err := h.campaignService.CreateCampaign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Campaign %s started for %d recipients", resp.ID, resp.Progress.Total)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *CampaignHandler) toolListCampaigns() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_campaign_list",
+		mcp.WithDescription("List broadcast campaigns for the current device with their progress."),
+		mcp.WithTitleAnnotation("List Campaigns"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("status",
+			mcp.Description("Filter by status."),
+			mcp.Enum(domainCampaign.StatusRunning, domainCampaign.StatusPaused, domainCampaign.StatusCompleted, domainCampaign.StatusCancelled),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of campaigns to return (default 25, max 100)."),
+			mcp.DefaultNumber(25),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of campaigns to skip (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *CampaignHandler) handleListCampaigns(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.campaignService.ListCampaigns(ctx, domainCampaign.ListCampaignsRequest{
+		Status: request.GetString("status", ""),
+		Limit:  request.GetInt("limit", 25),
+		Offset: request.GetInt("offset", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d of %d campaigns", len(resp.Data), resp.Total)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *CampaignHandler) toolCampaignStatus() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_campaign_status",
+		mcp.WithDescription("Get the progress of a campaign (sent, failed, skipped and pending recipients)."),
+		mcp.WithTitleAnnotation("Campaign Status"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("id",
+			mcp.Description("Campaign ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *CampaignHandler) handleCampaignStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := request.RequireString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.campaignService.GetCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf(
+		"Campaign %s is %s: %d sent, %d failed, %d skipped, %d pending of %d",
+		resp.ID, resp.Status, resp.Progress.Sent, resp.Progress.Failed, resp.Progress.Skipped, resp.Progress.Pending, resp.Progress.Total,
+	)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *CampaignHandler) toolControlCampaign() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_campaign_control",
+		mcp.WithDescription("Pause, resume or cancel a campaign. Cancelling is permanent; unsent recipients are marked cancelled."),
+		mcp.WithTitleAnnotation("Control Campaign"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("id",
+			mcp.Description("Campaign ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("action",
+			mcp.Description("Action to apply."),
+			mcp.Enum("pause", "resume", "cancel"),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *CampaignHandler) handleControlCampaign(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := request.RequireString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := request.RequireString("action")
+	if err != nil {
+		return nil, err
+	}
+
+	req := domainCampaign.CampaignActionRequest{ID: id}
+	var resp domainCampaign.Campaign
+	switch action {
+	case "pause":
+		resp, err = h.campaignService.PauseCampaign(ctx, req)
+	case "resume":
+		resp, err = h.campaignService.ResumeCampaign(ctx, req)
+	case "cancel":
+		resp, err = h.campaignService.CancelCampaign(ctx, req)
+	default:
+		return nil, fmt.Errorf("action must be one of pause, resume, cancel")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Campaign %s is now %s", resp.ID, resp.Status)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
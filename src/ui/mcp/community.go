@@ -0,0 +1,265 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	mcpHelpers "github.com/aldinokemal/go-whatsapp-web-multidevice/ui/mcp/helpers"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func communityIDOption() mcp.ToolOption {
+	return mcp.WithString("community_id",
+		mcp.Description("Community JID or numeric ID."),
+		mcp.Required(),
+	)
+}
+
+func (h *GroupHandler) toolCreateCommunity() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_create",
+		mcp.WithDescription("Create a community. WhatsApp adds its announcement group automatically."),
+		mcp.WithTitleAnnotation("Create Community"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("name",
+			mcp.Description("Community name."),
+			mcp.Required(),
+		),
+		mcp.WithString("description",
+			mcp.Description("Optional community description."),
+		),
+	)
+}
+
+func (h *GroupHandler) handleCreateCommunity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.groupService.CreateCommunity(ctx, domainGroup.CreateCommunityRequest{
+		Name:        strings.TrimSpace(name),
+		Description: request.GetString("description", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Created community %s", resp.CommunityID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *GroupHandler) toolCommunityInfo() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_info",
+		mcp.WithDescription("Get community metadata: name, description, owner, announcement group, linked groups and member count."),
+		mcp.WithTitleAnnotation("Community Info"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		communityIDOption(),
+	)
+}
+
+func (h *GroupHandler) handleCommunityInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	communityID, err := requireGroupID(request, "community_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.groupService.CommunityInfo(ctx, domainGroup.CommunityRequest{CommunityID: communityID})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Community %s (%s) has %d linked groups", resp.Name, resp.CommunityID, len(resp.Groups))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *GroupHandler) toolCommunityGroups() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_groups",
+		mcp.WithDescription("List the groups linked to a community and its announcement group."),
+		mcp.WithTitleAnnotation("List Community Groups"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		communityIDOption(),
+	)
+}
+
+func (h *GroupHandler) handleCommunityGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	communityID, err := requireGroupID(request, "community_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.groupService.GetCommunitySubGroups(ctx, domainGroup.CommunityRequest{CommunityID: communityID})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Community %s has %d linked groups", resp.CommunityID, len(resp.Groups))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *GroupHandler) toolCreateCommunityGroup() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_create_group",
+		mcp.WithDescription("Create a new group inside a community."),
+		mcp.WithTitleAnnotation("Create Community Group"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		communityIDOption(),
+		mcp.WithString("title",
+			mcp.Description("Group subject/title."),
+			mcp.Required(),
+		),
+		mcp.WithArray("participants",
+			mcp.Description("Phone numbers to add during creation (without @s.whatsapp.net suffix)."),
+			mcp.WithStringItems(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleCreateCommunityGroup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	communityID, err := requireGroupID(request, "community_id")
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := request.RequireString("title")
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := toStringSlice(request.GetArguments()["participants"])
+	if err != nil {
+		return nil, err
+	}
+
+	groupID, err := h.groupService.CreateCommunityGroup(ctx, domainGroup.CreateCommunityGroupRequest{
+		CommunityID:  communityID,
+		Title:        strings.TrimSpace(title),
+		Participants: participants,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	structured := map[string]any{
+		"group_id":     groupID,
+		"community_id": communityID,
+	}
+
+	fallback := fmt.Sprintf("Created group %s in community %s", groupID, communityID)
+	return mcp.NewToolResultStructured(structured, fallback), nil
+}
+
+func (h *GroupHandler) toolLinkCommunityGroups() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_link_groups",
+		mcp.WithDescription("Link existing groups to a community, or unlink them. Unlinked groups keep existing on their own."),
+		mcp.WithTitleAnnotation("Link Community Groups"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		communityIDOption(),
+		mcp.WithArray("group_ids",
+			mcp.Description("Group JIDs or numeric IDs to link or unlink."),
+			mcp.Required(),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("action",
+			mcp.Description("link (default) or unlink."),
+			mcp.Enum("link", "unlink"),
+			mcp.DefaultString("link"),
+		),
+	)
+}
+
+func (h *GroupHandler) handleLinkCommunityGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	communityID, err := requireGroupID(request, "community_id")
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs, err := toStringSlice(request.GetArguments()["group_ids"])
+	if err != nil {
+		return nil, err
+	}
+	for i := range groupIDs {
+		groupIDs[i] = strings.TrimSpace(groupIDs[i])
+		utils.SanitizePhone(&groupIDs[i])
+	}
+
+	linkRequest := domainGroup.CommunityGroupsRequest{CommunityID: communityID, GroupIDs: groupIDs}
+
+	var result []domainGroup.CommunityGroupStatus
+	action := strings.ToLower(strings.TrimSpace(request.GetString("action", "link")))
+	switch action {
+	case "link":
+		result, err = h.groupService.LinkCommunityGroups(ctx, linkRequest)
+	case "unlink":
+		result, err = h.groupService.UnlinkCommunityGroups(ctx, linkRequest)
+	default:
+		return nil, fmt.Errorf("invalid action %q, use link or unlink", action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	failed := 0
+	for _, status := range result {
+		if status.Status != "success" {
+			failed++
+		}
+	}
+
+	structured := map[string]any{
+		"community_id": communityID,
+		"action":       action,
+		"results":      result,
+	}
+
+	fallback := fmt.Sprintf("%s: %d of %d groups succeeded in community %s", action, len(result)-failed, len(result), communityID)
+	return mcp.NewToolResultStructured(structured, fallback), nil
+}
+
+// requireGroupID reads a required group or community ID argument, accepting bare numeric IDs
+func requireGroupID(request mcp.CallToolRequest, key string) (string, error) {
+	id, err := request.RequireString(key)
+	if err != nil {
+		return "", err
+	}
+	id = strings.TrimSpace(id)
+	utils.SanitizePhone(&id)
+	return id, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
 )
 
 type GroupHandler struct {
@@ -29,12 +30,23 @@ func (h *GroupHandler) AddGroupTools(mcpServer *server.MCPServer) {
 	mcpServer.AddTool(h.toolManageParticipants(), h.handleManageParticipants)
 	mcpServer.AddTool(h.toolGetInviteLink(), h.handleGetInviteLink)
 	mcpServer.AddTool(h.toolGroupInfo(), h.handleGroupInfo)
+	mcpServer.AddTool(h.toolGroupHistory(), h.handleGroupHistory)
 	mcpServer.AddTool(h.toolSetGroupName(), h.handleSetGroupName)
 	mcpServer.AddTool(h.toolSetGroupTopic(), h.handleSetGroupTopic)
 	mcpServer.AddTool(h.toolSetGroupLocked(), h.handleSetGroupLocked)
 	mcpServer.AddTool(h.toolSetGroupAnnounce(), h.handleSetGroupAnnounce)
+	mcpServer.AddTool(h.toolSetGroupDescription(), h.handleSetGroupDescription)
+	mcpServer.AddTool(h.toolSetGroupJoinApproval(), h.handleSetGroupJoinApproval)
+	mcpServer.AddTool(h.toolSetGroupMemberAddMode(), h.handleSetGroupMemberAddMode)
+	mcpServer.AddTool(h.toolSetGroupDisappearing(), h.handleSetGroupDisappearing)
+	mcpServer.AddTool(h.toolRevokeInviteLink(), h.handleRevokeInviteLink)
 	mcpServer.AddTool(h.toolListGroupJoinRequests(), h.handleListGroupJoinRequests)
 	mcpServer.AddTool(h.toolManageGroupJoinRequests(), h.handleManageGroupJoinRequests)
+	mcpServer.AddTool(h.toolCreateCommunity(), h.handleCreateCommunity)
+	mcpServer.AddTool(h.toolCommunityInfo(), h.handleCommunityInfo)
+	mcpServer.AddTool(h.toolCommunityGroups(), h.handleCommunityGroups)
+	mcpServer.AddTool(h.toolCreateCommunityGroup(), h.handleCreateCommunityGroup)
+	mcpServer.AddTool(h.toolLinkCommunityGroups(), h.handleLinkCommunityGroups)
 }
 
 func (h *GroupHandler) toolCreateGroup() mcp.Tool {
@@ -183,6 +195,10 @@ func (h *GroupHandler) toolGetParticipants() mcp.Tool {
 			mcp.Description("Group JID or numeric ID."),
 			mcp.Required(),
 		),
+		mcp.WithBoolean("refresh",
+			mcp.Description("Ask WhatsApp instead of using the stored group metadata."),
+			mcp.DefaultBool(false),
+		),
 	)
 }
 
@@ -200,7 +216,7 @@ func (h *GroupHandler) handleGetParticipants(ctx context.Context, request mcp.Ca
 	trimmed := strings.TrimSpace(groupID)
 	utils.SanitizePhone(&trimmed)
 
-	resp, err := h.groupService.GetGroupParticipants(ctx, domainGroup.GetGroupParticipantsRequest{GroupID: trimmed})
+	resp, err := h.groupService.GetGroupParticipants(ctx, domainGroup.GetGroupParticipantsRequest{GroupID: trimmed, Refresh: request.GetBool("refresh", false)})
 	if err != nil {
 		return nil, err
 	}
@@ -358,6 +374,10 @@ func (h *GroupHandler) toolGroupInfo() mcp.Tool {
 			mcp.Description("Group JID or numeric ID."),
 			mcp.Required(),
 		),
+		mcp.WithBoolean("refresh",
+			mcp.Description("Ask WhatsApp instead of using the stored group metadata."),
+			mcp.DefaultBool(false),
+		),
 	)
 }
 
@@ -375,7 +395,7 @@ func (h *GroupHandler) handleGroupInfo(ctx context.Context, request mcp.CallTool
 	trimmed := strings.TrimSpace(groupID)
 	utils.SanitizePhone(&trimmed)
 
-	resp, err := h.groupService.GroupInfo(ctx, domainGroup.GroupInfoRequest{GroupID: trimmed})
+	resp, err := h.groupService.GroupInfo(ctx, domainGroup.GroupInfoRequest{GroupID: trimmed, Refresh: request.GetBool("refresh", false)})
 	if err != nil {
 		return nil, err
 	}
@@ -384,6 +404,76 @@ func (h *GroupHandler) handleGroupInfo(ctx context.Context, request mcp.CallTool
 	return mcp.NewToolResultStructured(resp, fallback), nil
 }
 
+func (h *GroupHandler) toolGroupHistory() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_history",
+		mcp.WithDescription("List recorded group changes, newest first: joins, leaves, adds, removals, promotions, demotions and name, topic or settings changes, with who made them."),
+		mcp.WithTitleAnnotation("Group History"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("types",
+			mcp.Description("Comma separated event types: joined, added, left, removed, promoted, demoted, name_changed, topic_changed, setting_changed, linked, unlinked, invite_link_reset, deleted."),
+		),
+		mcp.WithString("participant",
+			mcp.Description("Only events about this phone number or JID."),
+		),
+		mcp.WithString("actor",
+			mcp.Description("Only events made by this phone number or JID."),
+		),
+		mcp.WithString("start_time",
+			mcp.Description("Only events at or after this RFC3339 time."),
+		),
+		mcp.WithString("end_time",
+			mcp.Description("Only events at or before this RFC3339 time."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of events to return (default 50, max 500)."),
+			mcp.DefaultNumber(50),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of events to skip (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *GroupHandler) handleGroupHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupID, err := request.RequireString("group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(groupID)
+	utils.SanitizePhone(&trimmed)
+
+	resp, err := h.groupService.GroupHistory(ctx, domainGroup.GroupHistoryRequest{
+		GroupID:     trimmed,
+		Types:       request.GetString("types", ""),
+		Participant: request.GetString("participant", ""),
+		Actor:       request.GetString("actor", ""),
+		StartTime:   request.GetString("start_time", ""),
+		EndTime:     request.GetString("end_time", ""),
+		Limit:       request.GetInt("limit", 0),
+		Offset:      request.GetInt("offset", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Group %s has %d recorded events (showing %d)", resp.GroupID, resp.Total, len(resp.Data))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
 func (h *GroupHandler) toolSetGroupName() mcp.Tool {
 	return mcp.NewTool(
 		"whatsapp_group_set_name",
@@ -594,6 +684,227 @@ func (h *GroupHandler) handleSetGroupAnnounce(ctx context.Context, request mcp.C
 	return mcp.NewToolResultText(fmt.Sprintf("Group %s is now in %s mode", trimmed, state)), nil
 }
 
+func (h *GroupHandler) toolSetGroupDescription() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_set_description",
+		mcp.WithDescription("Replace the group description and return the ID of the new description. Pass previous_id to make sure no one changed it since you last read it."),
+		mcp.WithTitleAnnotation("Set Group Description"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("description",
+			mcp.Description("New group description. Leave empty to remove it."),
+		),
+		mcp.WithString("previous_id",
+			mcp.Description("description_id from the group info; the change is rejected if the description was replaced since."),
+		),
+	)
+}
+
+func (h *GroupHandler) handleSetGroupDescription(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupID, err := requireGroupID(request, "group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.groupService.SetGroupDescription(ctx, domainGroup.SetGroupDescriptionRequest{
+		GroupID:     groupID,
+		Description: strings.TrimSpace(request.GetString("description", "")),
+		PreviousID:  strings.TrimSpace(request.GetString("previous_id", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Updated group %s description (id %s)", groupID, resp.DescriptionID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *GroupHandler) toolSetGroupJoinApproval() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_set_join_approval",
+		mcp.WithDescription("Toggle whether admins must approve people joining through the invite link."),
+		mcp.WithTitleAnnotation("Set Group Join Approval"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("enabled",
+			mcp.Description("Set to true to require admin approval for new members."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleSetGroupJoinApproval(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupID, err := requireGroupID(request, "group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := request.RequireBool("enabled")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.groupService.SetGroupJoinApproval(ctx, domainGroup.SetGroupJoinApprovalRequest{GroupID: groupID, Enabled: enabled}); err != nil {
+		return nil, err
+	}
+
+	state := "no longer requires"
+	if enabled {
+		state = "now requires"
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Group %s %s admin approval to join", groupID, state)), nil
+}
+
+func (h *GroupHandler) toolSetGroupMemberAddMode() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_set_member_add_mode",
+		mcp.WithDescription("Choose who can add members to the group: only admins or every member."),
+		mcp.WithTitleAnnotation("Set Group Member Add Mode"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("mode",
+			mcp.Description("admin_add for admins only, all_member_add for every member."),
+			mcp.Enum(string(types.GroupMemberAddModeAdmin), string(types.GroupMemberAddModeAllMember)),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleSetGroupMemberAddMode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupID, err := requireGroupID(request, "group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := request.RequireString("mode")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.groupService.SetGroupMemberAddMode(ctx, domainGroup.SetGroupMemberAddModeRequest{
+		GroupID: groupID,
+		Mode:    types.GroupMemberAddMode(strings.TrimSpace(mode)),
+	}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Group %s member add mode set to %s", groupID, mode)), nil
+}
+
+func (h *GroupHandler) toolSetGroupDisappearing() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_set_disappearing",
+		mcp.WithDescription("Set the disappearing messages timer of a group."),
+		mcp.WithTitleAnnotation("Set Group Disappearing Messages"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithNumber("timer_seconds",
+			mcp.Description("0 (off), 86400 (24h), 604800 (7d) or 7776000 (90d)."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleSetGroupDisappearing(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupID, err := requireGroupID(request, "group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	timer, err := request.RequireInt("timer_seconds")
+	if err != nil {
+		return nil, err
+	}
+	if timer < 0 {
+		return nil, fmt.Errorf("timer_seconds cannot be negative")
+	}
+
+	if err := h.groupService.SetGroupDisappearing(ctx, domainGroup.SetGroupDisappearingRequest{GroupID: groupID, TimerSeconds: uint32(timer)}); err != nil {
+		return nil, err
+	}
+
+	if timer == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Disabled disappearing messages in group %s", groupID)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Messages in group %s now disappear after %d seconds", groupID, timer)), nil
+}
+
+func (h *GroupHandler) toolRevokeInviteLink() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_revoke_invite_link",
+		mcp.WithDescription("Revoke the group invite link so it stops working, and get the new one."),
+		mcp.WithTitleAnnotation("Revoke Invite Link"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleRevokeInviteLink(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupID, err := requireGroupID(request, "group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.groupService.RevokeGroupInviteLink(ctx, domainGroup.RevokeGroupInviteLinkRequest{GroupID: groupID})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Revoked invite link of %s, new link: %s", groupID, resp.InviteLink)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
 func (h *GroupHandler) toolListGroupJoinRequests() mcp.Tool {
 	return mcp.NewTool(
 		"whatsapp_group_join_requests",
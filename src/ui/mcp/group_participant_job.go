@@ -0,0 +1,190 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	mcpHelpers "github.com/aldinokemal/go-whatsapp-web-multidevice/ui/mcp/helpers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type GroupParticipantJobHandler struct {
+	jobService domainGroup.IGroupParticipantJobUsecase
+}
+
+func InitMcpGroupParticipantJob(jobService domainGroup.IGroupParticipantJobUsecase) *GroupParticipantJobHandler {
+	return &GroupParticipantJobHandler{jobService: jobService}
+}
+
+func (h *GroupParticipantJobHandler) AddGroupParticipantJobTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolCreateParticipantJob(), h.handleCreateParticipantJob)
+	mcpServer.AddTool(h.toolParticipantJobStatus(), h.handleParticipantJobStatus)
+	mcpServer.AddTool(h.toolCancelParticipantJob(), h.handleCancelParticipantJob)
+}
+
+func (h *GroupParticipantJobHandler) toolCreateParticipantJob() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_participants_job",
+		mcp.WithDescription("Add, remove, promote or demote a long list of group participants in paced batches. When adding, people whose privacy settings block it get a group invite message instead. Returns a job to poll with whatsapp_group_participants_job_status."),
+		mcp.WithTitleAnnotation("Bulk Participant Job"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("action",
+			mcp.Description("Participant action."),
+			mcp.Enum("add", "remove", "promote", "demote"),
+			mcp.Required(),
+		),
+		mcp.WithArray("participants",
+			mcp.Description("Phone numbers or user JIDs."),
+			mcp.WithStringItems(),
+		),
+		mcp.WithString("participants_csv",
+			mcp.Description("Alternative to participants: CSV text with a header row containing a phone column."),
+		),
+		mcp.WithNumber("batch_size",
+			mcp.Description("Participants per WhatsApp request (default 10, max 50)."),
+			mcp.DefaultNumber(10),
+		),
+		mcp.WithNumber("batch_delay_seconds",
+			mcp.Description("Pause between batches in seconds (default 10, max 600)."),
+			mcp.DefaultNumber(10),
+		),
+		mcp.WithBoolean("invite_fallback",
+			mcp.Description("Send a group invite to people who cannot be added directly (add only, default true)."),
+		),
+		mcp.WithString("invite_message",
+			mcp.Description("Caption of the invite message."),
+		),
+	)
+}
+
+func (h *GroupParticipantJobHandler) handleCreateParticipantJob(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupID, err := requireGroupID(request, "group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	actionStr, err := request.RequireString("action")
+	if err != nil {
+		return nil, err
+	}
+	action, err := parseParticipantChange(actionStr)
+	if err != nil {
+		return nil, err
+	}
+
+	req := domainGroup.CreateParticipantJobRequest{
+		GroupID:           groupID,
+		Action:            action,
+		ParticipantsCSV:   request.GetString("participants_csv", ""),
+		BatchSize:         request.GetInt("batch_size", 0),
+		BatchDelaySeconds: request.GetInt("batch_delay_seconds", 0),
+		InviteMessage:     request.GetString("invite_message", ""),
+	}
+	if args := request.GetArguments(); args != nil {
+		if req.Participants, err = toStringSlice(args["participants"]); err != nil {
+			return nil, err
+		}
+		if raw, ok := args["invite_fallback"]; ok && raw != nil {
+			fallback, err := toBool(raw)
+			if err != nil {
+				return nil, err
+			}
+			req.InviteFallback = &fallback
+		}
+	}
+
+	resp, err := h.jobService.CreateParticipantJob(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Participant job %s started: %s %d participants in %s", resp.ID, resp.Action, resp.Progress.Total, resp.GroupID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *GroupParticipantJobHandler) toolParticipantJobStatus() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_participants_job_status",
+		mcp.WithDescription("Get the progress and per-participant results of a bulk participant job."),
+		mcp.WithTitleAnnotation("Participant Job Status"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("id",
+			mcp.Description("Participant job ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupParticipantJobHandler) handleParticipantJobStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := request.RequireString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.jobService.GetParticipantJob(ctx, domainGroup.ParticipantJobRequest{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	progress := resp.Progress
+	fallback := fmt.Sprintf(
+		"Participant job %s is %s: %d succeeded, %d invited, %d skipped, %d failed, %d pending of %d",
+		resp.ID, resp.Status, progress.Success, progress.Invited, progress.Skipped, progress.Failed, progress.Pending, progress.Total,
+	)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *GroupParticipantJobHandler) toolCancelParticipantJob() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_participants_job_cancel",
+		mcp.WithDescription("Cancel a bulk participant job. The batch in flight finishes; participants not reached yet are marked cancelled."),
+		mcp.WithTitleAnnotation("Cancel Participant Job"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("id",
+			mcp.Description("Participant job ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupParticipantJobHandler) handleCancelParticipantJob(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := request.RequireString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.jobService.CancelParticipantJob(ctx, domainGroup.ParticipantJobRequest{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Participant job %s is now %s", resp.ID, resp.Status)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
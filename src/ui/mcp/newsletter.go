@@ -0,0 +1,406 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
+	mcpHelpers "github.com/aldinokemal/go-whatsapp-web-multidevice/ui/mcp/helpers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type NewsletterHandler struct {
+	newsletterService domainNewsletter.INewsletterUsecase
+}
+
+func InitMcpNewsletter(newsletterService domainNewsletter.INewsletterUsecase) *NewsletterHandler {
+	return &NewsletterHandler{newsletterService: newsletterService}
+}
+
+func (h *NewsletterHandler) AddNewsletterTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolCreate(), h.handleCreate)
+	mcpServer.AddTool(h.toolFollow(), h.handleFollow)
+	mcpServer.AddTool(h.toolUnfollow(), h.handleUnfollow)
+	mcpServer.AddTool(h.toolInfo(), h.handleInfo)
+	mcpServer.AddTool(h.toolMessages(), h.handleMessages)
+	mcpServer.AddTool(h.toolMessageUpdates(), h.handleMessageUpdates)
+	mcpServer.AddTool(h.toolPost(), h.handlePost)
+	mcpServer.AddTool(h.toolReact(), h.handleReact)
+	mcpServer.AddTool(h.toolMute(), h.handleMute)
+}
+
+func newsletterIDOption() mcp.ToolOption {
+	return mcp.WithString("newsletter_id",
+		mcp.Description("Channel JID, e.g. 120363000000000000@newsletter."),
+		mcp.Required(),
+	)
+}
+
+func (h *NewsletterHandler) toolCreate() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_create",
+		mcp.WithDescription("Create a WhatsApp channel owned by this account."),
+		mcp.WithTitleAnnotation("Create Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("name",
+			mcp.Description("Channel name."),
+			mcp.Required(),
+		),
+		mcp.WithString("description",
+			mcp.Description("Optional channel description."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleCreate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Create(ctx, domainNewsletter.CreateRequest{
+		Name:        strings.TrimSpace(name),
+		Description: request.GetString("description", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Created channel %s (%s)", resp.ThreadMeta.Name.Text, resp.ID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolFollow() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_follow",
+		mcp.WithDescription("Follow a WhatsApp channel by JID or invite link."),
+		mcp.WithTitleAnnotation("Follow Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID. Give either this or invite_link."),
+		),
+		mcp.WithString("invite_link",
+			mcp.Description("Channel invite link, e.g. https://whatsapp.com/channel/0029Va..."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleFollow(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Follow(ctx, domainNewsletter.FollowRequest{
+		NewsletterID: strings.TrimSpace(request.GetString("newsletter_id", "")),
+		InviteLink:   strings.TrimSpace(request.GetString("invite_link", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Following channel %s (%s)", resp.ThreadMeta.Name.Text, resp.ID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolUnfollow() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_unfollow",
+		mcp.WithDescription("Stop following a WhatsApp channel."),
+		mcp.WithTitleAnnotation("Unfollow Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		newsletterIDOption(),
+	)
+}
+
+func (h *NewsletterHandler) handleUnfollow(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.newsletterService.Unfollow(ctx, domainNewsletter.UnfollowRequest{NewsletterID: strings.TrimSpace(newsletterID)}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Unfollowed channel %s", newsletterID)), nil
+}
+
+func (h *NewsletterHandler) toolInfo() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_info",
+		mcp.WithDescription("Get a channel's name, description, subscriber count, verification and our role, by JID or invite link."),
+		mcp.WithTitleAnnotation("Channel Info"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID. Give either this or invite_link."),
+		),
+		mcp.WithString("invite_link",
+			mcp.Description("Channel invite link."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Info(ctx, domainNewsletter.InfoRequest{
+		NewsletterID: strings.TrimSpace(request.GetString("newsletter_id", "")),
+		InviteLink:   strings.TrimSpace(request.GetString("invite_link", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Channel %s (%s): %d subscribers", resp.ThreadMeta.Name.Text, resp.ID, resp.ThreadMeta.SubscriberCount)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolMessages() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_messages",
+		mcp.WithDescription("Get a channel's posts, newest first, with their view and reaction counts."),
+		mcp.WithTitleAnnotation("Channel Messages"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		newsletterIDOption(),
+		mcp.WithNumber("count",
+			mcp.Description("Number of posts to return (default 25, max 100)."),
+			mcp.DefaultNumber(25),
+		),
+		mcp.WithNumber("before",
+			mcp.Description("Only return posts with a lower server ID, to page back through history."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleMessages(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Messages(ctx, domainNewsletter.MessagesRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		Count:        request.GetInt("count", 25),
+		Before:       request.GetInt("before", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Found %d posts in channel %s", len(resp.Data), resp.NewsletterID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolMessageUpdates() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_message_updates",
+		mcp.WithDescription("Get the current view and reaction counts of recently updated channel posts."),
+		mcp.WithTitleAnnotation("Channel Message Updates"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		newsletterIDOption(),
+		mcp.WithNumber("count",
+			mcp.Description("Number of posts to return (default 25, max 100)."),
+			mcp.DefaultNumber(25),
+		),
+		mcp.WithNumber("since",
+			mcp.Description("Unix timestamp; only return updates after it."),
+		),
+		mcp.WithNumber("after",
+			mcp.Description("Only return posts with a higher server ID."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleMessageUpdates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.MessageUpdates(ctx, domainNewsletter.MessageUpdatesRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		Count:        request.GetInt("count", 25),
+		Since:        int64(request.GetInt("since", 0)),
+		After:        request.GetInt("after", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Found %d updated posts in channel %s", len(resp.Data), resp.NewsletterID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolPost() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_post",
+		mcp.WithDescription("Publish a text, image or video post to a channel this account administers."),
+		mcp.WithTitleAnnotation("Post To Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		newsletterIDOption(),
+		mcp.WithString("message",
+			mcp.Description("Post text, or the caption of the image or video."),
+		),
+		mcp.WithString("image_url",
+			mcp.Description("URL of a jpg/png image to post."),
+		),
+		mcp.WithString("video_url",
+			mcp.Description("URL of an mp4 video to post."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handlePost(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	postRequest := domainNewsletter.PostRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		Message:      request.GetString("message", ""),
+	}
+	if imageURL := strings.TrimSpace(request.GetString("image_url", "")); imageURL != "" {
+		postRequest.ImageURL = &imageURL
+	}
+	if videoURL := strings.TrimSpace(request.GetString("video_url", "")); videoURL != "" {
+		postRequest.VideoURL = &videoURL
+	}
+
+	resp, err := h.newsletterService.Post(ctx, postRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
+
+func (h *NewsletterHandler) toolReact() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_react",
+		mcp.WithDescription("React to a channel post with an emoji, or remove our reaction."),
+		mcp.WithTitleAnnotation("React To Channel Post"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		newsletterIDOption(),
+		mcp.WithNumber("server_id",
+			mcp.Description("Server ID of the post, as returned by whatsapp_newsletter_messages."),
+			mcp.Required(),
+		),
+		mcp.WithString("reaction",
+			mcp.Description("Emoji to react with; leave empty to remove the reaction."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleReact(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	serverID, err := request.RequireInt("server_id")
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := request.GetString("reaction", "")
+	if err = h.newsletterService.React(ctx, domainNewsletter.ReactRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		ServerID:     serverID,
+		Reaction:     reaction,
+	}); err != nil {
+		return nil, err
+	}
+
+	if reaction == "" {
+		return mcp.NewToolResultText(fmt.Sprintf("Removed reaction from post %d in channel %s", serverID, newsletterID)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Reacted %s to post %d in channel %s", reaction, serverID, newsletterID)), nil
+}
+
+func (h *NewsletterHandler) toolMute() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_mute",
+		mcp.WithDescription("Mute or unmute notifications of a channel."),
+		mcp.WithTitleAnnotation("Mute Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		newsletterIDOption(),
+		mcp.WithBoolean("mute",
+			mcp.Description("true to mute, false to unmute (default true)."),
+			mcp.DefaultBool(true),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleMute(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	mute := request.GetBool("mute", true)
+	if err = h.newsletterService.Mute(ctx, domainNewsletter.MuteRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		Mute:         mute,
+	}); err != nil {
+		return nil, err
+	}
+
+	if mute {
+		return mcp.NewToolResultText(fmt.Sprintf("Muted channel %s", newsletterID)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Unmuted channel %s", newsletterID)), nil
+}
@@ -34,7 +34,10 @@ func (h *QueryHandler) AddQueryTools(mcpServer *server.MCPServer) {
 	mcpServer.AddTool(h.toolListChats(), h.handleListChats)
 	mcpServer.AddTool(h.toolGetChatMessages(), h.handleGetChatMessages)
 	mcpServer.AddTool(h.toolDownloadMedia(), h.handleDownloadMedia)
+	mcpServer.AddTool(h.toolForwardMessage(), h.handleForwardMessage)
+	mcpServer.AddTool(h.toolGetPollResults(), h.handleGetPollResults)
 	mcpServer.AddTool(h.toolArchiveChat(), h.handleArchiveChat)
+	mcpServer.AddTool(h.toolRequestChatHistory(), h.handleRequestChatHistory)
 }
 
 func (h *QueryHandler) toolListContacts() mcp.Tool {
@@ -289,6 +292,106 @@ func (h *QueryHandler) handleDownloadMedia(ctx context.Context, request mcp.Call
 	return mcp.NewToolResultStructured(resp, fallback), nil
 }
 
+func (h *QueryHandler) toolForwardMessage() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_forward_message",
+		mcp.WithDescription("Forward a stored message, including its media, to one or more chats. It is marked as forwarded."),
+		mcp.WithTitleAnnotation("Forward Message"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("message_id",
+			mcp.Description("The WhatsApp message ID to forward."),
+			mcp.Required(),
+		),
+		mcp.WithString("phone",
+			mcp.Description("The chat phone number or JID the message belongs to."),
+			mcp.Required(),
+		),
+		mcp.WithArray("recipients",
+			mcp.Description("Phone numbers or JIDs of the chats to forward to."),
+			mcp.Required(),
+			mcp.WithStringItems(),
+		),
+	)
+}
+
+func (h *QueryHandler) handleForwardMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return nil, err
+	}
+
+	phone, err := request.RequireString("phone")
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := toStringSlice(request.GetArguments()["recipients"])
+	if err != nil {
+		return nil, err
+	}
+
+	utils.SanitizePhone(&phone)
+	for i := range recipients {
+		utils.SanitizePhone(&recipients[i])
+	}
+
+	resp, err := h.messageService.ForwardMessage(ctx, domainMessage.ForwardRequest{
+		MessageID:  messageID,
+		Phone:      phone,
+		Recipients: recipients,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
+
+func (h *QueryHandler) toolGetPollResults() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_get_poll_results",
+		mcp.WithDescription("Get the vote tally and voters of a poll. Votes are counted from the moment gowa saw the poll; a later vote replaces the voter's earlier one."),
+		mcp.WithTitleAnnotation("Get Poll Results"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("message_id",
+			mcp.Description("The message ID of the poll."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *QueryHandler) handleGetPollResults(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messageID, err := request.RequireString("message_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.messageService.GetPollResults(ctx, domainMessage.PollResultsRequest{MessageID: messageID})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Poll %q has %d voters", resp.Question, resp.TotalVoters)
+	for _, option := range resp.Options {
+		fallback += fmt.Sprintf("\n- %s: %d", option.Name, option.Votes)
+	}
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
 func toBool(value any) (bool, error) {
 	switch v := value.(type) {
 	case bool:
@@ -366,3 +469,51 @@ func (h *QueryHandler) handleArchiveChat(ctx context.Context, request mcp.CallTo
 	fallback := resp.Message
 	return mcp.NewToolResultStructured(resp, fallback), nil
 }
+
+func (h *QueryHandler) toolRequestChatHistory() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_request_chat_history",
+		mcp.WithDescription("Ask the primary phone for messages older than a known message in a chat. Messages arrive asynchronously; fetch them afterwards with whatsapp_get_chat_messages."),
+		mcp.WithTitleAnnotation("Request Chat History"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("chat_jid",
+			mcp.Description("The chat JID (e.g., 628123456789@s.whatsapp.net or group@g.us)."),
+			mcp.Required(),
+		),
+		mcp.WithString("message_id",
+			mcp.Description("Known message to load history before. Defaults to the oldest stored message of the chat."),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of older messages to request (default 50, max 500)."),
+			mcp.DefaultNumber(50),
+		),
+	)
+}
+
+func (h *QueryHandler) handleRequestChatHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	req := domainChat.RequestHistoryRequest{
+		ChatJID:   chatJID,
+		MessageID: strings.TrimSpace(request.GetString("message_id", "")),
+		Count:     request.GetInt("count", 50),
+	}
+
+	resp, err := h.chatService.RequestHistory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Requested %d messages before %s in %s", resp.Count, resp.OldestMessageID, resp.ChatJID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
@@ -0,0 +1,231 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainSchedule "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/schedule"
+	mcpHelpers "github.com/aldinokemal/go-whatsapp-web-multidevice/ui/mcp/helpers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type ScheduleHandler struct {
+	scheduleService domainSchedule.IScheduleUsecase
+}
+
+func InitMcpSchedule(scheduleService domainSchedule.IScheduleUsecase) *ScheduleHandler {
+	return &ScheduleHandler{scheduleService: scheduleService}
+}
+
+func (h *ScheduleHandler) AddScheduleTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolScheduleMessage(), h.handleScheduleMessage)
+	mcpServer.AddTool(h.toolListScheduledMessages(), h.handleListScheduledMessages)
+	mcpServer.AddTool(h.toolRescheduleMessage(), h.handleRescheduleMessage)
+	mcpServer.AddTool(h.toolCancelScheduledMessage(), h.handleCancelScheduledMessage)
+}
+
+func (h *ScheduleHandler) toolScheduleMessage() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_schedule_message",
+		mcp.WithDescription("Schedule any send request (text, image, file, video, audio, sticker, contact, link, location, poll) to be sent at a future time."),
+		mcp.WithTitleAnnotation("Schedule Message"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("type",
+			mcp.Description("Message type, matching the /send/* endpoint name."),
+			mcp.Enum(
+				domainSchedule.TypeText, domainSchedule.TypeImage, domainSchedule.TypeFile, domainSchedule.TypeVideo,
+				domainSchedule.TypeAudio, domainSchedule.TypeSticker, domainSchedule.TypeContact, domainSchedule.TypeLink,
+				domainSchedule.TypeLocation, domainSchedule.TypePoll,
+			),
+			mcp.Required(),
+		),
+		mcp.WithString("send_at",
+			mcp.Description("When to send: RFC3339 (2026-03-01T09:00:00+07:00) or a local time (2026-03-01T09:00:00) interpreted in timezone."),
+			mcp.Required(),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA timezone for a local send_at, e.g. Asia/Jakarta (default UTC)."),
+		),
+		mcp.WithObject("payload",
+			mcp.Description("The same JSON body the matching /send/* endpoint accepts, e.g. {\"phone\":\"628123456789\",\"message\":\"Hello\"}."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ScheduleHandler) handleScheduleMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messageType, err := request.RequireString("type")
+	if err != nil {
+		return nil, err
+	}
+
+	sendAt, err := request.RequireString("send_at")
+	if err != nil {
+		return nil, err
+	}
+
+	var payload json.RawMessage
+	if args := request.GetArguments(); args != nil {
+		if raw, ok := args["payload"]; ok && raw != nil {
+			payload, err = json.Marshal(raw)
+			if err != nil {
+				return nil, fmt.Errorf("payload must be a JSON object: %w", err)
+			}
+		}
+	}
+
+	resp, err := h.scheduleService.CreateSchedule(ctx, domainSchedule.CreateScheduleRequest{
+		Type:     messageType,
+		SendAt:   sendAt,
+		Timezone: request.GetString("timezone", ""),
+		Payload:  payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Scheduled %s message %s for %s", resp.Type, resp.ID, resp.SendAt.Format("2006-01-02T15:04:05Z07:00"))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *ScheduleHandler) toolListScheduledMessages() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_list_scheduled_messages",
+		mcp.WithDescription("List scheduled messages for the current device, ordered by send time."),
+		mcp.WithTitleAnnotation("List Scheduled Messages"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("status",
+			mcp.Description("Filter by status."),
+			mcp.Enum(
+				domainSchedule.StatusPending, domainSchedule.StatusSending, domainSchedule.StatusSent,
+				domainSchedule.StatusFailed, domainSchedule.StatusCancelled,
+			),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of scheduled messages to return (default 25, max 100)."),
+			mcp.DefaultNumber(25),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of scheduled messages to skip (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *ScheduleHandler) handleListScheduledMessages(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := domainSchedule.ListScheduleRequest{
+		Status: request.GetString("status", ""),
+		Limit:  request.GetInt("limit", 25),
+		Offset: request.GetInt("offset", 0),
+	}
+
+	resp, err := h.scheduleService.ListSchedules(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d of %d scheduled messages", len(resp.Data), resp.Total)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *ScheduleHandler) toolRescheduleMessage() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_reschedule_message",
+		mcp.WithDescription("Move a pending scheduled message to a new send time."),
+		mcp.WithTitleAnnotation("Reschedule Message"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("id",
+			mcp.Description("Scheduled message ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("send_at",
+			mcp.Description("New send time: RFC3339 or a local time interpreted in timezone."),
+			mcp.Required(),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA timezone for a local send_at (default UTC)."),
+		),
+	)
+}
+
+func (h *ScheduleHandler) handleRescheduleMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := request.RequireString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	sendAt, err := request.RequireString("send_at")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.scheduleService.Reschedule(ctx, domainSchedule.RescheduleRequest{
+		ID:       id,
+		SendAt:   sendAt,
+		Timezone: request.GetString("timezone", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Rescheduled message %s for %s", resp.ID, resp.SendAt.Format("2006-01-02T15:04:05Z07:00"))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *ScheduleHandler) toolCancelScheduledMessage() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_cancel_scheduled_message",
+		mcp.WithDescription("Cancel a pending scheduled message so it is never sent."),
+		mcp.WithTitleAnnotation("Cancel Scheduled Message"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("id",
+			mcp.Description("Scheduled message ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ScheduleHandler) handleCancelScheduledMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := request.RequireString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.scheduleService.CancelSchedule(ctx, domainSchedule.CancelScheduleRequest{ID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Cancelled scheduled message %s", resp.ID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
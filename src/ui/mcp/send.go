@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -26,8 +27,15 @@ func (s *SendHandler) AddSendTools(mcpServer *server.MCPServer) {
 	mcpServer.AddTool(s.toolSendContact(), s.handleSendContact)
 	mcpServer.AddTool(s.toolSendLink(), s.handleSendLink)
 	mcpServer.AddTool(s.toolSendLocation(), s.handleSendLocation)
+	mcpServer.AddTool(s.toolStartLiveLocation(), s.handleStartLiveLocation)
+	mcpServer.AddTool(s.toolUpdateLiveLocation(), s.handleUpdateLiveLocation)
+	mcpServer.AddTool(s.toolStopLiveLocation(), s.handleStopLiveLocation)
 	mcpServer.AddTool(s.toolSendImage(), s.handleSendImage)
+	mcpServer.AddTool(s.toolSendVideo(), s.handleSendVideo)
+	mcpServer.AddTool(s.toolSendAudio(), s.handleSendAudio)
+	mcpServer.AddTool(s.toolSendAlbum(), s.handleSendAlbum)
 	mcpServer.AddTool(s.toolSendSticker(), s.handleSendSticker)
+	mcpServer.AddTool(s.toolSendStickerPack(), s.handleSendStickerPack)
 }
 
 func (s *SendHandler) toolSendText() mcp.Tool {
@@ -38,18 +46,15 @@ func (s *SendHandler) toolSendText() mcp.Tool {
 			mcp.Description("Phone number or group ID to send message to"),
 		),
 		mcp.WithString("message",
-			mcp.Required(),
-			mcp.Description("The text message to send."),
+			mcp.Description("The text message to send. Optional when template_id is set."),
 		),
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
-		mcp.WithString("reply_message_id",
-			mcp.Description("Message ID to reply to (optional)"),
-		),
-		mcp.WithArray("mentions",
-			mcp.Description("List of phone numbers or JIDs to mention (ghost mentions - users will be notified but @phone won't appear in message text). Use \"@everyone\" to mention all group participants. Example: [\"628123456789\", \"@everyone\"]"),
-		),
+		replyMessageIDOption(),
+		mentionsOption(),
+		templateIDOption(),
+		templateVariablesOption(),
 	)
 
 	return sendTextTool
@@ -66,8 +71,10 @@ func (s *SendHandler) handleSendText(ctx context.Context, request mcp.CallToolRe
 		return nil, errors.New("phone must be a string")
 	}
 
+	templateID, variables := templateArguments(request)
+
 	message, ok := request.GetArguments()["message"].(string)
-	if !ok {
+	if !ok && templateID == "" {
 		return nil, errors.New("message must be a string")
 	}
 
@@ -76,29 +83,18 @@ func (s *SendHandler) handleSendText(ctx context.Context, request mcp.CallToolRe
 		isForwarded = false
 	}
 
-	replyMessageId, ok := request.GetArguments()["reply_message_id"].(string)
-	if !ok {
-		replyMessageId = ""
-	}
-
-	// Parse mentions array (ghost mentions)
-	var mentions []string
-	if mentionsRaw, ok := request.GetArguments()["mentions"].([]interface{}); ok {
-		for _, m := range mentionsRaw {
-			if mentionStr, ok := m.(string); ok {
-				mentions = append(mentions, mentionStr)
-			}
-		}
-	}
+	replyMessageID, mentions := replyArguments(request)
 
 	res, err := s.sendService.SendText(ctx, domainSend.MessageRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			ReplyMessageID: replyMessageID,
+			Mentions:       mentions,
+			TemplateID:     templateID,
+			Variables:      variables,
 		},
-		Message:        message,
-		ReplyMessageID: &replyMessageId,
-		Mentions:       mentions,
+		Message: message,
 	})
 
 	if err != nil {
@@ -110,22 +106,40 @@ func (s *SendHandler) handleSendText(ctx context.Context, request mcp.CallToolRe
 
 func (s *SendHandler) toolSendContact() mcp.Tool {
 	sendContactTool := mcp.NewTool("whatsapp_send_contact",
-		mcp.WithDescription("Send a contact card to a WhatsApp contact or group."),
+		mcp.WithDescription("Send one or more contact cards to a WhatsApp contact or group. Use contact_name/contact_phone for a simple contact, or contacts for full cards; several contacts are sent as one message."),
 		mcp.WithString("phone",
 			mcp.Required(),
 			mcp.Description("Phone number or group ID to send contact to"),
 		),
 		mcp.WithString("contact_name",
-			mcp.Required(),
-			mcp.Description("Name of the contact to send"),
+			mcp.Description("Name of a simple contact to send"),
 		),
 		mcp.WithString("contact_phone",
-			mcp.Required(),
-			mcp.Description("Phone number of the contact to send"),
+			mcp.Description("Phone number of a simple contact to send"),
+		),
+		mcp.WithArray("contacts",
+			mcp.Description("Full contact cards: {\"name\":\"...\",\"phones\":[{\"number\":\"62812...\",\"type\":\"CELL\"}],\"emails\":[{\"address\":\"...\",\"type\":\"WORK\"}],\"organization\":\"...\",\"title\":\"...\",\"url\":\"...\"} or {\"vcard\":\"BEGIN:VCARD...\"}"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name":         map[string]any{"type": "string"},
+					"phones":       map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+					"emails":       map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+					"organization": map[string]any{"type": "string"},
+					"title":        map[string]any{"type": "string"},
+					"address":      map[string]any{"type": "object"},
+					"url":          map[string]any{"type": "string"},
+					"vcard":        map[string]any{"type": "string"},
+				},
+			}),
 		),
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
+		replyMessageIDOption(),
+		mentionsOption(),
+		templateIDOption(),
+		templateVariablesOption(),
 	)
 
 	return sendContactTool
@@ -142,14 +156,18 @@ func (s *SendHandler) handleSendContact(ctx context.Context, request mcp.CallToo
 		return nil, errors.New("phone must be a string")
 	}
 
-	contactName, ok := request.GetArguments()["contact_name"].(string)
-	if !ok {
-		return nil, errors.New("contact_name must be a string")
-	}
+	contactName := request.GetString("contact_name", "")
+	contactPhone := request.GetString("contact_phone", "")
 
-	contactPhone, ok := request.GetArguments()["contact_phone"].(string)
-	if !ok {
-		return nil, errors.New("contact_phone must be a string")
+	var contacts []domainSend.ContactCard
+	if raw, ok := request.GetArguments()["contacts"]; ok && raw != nil {
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(encoded, &contacts); err != nil {
+			return nil, fmt.Errorf("contacts must be an array of contact cards: %w", err)
+		}
 	}
 
 	isForwarded, ok := request.GetArguments()["is_forwarded"].(bool)
@@ -157,13 +175,21 @@ func (s *SendHandler) handleSendContact(ctx context.Context, request mcp.CallToo
 		isForwarded = false
 	}
 
+	replyMessageID, mentions := replyArguments(request)
+	templateID, variables := templateArguments(request)
+
 	res, err := s.sendService.SendContact(ctx, domainSend.ContactRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			ReplyMessageID: replyMessageID,
+			Mentions:       mentions,
+			TemplateID:     templateID,
+			Variables:      variables,
 		},
 		ContactName:  contactName,
 		ContactPhone: contactPhone,
+		Contacts:     contacts,
 	})
 
 	if err != nil {
@@ -185,12 +211,15 @@ func (s *SendHandler) toolSendLink() mcp.Tool {
 			mcp.Description("URL link to send"),
 		),
 		mcp.WithString("caption",
-			mcp.Required(),
-			mcp.Description("Caption or description for the link"),
+			mcp.Description("Caption or description for the link. Optional when template_id is set."),
 		),
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
+		replyMessageIDOption(),
+		mentionsOption(),
+		templateIDOption(),
+		templateVariablesOption(),
 	)
 
 	return sendLinkTool
@@ -222,10 +251,18 @@ func (s *SendHandler) handleSendLink(ctx context.Context, request mcp.CallToolRe
 		isForwarded = false
 	}
 
+	templateID, variables := templateArguments(request)
+
+	replyMessageID, mentions := replyArguments(request)
+
 	res, err := s.sendService.SendLink(ctx, domainSend.LinkRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			ReplyMessageID: replyMessageID,
+			Mentions:       mentions,
+			TemplateID:     templateID,
+			Variables:      variables,
 		},
 		Link:    link,
 		Caption: caption,
@@ -253,9 +290,19 @@ func (s *SendHandler) toolSendLocation() mcp.Tool {
 			mcp.Required(),
 			mcp.Description("Longitude coordinate (as string)"),
 		),
+		mcp.WithString("name",
+			mcp.Description("Optional place name shown on the pin"),
+		),
+		mcp.WithString("address",
+			mcp.Description("Optional street address shown under the place name"),
+		),
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
+		replyMessageIDOption(),
+		mentionsOption(),
+		templateIDOption(),
+		templateVariablesOption(),
 	)
 
 	return sendLocationTool
@@ -287,13 +334,22 @@ func (s *SendHandler) handleSendLocation(ctx context.Context, request mcp.CallTo
 		isForwarded = false
 	}
 
+	replyMessageID, mentions := replyArguments(request)
+	templateID, variables := templateArguments(request)
+
 	res, err := s.sendService.SendLocation(ctx, domainSend.LocationRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			ReplyMessageID: replyMessageID,
+			Mentions:       mentions,
+			TemplateID:     templateID,
+			Variables:      variables,
 		},
 		Latitude:  latitude,
 		Longitude: longitude,
+		Name:      request.GetString("name", ""),
+		Address:   request.GetString("address", ""),
 	})
 
 	if err != nil {
@@ -303,6 +359,163 @@ func (s *SendHandler) handleSendLocation(ctx context.Context, request mcp.CallTo
 	return mcp.NewToolResultText(fmt.Sprintf("Location sent successfully with ID %s", res.MessageID)), nil
 }
 
+func liveLocationPositionOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithNumber("latitude",
+			mcp.Required(),
+			mcp.Description("Latitude in degrees (-90 to 90)"),
+		),
+		mcp.WithNumber("longitude",
+			mcp.Required(),
+			mcp.Description("Longitude in degrees (-180 to 180)"),
+		),
+		mcp.WithNumber("accuracy_in_meters",
+			mcp.Description("Optional accuracy of the position in meters"),
+		),
+		mcp.WithNumber("speed_in_mps",
+			mcp.Description("Optional speed in meters per second"),
+		),
+		mcp.WithNumber("heading",
+			mcp.Description("Optional heading in degrees clockwise from magnetic north (0-359)"),
+		),
+	}
+}
+
+func liveLocationPosition(request mcp.CallToolRequest) domainSend.LiveLocationPosition {
+	return domainSend.LiveLocationPosition{
+		Latitude:         request.GetFloat("latitude", 0),
+		Longitude:        request.GetFloat("longitude", 0),
+		AccuracyInMeters: uint32(request.GetInt("accuracy_in_meters", 0)),
+		SpeedInMps:       float32(request.GetFloat("speed_in_mps", 0)),
+		Heading:          uint32(request.GetInt("heading", 0)),
+	}
+}
+
+func (s *SendHandler) toolStartLiveLocation() mcp.Tool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription("Start sharing a live location with a WhatsApp contact or group. Move it with whatsapp_update_live_location, or give a feed_url that is polled for positions, and end it with whatsapp_stop_live_location."),
+		mcp.WithString("phone",
+			mcp.Required(),
+			mcp.Description("Phone number or group ID to share the location with"),
+		),
+	}
+	options = append(options, liveLocationPositionOptions()...)
+	options = append(options,
+		mcp.WithString("caption",
+			mcp.Description("Optional caption shown on the live location"),
+		),
+		mcp.WithNumber("share_duration",
+			mcp.Description("How long to share, in seconds (60-28800, default 900)"),
+		),
+		mcp.WithString("feed_url",
+			mcp.Description("Optional URL answering GET with a JSON position, polled while sharing"),
+		),
+		mcp.WithNumber("feed_interval",
+			mcp.Description("Seconds between feed polls (minimum 5, default 30)"),
+		),
+		replyMessageIDOption(),
+		mentionsOption(),
+	)
+
+	return mcp.NewTool("whatsapp_start_live_location", options...)
+}
+
+func (s *SendHandler) handleStartLiveLocation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	phone, err := request.RequireString("phone")
+	if err != nil {
+		return nil, err
+	}
+
+	replyMessageID, mentions := replyArguments(request)
+
+	res, err := s.sendService.StartLiveLocation(ctx, domainSend.LiveLocationRequest{
+		BaseRequest: domainSend.BaseRequest{
+			Phone:          phone,
+			ReplyMessageID: replyMessageID,
+			Mentions:       mentions,
+		},
+		LiveLocationPosition: liveLocationPosition(request),
+		Caption:              request.GetString("caption", ""),
+		ShareDuration:        request.GetInt("share_duration", 0),
+		FeedURL:              request.GetString("feed_url", ""),
+		FeedInterval:         request.GetInt("feed_interval", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(res, fmt.Sprintf("Live location %s shared until %s", res.ShareID, res.ExpiresAt)), nil
+}
+
+func (s *SendHandler) toolUpdateLiveLocation() mcp.Tool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription("Send a new position for a running live location share."),
+		mcp.WithString("share_id",
+			mcp.Required(),
+			mcp.Description("Share ID returned by whatsapp_start_live_location"),
+		),
+	}
+	options = append(options, liveLocationPositionOptions()...)
+
+	return mcp.NewTool("whatsapp_update_live_location", options...)
+}
+
+func (s *SendHandler) handleUpdateLiveLocation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shareID, err := request.RequireString("share_id")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.sendService.UpdateLiveLocation(ctx, domainSend.LiveLocationUpdateRequest{
+		ShareID:              shareID,
+		LiveLocationPosition: liveLocationPosition(request),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(res, res.Status), nil
+}
+
+func (s *SendHandler) toolStopLiveLocation() mcp.Tool {
+	return mcp.NewTool("whatsapp_stop_live_location",
+		mcp.WithDescription("Stop a running live location share. No more positions are sent; the recipient's card ends when its share duration runs out."),
+		mcp.WithString("share_id",
+			mcp.Required(),
+			mcp.Description("Share ID returned by whatsapp_start_live_location"),
+		),
+	)
+}
+
+func (s *SendHandler) handleStopLiveLocation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shareID, err := request.RequireString("share_id")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.sendService.StopLiveLocation(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(res, res.Status), nil
+}
+
 func (s *SendHandler) toolSendImage() mcp.Tool {
 	sendImageTool := mcp.NewTool("whatsapp_send_image",
 		mcp.WithDescription("Send an image to a WhatsApp contact or group."),
@@ -325,6 +538,10 @@ func (s *SendHandler) toolSendImage() mcp.Tool {
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this message is being forwarded (default: false)"),
 		),
+		replyMessageIDOption(),
+		mentionsOption(),
+		templateIDOption(),
+		templateVariablesOption(),
 	)
 
 	return sendImageTool
@@ -341,8 +558,10 @@ func (s *SendHandler) handleSendImage(ctx context.Context, request mcp.CallToolR
 		return nil, errors.New("phone must be a string")
 	}
 
+	templateID, variables := templateArguments(request)
+
 	imageURL, imageURLOk := request.GetArguments()["image_url"].(string)
-	if !imageURLOk {
+	if !imageURLOk && templateID == "" {
 		return nil, errors.New("image_url must be a string")
 	}
 
@@ -366,11 +585,17 @@ func (s *SendHandler) handleSendImage(ctx context.Context, request mcp.CallToolR
 		isForwarded = false
 	}
 
+	replyMessageID, mentions := replyArguments(request)
+
 	// Create image request
 	imageRequest := domainSend.ImageRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			ReplyMessageID: replyMessageID,
+			Mentions:       mentions,
+			TemplateID:     templateID,
+			Variables:      variables,
 		},
 		Caption:  caption,
 		ViewOnce: viewOnce,
@@ -388,6 +613,232 @@ func (s *SendHandler) handleSendImage(ctx context.Context, request mcp.CallToolR
 	return mcp.NewToolResultText(fmt.Sprintf("Image sent successfully with ID %s", res.MessageID)), nil
 }
 
+func (s *SendHandler) toolSendVideo() mcp.Tool {
+	sendVideoTool := mcp.NewTool("whatsapp_send_video",
+		mcp.WithDescription("Send a video, a looping GIF or a round video note to a WhatsApp contact or group. GIF files are converted to MP4 automatically."),
+		mcp.WithString("phone",
+			mcp.Required(),
+			mcp.Description("Phone number or group ID to send video to"),
+		),
+		mcp.WithString("video_url",
+			mcp.Description("URL of the video or GIF to send. Optional when template_id is set."),
+		),
+		mcp.WithString("caption",
+			mcp.Description("Caption for the video; not allowed for video notes"),
+		),
+		mcp.WithBoolean("view_once",
+			mcp.Description("Whether this video should be viewed only once (default: false)"),
+		),
+		mcp.WithBoolean("compress",
+			mcp.Description("Whether to compress the video (default: false)"),
+		),
+		mcp.WithBoolean("gif_playback",
+			mcp.Description("Send the video as a muted, looping GIF (default: false)"),
+		),
+		mcp.WithBoolean("ptv",
+			mcp.Description("Send the video as a round video note, cropped to a square (default: false)"),
+		),
+		mcp.WithBoolean("is_forwarded",
+			mcp.Description("Whether this message is being forwarded (default: false)"),
+		),
+		replyMessageIDOption(),
+		mentionsOption(),
+		templateIDOption(),
+		templateVariablesOption(),
+	)
+
+	return sendVideoTool
+}
+
+func (s *SendHandler) handleSendVideo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	phone, err := request.RequireString("phone")
+	if err != nil {
+		return nil, err
+	}
+
+	templateID, variables := templateArguments(request)
+
+	videoURL := request.GetString("video_url", "")
+	if videoURL == "" && templateID == "" {
+		return nil, errors.New("video_url must be a string")
+	}
+
+	replyMessageID, mentions := replyArguments(request)
+
+	videoRequest := domainSend.VideoRequest{
+		BaseRequest: domainSend.BaseRequest{
+			Phone:          phone,
+			IsForwarded:    request.GetBool("is_forwarded", false),
+			ReplyMessageID: replyMessageID,
+			Mentions:       mentions,
+			TemplateID:     templateID,
+			Variables:      variables,
+		},
+		Caption:     request.GetString("caption", ""),
+		ViewOnce:    request.GetBool("view_once", false),
+		Compress:    request.GetBool("compress", false),
+		GifPlayback: request.GetBool("gif_playback", false),
+		PTV:         request.GetBool("ptv", false),
+	}
+	if videoURL != "" {
+		videoRequest.VideoURL = &videoURL
+	}
+
+	res, err := s.sendService.SendVideo(ctx, videoRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Video sent successfully with ID %s", res.MessageID)), nil
+}
+
+func (s *SendHandler) toolSendAudio() mcp.Tool {
+	sendAudioTool := mcp.NewTool("whatsapp_send_audio",
+		mcp.WithDescription("Send an audio file or voice note to a WhatsApp contact or group."),
+		mcp.WithString("phone",
+			mcp.Required(),
+			mcp.Description("Phone number or group ID to send audio to"),
+		),
+		mcp.WithString("audio_url",
+			mcp.Description("URL of the audio to send. Optional when template_id is set."),
+		),
+		mcp.WithBoolean("ptt",
+			mcp.Description("Send as a voice note; the audio is converted to OGG Opus when needed (default: false)"),
+		),
+		mcp.WithBoolean("view_once",
+			mcp.Description("Whether this audio should be played only once (default: false)"),
+		),
+		mcp.WithBoolean("is_forwarded",
+			mcp.Description("Whether this message is being forwarded (default: false)"),
+		),
+		replyMessageIDOption(),
+		mentionsOption(),
+		templateIDOption(),
+		templateVariablesOption(),
+	)
+
+	return sendAudioTool
+}
+
+func (s *SendHandler) handleSendAudio(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	phone, err := request.RequireString("phone")
+	if err != nil {
+		return nil, err
+	}
+
+	templateID, variables := templateArguments(request)
+
+	audioURL := request.GetString("audio_url", "")
+	if audioURL == "" && templateID == "" {
+		return nil, errors.New("audio_url must be a string")
+	}
+
+	replyMessageID, mentions := replyArguments(request)
+
+	audioRequest := domainSend.AudioRequest{
+		BaseRequest: domainSend.BaseRequest{
+			Phone:          phone,
+			IsForwarded:    request.GetBool("is_forwarded", false),
+			ReplyMessageID: replyMessageID,
+			Mentions:       mentions,
+			TemplateID:     templateID,
+			Variables:      variables,
+		},
+		PTT:      request.GetBool("ptt", false),
+		ViewOnce: request.GetBool("view_once", false),
+	}
+	if audioURL != "" {
+		audioRequest.AudioURL = &audioURL
+	}
+
+	res, err := s.sendService.SendAudio(ctx, audioRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Audio sent successfully with ID %s", res.MessageID)), nil
+}
+
+func (s *SendHandler) toolSendAlbum() mcp.Tool {
+	sendAlbumTool := mcp.NewTool("whatsapp_send_album",
+		mcp.WithDescription("Send several images and videos grouped as one album, each with its own caption."),
+		mcp.WithString("phone",
+			mcp.Required(),
+			mcp.Description("Phone number or group ID to send the album to"),
+		),
+		mcp.WithArray("items",
+			mcp.Required(),
+			mcp.Description("Album items in display order (2-30): {\"type\":\"image\",\"url\":\"https://...\",\"caption\":\"...\"}. type is image or video."),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"type":    map[string]any{"type": "string", "enum": []string{domainSend.AlbumItemImage, domainSend.AlbumItemVideo}},
+					"url":     map[string]any{"type": "string"},
+					"caption": map[string]any{"type": "string"},
+				},
+				"required": []string{"type", "url"},
+			}),
+		),
+		mcp.WithBoolean("compress",
+			mcp.Description("Whether to compress the images and videos (default: false)"),
+		),
+		mcp.WithBoolean("is_forwarded",
+			mcp.Description("Whether this album is being forwarded (default: false)"),
+		),
+		replyMessageIDOption(),
+		mentionsOption(),
+		templateIDOption(),
+		templateVariablesOption(),
+	)
+
+	return sendAlbumTool
+}
+
+func (s *SendHandler) handleSendAlbum(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	phone, ok := request.GetArguments()["phone"].(string)
+	if !ok {
+		return nil, errors.New("phone must be a string")
+	}
+
+	albumRequest := domainSend.AlbumRequest{
+		BaseRequest: domainSend.BaseRequest{Phone: phone},
+	}
+	albumRequest.Compress, _ = request.GetArguments()["compress"].(bool)
+	albumRequest.IsForwarded, _ = request.GetArguments()["is_forwarded"].(bool)
+	albumRequest.ReplyMessageID, albumRequest.Mentions = replyArguments(request)
+	albumRequest.TemplateID, albumRequest.Variables = templateArguments(request)
+
+	encoded, err := json.Marshal(request.GetArguments()["items"])
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(encoded, &albumRequest.Items); err != nil {
+		return nil, fmt.Errorf("items must be an array of {type, url, caption}: %w", err)
+	}
+
+	res, err := s.sendService.SendAlbum(ctx, albumRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(res, fmt.Sprintf("Album sent successfully with ID %s (%d items)", res.MessageID, len(res.MessageIDs))), nil
+}
+
 func (s *SendHandler) toolSendSticker() mcp.Tool {
 	sendStickerTool := mcp.NewTool("whatsapp_send_sticker",
 		mcp.WithDescription("Send a sticker to a WhatsApp contact or group. Images are automatically converted to WebP sticker format."),
@@ -398,9 +849,16 @@ func (s *SendHandler) toolSendSticker() mcp.Tool {
 		mcp.WithString("sticker_url",
 			mcp.Description("URL of the image to convert to sticker and send"),
 		),
+		stickerPackNameOption(),
+		stickerPackPublisherOption(),
+		stickerEmojisOption(),
 		mcp.WithBoolean("is_forwarded",
 			mcp.Description("Whether this is a forwarded sticker"),
 		),
+		replyMessageIDOption(),
+		mentionsOption(),
+		templateIDOption(),
+		templateVariablesOption(),
 	)
 
 	return sendStickerTool
@@ -417,8 +875,10 @@ func (s *SendHandler) handleSendSticker(ctx context.Context, request mcp.CallToo
 		return nil, errors.New("phone must be a string")
 	}
 
+	templateID, variables := templateArguments(request)
+
 	stickerURL, stickerURLOk := request.GetArguments()["sticker_url"].(string)
-	if !stickerURLOk || stickerURL == "" {
+	if (!stickerURLOk || stickerURL == "") && templateID == "" {
 		return nil, errors.New("sticker_url must be a non-empty string")
 	}
 
@@ -427,12 +887,21 @@ func (s *SendHandler) handleSendSticker(ctx context.Context, request mcp.CallToo
 		isForwarded = val
 	}
 
+	replyMessageID, mentions := replyArguments(request)
+
 	stickerRequest := domainSend.StickerRequest{
 		BaseRequest: domainSend.BaseRequest{
-			Phone:       phone,
-			IsForwarded: isForwarded,
+			Phone:          phone,
+			IsForwarded:    isForwarded,
+			ReplyMessageID: replyMessageID,
+			Mentions:       mentions,
+			TemplateID:     templateID,
+			Variables:      variables,
 		},
-		StickerURL: &stickerURL,
+		StickerMetadata: stickerMetadataArguments(request),
+	}
+	if stickerURL != "" {
+		stickerRequest.StickerURL = &stickerURL
 	}
 
 	res, err := s.sendService.SendSticker(ctx, stickerRequest)
@@ -442,3 +911,137 @@ func (s *SendHandler) handleSendSticker(ctx context.Context, request mcp.CallToo
 
 	return mcp.NewToolResultText(fmt.Sprintf("Sticker sent successfully with ID %s", res.MessageID)), nil
 }
+
+func (s *SendHandler) toolSendStickerPack() mcp.Tool {
+	sendStickerPackTool := mcp.NewTool("whatsapp_send_sticker_pack",
+		mcp.WithDescription("Send every image of a zip archive as a sticker of one pack, in file name order. Images are converted to WebP stickers."),
+		mcp.WithString("phone",
+			mcp.Required(),
+			mcp.Description("Phone number or group ID to send the stickers to"),
+		),
+		mcp.WithString("pack_url",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("URL of a zip archive with up to %d png/jpg/webp/gif images", domainSend.StickerPackMaxStickers)),
+		),
+		mcp.WithString("pack_name",
+			mcp.Required(),
+			mcp.Description("Sticker pack name shown with every sticker"),
+		),
+		stickerPackPublisherOption(),
+		stickerEmojisOption(),
+	)
+
+	return sendStickerPackTool
+}
+
+func (s *SendHandler) handleSendStickerPack(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	phone, err := request.RequireString("phone")
+	if err != nil {
+		return nil, err
+	}
+	packURL, err := request.RequireString("pack_url")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.sendService.SendStickerPack(ctx, domainSend.StickerPackRequest{
+		BaseRequest:     domainSend.BaseRequest{Phone: phone},
+		StickerMetadata: stickerMetadataArguments(request),
+		PackURL:         &packURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(res, res.Status), nil
+}
+
+func stickerPackNameOption() mcp.ToolOption {
+	return mcp.WithString("pack_name",
+		mcp.Description("Sticker pack name embedded in the sticker (optional)"),
+	)
+}
+
+func stickerPackPublisherOption() mcp.ToolOption {
+	return mcp.WithString("pack_publisher",
+		mcp.Description("Sticker pack publisher embedded in the sticker (optional)"),
+	)
+}
+
+func stickerEmojisOption() mcp.ToolOption {
+	return mcp.WithArray("emojis",
+		mcp.Description(fmt.Sprintf("Up to %d emojis the sticker is tagged with (optional)", domainSend.StickerMaxEmojis)),
+		mcp.WithStringItems(),
+	)
+}
+
+// stickerMetadataArguments reads the optional pack name, publisher and emojis shared by the sticker tools
+func stickerMetadataArguments(request mcp.CallToolRequest) domainSend.StickerMetadata {
+	return domainSend.StickerMetadata{
+		PackName:      request.GetString("pack_name", ""),
+		PackPublisher: request.GetString("pack_publisher", ""),
+		Emojis:        request.GetStringSlice("emojis", nil),
+	}
+}
+
+func templateIDOption() mcp.ToolOption {
+	return mcp.WithString("template_id",
+		mcp.Description("ID of a stored message template to render into this message (optional)"),
+	)
+}
+
+func templateVariablesOption() mcp.ToolOption {
+	return mcp.WithObject("variables",
+		mcp.Description("Values for the template {{placeholders}}, e.g. {\"name\":\"Budi\"}"),
+	)
+}
+
+// templateArguments reads the optional template_id and variables shared by the send tools
+func templateArguments(request mcp.CallToolRequest) (string, map[string]string) {
+	templateID, _ := request.GetArguments()["template_id"].(string)
+
+	var variables map[string]string
+	if raw, ok := request.GetArguments()["variables"].(map[string]any); ok {
+		variables = make(map[string]string, len(raw))
+		for name, value := range raw {
+			variables[name] = fmt.Sprint(value)
+		}
+	}
+	return templateID, variables
+}
+
+func replyMessageIDOption() mcp.ToolOption {
+	return mcp.WithString("reply_message_id",
+		mcp.Description("Message ID to reply to; the quoted message is looked up in chat storage (optional)"),
+	)
+}
+
+func mentionsOption() mcp.ToolOption {
+	return mcp.WithArray("mentions",
+		mcp.Description("List of phone numbers or JIDs to mention (ghost mentions - users will be notified but @phone won't appear in message text). Use \"@everyone\" to mention all group participants. Example: [\"628123456789\", \"@everyone\"]"),
+		mcp.WithStringItems(),
+	)
+}
+
+// replyArguments reads the optional reply_message_id and mentions shared by the send tools
+func replyArguments(request mcp.CallToolRequest) (*string, []string) {
+	var replyMessageID *string
+	if value, ok := request.GetArguments()["reply_message_id"].(string); ok && value != "" {
+		replyMessageID = &value
+	}
+
+	var mentions []string
+	if raw, ok := request.GetArguments()["mentions"].([]any); ok {
+		for _, mention := range raw {
+			if value, ok := mention.(string); ok {
+				mentions = append(mentions, value)
+			}
+		}
+	}
+	return replyMessageID, mentions
+}
@@ -0,0 +1,278 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	mcpHelpers "github.com/aldinokemal/go-whatsapp-web-multidevice/ui/mcp/helpers"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type StatusHandler struct {
+	statusService domainStatus.IStatusUsecase
+}
+
+func InitMcpStatus(statusService domainStatus.IStatusUsecase) *StatusHandler {
+	return &StatusHandler{statusService: statusService}
+}
+
+func (h *StatusHandler) AddStatusTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolPostText(), h.handlePostText)
+	mcpServer.AddTool(h.toolPostImage(), h.handlePostImage)
+	mcpServer.AddTool(h.toolListStatuses(), h.handleListStatuses)
+	mcpServer.AddTool(h.toolGetStatus(), h.handleGetStatus)
+	mcpServer.AddTool(h.toolMarkViewed(), h.handleMarkViewed)
+	mcpServer.AddTool(h.toolDeleteStatus(), h.handleDeleteStatus)
+}
+
+func (h *StatusHandler) toolPostText() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_post_text",
+		mcp.WithDescription("Post a text status (story). It is shown for 24 hours to the audience chosen in the phone's status privacy settings."),
+		mcp.WithTitleAnnotation("Post Text Status"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("text",
+			mcp.Description("Status text."),
+			mcp.Required(),
+		),
+		mcp.WithString("background_color",
+			mcp.Description("Background colour as #RRGGBB or #AARRGGBB (default #25D366)."),
+		),
+		mcp.WithString("text_color",
+			mcp.Description("Text colour as #RRGGBB or #AARRGGBB."),
+		),
+		mcp.WithString("font",
+			mcp.Description("Status font, e.g. SYSTEM, SYSTEM_BOLD, FB_SCRIPT, MORNINGBREEZE_REGULAR."),
+		),
+	)
+}
+
+func (h *StatusHandler) handlePostText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := request.RequireString("text")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.statusService.PostText(ctx, domainStatus.PostTextRequest{
+		Text:            text,
+		BackgroundColor: request.GetString("background_color", ""),
+		TextColor:       request.GetString("text_color", ""),
+		Font:            request.GetString("font", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Status %s posted, expires at %s", resp.StatusID, resp.ExpiresAt)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *StatusHandler) toolPostImage() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_post_image",
+		mcp.WithDescription("Post an image status (story) from a URL."),
+		mcp.WithTitleAnnotation("Post Image Status"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("image_url",
+			mcp.Description("URL of the image."),
+			mcp.Required(),
+		),
+		mcp.WithString("caption",
+			mcp.Description("Optional caption."),
+		),
+		mcp.WithBoolean("compress",
+			mcp.Description("Compress the image before posting (default true)."),
+			mcp.DefaultBool(true),
+		),
+	)
+}
+
+func (h *StatusHandler) handlePostImage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	imageURL, err := request.RequireString("image_url")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.statusService.PostImage(ctx, domainStatus.PostImageRequest{
+		Caption:  request.GetString("caption", ""),
+		ImageURL: &imageURL,
+		Compress: request.GetBool("compress", true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Image status %s posted, expires at %s", resp.StatusID, resp.ExpiresAt)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *StatusHandler) toolListStatuses() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_list",
+		mcp.WithDescription("List statuses that have not expired yet, newest first: contacts' statuses received on status@broadcast and our own."),
+		mcp.WithTitleAnnotation("List Statuses"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("sender",
+			mcp.Description("Only statuses of this contact (phone number or JID)."),
+		),
+		mcp.WithBoolean("mine",
+			mcp.Description("Only our own statuses."),
+		),
+		mcp.WithBoolean("unviewed_only",
+			mcp.Description("Only statuses not marked as viewed yet."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of statuses to return (default 50)."),
+			mcp.DefaultNumber(50),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of statuses to skip (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *StatusHandler) handleListStatuses(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.statusService.ListStatuses(ctx, domainStatus.ListStatusesRequest{
+		Sender:       request.GetString("sender", ""),
+		Mine:         request.GetBool("mine", false),
+		UnviewedOnly: request.GetBool("unviewed_only", false),
+		Limit:        request.GetInt("limit", 50),
+		Offset:       request.GetInt("offset", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d statuses", len(resp.Data))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *StatusHandler) toolGetStatus() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_get",
+		mcp.WithDescription("Get a status by ID."),
+		mcp.WithTitleAnnotation("Get Status"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("status_id",
+			mcp.Description("Status ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *StatusHandler) handleGetStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statusID, err := request.RequireString("status_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.statusService.GetStatus(ctx, statusID)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("%s status %s from %s", resp.Type, resp.ID, resp.Sender)
+	if resp.Text != "" {
+		fallback += ": " + resp.Text
+	}
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *StatusHandler) toolMarkViewed() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_mark_viewed",
+		mcp.WithDescription("Mark a contact's status as viewed; the contact sees us in its viewer list."),
+		mcp.WithTitleAnnotation("Mark Status Viewed"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("status_id",
+			mcp.Description("Status ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *StatusHandler) handleMarkViewed(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statusID, err := request.RequireString("status_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.statusService.MarkViewed(ctx, statusID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
+
+func (h *StatusHandler) toolDeleteStatus() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_status_delete",
+		mcp.WithDescription("Delete one of our own statuses for everyone."),
+		mcp.WithTitleAnnotation("Delete Status"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("status_id",
+			mcp.Description("Status ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *StatusHandler) handleDeleteStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ctx, err := mcpHelpers.ContextWithDefaultDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statusID, err := request.RequireString("status_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.statusService.DeleteStatus(ctx, statusID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
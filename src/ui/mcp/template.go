@@ -0,0 +1,221 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainTemplate "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/template"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type TemplateHandler struct {
+	templateService domainTemplate.ITemplateUsecase
+}
+
+func InitMcpTemplate(templateService domainTemplate.ITemplateUsecase) *TemplateHandler {
+	return &TemplateHandler{templateService: templateService}
+}
+
+func (h *TemplateHandler) AddTemplateTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolCreateTemplate(), h.handleCreateTemplate)
+	mcpServer.AddTool(h.toolListTemplates(), h.handleListTemplates)
+	mcpServer.AddTool(h.toolGetTemplate(), h.handleGetTemplate)
+	mcpServer.AddTool(h.toolUpdateTemplate(), h.handleUpdateTemplate)
+	mcpServer.AddTool(h.toolDeleteTemplate(), h.handleDeleteTemplate)
+}
+
+// templateContentOptions are the editable template fields shared by create and update
+func templateContentOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("name",
+			mcp.Description("Template name."),
+			mcp.Required(),
+		),
+		mcp.WithString("body",
+			mcp.Description("Message text with {{variable}} placeholders, e.g. \"Hi {{name}}, your order {{order}} shipped\". Required unless media_url is set."),
+		),
+		mcp.WithString("media_type",
+			mcp.Description("Type of the optional attachment."),
+			mcp.Enum(domainTemplate.MediaImage, domainTemplate.MediaVideo, domainTemplate.MediaFile, domainTemplate.MediaAudio, domainTemplate.MediaSticker),
+		),
+		mcp.WithString("media_url",
+			mcp.Description("URL of the optional attachment; may contain placeholders."),
+		),
+		mcp.WithString("caption",
+			mcp.Description("Default caption for media sends; the body is used when empty."),
+		),
+	}
+}
+
+func (h *TemplateHandler) toolCreateTemplate() mcp.Tool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription("Create a reusable message template. Send tools accept its ID as template_id together with variables."),
+		mcp.WithTitleAnnotation("Create Template"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+	}
+	return mcp.NewTool("whatsapp_template_create", append(options, templateContentOptions()...)...)
+}
+
+func (h *TemplateHandler) handleCreateTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.templateService.CreateTemplate(ctx, domainTemplate.CreateTemplateRequest{
+		Name:      name,
+		Body:      request.GetString("body", ""),
+		MediaType: request.GetString("media_type", ""),
+		MediaURL:  request.GetString("media_url", ""),
+		Caption:   request.GetString("caption", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Template %s created with ID %s", resp.Name, resp.ID)
+	if len(resp.Variables) > 0 {
+		fallback += fmt.Sprintf(" (variables: %s)", strings.Join(resp.Variables, ", "))
+	}
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *TemplateHandler) toolListTemplates() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_template_list",
+		mcp.WithDescription("List message templates with the variables each one needs."),
+		mcp.WithTitleAnnotation("List Templates"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("search",
+			mcp.Description("Filter by template name."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of templates to return (default 25)."),
+			mcp.DefaultNumber(25),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of templates to skip (default 0)."),
+			mcp.DefaultNumber(0),
+		),
+	)
+}
+
+func (h *TemplateHandler) handleListTemplates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resp, err := h.templateService.ListTemplates(ctx, domainTemplate.ListTemplatesRequest{
+		Search: request.GetString("search", ""),
+		Limit:  request.GetInt("limit", 25),
+		Offset: request.GetInt("offset", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Retrieved %d of %d templates", len(resp.Data), resp.Total)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *TemplateHandler) toolGetTemplate() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_template_get",
+		mcp.WithDescription("Get a message template by ID."),
+		mcp.WithTitleAnnotation("Get Template"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("id",
+			mcp.Description("Template ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *TemplateHandler) handleGetTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.templateService.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Template %s: %s", resp.Name, resp.Body)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *TemplateHandler) toolUpdateTemplate() mcp.Tool {
+	options := []mcp.ToolOption{
+		mcp.WithDescription("Replace the content of a message template."),
+		mcp.WithTitleAnnotation("Update Template"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("id",
+			mcp.Description("Template ID."),
+			mcp.Required(),
+		),
+	}
+	return mcp.NewTool("whatsapp_template_update", append(options, templateContentOptions()...)...)
+}
+
+func (h *TemplateHandler) handleUpdateTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.templateService.UpdateTemplate(ctx, domainTemplate.UpdateTemplateRequest{
+		ID:        id,
+		Name:      name,
+		Body:      request.GetString("body", ""),
+		MediaType: request.GetString("media_type", ""),
+		MediaURL:  request.GetString("media_url", ""),
+		Caption:   request.GetString("caption", ""),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Template %s updated", resp.ID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *TemplateHandler) toolDeleteTemplate() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_template_delete",
+		mcp.WithDescription("Delete a message template."),
+		mcp.WithTitleAnnotation("Delete Template"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("id",
+			mcp.Description("Template ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *TemplateHandler) handleDeleteTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.templateService.DeleteTemplate(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Template %s deleted", id)), nil
+}
@@ -26,6 +26,13 @@ func InitRestApp(app fiber.Router, service domainApp.IAppUsecase) App {
 	return App{Service: service}
 }
 
+// InitRestAppStats registers process-wide endpoints that do not belong to a device
+func InitRestAppStats(app fiber.Router, service domainApp.IAppUsecase) App {
+	rest := App{Service: service}
+	app.Get("/app/transcoder", rest.TranscoderStats)
+	return rest
+}
+
 func (handler *App) Login(c *fiber.Ctx) error {
 	device, err := getDeviceInstance(c)
 	if err != nil {
@@ -145,3 +152,15 @@ func getDeviceInstance(c *fiber.Ctx) (*whatsapp.DeviceInstance, error) {
 	}
 	return device, nil
 }
+
+func (handler *App) TranscoderStats(c *fiber.Ctx) error {
+	stats, err := handler.Service.TranscoderStats(c.UserContext())
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Transcoder stats retrieved",
+		Results: stats,
+	})
+}
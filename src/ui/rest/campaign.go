@@ -0,0 +1,189 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	domainCampaign "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/campaign"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Campaign struct {
+	Service domainCampaign.ICampaignUsecase
+}
+
+func InitRestCampaign(app fiber.Router, service domainCampaign.ICampaignUsecase) Campaign {
+	rest := Campaign{Service: service}
+
+	app.Post("/campaigns", rest.CreateCampaign)
+	app.Get("/campaigns", rest.ListCampaigns)
+	app.Get("/campaigns/:id", rest.GetCampaign)
+	app.Get("/campaigns/:id/recipients", rest.ListRecipients)
+	app.Post("/campaigns/:id/pause", rest.PauseCampaign)
+	app.Post("/campaigns/:id/resume", rest.ResumeCampaign)
+	app.Post("/campaigns/:id/cancel", rest.CancelCampaign)
+
+	return rest
+}
+
+func (controller *Campaign) CreateCampaign(c *fiber.Ctx) error {
+	var request domainCampaign.CreateCampaignRequest
+
+	if strings.HasPrefix(string(c.Request().Header.ContentType()), fiber.MIMEMultipartForm) {
+		utils.PanicIfNeeded(parseCampaignForm(c, &request))
+	} else {
+		err := c.BodyParser(&request)
+		utils.PanicIfNeeded(err)
+	}
+
+	response, err := controller.Service.CreateCampaign(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Campaign started",
+		Results: response,
+	})
+}
+
+// parseCampaignForm reads a multipart campaign: payload is a JSON string and recipients_file a .csv or .json upload
+func parseCampaignForm(c *fiber.Ctx, request *domainCampaign.CreateCampaignRequest) error {
+	request.Name = c.FormValue("name")
+	request.Type = c.FormValue("type")
+	request.QuietHoursStart = c.FormValue("quiet_hours_start")
+	request.QuietHoursEnd = c.FormValue("quiet_hours_end")
+	request.Timezone = c.FormValue("timezone")
+	request.RecipientsCSV = c.FormValue("recipients_csv")
+	if payload := c.FormValue("payload"); payload != "" {
+		request.Payload = json.RawMessage(payload)
+	}
+	for field, target := range map[string]*int{"rate_per_minute": &request.RatePerMinute, "jitter_seconds": &request.JitterSeconds} {
+		if value := c.FormValue(field); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return pkgError.ValidationError(field + ": must be a number.")
+			}
+			*target = parsed
+		}
+	}
+
+	file, err := c.FormFile("recipients_file")
+	if err != nil {
+		// The file is optional when recipients_csv is sent as a field
+		return nil
+	}
+	opened, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer opened.Close()
+	content, err := io.ReadAll(opened)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(file.Filename), ".json") {
+		if err := json.Unmarshal(content, &request.Recipients); err != nil {
+			return pkgError.ValidationError("recipients_file: must be a JSON array of {phone, variables}.")
+		}
+		return nil
+	}
+	request.RecipientsCSV = string(content)
+	return nil
+}
+
+func (controller *Campaign) ListCampaigns(c *fiber.Ctx) error {
+	var request domainCampaign.ListCampaignsRequest
+	request.Status = c.Query("status", "")
+	request.Limit = c.QueryInt("limit", 25)
+	request.Offset = c.QueryInt("offset", 0)
+
+	response, err := controller.Service.ListCampaigns(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Campaigns retrieved",
+		Results: response,
+	})
+}
+
+func (controller *Campaign) GetCampaign(c *fiber.Ctx) error {
+	response, err := controller.Service.GetCampaign(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), c.Params("id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Campaign progress retrieved",
+		Results: response,
+	})
+}
+
+func (controller *Campaign) ListRecipients(c *fiber.Ctx) error {
+	var request domainCampaign.ListRecipientsRequest
+	request.CampaignID = c.Params("id")
+	request.Status = c.Query("status", "")
+	request.Limit = c.QueryInt("limit", 100)
+	request.Offset = c.QueryInt("offset", 0)
+
+	response, err := controller.Service.ListRecipients(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Campaign recipients retrieved",
+		Results: response,
+	})
+}
+
+func (controller *Campaign) PauseCampaign(c *fiber.Ctx) error {
+	request := domainCampaign.CampaignActionRequest{ID: c.Params("id")}
+
+	response, err := controller.Service.PauseCampaign(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Campaign paused",
+		Results: response,
+	})
+}
+
+func (controller *Campaign) ResumeCampaign(c *fiber.Ctx) error {
+	request := domainCampaign.CampaignActionRequest{ID: c.Params("id")}
+
+	response, err := controller.Service.ResumeCampaign(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Campaign resumed",
+		Results: response,
+	})
+}
+
+func (controller *Campaign) CancelCampaign(c *fiber.Ctx) error {
+	request := domainCampaign.CampaignActionRequest{ID: c.Params("id")}
+
+	response, err := controller.Service.CancelCampaign(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Campaign cancelled",
+		Results: response,
+	})
+}
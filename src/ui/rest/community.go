@@ -0,0 +1,140 @@
+package rest
+
+import (
+	"fmt"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Community struct {
+	Service domainGroup.IGroupUsecase
+}
+
+func InitRestCommunity(app fiber.Router, service domainGroup.IGroupUsecase) Community {
+	rest := Community{Service: service}
+	app.Post("/community", rest.CreateCommunity)
+	app.Get("/community/info", rest.CommunityInfo)
+	app.Get("/community/groups", rest.ListGroups)
+	app.Post("/community/groups", rest.CreateGroup)
+	app.Post("/community/groups/link", rest.LinkGroups)
+	app.Post("/community/groups/unlink", rest.UnlinkGroups)
+	return rest
+}
+
+func (controller *Community) CreateCommunity(c *fiber.Ctx) error {
+	var request domainGroup.CreateCommunityRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.CreateCommunity(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Success created community with id %s", response.CommunityID),
+		Results: response,
+	})
+}
+
+func (controller *Community) CommunityInfo(c *fiber.Ctx) error {
+	var request domainGroup.CommunityRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.CommunityID)
+
+	response, err := controller.Service.CommunityInfo(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get community info",
+		Results: response,
+	})
+}
+
+func (controller *Community) ListGroups(c *fiber.Ctx) error {
+	var request domainGroup.CommunityRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.CommunityID)
+
+	response, err := controller.Service.GetCommunitySubGroups(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get community groups",
+		Results: response,
+	})
+}
+
+func (controller *Community) CreateGroup(c *fiber.Ctx) error {
+	var request domainGroup.CreateCommunityGroupRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.CommunityID)
+
+	groupID, err := controller.Service.CreateCommunityGroup(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Success created group with id %s in community %s", groupID, request.CommunityID),
+		Results: map[string]string{
+			"group_id":     groupID,
+			"community_id": request.CommunityID,
+		},
+	})
+}
+
+func (controller *Community) LinkGroups(c *fiber.Ctx) error {
+	var request domainGroup.CommunityGroupsRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+	sanitizeCommunityGroups(&request)
+
+	result, err := controller.Service.LinkCommunityGroups(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success link groups to community",
+		Results: result,
+	})
+}
+
+func (controller *Community) UnlinkGroups(c *fiber.Ctx) error {
+	var request domainGroup.CommunityGroupsRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+	sanitizeCommunityGroups(&request)
+
+	result, err := controller.Service.UnlinkCommunityGroups(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success unlink groups from community",
+		Results: result,
+	})
+}
+
+// sanitizeCommunityGroups turns bare numeric IDs into group JIDs
+func sanitizeCommunityGroups(request *domainGroup.CommunityGroupsRequest) {
+	utils.SanitizePhone(&request.CommunityID)
+	for i := range request.GroupIDs {
+		utils.SanitizePhone(&request.GroupIDs[i])
+	}
+}
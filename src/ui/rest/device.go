@@ -1,6 +1,9 @@
 package rest
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/domains/device"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/gofiber/fiber/v2"
@@ -24,6 +27,7 @@ func InitRestDevice(app fiber.Router, service device.IDeviceUsecase) Device {
 	app.Post("/devices/:device_id/logout", rest.LogoutDevice)
 	app.Post("/devices/:device_id/reconnect", rest.ReconnectDevice)
 	app.Get("/devices/:device_id/status", rest.Status)
+	app.Post("/devices/:device_id/history/replay", rest.ReplayHistory)
 
 	return rest
 }
@@ -170,3 +174,17 @@ func (handler *Device) Status(c *fiber.Ctx) error {
 		},
 	})
 }
+
+func (handler *Device) ReplayHistory(c *fiber.Ctx) error {
+	deviceID := c.Params("device_id")
+	// Replaying large dumps can outlive the request timeout; the work itself is not tied to the client
+	result, err := handler.Service.ReplayHistory(context.WithoutCancel(c.UserContext()), deviceID)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Replayed %d of %d history dump(s)", result.Replayed, result.Files),
+		Results: result,
+	})
+}
@@ -25,6 +25,7 @@ func InitRestGroup(app fiber.Router, service domainGroup.IGroupUsecase) Group {
 	app.Post("/group/join-with-link", rest.JoinGroupWithLink)
 	app.Get("/group/info-from-link", rest.GetGroupInfoFromLink)
 	app.Get("/group/info", rest.GroupInfo)
+	app.Get("/group/history", rest.GroupHistory)
 	app.Post("/group/leave", rest.LeaveGroup)
 	app.Get("/group/participants", rest.ListParticipants)
 	app.Get("/group/participants/export", rest.ExportParticipants)
@@ -40,7 +41,12 @@ func InitRestGroup(app fiber.Router, service domainGroup.IGroupUsecase) Group {
 	app.Post("/group/locked", rest.SetGroupLocked)
 	app.Post("/group/announce", rest.SetGroupAnnounce)
 	app.Post("/group/topic", rest.SetGroupTopic)
+	app.Post("/group/description", rest.SetGroupDescription)
+	app.Post("/group/join-approval", rest.SetGroupJoinApproval)
+	app.Post("/group/member-add-mode", rest.SetGroupMemberAddMode)
+	app.Post("/group/disappearing", rest.SetGroupDisappearing)
 	app.Get("/group/invite-link", rest.GetGroupInviteLink)
+	app.Post("/group/invite-link/revoke", rest.RevokeGroupInviteLink)
 	return rest
 }
 
@@ -408,6 +414,90 @@ func (controller *Group) SetGroupTopic(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *Group) SetGroupDescription(c *fiber.Ctx) error {
+	var request domainGroup.SetGroupDescriptionRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	response, err := controller.Service.SetGroupDescription(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success update group description"
+	if request.Description == "" {
+		message = "Success remove group description"
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+		Results: response,
+	})
+}
+
+func (controller *Group) SetGroupJoinApproval(c *fiber.Ctx) error {
+	var request domainGroup.SetGroupJoinApprovalRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	err = controller.Service.SetGroupJoinApproval(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success disable join approval"
+	if request.Enabled {
+		message = "Success enable join approval"
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+	})
+}
+
+func (controller *Group) SetGroupMemberAddMode(c *fiber.Ctx) error {
+	var request domainGroup.SetGroupMemberAddModeRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	err = controller.Service.SetGroupMemberAddMode(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Success set member add mode to %s", request.Mode),
+	})
+}
+
+func (controller *Group) SetGroupDisappearing(c *fiber.Ctx) error {
+	var request domainGroup.SetGroupDisappearingRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	err = controller.Service.SetGroupDisappearing(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success disable disappearing messages"
+	if request.TimerSeconds > 0 {
+		message = fmt.Sprintf("Success set disappearing messages to %d seconds", request.TimerSeconds)
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+	})
+}
+
 // GroupInfo handles the /group/info endpoint to fetch group information
 func (controller *Group) GroupInfo(c *fiber.Ctx) error {
 	var request domainGroup.GroupInfoRequest
@@ -427,6 +517,25 @@ func (controller *Group) GroupInfo(c *fiber.Ctx) error {
 	})
 }
 
+// GroupHistory handles the /group/history endpoint to list recorded membership and settings changes
+func (controller *Group) GroupHistory(c *fiber.Ctx) error {
+	var request domainGroup.GroupHistoryRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	response, err := controller.Service.GroupHistory(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get group history",
+		Results: response,
+	})
+}
+
 func (controller *Group) GetGroupInviteLink(c *fiber.Ctx) error {
 	var request domainGroup.GetGroupInviteLinkRequest
 	err := c.QueryParser(&request)
@@ -444,3 +553,21 @@ func (controller *Group) GetGroupInviteLink(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+func (controller *Group) RevokeGroupInviteLink(c *fiber.Ctx) error {
+	var request domainGroup.RevokeGroupInviteLinkRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	response, err := controller.Service.RevokeGroupInviteLink(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success revoke group invite link",
+		Results: response,
+	})
+}
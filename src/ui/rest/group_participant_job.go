@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow"
+)
+
+type GroupParticipantJob struct {
+	Service domainGroup.IGroupParticipantJobUsecase
+}
+
+func InitRestGroupParticipantJob(app fiber.Router, service domainGroup.IGroupParticipantJobUsecase) GroupParticipantJob {
+	rest := GroupParticipantJob{Service: service}
+	app.Post("/group/participants/jobs", rest.CreateJob)
+	app.Get("/group/participants/jobs/:id", rest.GetJob)
+	app.Post("/group/participants/jobs/:id/cancel", rest.CancelJob)
+	return rest
+}
+
+func (controller *GroupParticipantJob) CreateJob(c *fiber.Ctx) error {
+	var request domainGroup.CreateParticipantJobRequest
+
+	if strings.HasPrefix(string(c.Request().Header.ContentType()), fiber.MIMEMultipartForm) {
+		utils.PanicIfNeeded(parseParticipantJobForm(c, &request))
+	} else {
+		err := c.BodyParser(&request)
+		utils.PanicIfNeeded(err)
+	}
+
+	utils.SanitizePhone(&request.GroupID)
+
+	response, err := controller.Service.CreateParticipantJob(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Participant job started",
+		Results: response,
+	})
+}
+
+// parseParticipantJobForm reads a multipart job: participants repeat as form fields and participants_file is a CSV
+// upload with a phone column
+func parseParticipantJobForm(c *fiber.Ctx, request *domainGroup.CreateParticipantJobRequest) error {
+	request.GroupID = c.FormValue("group_id")
+	request.Action = whatsmeow.ParticipantChange(c.FormValue("action"))
+	request.ParticipantsCSV = c.FormValue("participants_csv")
+	request.InviteMessage = c.FormValue("invite_message")
+	if form, err := c.MultipartForm(); err == nil {
+		request.Participants = form.Value["participants"]
+	}
+	for field, target := range map[string]*int{"batch_size": &request.BatchSize, "batch_delay_seconds": &request.BatchDelaySeconds} {
+		if value := c.FormValue(field); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return pkgError.ValidationError(field + ": must be a number.")
+			}
+			*target = parsed
+		}
+	}
+	if value := c.FormValue("invite_fallback"); value != "" {
+		fallback, err := strconv.ParseBool(value)
+		if err != nil {
+			return pkgError.ValidationError("invite_fallback: must be true or false.")
+		}
+		request.InviteFallback = &fallback
+	}
+
+	file, err := c.FormFile("participants_file")
+	if err != nil {
+		// The file is optional when participants are sent as fields
+		return nil
+	}
+	opened, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer opened.Close()
+	content, err := io.ReadAll(opened)
+	if err != nil {
+		return err
+	}
+	request.ParticipantsCSV = string(content)
+	return nil
+}
+
+func (controller *GroupParticipantJob) GetJob(c *fiber.Ctx) error {
+	request := domainGroup.ParticipantJobRequest{ID: c.Params("id")}
+
+	response, err := controller.Service.GetParticipantJob(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Participant job progress retrieved",
+		Results: response,
+	})
+}
+
+func (controller *GroupParticipantJob) CancelJob(c *fiber.Ctx) error {
+	request := domainGroup.ParticipantJobRequest{ID: c.Params("id")}
+
+	response, err := controller.Service.CancelParticipantJob(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Participant job cancelled",
+		Results: response,
+	})
+}
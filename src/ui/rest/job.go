@@ -0,0 +1,30 @@
+package rest
+
+import (
+	domainJob "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/job"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Job struct {
+	Service domainJob.IJobUsecase
+}
+
+func InitRestJob(app fiber.Router, service domainJob.IJobUsecase) Job {
+	rest := Job{Service: service}
+	app.Get("/jobs/:id", rest.GetJob)
+	return rest
+}
+
+func (controller *Job) GetJob(c *fiber.Ctx) error {
+	response, err := controller.Service.GetJob(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), c.Params("id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Job retrieved",
+		Results: response,
+	})
+}
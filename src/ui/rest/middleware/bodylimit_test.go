@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBodyLimitApp() *fiber.App {
+	app := fiber.New(fiber.Config{StreamRequestBody: true, DisablePreParseMultipartForm: true, BodyLimit: 16})
+	app.Use(BodyLimit(16))
+	app.Post("/echo", func(c *fiber.Ctx) error {
+		return c.Send(c.Body())
+	})
+	return app
+}
+
+func TestBodyLimit_AllowsBodyWithinLimit(t *testing.T) {
+	req := httptest.NewRequest("POST", "/echo", strings.NewReader("small body"))
+	resp, err := newBodyLimitApp().Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "small body", string(body))
+}
+
+func TestBodyLimit_RejectsLargeContentLength(t *testing.T) {
+	req := httptest.NewRequest("POST", "/echo", strings.NewReader(strings.Repeat("x", 64)))
+	resp, err := newBodyLimitApp().Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func TestBodyLimit_ChunkedBody(t *testing.T) {
+	app := newBodyLimitApp()
+
+	// Wrapping the readers hides their size, so the request is sent chunked
+	req := httptest.NewRequest("POST", "/echo", io.MultiReader(bytes.NewReader([]byte("chunked"))))
+	req.TransferEncoding = []string{"chunked"}
+	resp, err := app.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "chunked", string(body))
+
+	req = httptest.NewRequest("POST", "/echo", io.MultiReader(strings.NewReader(strings.Repeat("x", 64))))
+	req.TransferEncoding = []string{"chunked"}
+	resp, err = app.Test(req, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, resp.StatusCode)
+}
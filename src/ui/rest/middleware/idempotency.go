@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	idempotencySweepEvery   = time.Minute
+	// maxIdempotencyEntries bounds the store; when it is full the stored response closest to expiry is evicted
+	maxIdempotencyEntries = 10000
+)
+
+// IdempotencyStore keeps the first successful response for each device and Idempotency-Key
+// so a client retrying after a timeout gets that response back instead of sending twice.
+type IdempotencyStore struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*idempotencyEntry
+	lastSweep  time.Time
+}
+
+type idempotencyEntry struct {
+	route       string
+	done        bool
+	status      int
+	contentType string
+	body        []byte
+	expiresAt   time.Time
+}
+
+func NewIdempotencyStore(ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		ttl:        ttl,
+		maxEntries: maxIdempotencyEntries,
+		entries:    make(map[string]*idempotencyEntry),
+	}
+}
+
+// begin returns the live entry for key, or reserves the key for route and returns nil
+func (s *IdempotencyStore) begin(key, route string, now time.Time) *idempotencyEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= idempotencySweepEvery {
+		s.sweep(now)
+	}
+
+	entry, ok := s.entries[key]
+	if ok && (!entry.done || now.Before(entry.expiresAt)) {
+		copied := *entry
+		return &copied
+	}
+
+	if !ok && len(s.entries) >= s.maxEntries && !s.evict(now) {
+		// Every entry is a request still in flight; this one runs without a reservation rather than grow the store
+		return nil
+	}
+	s.entries[key] = &idempotencyEntry{route: route}
+	return nil
+}
+
+// sweep drops the stored responses whose window has passed
+func (s *IdempotencyStore) sweep(now time.Time) {
+	for k, entry := range s.entries {
+		if entry.done && now.After(entry.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	s.lastSweep = now
+}
+
+// evict makes room for one entry, dropping expired responses first and otherwise the stored response closest to
+// expiry. Reservations of requests still in flight are never evicted; it reports false if nothing could be dropped.
+func (s *IdempotencyStore) evict(now time.Time) bool {
+	s.sweep(now)
+	if len(s.entries) < s.maxEntries {
+		return true
+	}
+
+	oldest := ""
+	for k, entry := range s.entries {
+		if entry.done && (oldest == "" || entry.expiresAt.Before(s.entries[oldest].expiresAt)) {
+			oldest = k
+		}
+	}
+	if oldest == "" {
+		return false
+	}
+	delete(s.entries, oldest)
+	return true
+}
+
+// complete stores the response of a reserved key for the configured window
+func (s *IdempotencyStore) complete(key string, status int, contentType string, body []byte, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok {
+		entry.done = true
+		entry.status = status
+		entry.contentType = contentType
+		entry.body = body
+		entry.expiresAt = now.Add(s.ttl)
+	}
+}
+
+// release frees a reserved key so the request can be retried
+func (s *IdempotencyStore) release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok && !entry.done {
+		delete(s.entries, key)
+	}
+}
+
+// Idempotency honours the Idempotency-Key header on write requests. Only successful responses are
+// stored: a failed send leaves nothing behind, so the client can retry it with the same key. A retry
+// that arrives while the first request is still running gets 409 instead of a second send.
+func Idempotency(store *IdempotencyStore) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := strings.TrimSpace(c.Get(IdempotencyKeyHeader))
+		if store == nil || store.ttl <= 0 || key == "" || c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+			return c.Next()
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(utils.ResponseData{
+				Status:  fiber.StatusBadRequest,
+				Code:    "INVALID_IDEMPOTENCY_KEY",
+				Message: "Idempotency-Key must be at most 255 characters",
+				Results: nil,
+			})
+		}
+
+		deviceID, _ := c.Locals("device_id").(string)
+		storeKey := deviceID + "\x00" + key
+		route := c.Method() + " " + c.Path()
+
+		if entry := store.begin(storeKey, route, time.Now()); entry != nil {
+			if entry.route != route {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(utils.ResponseData{
+					Status:  fiber.StatusUnprocessableEntity,
+					Code:    "IDEMPOTENCY_KEY_REUSED",
+					Message: "Idempotency-Key was already used for a different request",
+					Results: nil,
+				})
+			}
+			if !entry.done {
+				c.Set(fiber.HeaderRetryAfter, "1")
+				return c.Status(fiber.StatusConflict).JSON(utils.ResponseData{
+					Status:  fiber.StatusConflict,
+					Code:    "IDEMPOTENCY_KEY_IN_USE",
+					Message: "A request with this Idempotency-Key is still being processed",
+					Results: nil,
+				})
+			}
+
+			c.Set(IdempotencyReplayedHeader, "true")
+			c.Set(fiber.HeaderContentType, entry.contentType)
+			return c.Status(entry.status).Send(entry.body)
+		}
+
+		stored := false
+		defer func() {
+			// Handlers report errors by panicking, so release the key on every path that did not store a response
+			if !stored {
+				store.release(storeKey)
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusOK && status < fiber.StatusMultipleChoices {
+			body := append([]byte(nil), c.Response().Body()...)
+			store.complete(storeKey, status, string(c.Response().Header.ContentType()), body, time.Now())
+			stored = true
+		}
+		return nil
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIdempotencyTestApp(store *IdempotencyStore, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("device_id", c.Get(DeviceIDHeader))
+		return c.Next()
+	})
+	app.Use(Idempotency(store))
+	app.Post("/send/message", handler)
+	app.Post("/send/image", handler)
+	return app
+}
+
+func idempotentRequest(t *testing.T, app *fiber.App, path, device, key string) (int, string, string) {
+	req := httptest.NewRequest("POST", path, nil)
+	req.Header.Set(DeviceIDHeader, device)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	resp, err := app.Test(req, -1)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body), resp.Header.Get(IdempotencyReplayedHeader)
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	calls := 0
+	app := newIdempotencyTestApp(NewIdempotencyStore(time.Hour), func(c *fiber.Ctx) error {
+		calls++
+		return c.JSON(fiber.Map{"call": calls})
+	})
+
+	status, body, replayed := idempotentRequest(t, app, "/send/message", "dev-a", "key-1")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `{"call":1}`, body)
+	assert.Empty(t, replayed)
+
+	status, body, replayed = idempotentRequest(t, app, "/send/message", "dev-a", "key-1")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `{"call":1}`, body)
+	assert.Equal(t, "true", replayed)
+
+	// Keys are scoped per device and requests without a key are never deduplicated
+	_, body, _ = idempotentRequest(t, app, "/send/message", "dev-b", "key-1")
+	assert.Equal(t, `{"call":2}`, body)
+	_, body, _ = idempotentRequest(t, app, "/send/message", "dev-a", "")
+	assert.Equal(t, `{"call":3}`, body)
+	assert.Equal(t, 3, calls)
+}
+
+func TestIdempotency_DoesNotStoreFailures(t *testing.T) {
+	calls := 0
+	app := newIdempotencyTestApp(NewIdempotencyStore(time.Hour), func(c *fiber.Ctx) error {
+		calls++
+		if calls == 1 {
+			return c.Status(500).JSON(fiber.Map{"error": "boom"})
+		}
+		return c.JSON(fiber.Map{"call": calls})
+	})
+
+	status, _, _ := idempotentRequest(t, app, "/send/message", "dev-a", "key-1")
+	assert.Equal(t, 500, status)
+
+	status, body, _ := idempotentRequest(t, app, "/send/message", "dev-a", "key-1")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, `{"call":2}`, body)
+}
+
+func TestIdempotency_RejectsKeyReuseOnOtherRoute(t *testing.T) {
+	app := newIdempotencyTestApp(NewIdempotencyStore(time.Hour), func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	status, _, _ := idempotentRequest(t, app, "/send/message", "dev-a", "key-1")
+	assert.Equal(t, 200, status)
+
+	status, _, _ = idempotentRequest(t, app, "/send/image", "dev-a", "key-1")
+	assert.Equal(t, 422, status)
+}
+
+func TestIdempotency_ConflictWhileInFlight(t *testing.T) {
+	store := NewIdempotencyStore(time.Hour)
+	assert.Nil(t, store.begin("dev-a\x00key-1", "POST /send/message", time.Now()))
+
+	app := newIdempotencyTestApp(store, func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	status, _, _ := idempotentRequest(t, app, "/send/message", "dev-a", "key-1")
+	assert.Equal(t, 409, status)
+}
+
+func TestIdempotencyStore_Expires(t *testing.T) {
+	store := NewIdempotencyStore(time.Minute)
+	now := time.Now()
+
+	assert.Nil(t, store.begin("k", "POST /send/message", now))
+	store.complete("k", 200, "application/json", []byte("{}"), now)
+	assert.NotNil(t, store.begin("k", "POST /send/message", now.Add(30*time.Second)))
+	assert.Nil(t, store.begin("k", "POST /send/message", now.Add(2*time.Minute)))
+}
+
+func TestIdempotencyStore_EvictsWhenFull(t *testing.T) {
+	store := NewIdempotencyStore(time.Minute)
+	store.maxEntries = 2
+	now := time.Now()
+
+	assert.Nil(t, store.begin("a", "POST /send/message", now))
+	store.complete("a", 200, "application/json", []byte("{}"), now)
+	assert.Nil(t, store.begin("b", "POST /send/message", now))
+	store.complete("b", 200, "application/json", []byte("{}"), now.Add(time.Second))
+
+	// The response closest to expiry makes room for the new key
+	assert.Nil(t, store.begin("c", "POST /send/message", now.Add(2*time.Second)))
+	assert.Len(t, store.entries, 2)
+	assert.NotContains(t, store.entries, "a")
+	assert.NotNil(t, store.begin("b", "POST /send/message", now.Add(2*time.Second)))
+
+	// In-flight reservations are never evicted; a new key then runs without one
+	assert.Nil(t, store.begin("d", "POST /send/message", now.Add(2*time.Second)))
+	assert.Nil(t, store.begin("e", "POST /send/message", now.Add(2*time.Second)))
+	assert.Len(t, store.entries, 2)
+	assert.NotContains(t, store.entries, "e")
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
@@ -37,6 +38,11 @@ func Recovery() fiber.Handler {
 					res.Message = errValidation.Error()
 				}
 
+				if errRateLimit, isRateLimitError := err.(pkgError.RateLimitError); isRateLimitError {
+					ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(errRateLimit.RetryAfterSeconds()))
+					res.Results = map[string]int{"retry_after": errRateLimit.RetryAfterSeconds()}
+				}
+
 				_ = ctx.Status(res.Status).JSON(res)
 			}
 		}()
@@ -13,10 +13,55 @@ type Newsletter struct {
 
 func InitRestNewsletter(app fiber.Router, service domainNewsletter.INewsletterUsecase) Newsletter {
 	rest := Newsletter{Service: service}
+	app.Post("/newsletter/create", rest.Create)
+	app.Post("/newsletter/follow", rest.Follow)
 	app.Post("/newsletter/unfollow", rest.Unfollow)
+	app.Get("/newsletter/info", rest.Info)
+	app.Get("/newsletter/messages", rest.Messages)
+	app.Get("/newsletter/message-updates", rest.MessageUpdates)
+	app.Post("/newsletter/post", rest.Post)
+	app.Post("/newsletter/react", rest.React)
+	app.Post("/newsletter/mute", rest.Mute)
 	return rest
 }
 
+func (controller *Newsletter) Create(c *fiber.Ctx) error {
+	var request domainNewsletter.CreateRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	// Try to get file but ignore error if not provided
+	if picture, errFile := c.FormFile("picture"); errFile == nil {
+		request.Picture = picture
+	}
+
+	response, err := controller.Service.Create(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success create newsletter",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Follow(c *fiber.Ctx) error {
+	var request domainNewsletter.FollowRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.Follow(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success follow newsletter",
+		Results: response,
+	})
+}
+
 func (controller *Newsletter) Unfollow(c *fiber.Ctx) error {
 	var request domainNewsletter.UnfollowRequest
 	err := c.BodyParser(&request)
@@ -31,3 +76,115 @@ func (controller *Newsletter) Unfollow(c *fiber.Ctx) error {
 		Message: "Success unfollow newsletter",
 	})
 }
+
+func (controller *Newsletter) Info(c *fiber.Ctx) error {
+	var request domainNewsletter.InfoRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.Info(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get newsletter info",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Messages(c *fiber.Ctx) error {
+	var request domainNewsletter.MessagesRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.Messages(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get newsletter messages",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) MessageUpdates(c *fiber.Ctx) error {
+	var request domainNewsletter.MessageUpdatesRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.MessageUpdates(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get newsletter message updates",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Post(c *fiber.Ctx) error {
+	var request domainNewsletter.PostRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	// Try to get files but ignore error if not provided
+	if imageFile, errFile := c.FormFile("image"); errFile == nil {
+		request.Image = imageFile
+	}
+	if videoFile, errFile := c.FormFile("video"); errFile == nil {
+		request.Video = videoFile
+	}
+
+	response, err := controller.Service.Post(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) React(c *fiber.Ctx) error {
+	var request domainNewsletter.ReactRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.React(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success react to newsletter message"
+	if request.Reaction == "" {
+		message = "Success remove reaction from newsletter message"
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+	})
+}
+
+func (controller *Newsletter) Mute(c *fiber.Ctx) error {
+	var request domainNewsletter.MuteRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.Mute(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success unmute newsletter"
+	if request.Mute {
+		message = "Success mute newsletter"
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+	})
+}
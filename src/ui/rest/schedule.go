@@ -0,0 +1,101 @@
+package rest
+
+import (
+	domainSchedule "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/schedule"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Schedule struct {
+	Service domainSchedule.IScheduleUsecase
+}
+
+func InitRestSchedule(app fiber.Router, service domainSchedule.IScheduleUsecase) Schedule {
+	rest := Schedule{Service: service}
+
+	app.Post("/schedules", rest.CreateSchedule)
+	app.Get("/schedules", rest.ListSchedules)
+	app.Get("/schedules/:id", rest.GetSchedule)
+	app.Post("/schedules/:id/reschedule", rest.Reschedule)
+	app.Post("/schedules/:id/cancel", rest.CancelSchedule)
+
+	return rest
+}
+
+func (controller *Schedule) CreateSchedule(c *fiber.Ctx) error {
+	var request domainSchedule.CreateScheduleRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.CreateSchedule(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Message scheduled",
+		Results: response,
+	})
+}
+
+func (controller *Schedule) ListSchedules(c *fiber.Ctx) error {
+	var request domainSchedule.ListScheduleRequest
+	request.Status = c.Query("status", "")
+	request.Limit = c.QueryInt("limit", 25)
+	request.Offset = c.QueryInt("offset", 0)
+
+	response, err := controller.Service.ListSchedules(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Scheduled messages retrieved",
+		Results: response,
+	})
+}
+
+func (controller *Schedule) GetSchedule(c *fiber.Ctx) error {
+	response, err := controller.Service.GetSchedule(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), c.Params("id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Scheduled message retrieved",
+		Results: response,
+	})
+}
+
+func (controller *Schedule) Reschedule(c *fiber.Ctx) error {
+	var request domainSchedule.RescheduleRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	request.ID = c.Params("id")
+
+	response, err := controller.Service.Reschedule(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Scheduled message rescheduled",
+		Results: response,
+	})
+}
+
+func (controller *Schedule) CancelSchedule(c *fiber.Ctx) error {
+	request := domainSchedule.CancelScheduleRequest{ID: c.Params("id")}
+
+	response, err := controller.Service.CancelSchedule(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Scheduled message cancelled",
+		Results: response,
+	})
+}
@@ -1,26 +1,37 @@
 package rest
 
 import (
+	"context"
+	"encoding/json"
+
+	domainJob "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/job"
 	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/gofiber/fiber/v2"
 )
 
 type Send struct {
 	Service domainSend.ISendUsecase
+	Jobs    domainJob.IJobUsecase
 }
 
-func InitRestSend(app fiber.Router, service domainSend.ISendUsecase) Send {
-	rest := Send{Service: service}
+func InitRestSend(app fiber.Router, service domainSend.ISendUsecase, jobs domainJob.IJobUsecase) Send {
+	rest := Send{Service: service, Jobs: jobs}
 	app.Post("/send/message", rest.SendText)
 	app.Post("/send/image", rest.SendImage)
 	app.Post("/send/file", rest.SendFile)
 	app.Post("/send/video", rest.SendVideo)
+	app.Post("/send/album", rest.SendAlbum)
 	app.Post("/send/sticker", rest.SendSticker)
+	app.Post("/send/sticker-pack", rest.SendStickerPack)
 	app.Post("/send/contact", rest.SendContact)
 	app.Post("/send/link", rest.SendLink)
 	app.Post("/send/location", rest.SendLocation)
+	app.Post("/send/live-location", rest.StartLiveLocation)
+	app.Post("/send/live-location/:share_id/update", rest.UpdateLiveLocation)
+	app.Post("/send/live-location/:share_id/stop", rest.StopLiveLocation)
 	app.Post("/send/audio", rest.SendAudio)
 	app.Post("/send/poll", rest.SendPoll)
 	app.Post("/send/presence", rest.SendPresence)
@@ -32,9 +43,16 @@ func (controller *Send) SendText(c *fiber.Ctx) error {
 	var request domainSend.MessageRequest
 	err := c.BodyParser(&request)
 	utils.PanicIfNeeded(err)
+	utils.PanicIfNeeded(parseTemplateVariables(c, &request.BaseRequest))
 
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		return controller.enqueueSend(c, "text", request.Phone, request, nil, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendText(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendText(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -52,6 +70,7 @@ func (controller *Send) SendImage(c *fiber.Ctx) error {
 
 	err := c.BodyParser(&request)
 	utils.PanicIfNeeded(err)
+	utils.PanicIfNeeded(parseTemplateVariables(c, &request.BaseRequest))
 
 	file, err := c.FormFile("image")
 	if err == nil {
@@ -60,6 +79,15 @@ func (controller *Send) SendImage(c *fiber.Ctx) error {
 
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		upload, cleanup, err := detachUpload(request.Image)
+		utils.PanicIfNeeded(err)
+		request.UploadPath = upload
+		return controller.enqueueSend(c, "image", request.Phone, request, cleanup, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendImage(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendImage(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -75,13 +103,24 @@ func (controller *Send) SendFile(c *fiber.Ctx) error {
 	var request domainSend.FileRequest
 	err := c.BodyParser(&request)
 	utils.PanicIfNeeded(err)
+	utils.PanicIfNeeded(parseTemplateVariables(c, &request.BaseRequest))
 
-	file, err := c.FormFile("file")
-	utils.PanicIfNeeded(err)
+	// A template attachment or file_url can stand in for the upload
+	if file, errFile := c.FormFile("file"); errFile == nil {
+		request.File = file
+	}
 
-	request.File = file
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		upload, cleanup, err := detachUpload(request.File)
+		utils.PanicIfNeeded(err)
+		request.UploadPath = upload
+		return controller.enqueueSend(c, "file", request.Phone, request, cleanup, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendFile(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendFile(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -97,6 +136,7 @@ func (controller *Send) SendVideo(c *fiber.Ctx) error {
 	var request domainSend.VideoRequest
 	err := c.BodyParser(&request)
 	utils.PanicIfNeeded(err)
+	utils.PanicIfNeeded(parseTemplateVariables(c, &request.BaseRequest))
 
 	// Try to get file but ignore error if not provided
 	if videoFile, errFile := c.FormFile("video"); errFile == nil {
@@ -105,6 +145,15 @@ func (controller *Send) SendVideo(c *fiber.Ctx) error {
 
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		upload, cleanup, err := detachUpload(request.Video)
+		utils.PanicIfNeeded(err)
+		request.UploadPath = upload
+		return controller.enqueueSend(c, "video", request.Phone, request, cleanup, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendVideo(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendVideo(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -120,6 +169,7 @@ func (controller *Send) SendSticker(c *fiber.Ctx) error {
 	var request domainSend.StickerRequest
 	err := c.BodyParser(&request)
 	utils.PanicIfNeeded(err)
+	utils.PanicIfNeeded(parseTemplateVariables(c, &request.BaseRequest))
 
 	// Try to get file but ignore error if not provided
 	if stickerFile, errFile := c.FormFile("sticker"); errFile == nil {
@@ -128,6 +178,15 @@ func (controller *Send) SendSticker(c *fiber.Ctx) error {
 
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		upload, cleanup, err := detachUpload(request.Sticker)
+		utils.PanicIfNeeded(err)
+		request.UploadPath = upload
+		return controller.enqueueSend(c, "sticker", request.Phone, request, cleanup, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendSticker(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendSticker(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -146,6 +205,12 @@ func (controller *Send) SendContact(c *fiber.Ctx) error {
 
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		return controller.enqueueSend(c, "contact", request.Phone, request, nil, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendContact(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendContact(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -161,9 +226,16 @@ func (controller *Send) SendLink(c *fiber.Ctx) error {
 	var request domainSend.LinkRequest
 	err := c.BodyParser(&request)
 	utils.PanicIfNeeded(err)
+	utils.PanicIfNeeded(parseTemplateVariables(c, &request.BaseRequest))
 
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		return controller.enqueueSend(c, "link", request.Phone, request, nil, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendLink(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendLink(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -182,6 +254,12 @@ func (controller *Send) SendLocation(c *fiber.Ctx) error {
 
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		return controller.enqueueSend(c, "location", request.Phone, request, nil, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendLocation(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendLocation(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -193,10 +271,58 @@ func (controller *Send) SendLocation(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *Send) StartLiveLocation(c *fiber.Ctx) error {
+	var request domainSend.LiveLocationRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.Phone)
+
+	response, err := controller.Service.StartLiveLocation(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Send) UpdateLiveLocation(c *fiber.Ctx) error {
+	var request domainSend.LiveLocationUpdateRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+	request.ShareID = c.Params("share_id")
+
+	response, err := controller.Service.UpdateLiveLocation(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Send) StopLiveLocation(c *fiber.Ctx) error {
+	response, err := controller.Service.StopLiveLocation(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), c.Params("share_id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
 func (controller *Send) SendAudio(c *fiber.Ctx) error {
 	var request domainSend.AudioRequest
 	err := c.BodyParser(&request)
 	utils.PanicIfNeeded(err)
+	utils.PanicIfNeeded(parseTemplateVariables(c, &request.BaseRequest))
 
 	// Try to get file but ignore error if not provided
 	if audioFile, errFile := c.FormFile("audio"); errFile == nil {
@@ -205,6 +331,15 @@ func (controller *Send) SendAudio(c *fiber.Ctx) error {
 
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		upload, cleanup, err := detachUpload(request.Audio)
+		utils.PanicIfNeeded(err)
+		request.UploadPath = upload
+		return controller.enqueueSend(c, "audio", request.Phone, request, cleanup, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendAudio(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendAudio(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -220,9 +355,16 @@ func (controller *Send) SendPoll(c *fiber.Ctx) error {
 	var request domainSend.PollRequest
 	err := c.BodyParser(&request)
 	utils.PanicIfNeeded(err)
+	utils.PanicIfNeeded(parseTemplateVariables(c, &request.BaseRequest))
 
 	utils.SanitizePhone(&request.Phone)
 
+	if isAsyncSend(c, request.BaseRequest) {
+		return controller.enqueueSend(c, "poll", request.Phone, request, nil, func(ctx context.Context) (domainSend.GenericResponse, error) {
+			return controller.Service.SendPoll(ctx, request)
+		})
+	}
+
 	response, err := controller.Service.SendPoll(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
 	utils.PanicIfNeeded(err)
 
@@ -267,3 +409,16 @@ func (controller *Send) SendChatPresence(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+// parseTemplateVariables reads template variables sent as a JSON object in the "variables" form field,
+// since multipart bodies cannot carry a map directly. JSON bodies are already decoded by BodyParser.
+func parseTemplateVariables(c *fiber.Ctx, request *domainSend.BaseRequest) error {
+	raw := c.FormValue("variables")
+	if request.Variables != nil || raw == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(raw), &request.Variables); err != nil {
+		return pkgError.ValidationError("variables: must be a JSON object of strings")
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	domainJob "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/job"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (controller *Send) SendAlbum(c *fiber.Ctx) error {
+	var request domainSend.AlbumRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+	utils.PanicIfNeeded(parseAlbumItems(c, &request))
+
+	utils.SanitizePhone(&request.Phone)
+
+	if isAsyncSend(c, request.BaseRequest) {
+		var cleanups []func()
+		cleanup := func() {
+			for _, fn := range cleanups {
+				fn()
+			}
+		}
+		for i := range request.Items {
+			upload, fn, err := detachUpload(request.Items[i].File)
+			if err != nil {
+				cleanup()
+				utils.PanicIfNeeded(err)
+			}
+			cleanups = append(cleanups, fn)
+			request.Items[i].UploadPath = upload
+		}
+		return controller.enqueueSendResult(c, "album", request.Phone, request, cleanup, func(ctx context.Context) (domainJob.SendResult, error) {
+			album, err := controller.Service.SendAlbum(ctx, request)
+			return domainJob.SendResult{MessageID: album.MessageID, Response: album}, err
+		})
+	}
+
+	response, err := controller.Service.SendAlbum(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+// parseAlbumItems completes a multipart album request: item metadata comes as a JSON array in the
+// "items" field and uploads as repeated "files" fields. Uploaded files fill the items without a url in
+// order; files left over become extra items typed by their Content-Type.
+func parseAlbumItems(c *fiber.Ctx, request *domainSend.AlbumRequest) error {
+	if err := parseTemplateVariables(c, &request.BaseRequest); err != nil {
+		return err
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		// Not a multipart body, items were decoded from JSON
+		return nil
+	}
+
+	if raw := c.FormValue("items"); raw != "" && len(request.Items) == 0 {
+		if err := json.Unmarshal([]byte(raw), &request.Items); err != nil {
+			return pkgError.ValidationError("items: must be a JSON array of album items")
+		}
+	}
+
+	files := form.File["files"]
+	for i := range request.Items {
+		if len(files) == 0 {
+			break
+		}
+		if request.Items[i].URL == nil || *request.Items[i].URL == "" {
+			request.Items[i].File, files = files[0], files[1:]
+		}
+	}
+	for _, file := range files {
+		request.Items = append(request.Items, domainSend.AlbumItem{File: file})
+	}
+
+	for i, item := range request.Items {
+		if item.Type != "" || item.File == nil {
+			continue
+		}
+		contentType := item.File.Header.Get("Content-Type")
+		switch {
+		case strings.HasPrefix(contentType, "image/"):
+			request.Items[i].Type = domainSend.AlbumItemImage
+		case strings.HasPrefix(contentType, "video/"):
+			request.Items[i].Type = domainSend.AlbumItemVideo
+		default:
+			return pkgError.ValidationError(fmt.Sprintf("items[%d]: cannot tell whether %q is an image or a video", i, item.File.Filename))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"context"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainJob "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/job"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+	fiberUtils "github.com/gofiber/fiber/v2/utils"
+	"github.com/valyala/fasthttp"
+)
+
+// isAsyncSend reports whether the caller asked to queue the send, via the async field or ?async=true
+func isAsyncSend(c *fiber.Ctx, request domainSend.BaseRequest) bool {
+	return request.Async || c.QueryBool("async")
+}
+
+// enqueueSend validates a send, hands it to the job queue and answers 202 with the job, whose ID can be polled at
+// /jobs/:id. An invalid request is answered with 400 and never queued.
+func (controller *Send) enqueueSend(c *fiber.Ctx, sendType, phone string, request any, cleanup func(), send func(ctx context.Context) (domainSend.GenericResponse, error)) error {
+	return controller.enqueueSendResult(c, sendType, phone, request, cleanup, func(ctx context.Context) (domainJob.SendResult, error) {
+		response, err := send(ctx)
+		return domainJob.SendResult{MessageID: response.MessageID, Response: response}, err
+	})
+}
+
+// enqueueSendResult is enqueueSend for sends whose response is more than a GenericResponse; the job keeps it whole
+func (controller *Send) enqueueSendResult(c *fiber.Ctx, sendType, phone string, request any, cleanup func(), send domainJob.SendFunc) error {
+	job, err := controller.Jobs.EnqueueSend(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), domainJob.EnqueueSendRequest{
+		Type:  sendType,
+		Phone: phone,
+		Validate: func(ctx context.Context) error {
+			return controller.Service.ValidateSend(ctx, request)
+		},
+		Send:    send,
+		Cleanup: cleanup,
+	})
+	utils.PanicIfNeeded(err)
+
+	return c.Status(fiber.StatusAccepted).JSON(utils.ResponseData{
+		Status:  fiber.StatusAccepted,
+		Code:    "ACCEPTED",
+		Message: "Send queued",
+		Results: job,
+	})
+}
+
+// detachUpload copies an uploaded file out of the request, whose multipart form is released as soon as the handler
+// returns, into a temp file a queued job can still read. The returned cleanup removes the copy.
+func detachUpload(file *multipart.FileHeader) (string, func(), error) {
+	if file == nil {
+		return "", func() {}, nil
+	}
+
+	path := filepath.Join(config.PathSendItems, "async_"+fiberUtils.UUIDv4()+filepath.Ext(file.Filename))
+	if err := fasthttp.SaveMultipartFile(file, path); err != nil {
+		return "", func() {}, err
+	}
+	return path, func() { _ = os.Remove(path) }, nil
+}
@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"context"
+
+	domainJob "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/job"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (controller *Send) SendStickerPack(c *fiber.Ctx) error {
+	var request domainSend.StickerPackRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	// Try to get file but ignore error if not provided
+	if packFile, errFile := c.FormFile("pack"); errFile == nil {
+		request.Pack = packFile
+	}
+
+	utils.SanitizePhone(&request.Phone)
+
+	if isAsyncSend(c, request.BaseRequest) {
+		upload, cleanup, err := detachUpload(request.Pack)
+		utils.PanicIfNeeded(err)
+		request.UploadPath = upload
+		return controller.enqueueSendResult(c, "sticker_pack", request.Phone, request, cleanup, func(ctx context.Context) (domainJob.SendResult, error) {
+			pack, err := controller.Service.SendStickerPack(ctx, request)
+			result := domainJob.SendResult{Response: pack}
+			// The webhook reports the first sticker that went out; the job result lists them all
+			for _, sticker := range pack.Stickers {
+				if sticker.MessageID != "" {
+					result.MessageID = sticker.MessageID
+					break
+				}
+			}
+			return result, err
+		})
+	}
+
+	response, err := controller.Service.SendStickerPack(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
@@ -0,0 +1,147 @@
+package rest
+
+import (
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Status struct {
+	Service domainStatus.IStatusUsecase
+}
+
+func InitRestStatus(app fiber.Router, service domainStatus.IStatusUsecase) Status {
+	rest := Status{Service: service}
+	app.Post("/status/text", rest.PostText)
+	app.Post("/status/image", rest.PostImage)
+	app.Post("/status/video", rest.PostVideo)
+	app.Get("/statuses", rest.ListStatuses)
+	app.Get("/status/privacy", rest.GetPrivacy)
+	app.Get("/status/:status_id", rest.GetStatus)
+	app.Post("/status/:status_id/view", rest.MarkViewed)
+	app.Delete("/status/:status_id", rest.DeleteStatus)
+	return rest
+}
+
+func (controller *Status) PostText(c *fiber.Ctx) error {
+	var request domainStatus.PostTextRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.PostText(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) PostImage(c *fiber.Ctx) error {
+	var request domainStatus.PostImageRequest
+	request.Compress = true
+
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	if file, errFile := c.FormFile("image"); errFile == nil {
+		request.Image = file
+	}
+
+	response, err := controller.Service.PostImage(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) PostVideo(c *fiber.Ctx) error {
+	var request domainStatus.PostVideoRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	if file, errFile := c.FormFile("video"); errFile == nil {
+		request.Video = file
+	}
+
+	response, err := controller.Service.PostVideo(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) ListStatuses(c *fiber.Ctx) error {
+	var request domainStatus.ListStatusesRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.ListStatuses(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get statuses",
+		Results: response,
+	})
+}
+
+func (controller *Status) GetStatus(c *fiber.Ctx) error {
+	response, err := controller.Service.GetStatus(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), c.Params("status_id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get status",
+		Results: response,
+	})
+}
+
+func (controller *Status) MarkViewed(c *fiber.Ctx) error {
+	response, err := controller.Service.MarkViewed(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), c.Params("status_id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) DeleteStatus(c *fiber.Ctx) error {
+	response, err := controller.Service.DeleteStatus(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)), c.Params("status_id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) GetPrivacy(c *fiber.Ctx) error {
+	response, err := controller.Service.GetPrivacy(whatsapp.ContextWithDevice(c.UserContext(), getDeviceFromCtx(c)))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get status privacy",
+		Results: response,
+	})
+}
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	domainTemplate "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/template"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Template struct {
+	Service domainTemplate.ITemplateUsecase
+}
+
+// InitRestTemplate registers the template routes. Templates are shared by every device, so these
+// routes sit outside the device-scoped group.
+func InitRestTemplate(app fiber.Router, service domainTemplate.ITemplateUsecase) Template {
+	rest := Template{Service: service}
+
+	app.Post("/templates", rest.CreateTemplate)
+	app.Get("/templates", rest.ListTemplates)
+	app.Get("/templates/:id", rest.GetTemplate)
+	app.Put("/templates/:id", rest.UpdateTemplate)
+	app.Delete("/templates/:id", rest.DeleteTemplate)
+
+	return rest
+}
+
+func (controller *Template) CreateTemplate(c *fiber.Ctx) error {
+	var request domainTemplate.CreateTemplateRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.CreateTemplate(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Template created",
+		Results: response,
+	})
+}
+
+func (controller *Template) ListTemplates(c *fiber.Ctx) error {
+	var request domainTemplate.ListTemplatesRequest
+	request.Search = c.Query("search", "")
+	request.Limit = c.QueryInt("limit", 25)
+	request.Offset = c.QueryInt("offset", 0)
+
+	response, err := controller.Service.ListTemplates(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Templates retrieved",
+		Results: response,
+	})
+}
+
+func (controller *Template) GetTemplate(c *fiber.Ctx) error {
+	response, err := controller.Service.GetTemplate(c.UserContext(), c.Params("id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Template retrieved",
+		Results: response,
+	})
+}
+
+func (controller *Template) UpdateTemplate(c *fiber.Ctx) error {
+	var request domainTemplate.UpdateTemplateRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	request.ID = c.Params("id")
+
+	response, err := controller.Service.UpdateTemplate(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Template updated",
+		Results: response,
+	})
+}
+
+func (controller *Template) DeleteTemplate(c *fiber.Ctx) error {
+	err := controller.Service.DeleteTemplate(c.UserContext(), c.Params("id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Template deleted",
+		Results: nil,
+	})
+}
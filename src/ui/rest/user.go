@@ -101,13 +101,17 @@ func (controller *User) UserMyPrivacySetting(c *fiber.Ctx) error {
 }
 
 func (controller *User) UserMyListGroups(c *fiber.Ctx) error {
+	var request domainUser.MyListGroupsRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
 	deviceVal := c.Locals("device")
 	ctx := c.UserContext()
 	if device, ok := deviceVal.(*whatsapp.DeviceInstance); ok {
 		ctx = whatsapp.ContextWithDevice(ctx, device)
 	}
 
-	response, err := controller.Service.MyListGroups(ctx)
+	response, err := controller.Service.MyListGroups(ctx, request)
 	utils.PanicIfNeeded(err)
 
 	return c.JSON(utils.ResponseData{
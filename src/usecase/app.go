@@ -285,3 +285,24 @@ func (service *serviceApp) ensureClient(ctx context.Context, deviceID string) (*
 
 	return instance, client, nil
 }
+
+func (service *serviceApp) TranscoderStats(_ context.Context) (response domainApp.TranscoderStatsResponse, err error) {
+	stats := mediaTranscoder().Stats()
+	response = domainApp.TranscoderStatsResponse{
+		Workers:   stats.Workers,
+		QueueSize: stats.QueueSize,
+		Running:   stats.Running,
+		Queued:    stats.Queued,
+		Started:   stats.Started,
+		Completed: stats.Completed,
+		Failed:    stats.Failed,
+		TimedOut:  stats.TimedOut,
+		Canceled:  stats.Canceled,
+		Rejected:  stats.Rejected,
+		MaxRunMs:  stats.MaxRunTime.Milliseconds(),
+	}
+	if finished := stats.Started - uint64(stats.Running); finished > 0 {
+		response.AverageRunMs = (stats.TotalRunTime / time.Duration(finished)).Milliseconds()
+	}
+	return response, nil
+}
@@ -0,0 +1,662 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	domainCampaign "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/campaign"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	maxCampaignRecipients    = 10000
+	campaignSendTimeout      = 2 * time.Minute
+	campaignDeviceRetryDelay = 30 * time.Second
+	campaignSkipDelay        = time.Second
+)
+
+// campaignWorkers tracks the sender goroutine of every running campaign
+type campaignWorkers struct {
+	mu      sync.Mutex
+	ctx     context.Context
+	running map[string]*campaignWorker
+}
+
+type campaignWorker struct {
+	cancel context.CancelFunc
+}
+
+type serviceCampaign struct {
+	repo        domainCampaign.ICampaignRepository
+	sendService domainSend.ISendUsecase
+	manager     *whatsapp.DeviceManager
+	workers     *campaignWorkers
+}
+
+func NewCampaignService(repo domainCampaign.ICampaignRepository, sendService domainSend.ISendUsecase, manager *whatsapp.DeviceManager) domainCampaign.ICampaignUsecase {
+	return &serviceCampaign{
+		repo:        repo,
+		sendService: sendService,
+		manager:     manager,
+		workers: &campaignWorkers{
+			ctx:     context.Background(),
+			running: make(map[string]*campaignWorker),
+		},
+	}
+}
+
+func (service serviceCampaign) CreateCampaign(ctx context.Context, request domainCampaign.CreateCampaignRequest) (response domainCampaign.Campaign, err error) {
+	if err = validations.ValidateCreateCampaign(ctx, &request); err != nil {
+		return response, err
+	}
+
+	recipients, err := collectCampaignRecipients(request)
+	if err != nil {
+		return response, err
+	}
+
+	// Render the payload for the first recipient so template or payload mistakes fail now, not mid-campaign
+	handler := sendPayloadHandlers[request.Type]
+	sample, err := renderCampaignPayload(request.Payload, recipients[0].Phone, recipients[0].Variables)
+	if err != nil {
+		return response, err
+	}
+	if err = handler.validate(ctx, service.sendService, sample); err != nil {
+		return response, err
+	}
+
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	campaign := &domainCampaign.Campaign{
+		ID:              uuid.NewString(),
+		DeviceID:        deviceID,
+		Name:            request.Name,
+		Type:            request.Type,
+		Payload:         request.Payload,
+		Status:          domainCampaign.StatusRunning,
+		RatePerMinute:   request.RatePerMinute,
+		JitterSeconds:   request.JitterSeconds,
+		QuietHoursStart: request.QuietHoursStart,
+		QuietHoursEnd:   request.QuietHoursEnd,
+		Timezone:        request.Timezone,
+	}
+	if err = service.repo.Create(campaign, recipients); err != nil {
+		return response, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"campaign_id": campaign.ID,
+		"device_id":   deviceID,
+		"type":        campaign.Type,
+		"recipients":  len(recipients),
+	}).Info("Campaign created")
+
+	service.startWorker(campaign.ID)
+	return service.GetCampaign(ctx, campaign.ID)
+}
+
+// collectCampaignRecipients merges JSON and CSV recipients, normalizes phones and drops duplicates
+func collectCampaignRecipients(request domainCampaign.CreateCampaignRequest) ([]*domainCampaign.Recipient, error) {
+	inputs := request.Recipients
+	if request.RecipientsCSV != "" {
+		parsed, err := parseRecipientsCSV(request.RecipientsCSV)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, parsed...)
+	}
+
+	seen := make(map[string]bool, len(inputs))
+	recipients := make([]*domainCampaign.Recipient, 0, len(inputs))
+	for i, input := range inputs {
+		phone := strings.TrimPrefix(strings.TrimSpace(input.Phone), "+")
+		if phone == "" {
+			return nil, pkgError.ValidationError(fmt.Sprintf("recipients[%d].phone: cannot be blank.", i))
+		}
+		utils.SanitizePhone(&phone)
+		if seen[phone] {
+			continue
+		}
+		seen[phone] = true
+		recipients = append(recipients, &domainCampaign.Recipient{Phone: phone, Variables: input.Variables})
+	}
+
+	if len(recipients) == 0 {
+		return nil, pkgError.ValidationError("recipients: cannot be blank.")
+	}
+	if len(recipients) > maxCampaignRecipients {
+		return nil, pkgError.ValidationError(fmt.Sprintf("recipients: at most %d recipients are allowed per campaign.", maxCampaignRecipients))
+	}
+	return recipients, nil
+}
+
+// parseRecipientsCSV reads a CSV with a header row. The phone column is required; every other column
+// becomes a template variable named after its header.
+func parseRecipientsCSV(text string) ([]domainCampaign.RecipientInput, error) {
+	return parsePhoneCSV("recipients_csv", text)
+}
+
+// parsePhoneCSV reads the phone column of a CSV with a header row, keeping the other columns as variables.
+// field names the request field in validation errors.
+func parsePhoneCSV(field, text string) ([]domainCampaign.RecipientInput, error) {
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(text, "\ufeff")))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, pkgError.ValidationError(field + ": cannot be blank.")
+	}
+	if err != nil {
+		return nil, pkgError.ValidationError(fmt.Sprintf("%s: %v", field, err))
+	}
+
+	phoneColumn := -1
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+		if strings.EqualFold(header[i], "phone") {
+			phoneColumn = i
+		}
+	}
+	if phoneColumn < 0 {
+		return nil, pkgError.ValidationError(field + ": header must contain a phone column.")
+	}
+
+	var recipients []domainCampaign.RecipientInput
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, pkgError.ValidationError(fmt.Sprintf("%s: %v", field, err))
+		}
+		if phoneColumn >= len(record) || strings.TrimSpace(record[phoneColumn]) == "" {
+			continue
+		}
+
+		input := domainCampaign.RecipientInput{Phone: strings.TrimSpace(record[phoneColumn])}
+		for i, value := range record {
+			if i == phoneColumn || i >= len(header) || header[i] == "" {
+				continue
+			}
+			if input.Variables == nil {
+				input.Variables = make(map[string]string)
+			}
+			input.Variables[header[i]] = strings.TrimSpace(value)
+		}
+		recipients = append(recipients, input)
+	}
+	return recipients, nil
+}
+
+// renderCampaignPayload fills {{variables}} in every string of the payload and points it at the recipient
+func renderCampaignPayload(payload json.RawMessage, phone string, variables map[string]string) (json.RawMessage, error) {
+	var fields map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil || fields == nil {
+		return nil, pkgError.ValidationError("payload: must be a JSON object.")
+	}
+
+	vars := make(map[string]string, len(variables)+1)
+	vars["phone"] = strings.SplitN(phone, "@", 2)[0]
+	for key, value := range variables {
+		vars[key] = value
+	}
+
+	for key, value := range fields {
+		fields[key] = renderTemplateValue(value, vars)
+	}
+	fields["phone"] = phone
+
+	rendered, err := json.Marshal(fields)
+	if err != nil {
+		return nil, pkgError.ValidationError(fmt.Sprintf("payload: %v", err))
+	}
+	return rendered, nil
+}
+
+func renderTemplateValue(value any, vars map[string]string) any {
+	switch v := value.(type) {
+	case string:
+		return utils.RenderTemplate(v, vars)
+	case []any:
+		for i := range v {
+			v[i] = renderTemplateValue(v[i], vars)
+		}
+		return v
+	case map[string]any:
+		for key := range v {
+			v[key] = renderTemplateValue(v[key], vars)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// quietHoursWait returns how long to wait until quiet hours end, or 0 outside quiet hours.
+// A window whose start is after its end (e.g. 21:00-08:00) spans midnight.
+func quietHoursWait(now time.Time, start, end, timezone string) time.Duration {
+	if start == "" || end == "" {
+		return 0
+	}
+	startClock, err := time.Parse("15:04", start)
+	if err != nil {
+		return 0
+	}
+	endClock, err := time.Parse("15:04", end)
+	if err != nil {
+		return 0
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		if loaded, err := time.LoadLocation(timezone); err == nil {
+			loc = loaded
+		}
+	}
+	local := now.In(loc)
+
+	startMinute := startClock.Hour()*60 + startClock.Minute()
+	endMinute := endClock.Hour()*60 + endClock.Minute()
+	current := local.Hour()*60 + local.Minute()
+
+	var quiet bool
+	switch {
+	case startMinute == endMinute:
+		quiet = false
+	case startMinute < endMinute:
+		quiet = current >= startMinute && current < endMinute
+	default:
+		quiet = current >= startMinute || current < endMinute
+	}
+	if !quiet {
+		return 0
+	}
+
+	resumeAt := time.Date(local.Year(), local.Month(), local.Day(), endClock.Hour(), endClock.Minute(), 0, 0, loc)
+	if !resumeAt.After(local) {
+		resumeAt = resumeAt.AddDate(0, 0, 1)
+	}
+	return resumeAt.Sub(local)
+}
+
+// campaignSendInterval spaces sends evenly at the configured rate plus a random jitter
+func campaignSendInterval(ratePerMinute, jitterSeconds int) time.Duration {
+	if ratePerMinute <= 0 {
+		ratePerMinute = 1
+	}
+	interval := time.Minute / time.Duration(ratePerMinute)
+	if jitterSeconds > 0 {
+		interval += time.Duration(rand.Int64N(int64(jitterSeconds)*int64(time.Second) + 1))
+	}
+	return interval
+}
+
+func (service serviceCampaign) ListCampaigns(ctx context.Context, request domainCampaign.ListCampaignsRequest) (response domainCampaign.ListCampaignsResponse, err error) {
+	if err = validations.ValidateListCampaigns(ctx, &request); err != nil {
+		return response, err
+	}
+
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	campaigns, total, err := service.repo.List(&domainCampaign.CampaignFilter{
+		DeviceID: deviceID,
+		Status:   request.Status,
+		Limit:    request.Limit,
+		Offset:   request.Offset,
+	})
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainCampaign.Campaign, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		response.Data = append(response.Data, *campaign)
+	}
+	response.Total = total
+	return response, nil
+}
+
+func (service serviceCampaign) GetCampaign(ctx context.Context, id string) (response domainCampaign.Campaign, err error) {
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	campaign, err := service.repo.Get(deviceID, id)
+	if err != nil {
+		return response, err
+	}
+	if campaign == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("campaign %s not found", id))
+	}
+	return *campaign, nil
+}
+
+func (service serviceCampaign) ListRecipients(ctx context.Context, request domainCampaign.ListRecipientsRequest) (response domainCampaign.ListRecipientsResponse, err error) {
+	if err = validations.ValidateListCampaignRecipients(ctx, &request); err != nil {
+		return response, err
+	}
+
+	// Ensures the campaign belongs to the device in context
+	if _, err = service.GetCampaign(ctx, request.CampaignID); err != nil {
+		return response, err
+	}
+
+	recipients, total, err := service.repo.ListRecipients(&domainCampaign.RecipientFilter{
+		CampaignID: request.CampaignID,
+		Status:     request.Status,
+		Limit:      request.Limit,
+		Offset:     request.Offset,
+	})
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainCampaign.Recipient, 0, len(recipients))
+	for _, recipient := range recipients {
+		response.Data = append(response.Data, *recipient)
+	}
+	response.Total = total
+	return response, nil
+}
+
+func (service serviceCampaign) PauseCampaign(ctx context.Context, request domainCampaign.CampaignActionRequest) (response domainCampaign.Campaign, err error) {
+	return service.transition(ctx, request, []string{domainCampaign.StatusRunning}, domainCampaign.StatusPaused)
+}
+
+func (service serviceCampaign) ResumeCampaign(ctx context.Context, request domainCampaign.CampaignActionRequest) (response domainCampaign.Campaign, err error) {
+	return service.transition(ctx, request, []string{domainCampaign.StatusPaused}, domainCampaign.StatusRunning)
+}
+
+func (service serviceCampaign) CancelCampaign(ctx context.Context, request domainCampaign.CampaignActionRequest) (response domainCampaign.Campaign, err error) {
+	return service.transition(ctx, request, []string{domainCampaign.StatusRunning, domainCampaign.StatusPaused}, domainCampaign.StatusCancelled)
+}
+
+// transition moves a campaign between states and starts or stops its sender accordingly
+func (service serviceCampaign) transition(ctx context.Context, request domainCampaign.CampaignActionRequest, from []string, to string) (response domainCampaign.Campaign, err error) {
+	if err = validations.ValidateCampaignAction(ctx, &request); err != nil {
+		return response, err
+	}
+
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	changed, err := service.repo.SetStatus(deviceID, request.ID, from, to)
+	if err != nil {
+		return response, err
+	}
+	if !changed {
+		current, err := service.GetCampaign(ctx, request.ID)
+		if err != nil {
+			return response, err
+		}
+		return response, pkgError.ValidationError(fmt.Sprintf("campaign %s is %s and cannot be %s", request.ID, current.Status, to))
+	}
+
+	switch to {
+	case domainCampaign.StatusRunning:
+		service.startWorker(request.ID)
+	case domainCampaign.StatusPaused:
+		service.stopWorker(request.ID)
+	case domainCampaign.StatusCancelled:
+		service.stopWorker(request.ID)
+		if err = service.repo.CancelPendingRecipients(request.ID); err != nil {
+			return response, err
+		}
+	}
+
+	logrus.WithFields(logrus.Fields{"campaign_id": request.ID, "device_id": deviceID}).Infof("Campaign %s", to)
+	return service.GetCampaign(ctx, request.ID)
+}
+
+func (service serviceCampaign) Run(ctx context.Context) {
+	service.workers.mu.Lock()
+	service.workers.ctx = ctx
+	service.workers.mu.Unlock()
+
+	// A recipient claimed by a previous process may or may not have been sent; never send twice
+	if failed, err := service.repo.FailInterrupted("interrupted by restart before completion"); err != nil {
+		logrus.Errorf("Campaign: failed to recover interrupted recipients: %v", err)
+	} else if failed > 0 {
+		logrus.Warnf("Campaign: marked %d interrupted recipient(s) as failed", failed)
+	}
+
+	running, _, err := service.repo.List(&domainCampaign.CampaignFilter{Status: domainCampaign.StatusRunning})
+	if err != nil {
+		logrus.Errorf("Campaign: failed to load running campaigns: %v", err)
+	}
+	for _, campaign := range running {
+		service.startWorker(campaign.ID)
+	}
+
+	<-ctx.Done()
+}
+
+func (service serviceCampaign) startWorker(id string) {
+	workers := service.workers
+	workers.mu.Lock()
+	defer workers.mu.Unlock()
+
+	if _, ok := workers.running[id]; ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(workers.ctx)
+	worker := &campaignWorker{cancel: cancel}
+	workers.running[id] = worker
+
+	go func() {
+		defer func() {
+			cancel()
+			workers.mu.Lock()
+			if workers.running[id] == worker {
+				delete(workers.running, id)
+			}
+			workers.mu.Unlock()
+		}()
+		service.runCampaign(ctx, id)
+	}()
+}
+
+func (service serviceCampaign) stopWorker(id string) {
+	workers := service.workers
+	workers.mu.Lock()
+	defer workers.mu.Unlock()
+
+	if worker, ok := workers.running[id]; ok {
+		worker.cancel()
+		delete(workers.running, id)
+	}
+}
+
+// runCampaign sends to pending recipients one at a time until the campaign is done or no longer running
+func (service serviceCampaign) runCampaign(ctx context.Context, id string) {
+	for ctx.Err() == nil {
+		campaign, err := service.repo.Get("", id)
+		if err != nil {
+			logrus.Errorf("Campaign %s: failed to load: %v", id, err)
+			if !sleepContext(ctx, campaignDeviceRetryDelay) {
+				return
+			}
+			continue
+		}
+		if campaign == nil || campaign.Status != domainCampaign.StatusRunning {
+			return
+		}
+
+		if wait := quietHoursWait(time.Now(), campaign.QuietHoursStart, campaign.QuietHoursEnd, campaign.Timezone); wait > 0 {
+			logrus.Infof("Campaign %s: quiet hours, resuming in %s", id, wait.Round(time.Second))
+			if !sleepContext(ctx, wait) {
+				return
+			}
+			continue
+		}
+
+		inst, ok := service.manager.GetDevice(campaign.DeviceID)
+		if !ok || inst == nil {
+			logrus.Warnf("Campaign %s: device %s no longer exists, pausing", id, campaign.DeviceID)
+			_, _ = service.repo.SetStatus("", id, []string{domainCampaign.StatusRunning}, domainCampaign.StatusPaused)
+			return
+		}
+		if !inst.IsConnected() || !inst.IsLoggedIn() {
+			if !sleepContext(ctx, campaignDeviceRetryDelay) {
+				return
+			}
+			continue
+		}
+
+		recipient, err := service.repo.ClaimNextRecipient(id)
+		if err != nil {
+			logrus.Errorf("Campaign %s: failed to claim recipient: %v", id, err)
+			if !sleepContext(ctx, campaignDeviceRetryDelay) {
+				return
+			}
+			continue
+		}
+		if recipient == nil {
+			service.complete(campaign, inst)
+			return
+		}
+
+		delay := service.sendToRecipient(ctx, inst, campaign, recipient)
+		if !sleepContext(ctx, delay) {
+			return
+		}
+	}
+}
+
+// sendToRecipient delivers one message and returns how long to wait before the next one
+func (service serviceCampaign) sendToRecipient(ctx context.Context, inst *whatsapp.DeviceInstance, campaign *domainCampaign.Campaign, recipient *domainCampaign.Recipient) time.Duration {
+	// Pausing or cancelling stops the loop, but a send that already started is allowed to finish
+	sendCtx, cancel := context.WithTimeout(whatsapp.ContextWithDevice(context.WithoutCancel(ctx), inst), campaignSendTimeout)
+	defer cancel()
+
+	status, messageID, errMsg := domainCampaign.RecipientSent, "", ""
+	delay := campaignSendInterval(campaign.RatePerMinute, campaign.JitterSeconds)
+
+	err := func() (err error) {
+		// Send usecases panic on login/connection problems; turn that into a failed recipient
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+
+		if strings.HasSuffix(recipient.Phone, "@s.whatsapp.net") {
+			results, err := inst.GetClient().IsOnWhatsApp(sendCtx, []string{"+" + strings.TrimSuffix(recipient.Phone, "@s.whatsapp.net")})
+			if err != nil {
+				return fmt.Errorf("check WhatsApp registration: %w", err)
+			}
+			if len(results) == 0 || !results[0].IsIn {
+				status, errMsg, delay = domainCampaign.RecipientSkipped, "not on WhatsApp", campaignSkipDelay
+				return nil
+			}
+		}
+
+		payload, err := renderCampaignPayload(campaign.Payload, recipient.Phone, recipient.Variables)
+		if err != nil {
+			return err
+		}
+		resp, err := sendPayloadHandlers[campaign.Type].send(sendCtx, service.sendService, payload)
+		messageID = resp.MessageID
+		return err
+	}()
+	var rateLimited pkgError.RateLimitError
+	switch {
+	case errors.As(err, &rateLimited):
+		// Put the recipient back in the queue and wait for the limiter instead of failing it
+		status, errMsg, delay = domainCampaign.RecipientPending, "", rateLimited.RetryAfter
+	case err != nil:
+		status, errMsg = domainCampaign.RecipientFailed, err.Error()
+	}
+
+	if finishErr := service.repo.FinishRecipient(recipient.ID, status, messageID, errMsg, time.Now()); finishErr != nil {
+		logrus.Errorf("Campaign %s: failed to record result for %s: %v", campaign.ID, recipient.Phone, finishErr)
+	}
+	logrus.WithFields(logrus.Fields{
+		"campaign_id": campaign.ID,
+		"phone":       recipient.Phone,
+		"status":      status,
+	}).Debug("Campaign recipient processed")
+
+	return delay
+}
+
+func (service serviceCampaign) complete(campaign *domainCampaign.Campaign, inst *whatsapp.DeviceInstance) {
+	completed, err := service.repo.SetStatus("", campaign.ID, []string{domainCampaign.StatusRunning}, domainCampaign.StatusCompleted)
+	if err != nil {
+		logrus.Errorf("Campaign %s: failed to mark completed: %v", campaign.ID, err)
+		return
+	}
+	if !completed {
+		return
+	}
+
+	finished, err := service.repo.Get("", campaign.ID)
+	if err != nil || finished == nil {
+		return
+	}
+	logrus.WithFields(logrus.Fields{
+		"campaign_id": campaign.ID,
+		"sent":        finished.Progress.Sent,
+		"failed":      finished.Progress.Failed,
+		"skipped":     finished.Progress.Skipped,
+	}).Info("Campaign completed")
+
+	webhookDeviceID := campaign.DeviceID
+	if jid := inst.JID(); jid != "" {
+		webhookDeviceID = jid
+	}
+	payload := map[string]any{
+		"id":       finished.ID,
+		"name":     finished.Name,
+		"status":   finished.Status,
+		"progress": finished.Progress,
+	}
+	go func() {
+		webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := whatsapp.ForwardEventToWebhooks(webhookCtx, "campaign.completed", webhookDeviceID, payload); err != nil {
+			logrus.Errorf("Campaign %s: failed to forward completion webhook: %v", campaign.ID, err)
+		}
+	}()
+}
+
+// sleepContext waits for d and reports false if ctx was cancelled first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	domainCampaign "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/campaign"
+)
+
+func TestParseRecipientsCSV(t *testing.T) {
+	recipients, err := parseRecipientsCSV("\ufeffname,Phone,order\nBudi,6289685028129,INV-1\n,,\nSiti, +6281234567890 ,INV-2\n")
+	if err != nil {
+		t.Fatalf("parseRecipientsCSV() error = %v", err)
+	}
+	if len(recipients) != 2 {
+		t.Fatalf("expected 2 recipients, got %d", len(recipients))
+	}
+	if recipients[0].Phone != "6289685028129" || recipients[0].Variables["name"] != "Budi" || recipients[0].Variables["order"] != "INV-1" {
+		t.Fatalf("unexpected first recipient: %+v", recipients[0])
+	}
+	if recipients[1].Phone != "+6281234567890" {
+		t.Fatalf("unexpected second phone %q", recipients[1].Phone)
+	}
+
+	if _, err := parseRecipientsCSV("name,number\nBudi,628123"); err == nil {
+		t.Fatal("expected error for CSV without phone column")
+	}
+}
+
+func TestCollectCampaignRecipientsDeduplicates(t *testing.T) {
+	recipients, err := collectCampaignRecipients(domainCampaign.CreateCampaignRequest{
+		Recipients:    []domainCampaign.RecipientInput{{Phone: "+6289685028129"}},
+		RecipientsCSV: "phone\n6289685028129\n6281234567890",
+	})
+	if err != nil {
+		t.Fatalf("collectCampaignRecipients() error = %v", err)
+	}
+	if len(recipients) != 2 {
+		t.Fatalf("expected 2 unique recipients, got %d", len(recipients))
+	}
+	if recipients[0].Phone != "6289685028129@s.whatsapp.net" {
+		t.Fatalf("phone was not sanitized: %q", recipients[0].Phone)
+	}
+}
+
+func TestRenderCampaignPayload(t *testing.T) {
+	payload := json.RawMessage(`{"phone":"ignored","message":"Hi {{name}} ({{phone}})","options":["{{name}}","b"],"duration":3600}`)
+
+	rendered, err := renderCampaignPayload(payload, "6289685028129@s.whatsapp.net", map[string]string{"name": "Budi"})
+	if err != nil {
+		t.Fatalf("renderCampaignPayload() error = %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(rendered, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["phone"] != "6289685028129@s.whatsapp.net" {
+		t.Fatalf("phone = %v", got["phone"])
+	}
+	if got["message"] != "Hi Budi (6289685028129)" {
+		t.Fatalf("message = %v", got["message"])
+	}
+	if options := got["options"].([]any); options[0] != "Budi" {
+		t.Fatalf("options = %v", options)
+	}
+	if got["duration"] != float64(3600) {
+		t.Fatalf("duration = %v", got["duration"])
+	}
+
+	if _, err := renderCampaignPayload(json.RawMessage(`["not","object"]`), "628", nil); err == nil {
+		t.Fatal("expected error for non-object payload")
+	}
+}
+
+func TestQuietHoursWait(t *testing.T) {
+	at := func(clock string) time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", "2026-03-01 "+clock)
+		return t
+	}
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		start, end string
+		want       time.Duration
+	}{
+		{"disabled", at("23:00"), "", "", 0},
+		{"outside overnight window", at("12:00"), "21:00", "08:00", 0},
+		{"inside overnight window before midnight", at("22:30"), "21:00", "08:00", 9*time.Hour + 30*time.Minute},
+		{"inside overnight window after midnight", at("07:00"), "21:00", "08:00", time.Hour},
+		{"inside daytime window", at("12:15"), "12:00", "13:00", 45 * time.Minute},
+		{"at window end", at("13:00"), "12:00", "13:00", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quietHoursWait(tt.now, tt.start, tt.end, ""); got != tt.want {
+				t.Fatalf("quietHoursWait() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// 22:00 UTC is 05:00 in Jakarta, inside a 21:00-08:00 window there
+	if got := quietHoursWait(at("22:00"), "21:00", "08:00", "Asia/Jakarta"); got != 3*time.Hour {
+		t.Fatalf("quietHoursWait() with timezone = %s, want 3h", got)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
 	"github.com/sirupsen/logrus"
 	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/types"
 )
 
 type serviceChat struct {
@@ -363,3 +364,80 @@ func (service serviceChat) ArchiveChat(ctx context.Context, request domainChat.A
 
 	return response, nil
 }
+
+func (service serviceChat) RequestHistory(ctx context.Context, request domainChat.RequestHistoryRequest) (response domainChat.RequestHistoryResponse, err error) {
+	if err = validations.ValidateRequestHistory(ctx, &request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	// Validate JID and ensure connection
+	targetJID, err := utils.ValidateJidWithLogin(client, request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	deviceID := deviceIDFromContext(ctx)
+	chatJID := targetJID.String()
+
+	// The phone only sends messages older than a message we already know about
+	var anchor *domainChatStorage.Message
+	if request.MessageID != "" {
+		anchor, err = service.chatStorageRepo.GetMessageByIDByDevice(deviceID, request.MessageID)
+		if err != nil {
+			return response, err
+		}
+		if anchor == nil || anchor.ChatJID != chatJID {
+			return response, pkgError.ValidationError(fmt.Sprintf("message %s not found in chat %s", request.MessageID, chatJID))
+		}
+	} else {
+		anchor, err = service.chatStorageRepo.GetOldestMessageByDevice(deviceID, chatJID)
+		if err != nil {
+			return response, err
+		}
+		if anchor == nil {
+			return response, pkgError.ValidationError(fmt.Sprintf("no stored messages for chat %s, provide message_id to anchor the history request", chatJID))
+		}
+	}
+
+	lastKnown := &types.MessageInfo{
+		MessageSource: types.MessageSource{
+			Chat:     targetJID,
+			IsFromMe: anchor.IsFromMe,
+		},
+		ID:        anchor.ID,
+		Timestamp: anchor.Timestamp,
+	}
+
+	resp, err := client.SendPeerMessage(ctx, client.BuildHistorySyncRequest(lastKnown, request.Count))
+	if err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"chat_jid":   chatJID,
+			"message_id": anchor.ID,
+			"count":      request.Count,
+		}).Error("Failed to send history sync request")
+		return response, err
+	}
+
+	whatsapp.TrackHistoryRequest(ctx, deviceID, chatJID, resp.ID, request.Count)
+
+	response.Status = "success"
+	response.Message = "History sync requested, messages will be stored as the phone delivers them"
+	response.ChatJID = chatJID
+	response.OldestMessageID = anchor.ID
+	response.Count = request.Count
+	response.RequestID = resp.ID
+
+	logrus.WithFields(logrus.Fields{
+		"chat_jid":   chatJID,
+		"message_id": anchor.ID,
+		"count":      request.Count,
+		"request_id": resp.ID,
+	}).Info("History sync request sent successfully")
+
+	return response, nil
+}
@@ -145,6 +145,26 @@ func (s *serviceDevice) GetStatus(_ context.Context, deviceID string) (bool, boo
 	return false, false, fmt.Errorf("device %s not found", deviceID)
 }
 
+func (s *serviceDevice) ReplayHistory(ctx context.Context, deviceID string) (domainDevice.HistoryReplayResult, error) {
+	inst, resolvedID, err := s.manager.ResolveDevice(deviceID)
+	if err != nil {
+		return domainDevice.HistoryReplayResult{}, err
+	}
+
+	replay, err := whatsapp.ReplayHistoryDumps(ctx, inst)
+	if err != nil {
+		return domainDevice.HistoryReplayResult{}, err
+	}
+
+	return domainDevice.HistoryReplayResult{
+		DeviceID: resolvedID,
+		Files:    replay.Files,
+		Replayed: replay.Replayed,
+		Failed:   replay.Failed,
+		Errors:   replay.Errors,
+	}, nil
+}
+
 func convertInstance(inst *whatsapp.DeviceInstance) domainDevice.Device {
 	if inst == nil {
 		return domainDevice.Device{}
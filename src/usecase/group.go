@@ -3,12 +3,14 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/types"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -16,10 +18,14 @@ import (
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
 )
 
-type serviceGroup struct{}
+type serviceGroup struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
 
-func NewGroupService() domainGroup.IGroupUsecase {
-	return &serviceGroup{}
+func NewGroupService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainGroup.IGroupUsecase {
+	return &serviceGroup{
+		chatStorageRepo: chatStorageRepo,
+	}
 }
 
 func (service serviceGroup) JoinGroupWithLink(ctx context.Context, request domainGroup.JoinGroupWithLinkRequest) (groupID string, err error) {
@@ -180,7 +186,7 @@ func (service serviceGroup) GetGroupParticipants(ctx context.Context, request do
 		return response, err
 	}
 
-	groupInfo, err := client.GetGroupInfo(ctx, groupJID)
+	groupInfo, err := whatsapp.StoredGroupInfo(ctx, client, service.chatStorageRepo, deviceIDFromContext(ctx), groupJID, request.Refresh)
 	if err != nil {
 		return response, err
 	}
@@ -471,6 +477,103 @@ func (service serviceGroup) SetGroupTopic(ctx context.Context, request domainGro
 	return client.SetGroupTopic(ctx, groupJID, "", "", request.Topic)
 }
 
+func (service serviceGroup) SetGroupJoinApproval(ctx context.Context, request domainGroup.SetGroupJoinApprovalRequest) (err error) {
+	if err = validations.ValidateSetGroupJoinApproval(ctx, request); err != nil {
+		return err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return pkgError.ErrWaCLI
+	}
+
+	groupJID, err := utils.ValidateJidWithLogin(client, request.GroupID)
+	if err != nil {
+		return err
+	}
+
+	return client.SetGroupJoinApprovalMode(ctx, groupJID, request.Enabled)
+}
+
+func (service serviceGroup) SetGroupMemberAddMode(ctx context.Context, request domainGroup.SetGroupMemberAddModeRequest) (err error) {
+	if err = validations.ValidateSetGroupMemberAddMode(ctx, request); err != nil {
+		return err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return pkgError.ErrWaCLI
+	}
+
+	groupJID, err := utils.ValidateJidWithLogin(client, request.GroupID)
+	if err != nil {
+		return err
+	}
+
+	return client.SetGroupMemberAddMode(ctx, groupJID, request.Mode)
+}
+
+func (service serviceGroup) SetGroupDisappearing(ctx context.Context, request domainGroup.SetGroupDisappearingRequest) (err error) {
+	if err = validations.ValidateSetGroupDisappearing(ctx, request); err != nil {
+		return err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return pkgError.ErrWaCLI
+	}
+
+	groupJID, err := utils.ValidateJidWithLogin(client, request.GroupID)
+	if err != nil {
+		return err
+	}
+	if groupJID.Server != types.GroupServer {
+		return pkgError.ValidationError(fmt.Sprintf("%s is not a group", request.GroupID))
+	}
+
+	return client.SetDisappearingTimer(ctx, groupJID, time.Duration(request.TimerSeconds)*time.Second, time.Now())
+}
+
+// SetGroupDescription replaces the group description and returns the ID of the new one, which a later edit passes
+// as previous_id
+func (service serviceGroup) SetGroupDescription(ctx context.Context, request domainGroup.SetGroupDescriptionRequest) (response domainGroup.SetGroupDescriptionResponse, err error) {
+	if err = validations.ValidateSetGroupDescription(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	groupJID, err := utils.ValidateJidWithLogin(client, request.GroupID)
+	if err != nil {
+		return response, err
+	}
+
+	previousID := request.PreviousID
+	if previousID == "" {
+		groupInfo, err := client.GetGroupInfo(ctx, groupJID)
+		if err != nil {
+			return response, err
+		}
+		if groupInfo != nil {
+			previousID = groupInfo.TopicID
+		}
+	}
+
+	descriptionID := client.GenerateMessageID()
+	if err = client.SetGroupTopic(ctx, groupJID, previousID, descriptionID, request.Description); err != nil {
+		return response, err
+	}
+
+	return domainGroup.SetGroupDescriptionResponse{
+		GroupID:       groupJID.String(),
+		DescriptionID: descriptionID,
+		PreviousID:    previousID,
+	}, nil
+}
+
 // GroupInfo retrieves detailed information about a WhatsApp group
 func (service serviceGroup) GroupInfo(ctx context.Context, request domainGroup.GroupInfoRequest) (response domainGroup.GroupInfoResponse, err error) {
 	// Validate the incoming request
@@ -492,15 +595,18 @@ func (service serviceGroup) GroupInfo(ctx context.Context, request domainGroup.G
 		return response, err
 	}
 
-	// Fetch group information from WhatsApp
-	groupInfo, err := client.GetGroupInfo(ctx, groupJID)
+	// Use the stored group metadata unless a refresh from WhatsApp is asked for
+	groupInfo, err := whatsapp.StoredGroupInfo(ctx, client, service.chatStorageRepo, deviceIDFromContext(ctx), groupJID, request.Refresh)
 	if err != nil {
 		return response, err
 	}
 
 	// Map the response
 	if groupInfo != nil {
-		response.Data = *groupInfo
+		response.Data = domainGroup.GroupInfo{
+			GroupInfo: *groupInfo,
+			Settings:  groupSettings(groupInfo),
+		}
 	}
 
 	return response, nil
@@ -534,3 +640,44 @@ func (service serviceGroup) GetGroupInviteLink(ctx context.Context, request doma
 
 	return response, nil
 }
+
+// RevokeGroupInviteLink invalidates the current invite link and returns the one replacing it
+func (service serviceGroup) RevokeGroupInviteLink(ctx context.Context, request domainGroup.RevokeGroupInviteLinkRequest) (response domainGroup.GetGroupInviteLinkResponse, err error) {
+	if err = validations.ValidateRevokeGroupInviteLink(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	groupJID, err := utils.ValidateJidWithLogin(client, request.GroupID)
+	if err != nil {
+		return response, err
+	}
+
+	inviteLink, err := client.GetGroupInviteLink(ctx, groupJID, true)
+	if err != nil {
+		return response, err
+	}
+
+	return domainGroup.GetGroupInviteLinkResponse{
+		InviteLink: inviteLink,
+		GroupID:    request.GroupID,
+	}, nil
+}
+
+// groupSettings gathers the settings spread over the group info
+func groupSettings(info *types.GroupInfo) domainGroup.GroupSettings {
+	return domainGroup.GroupSettings{
+		IsLocked:               info.IsLocked,
+		IsAnnounce:             info.IsAnnounce,
+		IsJoinApprovalRequired: info.IsJoinApprovalRequired,
+		MemberAddMode:          info.MemberAddMode,
+		DisappearingTimer:      info.DisappearingTimer,
+		Description:            info.Topic,
+		DescriptionID:          info.TopicID,
+	}
+}
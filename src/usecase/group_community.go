@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func (service serviceGroup) CreateCommunity(ctx context.Context, request domainGroup.CreateCommunityRequest) (response domainGroup.CreateCommunityResponse, err error) {
+	if err = validations.ValidateCreateCommunity(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	communityInfo, err := client.CreateGroup(ctx, whatsmeow.ReqCreateGroup{
+		Name:        request.Name,
+		GroupParent: types.GroupParent{IsParent: true},
+	})
+	if err != nil {
+		return response, err
+	}
+	response.CommunityID = communityInfo.JID.String()
+
+	if request.Description != "" {
+		if err = client.SetGroupTopic(ctx, communityInfo.JID, "", "", request.Description); err != nil {
+			logrus.Warnf("Community %s created, but setting its description failed: %v", communityInfo.JID, err)
+		}
+	}
+
+	// WhatsApp creates the announcement group together with the community
+	if subGroups, err := client.GetSubGroups(ctx, communityInfo.JID); err == nil {
+		if announcement, _ := splitCommunityGroups(subGroups); announcement != nil {
+			response.AnnouncementGroupID = announcement.GroupID
+		}
+	}
+
+	return response, nil
+}
+
+func (service serviceGroup) CommunityInfo(ctx context.Context, request domainGroup.CommunityRequest) (response domainGroup.CommunityInfoResponse, err error) {
+	if err = validations.ValidateCommunity(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	communityInfo, err := service.communityInfo(ctx, client, request.CommunityID)
+	if err != nil {
+		return response, err
+	}
+
+	subGroups, err := client.GetSubGroups(ctx, communityInfo.JID)
+	if err != nil {
+		return response, err
+	}
+
+	response = domainGroup.CommunityInfoResponse{
+		CommunityID:            communityInfo.JID.String(),
+		Name:                   communityInfo.Name,
+		Description:            communityInfo.Topic,
+		OwnerJID:               communityInfo.OwnerJID.String(),
+		CreatedAt:              communityInfo.GroupCreated,
+		IsLocked:               communityInfo.IsLocked,
+		MembershipApprovalMode: communityInfo.DefaultMembershipApprovalMode,
+	}
+	response.AnnouncementGroup, response.Groups = splitCommunityGroups(subGroups)
+
+	// Members are the union of all linked groups; only admins of the community may list them
+	if members, err := client.GetLinkedGroupsParticipants(ctx, communityInfo.JID); err == nil {
+		response.MemberCount = len(members)
+	} else {
+		logrus.Debugf("Could not count members of community %s: %v", communityInfo.JID, err)
+	}
+
+	return response, nil
+}
+
+func (service serviceGroup) GetCommunitySubGroups(ctx context.Context, request domainGroup.CommunityRequest) (response domainGroup.CommunitySubGroupsResponse, err error) {
+	if err = validations.ValidateCommunity(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	communityJID, err := utils.ValidateJidWithLogin(client, request.CommunityID)
+	if err != nil {
+		return response, err
+	}
+
+	subGroups, err := client.GetSubGroups(ctx, communityJID)
+	if err != nil {
+		return response, err
+	}
+
+	response.CommunityID = communityJID.String()
+	response.AnnouncementGroup, response.Groups = splitCommunityGroups(subGroups)
+	return response, nil
+}
+
+// LinkCommunityGroups adds existing groups to a community. Each group is linked on its own, so one group we do
+// not administer does not stop the others.
+func (service serviceGroup) LinkCommunityGroups(ctx context.Context, request domainGroup.CommunityGroupsRequest) (result []domainGroup.CommunityGroupStatus, err error) {
+	if err = validations.ValidateCommunityGroups(ctx, request); err != nil {
+		return result, err
+	}
+	return service.changeCommunityGroups(ctx, request, "link", func(client *whatsmeow.Client, community, group types.JID) error {
+		return client.LinkGroup(ctx, community, group)
+	})
+}
+
+// UnlinkCommunityGroups removes groups from a community; the groups themselves keep existing
+func (service serviceGroup) UnlinkCommunityGroups(ctx context.Context, request domainGroup.CommunityGroupsRequest) (result []domainGroup.CommunityGroupStatus, err error) {
+	if err = validations.ValidateCommunityGroups(ctx, request); err != nil {
+		return result, err
+	}
+	return service.changeCommunityGroups(ctx, request, "unlink", func(client *whatsmeow.Client, community, group types.JID) error {
+		return client.UnlinkGroup(ctx, community, group)
+	})
+}
+
+func (service serviceGroup) CreateCommunityGroup(ctx context.Context, request domainGroup.CreateCommunityGroupRequest) (groupID string, err error) {
+	if err = validations.ValidateCreateCommunityGroup(ctx, request); err != nil {
+		return groupID, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return groupID, pkgError.ErrWaCLI
+	}
+
+	communityInfo, err := service.communityInfo(ctx, client, request.CommunityID)
+	if err != nil {
+		return groupID, err
+	}
+
+	participantsJID, err := service.participantToJID(ctx, request.Participants)
+	if err != nil {
+		return groupID, err
+	}
+
+	groupInfo, err := client.CreateGroup(ctx, whatsmeow.ReqCreateGroup{
+		Name:              request.Title,
+		Participants:      participantsJID,
+		GroupLinkedParent: types.GroupLinkedParent{LinkedParentJID: communityInfo.JID},
+	})
+	if err != nil {
+		return groupID, err
+	}
+
+	return groupInfo.JID.String(), nil
+}
+
+func (service serviceGroup) changeCommunityGroups(ctx context.Context, request domainGroup.CommunityGroupsRequest, action string, change func(client *whatsmeow.Client, community, group types.JID) error) (result []domainGroup.CommunityGroupStatus, err error) {
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return result, pkgError.ErrWaCLI
+	}
+
+	communityInfo, err := service.communityInfo(ctx, client, request.CommunityID)
+	if err != nil {
+		return result, err
+	}
+
+	for _, groupID := range request.GroupIDs {
+		status := domainGroup.CommunityGroupStatus{GroupID: groupID, Status: "success", Message: fmt.Sprintf("Action %s success", action)}
+
+		groupJID, err := utils.ParseJID(groupID)
+		if err == nil && groupJID.Server != types.GroupServer {
+			err = fmt.Errorf("%s is not a group", groupID)
+		}
+		if err == nil {
+			status.GroupID = groupJID.String()
+			err = change(client, communityInfo.JID, groupJID)
+		}
+		if err != nil {
+			status.Status = "error"
+			status.Message = fmt.Sprintf("Action %s failed: %v", action, err)
+		}
+		result = append(result, status)
+	}
+
+	return result, nil
+}
+
+// communityInfo fetches the group info of a community, rejecting plain groups
+func (service serviceGroup) communityInfo(ctx context.Context, client *whatsmeow.Client, communityID string) (*types.GroupInfo, error) {
+	communityJID, err := utils.ValidateJidWithLogin(client, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.GetGroupInfo(ctx, communityJID)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil || !info.IsParent {
+		return nil, pkgError.ValidationError(fmt.Sprintf("%s is not a community", communityID))
+	}
+	return info, nil
+}
+
+// splitCommunityGroups separates the announcement group from the other linked groups, which are sorted by name
+func splitCommunityGroups(subGroups []*types.GroupLinkTarget) (announcement *domainGroup.CommunityGroup, groups []domainGroup.CommunityGroup) {
+	groups = make([]domainGroup.CommunityGroup, 0, len(subGroups))
+	for _, subGroup := range subGroups {
+		if subGroup == nil {
+			continue
+		}
+		group := domainGroup.CommunityGroup{
+			GroupID:        subGroup.JID.String(),
+			Name:           subGroup.Name,
+			IsAnnouncement: subGroup.IsDefaultSubGroup,
+		}
+		if group.IsAnnouncement {
+			announcement = &group
+			continue
+		}
+		groups = append(groups, group)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return announcement, groups
+}
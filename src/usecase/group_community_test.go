@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestSplitCommunityGroups(t *testing.T) {
+	target := func(user, name string, announcement bool) *types.GroupLinkTarget {
+		return &types.GroupLinkTarget{
+			JID:               types.NewJID(user, types.GroupServer),
+			GroupName:         types.GroupName{Name: name},
+			GroupIsDefaultSub: types.GroupIsDefaultSub{IsDefaultSubGroup: announcement},
+		}
+	}
+
+	announcement, groups := splitCommunityGroups([]*types.GroupLinkTarget{
+		target("120363000000000003", "Sports", false),
+		nil,
+		target("120363000000000002", "Neighbourhood", true),
+		target("120363000000000004", "Parents", false),
+	})
+
+	if announcement == nil || announcement.GroupID != "120363000000000002@g.us" || !announcement.IsAnnouncement {
+		t.Fatalf("unexpected announcement group: %+v", announcement)
+	}
+	if len(groups) != 2 || groups[0].Name != "Parents" || groups[1].Name != "Sports" {
+		t.Fatalf("expected the other groups sorted by name, got %+v", groups)
+	}
+
+	announcement, groups = splitCommunityGroups(nil)
+	if announcement != nil || groups == nil || len(groups) != 0 {
+		t.Fatalf("expected no announcement group and an empty list, got %+v %+v", announcement, groups)
+	}
+}
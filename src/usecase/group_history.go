@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// GroupHistory lists the recorded changes of a group, newest first. Only changes seen while gowa was running are
+// known; it reads stored events, so the device does not have to be connected.
+func (service serviceGroup) GroupHistory(ctx context.Context, request domainGroup.GroupHistoryRequest) (response domainGroup.GroupHistoryResponse, err error) {
+	if err = validations.ValidateGroupHistory(ctx, &request); err != nil {
+		return response, err
+	}
+
+	groupJID, err := utils.ParseJID(request.GroupID)
+	if err != nil || groupJID.Server != types.GroupServer {
+		return response, pkgError.ValidationError(fmt.Sprintf("%s is not a group", request.GroupID))
+	}
+
+	filter := &domainChatStorage.GroupEventFilter{
+		DeviceID: deviceIDFromContext(ctx),
+		GroupJID: groupJID.ToNonAD().String(),
+		Limit:    request.Limit,
+		Offset:   request.Offset,
+	}
+	for _, eventType := range strings.Split(request.Types, ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			filter.Types = append(filter.Types, eventType)
+		}
+	}
+	if filter.Participant, err = historyUserFilter("participant", request.Participant); err != nil {
+		return response, err
+	}
+	if filter.Actor, err = historyUserFilter("actor", request.Actor); err != nil {
+		return response, err
+	}
+	// Validation already checked the format
+	if request.StartTime != "" {
+		startTime, _ := time.Parse(time.RFC3339, request.StartTime)
+		filter.StartTime = &startTime
+	}
+	if request.EndTime != "" {
+		endTime, _ := time.Parse(time.RFC3339, request.EndTime)
+		filter.EndTime = &endTime
+	}
+
+	records, total, err := service.chatStorageRepo.GetGroupEvents(filter)
+	if err != nil {
+		return response, err
+	}
+
+	response.GroupID = filter.GroupJID
+	response.Total = total
+	response.Data = make([]domainGroup.GroupHistoryEvent, 0, len(records))
+	for _, record := range records {
+		response.Data = append(response.Data, domainGroup.GroupHistoryEvent{
+			ID:          record.ID,
+			Type:        record.Type,
+			Participant: record.Participant,
+			Actor:       record.Actor,
+			Setting:     record.Setting,
+			Value:       record.Value,
+			Timestamp:   record.Timestamp,
+		})
+	}
+	return response, nil
+}
+
+// historyUserFilter turns a phone number or JID into the user JID form history events are stored with
+func historyUserFilter(field, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	jid, err := utils.ParseJID(value)
+	if err != nil {
+		return "", pkgError.ValidationError(fmt.Sprintf("%s: %v", field, err))
+	}
+	return jid.ToNonAD().String(), nil
+}
@@ -0,0 +1,512 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxParticipantJobSize matches the largest group WhatsApp allows
+	maxParticipantJobSize       = 1024
+	participantJobBatchTimeout  = 2 * time.Minute
+	participantJobRetention     = 24 * time.Hour
+	participantJobSweepInterval = 10 * time.Minute
+)
+
+// participantJobs holds every job still within the retention window
+type participantJobs struct {
+	mu   sync.RWMutex
+	ctx  context.Context
+	jobs map[string]*participantJobState
+}
+
+type participantJobState struct {
+	job          *domainGroup.ParticipantJob
+	groupName    string
+	inviteText   string
+	participants []types.JID
+	cancel       context.CancelFunc
+}
+
+// participantGateway is the WhatsApp side of a participant job
+type participantGateway interface {
+	updateParticipants(ctx context.Context, group types.JID, participants []types.JID, action whatsmeow.ParticipantChange) ([]types.GroupParticipant, error)
+	sendInvite(ctx context.Context, group types.JID, groupName string, participant types.JID, request *types.GroupParticipantAddRequest, caption string) error
+}
+
+type serviceGroupParticipantJob struct {
+	state   *participantJobs
+	gateway participantGateway
+}
+
+func NewGroupParticipantJobService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainGroup.IGroupParticipantJobUsecase {
+	return &serviceGroupParticipantJob{
+		state: &participantJobs{
+			ctx:  context.Background(),
+			jobs: make(map[string]*participantJobState),
+		},
+		gateway: whatsappParticipantGateway{sender: serviceSend{chatStorageRepo: chatStorageRepo, limiter: sharedOutboundLimiter()}},
+	}
+}
+
+func (service serviceGroupParticipantJob) CreateParticipantJob(ctx context.Context, request domainGroup.CreateParticipantJobRequest) (response domainGroup.ParticipantJob, err error) {
+	if err = validations.ValidateCreateParticipantJob(ctx, &request); err != nil {
+		return response, err
+	}
+
+	participants, err := collectJobParticipants(request)
+	if err != nil {
+		return response, err
+	}
+
+	inst, ok := whatsapp.DeviceFromContext(ctx)
+	if !ok || inst == nil {
+		return response, pkgError.ValidationError("device context is required")
+	}
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	groupJID, err := utils.ValidateJidWithLogin(client, request.GroupID)
+	if err != nil {
+		return response, err
+	}
+	groupInfo, err := client.GetGroupInfo(ctx, groupJID)
+	if err != nil {
+		return response, err
+	}
+
+	now := time.Now().UTC()
+	job := &domainGroup.ParticipantJob{
+		ID:                uuid.NewString(),
+		DeviceID:          inst.ID(),
+		GroupID:           groupJID.String(),
+		Action:            request.Action,
+		Status:            domainGroup.JobStatusRunning,
+		BatchSize:         request.BatchSize,
+		BatchDelaySeconds: request.BatchDelaySeconds,
+		InviteFallback:    *request.InviteFallback,
+		Results:           make([]domainGroup.ParticipantJobResult, len(participants)),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	for i, participant := range participants {
+		job.Results[i] = domainGroup.ParticipantJobResult{Participant: participant.String(), Status: domainGroup.ParticipantPending}
+	}
+	job.Progress = participantJobProgress(job.Results)
+
+	jobState := &participantJobState{job: job, inviteText: request.InviteMessage, participants: participants}
+	if groupInfo != nil {
+		jobState.groupName = groupInfo.Name
+	}
+
+	service.state.mu.Lock()
+	for _, other := range service.state.jobs {
+		if other.job.Status == domainGroup.JobStatusRunning && other.job.DeviceID == job.DeviceID && other.job.GroupID == job.GroupID {
+			service.state.mu.Unlock()
+			return response, pkgError.ValidationError(fmt.Sprintf("participant job %s is still running for group %s", other.job.ID, job.GroupID))
+		}
+	}
+	jobCtx, cancel := context.WithCancel(whatsapp.ContextWithDevice(service.state.ctx, inst))
+	jobState.cancel = cancel
+	service.state.jobs[job.ID] = jobState
+	response = copyParticipantJob(job)
+	service.state.mu.Unlock()
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":       job.ID,
+		"device_id":    job.DeviceID,
+		"group_id":     job.GroupID,
+		"action":       job.Action,
+		"participants": len(participants),
+	}).Info("Group participant job started")
+
+	go service.runJob(jobCtx, jobState)
+	return response, nil
+}
+
+// collectJobParticipants merges JSON and CSV participants into unique user JIDs
+func collectJobParticipants(request domainGroup.CreateParticipantJobRequest) ([]types.JID, error) {
+	inputs := request.Participants
+	if request.ParticipantsCSV != "" {
+		parsed, err := parsePhoneCSV("participants_csv", request.ParticipantsCSV)
+		if err != nil {
+			return nil, err
+		}
+		for _, input := range parsed {
+			inputs = append(inputs, input.Phone)
+		}
+	}
+
+	seen := make(map[types.JID]bool, len(inputs))
+	participants := make([]types.JID, 0, len(inputs))
+	for i, input := range inputs {
+		participant := strings.TrimPrefix(strings.TrimSpace(input), "+")
+		if participant == "" {
+			return nil, pkgError.ValidationError(fmt.Sprintf("participants[%d]: cannot be blank.", i))
+		}
+		if !strings.Contains(participant, "@") {
+			participant += "@" + types.DefaultUserServer
+		}
+		jid, err := types.ParseJID(participant)
+		if err != nil || jid.User == "" || (jid.Server != types.DefaultUserServer && jid.Server != types.HiddenUserServer) {
+			return nil, pkgError.ValidationError(fmt.Sprintf("participants[%d]: %q is not a phone number or user JID.", i, input))
+		}
+		jid = jid.ToNonAD()
+		if seen[jid] {
+			continue
+		}
+		seen[jid] = true
+		participants = append(participants, jid)
+	}
+
+	if len(participants) == 0 {
+		return nil, pkgError.ValidationError("participants: cannot be blank.")
+	}
+	if len(participants) > maxParticipantJobSize {
+		return nil, pkgError.ValidationError(fmt.Sprintf("participants: at most %d participants are allowed per job.", maxParticipantJobSize))
+	}
+	return participants, nil
+}
+
+func (service serviceGroupParticipantJob) GetParticipantJob(ctx context.Context, request domainGroup.ParticipantJobRequest) (response domainGroup.ParticipantJob, err error) {
+	if err = validations.ValidateParticipantJob(ctx, request); err != nil {
+		return response, err
+	}
+
+	jobState, err := service.find(ctx, request.ID)
+	if err != nil {
+		return response, err
+	}
+
+	service.state.mu.RLock()
+	defer service.state.mu.RUnlock()
+	return copyParticipantJob(jobState.job), nil
+}
+
+// CancelParticipantJob stops the job after the batch in flight; participants not reached yet are cancelled
+func (service serviceGroupParticipantJob) CancelParticipantJob(ctx context.Context, request domainGroup.ParticipantJobRequest) (response domainGroup.ParticipantJob, err error) {
+	if err = validations.ValidateParticipantJob(ctx, request); err != nil {
+		return response, err
+	}
+
+	jobState, err := service.find(ctx, request.ID)
+	if err != nil {
+		return response, err
+	}
+
+	service.state.mu.Lock()
+	defer service.state.mu.Unlock()
+
+	job := jobState.job
+	if job.Status != domainGroup.JobStatusRunning {
+		return response, pkgError.ValidationError(fmt.Sprintf("participant job %s is %s and cannot be cancelled", job.ID, job.Status))
+	}
+	jobState.cancel()
+	for i := range job.Results {
+		if job.Results[i].Status == domainGroup.ParticipantPending {
+			job.Results[i].Status = domainGroup.ParticipantCancelled
+		}
+	}
+	finishedAt := time.Now().UTC()
+	job.Status = domainGroup.JobStatusCancelled
+	job.FinishedAt = &finishedAt
+	job.UpdatedAt = finishedAt
+	job.Progress = participantJobProgress(job.Results)
+
+	logrus.WithFields(logrus.Fields{"job_id": job.ID, "device_id": job.DeviceID}).Info("Group participant job cancelled")
+	return copyParticipantJob(job), nil
+}
+
+func (service serviceGroupParticipantJob) Run(ctx context.Context) {
+	service.state.mu.Lock()
+	service.state.ctx = ctx
+	service.state.mu.Unlock()
+
+	ticker := time.NewTicker(participantJobSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			service.sweep(now)
+		}
+	}
+}
+
+func (service serviceGroupParticipantJob) find(ctx context.Context, id string) (*participantJobState, error) {
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	service.state.mu.RLock()
+	defer service.state.mu.RUnlock()
+
+	jobState, ok := service.state.jobs[id]
+	if !ok || jobState.job.DeviceID != deviceID {
+		return nil, pkgError.NotFoundError(fmt.Sprintf("participant job %s not found", id))
+	}
+	return jobState, nil
+}
+
+// runJob works through the participants one batch at a time, pausing between batches
+func (service serviceGroupParticipantJob) runJob(ctx context.Context, jobState *participantJobState) {
+	defer jobState.cancel()
+
+	service.state.mu.RLock()
+	job := jobState.job
+	groupJID, _ := types.ParseJID(job.GroupID)
+	batchSize, delay := job.BatchSize, time.Duration(job.BatchDelaySeconds)*time.Second
+	service.state.mu.RUnlock()
+
+	for start := 0; start < len(jobState.participants); start += batchSize {
+		if ctx.Err() != nil {
+			return
+		}
+		if start > 0 && !sleepContext(ctx, delay) {
+			return
+		}
+
+		end := min(start+batchSize, len(jobState.participants))
+		results := service.processBatch(ctx, jobState, groupJID, jobState.participants[start:end])
+
+		service.state.mu.Lock()
+		copy(job.Results[start:end], results)
+		job.Progress = participantJobProgress(job.Results)
+		job.UpdatedAt = time.Now().UTC()
+		service.state.mu.Unlock()
+	}
+
+	service.state.mu.Lock()
+	defer service.state.mu.Unlock()
+	if job.Status != domainGroup.JobStatusRunning {
+		return
+	}
+	finishedAt := time.Now().UTC()
+	job.Status = domainGroup.JobStatusCompleted
+	job.FinishedAt = &finishedAt
+	job.UpdatedAt = finishedAt
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":  job.ID,
+		"success": job.Progress.Success,
+		"invited": job.Progress.Invited,
+		"skipped": job.Progress.Skipped,
+		"failed":  job.Progress.Failed,
+	}).Info("Group participant job completed")
+}
+
+// processBatch applies the job action to one batch and invites the people who cannot be added directly
+func (service serviceGroupParticipantJob) processBatch(ctx context.Context, jobState *participantJobState, group types.JID, batch []types.JID) (results []domainGroup.ParticipantJobResult) {
+	results = make([]domainGroup.ParticipantJobResult, len(batch))
+	for i, participant := range batch {
+		results[i] = domainGroup.ParticipantJobResult{Participant: participant.String()}
+	}
+
+	// Cancelling stops the job between batches, but a batch that already started is allowed to finish
+	batchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), participantJobBatchTimeout)
+	defer cancel()
+
+	changed, err := func() (changed []types.GroupParticipant, err error) {
+		// Client lookups panic on login/connection problems; turn that into failed participants
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+		return service.gateway.updateParticipants(batchCtx, group, batch, jobState.job.Action)
+	}()
+	if err != nil {
+		for i := range results {
+			results[i].Status = domainGroup.ParticipantFailed
+			results[i].Message = err.Error()
+		}
+		return results
+	}
+
+	requests := make([]*types.GroupParticipantAddRequest, len(batch))
+	for i, participant := range batch {
+		answer, found := findChangedParticipant(changed, participant)
+		if !found {
+			results[i].Status = domainGroup.ParticipantFailed
+			results[i].Message = "WhatsApp did not report a result for this participant"
+			continue
+		}
+
+		results[i].ErrorCode = answer.Error
+		results[i].Status, results[i].Message = participantChangeOutcome(jobState.job.Action, answer.Error)
+		if answer.Error == 403 && answer.AddRequest != nil && jobState.job.InviteFallback {
+			requests[i] = answer.AddRequest
+		}
+	}
+
+	service.sendInvites(ctx, jobState, group, batch, requests, results)
+	return results
+}
+
+// sendInvites sends the group invite to every participant of the batch with an add request. An invite that hits the
+// outbound rate limit waits for a free slot and goes back to the end of the queue instead of failing.
+func (service serviceGroupParticipantJob) sendInvites(ctx context.Context, jobState *participantJobState, group types.JID, batch []types.JID, requests []*types.GroupParticipantAddRequest, results []domainGroup.ParticipantJobResult) {
+	var queue []int
+	for i, request := range requests {
+		if request != nil {
+			queue = append(queue, i)
+		}
+	}
+
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+
+		err := func() error {
+			sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), participantJobBatchTimeout)
+			defer cancel()
+			return service.gateway.sendInvite(sendCtx, group, jobState.groupName, batch[i], requests[i], jobState.inviteText)
+		}()
+
+		var rateLimited pkgError.RateLimitError
+		if errors.As(err, &rateLimited) {
+			if !sleepContext(ctx, rateLimited.RetryAfter) {
+				results[i].Message += "; the job was cancelled before the group invite was sent"
+				continue
+			}
+			queue = append(queue, i)
+			continue
+		}
+		if err != nil {
+			results[i].Message = fmt.Sprintf("%s; sending the group invite failed: %v", results[i].Message, err)
+			continue
+		}
+		results[i].Status = domainGroup.ParticipantInvited
+		results[i].Message = "Privacy settings do not allow adding this user, a group invite was sent instead"
+	}
+}
+
+// findChangedParticipant matches a requested participant with WhatsApp's answer, which may name it by phone or LID
+func findChangedParticipant(changed []types.GroupParticipant, participant types.JID) (types.GroupParticipant, bool) {
+	for _, answer := range changed {
+		if answer.JID.ToNonAD() == participant || answer.PhoneNumber.ToNonAD() == participant || answer.LID.ToNonAD() == participant {
+			return answer, true
+		}
+	}
+	return types.GroupParticipant{}, false
+}
+
+// participantChangeOutcome explains the code WhatsApp answered for one participant
+func participantChangeOutcome(action whatsmeow.ParticipantChange, code int) (status, message string) {
+	switch code {
+	case 0, 200:
+		return domainGroup.ParticipantSuccess, fmt.Sprintf("Action %s success", action)
+	case 403:
+		return domainGroup.ParticipantFailed, "Privacy settings do not allow adding this user"
+	case 404:
+		return domainGroup.ParticipantFailed, "User is not on WhatsApp or not in the group"
+	case 408:
+		return domainGroup.ParticipantFailed, "User left the group recently and cannot be added yet"
+	case 409:
+		if action == whatsmeow.ParticipantChangeAdd {
+			return domainGroup.ParticipantSkipped, "User is already in the group"
+		}
+		return domainGroup.ParticipantSkipped, "Nothing to change for this user"
+	default:
+		return domainGroup.ParticipantFailed, fmt.Sprintf("WhatsApp refused the change with code %d", code)
+	}
+}
+
+func participantJobProgress(results []domainGroup.ParticipantJobResult) (progress domainGroup.ParticipantJobProgress) {
+	progress.Total = len(results)
+	for _, result := range results {
+		switch result.Status {
+		case domainGroup.ParticipantPending:
+			progress.Pending++
+		case domainGroup.ParticipantSuccess:
+			progress.Success++
+		case domainGroup.ParticipantInvited:
+			progress.Invited++
+		case domainGroup.ParticipantSkipped:
+			progress.Skipped++
+		case domainGroup.ParticipantFailed:
+			progress.Failed++
+		case domainGroup.ParticipantCancelled:
+			progress.Cancelled++
+		}
+	}
+	if progress.Total > 0 {
+		progress.Percent = float64(progress.Total-progress.Pending) * 100 / float64(progress.Total)
+	}
+	return progress
+}
+
+// copyParticipantJob detaches a job from the running worker; the caller must hold the state lock
+func copyParticipantJob(job *domainGroup.ParticipantJob) domainGroup.ParticipantJob {
+	copied := *job
+	copied.Results = append([]domainGroup.ParticipantJobResult(nil), job.Results...)
+	return copied
+}
+
+// sweep forgets finished jobs older than the retention window
+func (service serviceGroupParticipantJob) sweep(now time.Time) {
+	service.state.mu.Lock()
+	defer service.state.mu.Unlock()
+	for id, jobState := range service.state.jobs {
+		if finished := jobState.job.FinishedAt; finished != nil && now.Sub(*finished) > participantJobRetention {
+			delete(service.state.jobs, id)
+		}
+	}
+}
+
+// whatsappParticipantGateway runs participant jobs with the client of the device in the context
+type whatsappParticipantGateway struct {
+	sender serviceSend
+}
+
+func (gateway whatsappParticipantGateway) updateParticipants(ctx context.Context, group types.JID, participants []types.JID, action whatsmeow.ParticipantChange) ([]types.GroupParticipant, error) {
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return nil, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+	return client.UpdateGroupParticipants(ctx, group, participants, action)
+}
+
+// sendInvite sends the invite WhatsApp hands out for a user it would not add; it counts against the outbound rate
+// limit like any other send
+func (gateway whatsappParticipantGateway) sendInvite(ctx context.Context, group types.JID, groupName string, participant types.JID, request *types.GroupParticipantAddRequest, caption string) error {
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return pkgError.ErrWaCLI
+	}
+
+	invite := &waE2E.GroupInviteMessage{
+		GroupJID:         proto.String(group.String()),
+		InviteCode:       proto.String(request.Code),
+		InviteExpiration: proto.Int64(request.Expiration.Unix()),
+		GroupName:        proto.String(groupName),
+	}
+	if caption != "" {
+		invite.Caption = proto.String(caption)
+	}
+
+	_, err := gateway.sender.wrapSendMessage(ctx, client, participant, &waE2E.Message{GroupInviteMessage: invite}, fmt.Sprintf("👥 Group invite: %s", groupName))
+	return err
+}
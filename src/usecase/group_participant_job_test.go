@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+type fakeParticipantGateway struct {
+	batches [][]types.JID
+	answers map[string]types.GroupParticipant
+	invited []string
+	limited map[string]int
+}
+
+func (gateway *fakeParticipantGateway) updateParticipants(ctx context.Context, group types.JID, participants []types.JID, action whatsmeow.ParticipantChange) ([]types.GroupParticipant, error) {
+	gateway.batches = append(gateway.batches, participants)
+	if len(gateway.batches) == 3 {
+		return nil, errors.New("websocket not connected")
+	}
+	var changed []types.GroupParticipant
+	for _, participant := range participants {
+		if answer, ok := gateway.answers[participant.User]; ok {
+			changed = append(changed, answer)
+			continue
+		}
+		changed = append(changed, types.GroupParticipant{JID: participant})
+	}
+	return changed, nil
+}
+
+func (gateway *fakeParticipantGateway) sendInvite(ctx context.Context, group types.JID, groupName string, participant types.JID, request *types.GroupParticipantAddRequest, caption string) error {
+	if gateway.limited[participant.User] > 0 {
+		gateway.limited[participant.User]--
+		return pkgError.RateLimited("device exceeded its outbound message rate", time.Millisecond)
+	}
+	gateway.invited = append(gateway.invited, participant.User+":"+request.Code+":"+groupName+":"+caption)
+	return nil
+}
+
+func TestCollectJobParticipants(t *testing.T) {
+	participants, err := collectJobParticipants(domainGroup.CreateParticipantJobRequest{
+		Participants:    []string{"+6289685028129", "6281234567890@s.whatsapp.net", "123456789012345@lid"},
+		ParticipantsCSV: "name,phone\nBudi,6289685028129\nSiti,6281111111111\n",
+	})
+	if err != nil {
+		t.Fatalf("collectJobParticipants() error = %v", err)
+	}
+
+	expected := []string{"6289685028129@s.whatsapp.net", "6281234567890@s.whatsapp.net", "123456789012345@lid", "6281111111111@s.whatsapp.net"}
+	if len(participants) != len(expected) {
+		t.Fatalf("expected %d unique participants, got %v", len(expected), participants)
+	}
+	for i, participant := range participants {
+		if participant.String() != expected[i] {
+			t.Fatalf("participants[%d] = %s, want %s", i, participant, expected[i])
+		}
+	}
+
+	if _, err := collectJobParticipants(domainGroup.CreateParticipantJobRequest{Participants: []string{"120363000000000001@g.us"}}); err == nil {
+		t.Fatal("expected an error for a group JID")
+	}
+	if _, err := collectJobParticipants(domainGroup.CreateParticipantJobRequest{ParticipantsCSV: "number\n628123"}); err == nil {
+		t.Fatal("expected an error for a CSV without phone column")
+	}
+}
+
+func TestParticipantJobRunsBatchesWithInviteFallback(t *testing.T) {
+	jid := func(user string) types.JID { return types.NewJID(user, types.DefaultUserServer) }
+	gateway := &fakeParticipantGateway{answers: map[string]types.GroupParticipant{
+		"62802": {JID: jid("62802"), Error: 403, AddRequest: &types.GroupParticipantAddRequest{Code: "INVITE1", Expiration: time.Now().Add(time.Hour)}},
+		// WhatsApp may answer with the LID of a participant we asked for by phone
+		"62803": {JID: types.NewJID("99903", types.HiddenUserServer), PhoneNumber: jid("62803"), Error: 409},
+		"62804": {JID: jid("62804"), Error: 408},
+	}}
+	service := serviceGroupParticipantJob{
+		state:   &participantJobs{ctx: context.Background(), jobs: make(map[string]*participantJobState)},
+		gateway: gateway,
+	}
+
+	participants := []types.JID{jid("62801"), jid("62802"), jid("62803"), jid("62804"), jid("62805")}
+	job := &domainGroup.ParticipantJob{
+		ID:             "job-1",
+		GroupID:        "120363000000000001@g.us",
+		Action:         whatsmeow.ParticipantChangeAdd,
+		Status:         domainGroup.JobStatusRunning,
+		BatchSize:      2,
+		InviteFallback: true,
+		Results:        make([]domainGroup.ParticipantJobResult, len(participants)),
+	}
+	for i := range job.Results {
+		job.Results[i] = domainGroup.ParticipantJobResult{Participant: participants[i].String(), Status: domainGroup.ParticipantPending}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	service.runJob(ctx, &participantJobState{job: job, groupName: "Neighbours", inviteText: "Join us", participants: participants, cancel: cancel})
+
+	if len(gateway.batches) != 3 || len(gateway.batches[2]) != 1 {
+		t.Fatalf("expected batches of 2, 2 and 1, got %v", gateway.batches)
+	}
+	if len(gateway.invited) != 1 || gateway.invited[0] != "62802:INVITE1:Neighbours:Join us" {
+		t.Fatalf("unexpected invites %v", gateway.invited)
+	}
+
+	expected := []string{
+		domainGroup.ParticipantSuccess, domainGroup.ParticipantInvited, domainGroup.ParticipantSkipped,
+		domainGroup.ParticipantFailed, domainGroup.ParticipantFailed,
+	}
+	for i, result := range job.Results {
+		if result.Status != expected[i] {
+			t.Fatalf("results[%d] = %+v, want status %s", i, result, expected[i])
+		}
+	}
+	if job.Results[4].Message != "websocket not connected" || job.Results[3].ErrorCode != 408 {
+		t.Fatalf("unexpected failure details %+v %+v", job.Results[3], job.Results[4])
+	}
+
+	progress := job.Progress
+	if job.Status != domainGroup.JobStatusCompleted || progress.Total != 5 || progress.Pending != 0 || progress.Success != 1 ||
+		progress.Invited != 1 || progress.Skipped != 1 || progress.Failed != 2 || progress.Percent != 100 {
+		t.Fatalf("unexpected job %s progress %+v", job.Status, progress)
+	}
+}
+
+func TestParticipantJobRequeuesRateLimitedInvites(t *testing.T) {
+	jid := func(user string) types.JID { return types.NewJID(user, types.DefaultUserServer) }
+	request := &types.GroupParticipantAddRequest{Code: "INVITE", Expiration: time.Now().Add(time.Hour)}
+	gateway := &fakeParticipantGateway{
+		answers: map[string]types.GroupParticipant{
+			"62801": {JID: jid("62801"), Error: 403, AddRequest: request},
+			"62802": {JID: jid("62802"), Error: 403, AddRequest: request},
+		},
+		limited: map[string]int{"62801": 2},
+	}
+	service := serviceGroupParticipantJob{gateway: gateway}
+	jobState := &participantJobState{
+		job:       &domainGroup.ParticipantJob{Action: whatsmeow.ParticipantChangeAdd, InviteFallback: true},
+		groupName: "Neighbours",
+	}
+
+	results := service.processBatch(context.Background(), jobState, types.NewJID("120363000000000001", types.GroupServer), []types.JID{jid("62801"), jid("62802")})
+
+	// The rate-limited invite waits at the back of the queue while the next one goes out
+	if len(gateway.invited) != 2 || gateway.invited[0] != "62802:INVITE:Neighbours:" || gateway.invited[1] != "62801:INVITE:Neighbours:" {
+		t.Fatalf("unexpected invites %v", gateway.invited)
+	}
+	for i, result := range results {
+		if result.Status != domainGroup.ParticipantInvited {
+			t.Fatalf("results[%d] = %+v, want status %s", i, result, domainGroup.ParticipantInvited)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestGroupInfoCarriesSettings(t *testing.T) {
+	info := &types.GroupInfo{
+		JID:                         types.NewJID("120363000000000001", types.GroupServer),
+		GroupName:                   types.GroupName{Name: "Neighbours"},
+		GroupTopic:                  types.GroupTopic{Topic: "Be nice", TopicID: "3EB0ABCDEF"},
+		GroupLocked:                 types.GroupLocked{IsLocked: true},
+		GroupEphemeral:              types.GroupEphemeral{IsEphemeral: true, DisappearingTimer: 604800},
+		GroupMembershipApprovalMode: types.GroupMembershipApprovalMode{IsJoinApprovalRequired: true},
+		MemberAddMode:               types.GroupMemberAddModeAdmin,
+	}
+
+	settings := groupSettings(info)
+	expected := domainGroup.GroupSettings{
+		IsLocked:               true,
+		IsJoinApprovalRequired: true,
+		MemberAddMode:          types.GroupMemberAddModeAdmin,
+		DisappearingTimer:      604800,
+		Description:            "Be nice",
+		DescriptionID:          "3EB0ABCDEF",
+	}
+	if settings != expected {
+		t.Fatalf("groupSettings() = %+v, want %+v", settings, expected)
+	}
+
+	// The group info fields keep their place in the JSON; settings is added next to them
+	raw, err := json.Marshal(domainGroup.GroupInfo{GroupInfo: *info, Settings: settings})
+	if err != nil {
+		t.Fatalf("marshal group info: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal group info: %v", err)
+	}
+	if decoded["Name"] != "Neighbours" || decoded["MemberAddMode"] != "admin_add" {
+		t.Fatalf("group info fields missing from %s", raw)
+	}
+	if s, ok := decoded["settings"].(map[string]any); !ok || s["member_add_mode"] != "admin_add" || s["description_id"] != "3EB0ABCDEF" {
+		t.Fatalf("settings missing from %s", raw)
+	}
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainJob "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/job"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	jobSendTimeout    = 10 * time.Minute
+	jobRetention      = 24 * time.Hour
+	jobSweepInterval  = 10 * time.Minute
+	jobDefaultWorkers = 4
+)
+
+// jobQueue holds queued sends and the status of every job still within the retention window
+type jobQueue struct {
+	mu    sync.RWMutex
+	jobs  map[string]*domainJob.Job
+	queue chan *queuedJob
+}
+
+type queuedJob struct {
+	id      string
+	device  *whatsapp.DeviceInstance
+	request domainJob.EnqueueSendRequest
+	webhook string
+}
+
+type serviceJob struct {
+	state   *jobQueue
+	workers int
+}
+
+func NewJobService() domainJob.IJobUsecase {
+	queueSize := config.WhatsappAsyncQueueSize
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	workers := config.WhatsappAsyncSendWorkers
+	if workers < 1 {
+		workers = jobDefaultWorkers
+	}
+
+	return &serviceJob{
+		state: &jobQueue{
+			jobs:  make(map[string]*domainJob.Job),
+			queue: make(chan *queuedJob, queueSize),
+		},
+		workers: workers,
+	}
+}
+
+func (service serviceJob) EnqueueSend(ctx context.Context, request domainJob.EnqueueSendRequest) (response domainJob.Job, err error) {
+	inst, ok := whatsapp.DeviceFromContext(ctx)
+	if !ok || inst == nil {
+		if request.Cleanup != nil {
+			request.Cleanup()
+		}
+		return response, pkgError.ValidationError("device context is required")
+	}
+
+	if request.Validate != nil {
+		if err = request.Validate(ctx); err != nil {
+			if request.Cleanup != nil {
+				request.Cleanup()
+			}
+			return response, err
+		}
+	}
+
+	webhookDeviceID := inst.ID()
+	if jid := inst.JID(); jid != "" {
+		webhookDeviceID = jid
+	}
+
+	job := &domainJob.Job{
+		ID:        uuid.NewString(),
+		DeviceID:  inst.ID(),
+		Type:      request.Type,
+		Phone:     request.Phone,
+		Status:    domainJob.StatusQueued,
+		CreatedAt: time.Now().UTC(),
+	}
+	queued := &queuedJob{id: job.ID, device: inst, request: request, webhook: webhookDeviceID}
+
+	service.state.mu.Lock()
+	select {
+	case service.state.queue <- queued:
+		service.state.jobs[job.ID] = job
+		response = *job
+	default:
+		err = pkgError.QueueFullError(fmt.Sprintf("async send queue is full (%d jobs waiting), retry later", cap(service.state.queue)))
+	}
+	service.state.mu.Unlock()
+
+	if err != nil {
+		if request.Cleanup != nil {
+			request.Cleanup()
+		}
+		return response, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"job_id":    job.ID,
+		"device_id": job.DeviceID,
+		"type":      job.Type,
+	}).Debug("Async send queued")
+
+	return response, nil
+}
+
+func (service serviceJob) GetJob(ctx context.Context, id string) (response domainJob.Job, err error) {
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	service.state.mu.RLock()
+	defer service.state.mu.RUnlock()
+
+	job, ok := service.state.jobs[id]
+	if !ok || job.DeviceID != deviceID {
+		return response, pkgError.NotFoundError(fmt.Sprintf("job %s not found", id))
+	}
+	return *job, nil
+}
+
+func (service serviceJob) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < service.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case queued := <-service.state.queue:
+					service.execute(ctx, queued)
+				}
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(jobSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case now := <-ticker.C:
+			service.sweep(now)
+		}
+	}
+}
+
+func (service serviceJob) execute(ctx context.Context, queued *queuedJob) {
+	if queued.request.Cleanup != nil {
+		defer queued.request.Cleanup()
+	}
+
+	startedAt := time.Now().UTC()
+	service.update(queued.id, func(job *domainJob.Job) {
+		job.Status = domainJob.StatusRunning
+		job.StartedAt = &startedAt
+	})
+
+	sendCtx, cancel := context.WithTimeout(whatsapp.ContextWithDevice(ctx, queued.device), jobSendTimeout)
+	defer cancel()
+
+	resp, err := service.send(sendCtx, queued.request.Send)
+
+	finishedAt := time.Now().UTC()
+	service.update(queued.id, func(job *domainJob.Job) {
+		job.FinishedAt = &finishedAt
+		if err != nil {
+			job.Status = domainJob.StatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = domainJob.StatusCompleted
+		job.Result = resp.Response
+	})
+
+	if err != nil {
+		logrus.Warnf("Async send %s (%s to %s) failed: %v", queued.id, queued.request.Type, queued.request.Phone, err)
+	}
+	service.notify(queued, resp, err)
+}
+
+// send runs the queued send, waiting out outbound rate limits instead of failing the job
+func (service serviceJob) send(ctx context.Context, send domainJob.SendFunc) (resp domainJob.SendResult, err error) {
+	for {
+		resp, err = func() (resp domainJob.SendResult, err error) {
+			// Send usecases panic on login/connection problems; turn that into a failed job
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%v", r)
+				}
+			}()
+			return send(ctx)
+		}()
+
+		var rateLimited pkgError.RateLimitError
+		if !errors.As(err, &rateLimited) {
+			return resp, err
+		}
+		if !sleepContext(ctx, rateLimited.RetryAfter) {
+			return resp, err
+		}
+	}
+}
+
+func (service serviceJob) update(id string, apply func(job *domainJob.Job)) {
+	service.state.mu.Lock()
+	defer service.state.mu.Unlock()
+	if job, ok := service.state.jobs[id]; ok {
+		apply(job)
+	}
+}
+
+// sweep forgets finished jobs older than the retention window
+func (service serviceJob) sweep(now time.Time) {
+	service.state.mu.Lock()
+	defer service.state.mu.Unlock()
+	for id, job := range service.state.jobs {
+		if job.FinishedAt != nil && now.Sub(*job.FinishedAt) > jobRetention {
+			delete(service.state.jobs, id)
+		}
+	}
+}
+
+func (service serviceJob) notify(queued *queuedJob, resp domainJob.SendResult, err error) {
+	event := "send.completed"
+	status := domainJob.StatusCompleted
+	payload := map[string]any{
+		"job_id": queued.id,
+		"type":   queued.request.Type,
+		"phone":  queued.request.Phone,
+	}
+	if err != nil {
+		event = "send.failed"
+		status = domainJob.StatusFailed
+		payload["error"] = err.Error()
+	} else {
+		payload["message_id"] = resp.MessageID
+	}
+	payload["status"] = status
+
+	go func() {
+		webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := whatsapp.ForwardEventToWebhooks(webhookCtx, event, queued.webhook, payload); err != nil {
+			logrus.Errorf("Async send: failed to forward %s webhook: %v", event, err)
+		}
+	}()
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainJob "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/job"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+)
+
+func waitForJob(t *testing.T, service domainJob.IJobUsecase, ctx context.Context, id string) domainJob.Job {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := service.GetJob(ctx, id)
+		if err != nil {
+			t.Fatalf("GetJob() error = %v", err)
+		}
+		if job.Status == domainJob.StatusCompleted || job.Status == domainJob.StatusFailed {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return domainJob.Job{}
+}
+
+func TestJobServiceRunsQueuedSends(t *testing.T) {
+	service := NewJobService()
+	runCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go service.Run(runCtx)
+
+	ctx := whatsapp.ContextWithDevice(context.Background(), whatsapp.NewDeviceInstance("dev-a", nil, nil))
+
+	cleaned := make(chan struct{})
+	ok, err := service.EnqueueSend(ctx, domainJob.EnqueueSendRequest{
+		Type:  "album",
+		Phone: "6289685028129",
+		Send: func(sendCtx context.Context) (domainJob.SendResult, error) {
+			if inst, found := whatsapp.DeviceFromContext(sendCtx); !found || inst.ID() != "dev-a" {
+				return domainJob.SendResult{}, errors.New("device missing from job context")
+			}
+			album := domainSend.AlbumResponse{MessageID: "MSG1", MessageIDs: []string{"MSG2", "MSG3"}, Status: "sent"}
+			return domainJob.SendResult{MessageID: album.MessageID, Response: album}, nil
+		},
+		Cleanup: func() { close(cleaned) },
+	})
+	if err != nil {
+		t.Fatalf("EnqueueSend() error = %v", err)
+	}
+	if ok.Status != domainJob.StatusQueued || ok.DeviceID != "dev-a" {
+		t.Fatalf("unexpected queued job %+v", ok)
+	}
+
+	failed, err := service.EnqueueSend(ctx, domainJob.EnqueueSendRequest{
+		Type: "audio",
+		Send: func(context.Context) (domainJob.SendResult, error) {
+			panic("you are not loggedin")
+		},
+	})
+	if err != nil {
+		t.Fatalf("EnqueueSend() error = %v", err)
+	}
+
+	// The job keeps the full response, not just the message ID the webhook reports
+	if job := waitForJob(t, service, ctx, ok.ID); job.Status != domainJob.StatusCompleted {
+		t.Fatalf("unexpected completed job %+v", job)
+	} else if album, isAlbum := job.Result.(domainSend.AlbumResponse); !isAlbum || album.MessageID != "MSG1" || len(album.MessageIDs) != 2 {
+		t.Fatalf("unexpected job result %+v", job.Result)
+	}
+	if job := waitForJob(t, service, ctx, failed.ID); job.Status != domainJob.StatusFailed || job.Error != "you are not loggedin" {
+		t.Fatalf("unexpected failed job %+v", job)
+	}
+
+	select {
+	case <-cleaned:
+	case <-time.After(time.Second):
+		t.Fatal("cleanup was not called")
+	}
+
+	// Jobs are only visible to the device that queued them
+	otherCtx := whatsapp.ContextWithDevice(context.Background(), whatsapp.NewDeviceInstance("dev-b", nil, nil))
+	var notFound pkgError.NotFoundError
+	if _, err := service.GetJob(otherCtx, ok.ID); !errors.As(err, &notFound) {
+		t.Fatalf("expected not found for another device, got %v", err)
+	}
+}
+
+func TestJobServiceRejectsWhenQueueFull(t *testing.T) {
+	service := &serviceJob{state: &jobQueue{jobs: map[string]*domainJob.Job{}, queue: make(chan *queuedJob, 1)}, workers: 1}
+	ctx := whatsapp.ContextWithDevice(context.Background(), whatsapp.NewDeviceInstance("dev-a", nil, nil))
+	send := func(context.Context) (domainJob.SendResult, error) { return domainJob.SendResult{}, nil }
+
+	if _, err := service.EnqueueSend(ctx, domainJob.EnqueueSendRequest{Type: "text", Send: send}); err != nil {
+		t.Fatalf("EnqueueSend() error = %v", err)
+	}
+
+	cleaned := false
+	_, err := service.EnqueueSend(ctx, domainJob.EnqueueSendRequest{Type: "text", Send: send, Cleanup: func() { cleaned = true }})
+	var queueFull pkgError.QueueFullError
+	if !errors.As(err, &queueFull) {
+		t.Fatalf("expected queue full error, got %v", err)
+	}
+	if !cleaned {
+		t.Fatal("rejected job must release its uploads")
+	}
+}
+
+func TestJobServiceRejectsInvalidSends(t *testing.T) {
+	service := &serviceJob{state: &jobQueue{jobs: map[string]*domainJob.Job{}, queue: make(chan *queuedJob, 1)}, workers: 1}
+	ctx := whatsapp.ContextWithDevice(context.Background(), whatsapp.NewDeviceInstance("dev-a", nil, nil))
+
+	cleaned := false
+	_, err := service.EnqueueSend(ctx, domainJob.EnqueueSendRequest{
+		Type:     "text",
+		Validate: func(context.Context) error { return pkgError.ValidationError("message: cannot be blank.") },
+		Send: func(context.Context) (domainJob.SendResult, error) {
+			t.Fatal("an invalid send must not run")
+			return domainJob.SendResult{}, nil
+		},
+		Cleanup: func() { cleaned = true },
+	})
+	if err != pkgError.ValidationError("message: cannot be blank.") {
+		t.Fatalf("expected the validation error, got %v", err)
+	}
+	if !cleaned {
+		t.Fatal("rejected job must release its uploads")
+	}
+	if len(service.state.queue) != 0 || len(service.state.jobs) != 0 {
+		t.Fatal("an invalid send must not be queued")
+	}
+}
@@ -26,11 +26,13 @@ import (
 
 type serviceMessage struct {
 	chatStorageRepo domainChatStorage.IChatStorageRepository
+	limiter         *outboundLimiter
 }
 
 func NewMessageService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainMessage.IMessageUsecase {
 	return &serviceMessage{
 		chatStorageRepo: chatStorageRepo,
+		limiter:         sharedOutboundLimiter(),
 	}
 }
 
@@ -105,6 +107,10 @@ func (service serviceMessage) ReactMessage(ctx context.Context, request domainMe
 			SenderTimestampMS: proto.Int64(time.Now().UnixMilli()),
 		},
 	}
+	if err = service.limiter.acquire(sendLimiterKey(ctx, client), dataWaRecipient); err != nil {
+		return response, err
+	}
+
 	ts, err := client.SendMessage(ctx, dataWaRecipient, msg)
 	if err != nil {
 		return response, err
@@ -130,6 +136,10 @@ func (service serviceMessage) RevokeMessage(ctx context.Context, request domainM
 		return response, err
 	}
 
+	if err = service.limiter.acquire(sendLimiterKey(ctx, client), dataWaRecipient); err != nil {
+		return response, err
+	}
+
 	ts, err := client.SendMessage(ctx, dataWaRecipient, client.BuildRevoke(dataWaRecipient, types.EmptyJID, request.MessageID))
 	if err != nil {
 		return response, err
@@ -196,6 +206,10 @@ func (service serviceMessage) UpdateMessage(ctx context.Context, request domainM
 	}
 
 	msg := &waE2E.Message{Conversation: proto.String(request.Message)}
+	if err = service.limiter.acquire(sendLimiterKey(ctx, client), dataWaRecipient); err != nil {
+		return response, err
+	}
+
 	ts, err := client.SendMessage(ctx, dataWaRecipient, client.BuildEdit(dataWaRecipient, request.MessageID, msg))
 	if err != nil {
 		return response, err
@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// forwardMediaGrace is how long before the CDN expiry a stored media URL is still reused as-is
+const forwardMediaGrace = 10 * time.Minute
+
+// forwardMedia describes how each stored media type is rebuilt
+var forwardMedia = map[string]struct {
+	appInfo  whatsmeow.MediaType
+	mmsType  string
+	mimeType string
+}{
+	"image":      {whatsmeow.MediaImage, "image", "image/jpeg"},
+	"video":      {whatsmeow.MediaVideo, "video", "video/mp4"},
+	"video_note": {whatsmeow.MediaVideo, "video", "video/mp4"},
+	"audio":      {whatsmeow.MediaAudio, "audio", "audio/ogg; codecs=opus"},
+	"document":   {whatsmeow.MediaDocument, "document", "application/octet-stream"},
+	"sticker":    {whatsmeow.MediaImage, "image", "image/webp"},
+}
+
+// ForwardMessage sends a stored message to other chats, marked as forwarded. Media is forwarded with the original
+// encryption keys while WhatsApp still serves it, and downloaded and uploaded again once the stored URL has expired.
+// Media is sent with the metadata stored with it, such as voice note waveforms, thumbnails and dimensions.
+func (service serviceMessage) ForwardMessage(ctx context.Context, request domainMessage.ForwardRequest) (response domainMessage.ForwardResponse, err error) {
+	if err = validations.ValidateForwardMessage(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	dataWaRecipient, err := utils.ValidateJidWithLogin(client, request.Phone)
+	if err != nil {
+		return response, err
+	}
+
+	message, err := service.chatStorageRepo.GetMessageByIDByDevice(deviceIDFromContext(ctx), request.MessageID)
+	if err != nil {
+		return response, err
+	}
+	if message == nil || message.ChatJID != dataWaRecipient.String() {
+		return response, pkgError.NotFoundError(fmt.Sprintf("message %s not found in chat %s", request.MessageID, dataWaRecipient.String()))
+	}
+
+	msg, content, err := service.buildForwardMessage(ctx, client, message)
+	if err != nil {
+		return response, err
+	}
+
+	sender := serviceSend{chatStorageRepo: service.chatStorageRepo, limiter: service.limiter}
+	var firstErr error
+	for _, phone := range request.Recipients {
+		result := domainMessage.ForwardResult{Phone: phone}
+
+		recipient, err := utils.ValidateJidWithLogin(client, phone)
+		if err == nil {
+			var ts whatsmeow.SendResponse
+			ts, err = sender.wrapSendMessage(ctx, client, recipient, proto.Clone(msg).(*waE2E.Message), content)
+			result.MessageID = ts.ID
+		}
+		if err != nil {
+			logrus.Warnf("Forward %s to %s failed: %v", request.MessageID, phone, err)
+			result.Error = err.Error()
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	sent := 0
+	for _, result := range response.Results {
+		if result.Error == "" {
+			sent++
+		}
+	}
+	if sent == 0 {
+		return response, firstErr
+	}
+
+	response.MessageID = request.MessageID
+	response.Status = fmt.Sprintf("Message forwarded to %d of %d recipients", sent, len(request.Recipients))
+	return response, nil
+}
+
+// buildForwardMessage rebuilds the message proto from what chat storage kept of it, one forward further along
+func (service serviceMessage) buildForwardMessage(ctx context.Context, client *whatsmeow.Client, message *domainChatStorage.Message) (*waE2E.Message, string, error) {
+	if message.MediaType == "" {
+		if strings.TrimSpace(message.Content) == "" {
+			return nil, "", pkgError.ValidationError(fmt.Sprintf("message %s has no content that can be forwarded", message.ID))
+		}
+		return &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text:        proto.String(message.Content),
+			ContextInfo: forwardContext(0),
+		}}, message.Content, nil
+	}
+
+	if _, ok := forwardMedia[message.MediaType]; !ok || message.URL == "" || len(message.MediaKey) == 0 {
+		return nil, "", pkgError.ValidationError(fmt.Sprintf("media of message %s (%s) cannot be forwarded", message.ID, message.MediaType))
+	}
+
+	// Messages stored before the media part was kept only know the media location; their mimetype is
+	// sniffed from a fresh download
+	var msg *waE2E.Message
+	if len(message.MediaMessage) > 0 {
+		msg = &waE2E.Message{}
+		if err := proto.Unmarshal(message.MediaMessage, msg); err != nil {
+			logrus.Warnf("Stored media of message %s is unreadable, rebuilding it: %v", message.ID, err)
+			msg = nil
+		}
+	}
+
+	uploaded := whatsmeow.UploadResponse{
+		URL:           message.URL,
+		DirectPath:    mediaDirectPath(message.URL),
+		MediaKey:      message.MediaKey,
+		FileSHA256:    message.FileSHA256,
+		FileEncSHA256: message.FileEncSHA256,
+		FileLength:    message.FileLength,
+	}
+	reuploaded := false
+	if msg == nil || !mediaURLUsable(message.URL, time.Now()) {
+		var head []byte
+		var err error
+		uploaded, head, err = service.reuploadForwardMedia(ctx, client, message, uploaded.DirectPath)
+		if err != nil {
+			return nil, "", err
+		}
+		reuploaded = true
+		if msg == nil {
+			msg = legacyForwardMessage(message, forwardMIMEType(message.MediaType, message.Filename, head))
+		}
+	}
+
+	if !applyForwardMedia(msg, uploaded, reuploaded) {
+		return nil, "", pkgError.ValidationError(fmt.Sprintf("media of message %s (%s) cannot be forwarded", message.ID, message.MediaType))
+	}
+	content := strings.TrimSpace(fmt.Sprintf("%s %s", message.MediaType, message.Content))
+	return msg, content, nil
+}
+
+// reuploadForwardMedia downloads stored media to a temporary file and uploads it again, streaming both ways. It
+// returns the new upload and the first bytes of the file for content sniffing.
+func (service serviceMessage) reuploadForwardMedia(ctx context.Context, client *whatsmeow.Client, message *domainChatStorage.Message, directPath string) (uploaded whatsmeow.UploadResponse, head []byte, err error) {
+	media := forwardMedia[message.MediaType]
+
+	file, err := os.CreateTemp(config.PathSendItems, "forward-*")
+	if err != nil {
+		return uploaded, nil, pkgError.InternalServerError(fmt.Sprintf("failed to create temporary file: %v", err))
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	if err = client.DownloadMediaWithPathToFile(ctx, directPath, message.FileEncSHA256, message.FileSHA256, message.MediaKey, int(message.FileLength), media.appInfo, media.mmsType, file); err != nil {
+		return uploaded, nil, pkgError.ValidationError(fmt.Sprintf("media of message %s is no longer available: %v", message.ID, err))
+	}
+
+	sender := serviceSend{chatStorageRepo: service.chatStorageRepo, limiter: service.limiter}
+	uploaded, err = sender.uploadMediaFile(ctx, client, media.appInfo, file.Name(), types.EmptyJID)
+	if err != nil {
+		return uploaded, nil, pkgError.InternalServerError(fmt.Sprintf("failed to upload media: %v", err))
+	}
+	return uploaded, fileHead(file.Name()), nil
+}
+
+// forwardContext marks a message as forwarded once more than the original was
+func forwardContext(previousScore uint32) *waE2E.ContextInfo {
+	return &waE2E.ContextInfo{
+		IsForwarded:     proto.Bool(true),
+		ForwardingScore: proto.Uint32(previousScore + 1),
+	}
+}
+
+// forwardMIMEType picks the mimetype of stored media from its content, for messages stored without one. Documents
+// prefer the type their extension names; anything unrecognised keeps the media type's default.
+func forwardMIMEType(mediaType, filename string, head []byte) string {
+	media := forwardMedia[mediaType]
+	if extension := strings.ToLower(filepath.Ext(filename)); mediaType == "document" && extension != "" {
+		if known, ok := utils.KnownDocumentMIMEByExtension(extension); ok {
+			return known
+		} else if byExt := mime.TypeByExtension(extension); byExt != "" {
+			return byExt
+		}
+	}
+
+	sniffed := http.DetectContentType(head)
+	switch {
+	case sniffed == "application/ogg":
+		// Ogg is sniffed without its codec; voice notes and videos keep the type WhatsApp expects
+		return media.mimeType
+	case mediaType == "document" && sniffed != "application/octet-stream":
+		return sniffed
+	case strings.HasPrefix(sniffed, media.mmsType+"/"):
+		return sniffed
+	}
+	return media.mimeType
+}
+
+// legacyForwardMessage builds the media message for a message stored with only its media location
+func legacyForwardMessage(message *domainChatStorage.Message, mimeType string) *waE2E.Message {
+	switch message.MediaType {
+	case "image":
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{Caption: proto.String(message.Content), Mimetype: proto.String(mimeType)}}
+	case "video":
+		return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{Caption: proto.String(message.Content), Mimetype: proto.String(mimeType)}}
+	case "video_note":
+		return &waE2E.Message{PtvMessage: &waE2E.VideoMessage{Mimetype: proto.String(mimeType)}}
+	case "audio":
+		return &waE2E.Message{AudioMessage: &waE2E.AudioMessage{Mimetype: proto.String(mimeType)}}
+	case "document":
+		return &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+			Caption:  proto.String(message.Content),
+			FileName: proto.String(message.Filename),
+			Title:    proto.String(message.Filename),
+			Mimetype: proto.String(mimeType),
+		}}
+	case "sticker":
+		return &waE2E.Message{StickerMessage: &waE2E.StickerMessage{Mimetype: proto.String(mimeType)}}
+	}
+	return &waE2E.Message{}
+}
+
+// applyForwardMedia points the media message at the uploaded file and marks it forwarded. A new upload has its own
+// media key, so thumbnails stored on the server under the old one are dropped; inline thumbnails stay. It reports
+// false when the message holds no media.
+func applyForwardMedia(msg *waE2E.Message, uploaded whatsmeow.UploadResponse, reuploaded bool) bool {
+	var mediaKeyTimestamp *int64
+	if reuploaded {
+		mediaKeyTimestamp = proto.Int64(time.Now().Unix())
+	}
+
+	switch {
+	case msg.GetImageMessage() != nil:
+		image := msg.ImageMessage
+		image.URL = proto.String(uploaded.URL)
+		image.DirectPath = proto.String(uploaded.DirectPath)
+		image.MediaKey = uploaded.MediaKey
+		image.FileSHA256 = uploaded.FileSHA256
+		image.FileEncSHA256 = uploaded.FileEncSHA256
+		image.FileLength = proto.Uint64(uploaded.FileLength)
+		if reuploaded {
+			image.MediaKeyTimestamp = mediaKeyTimestamp
+			image.ThumbnailDirectPath, image.ThumbnailSHA256, image.ThumbnailEncSHA256 = nil, nil, nil
+		}
+		image.ContextInfo = forwardContext(image.GetContextInfo().GetForwardingScore())
+	case msg.GetVideoMessage() != nil, msg.GetPtvMessage() != nil:
+		video := msg.VideoMessage
+		if video == nil {
+			video = msg.PtvMessage
+		}
+		video.URL = proto.String(uploaded.URL)
+		video.DirectPath = proto.String(uploaded.DirectPath)
+		video.MediaKey = uploaded.MediaKey
+		video.FileSHA256 = uploaded.FileSHA256
+		video.FileEncSHA256 = uploaded.FileEncSHA256
+		video.FileLength = proto.Uint64(uploaded.FileLength)
+		if reuploaded {
+			video.MediaKeyTimestamp = mediaKeyTimestamp
+			video.ThumbnailDirectPath, video.ThumbnailSHA256, video.ThumbnailEncSHA256 = nil, nil, nil
+		}
+		video.ContextInfo = forwardContext(video.GetContextInfo().GetForwardingScore())
+	case msg.GetAudioMessage() != nil:
+		audio := msg.AudioMessage
+		audio.URL = proto.String(uploaded.URL)
+		audio.DirectPath = proto.String(uploaded.DirectPath)
+		audio.MediaKey = uploaded.MediaKey
+		audio.FileSHA256 = uploaded.FileSHA256
+		audio.FileEncSHA256 = uploaded.FileEncSHA256
+		audio.FileLength = proto.Uint64(uploaded.FileLength)
+		if reuploaded {
+			audio.MediaKeyTimestamp = mediaKeyTimestamp
+		}
+		audio.ContextInfo = forwardContext(audio.GetContextInfo().GetForwardingScore())
+	case msg.GetDocumentMessage() != nil:
+		document := msg.DocumentMessage
+		document.URL = proto.String(uploaded.URL)
+		document.DirectPath = proto.String(uploaded.DirectPath)
+		document.MediaKey = uploaded.MediaKey
+		document.FileSHA256 = uploaded.FileSHA256
+		document.FileEncSHA256 = uploaded.FileEncSHA256
+		document.FileLength = proto.Uint64(uploaded.FileLength)
+		if reuploaded {
+			document.MediaKeyTimestamp = mediaKeyTimestamp
+			document.ThumbnailDirectPath, document.ThumbnailSHA256, document.ThumbnailEncSHA256 = nil, nil, nil
+		}
+		document.ContextInfo = forwardContext(document.GetContextInfo().GetForwardingScore())
+	case msg.GetStickerMessage() != nil:
+		sticker := msg.StickerMessage
+		sticker.URL = proto.String(uploaded.URL)
+		sticker.DirectPath = proto.String(uploaded.DirectPath)
+		sticker.MediaKey = uploaded.MediaKey
+		sticker.FileSHA256 = uploaded.FileSHA256
+		sticker.FileEncSHA256 = uploaded.FileEncSHA256
+		sticker.FileLength = proto.Uint64(uploaded.FileLength)
+		if reuploaded {
+			sticker.MediaKeyTimestamp = mediaKeyTimestamp
+		}
+		sticker.ContextInfo = forwardContext(sticker.GetContextInfo().GetForwardingScore())
+	default:
+		return false
+	}
+	return true
+}
+
+// mediaDirectPath strips the CDN host from a media URL; WhatsApp clients fetch media by direct path
+func mediaDirectPath(mediaURL string) string {
+	parsed, err := url.Parse(mediaURL)
+	if err != nil || parsed.Path == "" {
+		return ""
+	}
+	if parsed.RawQuery == "" {
+		return parsed.Path
+	}
+	return parsed.Path + "?" + parsed.RawQuery
+}
+
+// mediaURLUsable reports whether a WhatsApp CDN URL has not expired yet. The expiry is the hex unix
+// timestamp in the oe query parameter; URLs without one are treated as expired.
+func mediaURLUsable(mediaURL string, now time.Time) bool {
+	parsed, err := url.Parse(mediaURL)
+	if err != nil {
+		return false
+	}
+	expiry, err := strconv.ParseInt(parsed.Query().Get("oe"), 16, 64)
+	if err != nil {
+		return false
+	}
+	return time.Unix(expiry, 0).After(now.Add(forwardMediaGrace))
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMediaURLUsable(t *testing.T) {
+	now := time.Unix(0x66500000, 0)
+
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{
+			name: "expiry in the future",
+			url:  "https://mmg.whatsapp.net/v/t62.7118-24/123_456_n.enc?ccb=11-4&oh=01_Q5AaI&oe=66510000&_nc_sid=5e03e0",
+			want: true,
+		},
+		{
+			name: "expiry within the grace period",
+			url:  "https://mmg.whatsapp.net/v/t62.7118-24/123_456_n.enc?ccb=11-4&oe=66500010",
+			want: false,
+		},
+		{
+			name: "expired",
+			url:  "https://mmg.whatsapp.net/v/t62.7118-24/123_456_n.enc?ccb=11-4&oe=664F0000",
+			want: false,
+		},
+		{
+			name: "no expiry",
+			url:  "https://mmg.whatsapp.net/v/t62.7118-24/123_456_n.enc",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mediaURLUsable(tt.url, now); got != tt.want {
+				t.Fatalf("mediaURLUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMediaDirectPath(t *testing.T) {
+	tests := map[string]string{
+		"https://mmg.whatsapp.net/v/t62.7118-24/123_456_n.enc?ccb=11-4&oe=66510000": "/v/t62.7118-24/123_456_n.enc?ccb=11-4&oe=66510000",
+		"https://mmg.whatsapp.net/o1/v/t24/f2/m232/abc":                             "/o1/v/t24/f2/m232/abc",
+		"": "",
+	}
+
+	for url, want := range tests {
+		if got := mediaDirectPath(url); got != want {
+			t.Fatalf("mediaDirectPath(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestBuildForwardMessageKeepsStoredMedia(t *testing.T) {
+	original := &waE2E.Message{AudioMessage: &waE2E.AudioMessage{
+		URL:           proto.String("https://mmg.whatsapp.net/v/t62.7117-24/1_2_n.enc?ccb=11-4&oe=7FFFFFFF"),
+		Mimetype:      proto.String("audio/ogg; codecs=opus"),
+		MediaKey:      []byte("key"),
+		FileSHA256:    []byte("sha"),
+		FileEncSHA256: []byte("enc"),
+		FileLength:    proto.Uint64(1024),
+		Seconds:       proto.Uint32(7),
+		PTT:           proto.Bool(true),
+		Waveform:      []byte{1, 2, 3},
+		ContextInfo: &waE2E.ContextInfo{
+			IsForwarded:     proto.Bool(true),
+			ForwardingScore: proto.Uint32(2),
+			StanzaID:        proto.String("QUOTED"),
+		},
+	}}
+	mediaType, filename, url, mediaKey, fileSHA256, fileEncSHA256, fileLength := utils.ExtractMediaInfo(original)
+	message := &domainChatStorage.Message{
+		ID:            "MSG1",
+		MediaType:     mediaType,
+		Filename:      filename,
+		URL:           url,
+		MediaKey:      mediaKey,
+		FileSHA256:    fileSHA256,
+		FileEncSHA256: fileEncSHA256,
+		FileLength:    fileLength,
+		MediaMessage:  utils.ExtractMediaMessage(original),
+	}
+
+	// The URL is still served, so no client is needed to download it again
+	msg, _, err := serviceMessage{}.buildForwardMessage(context.Background(), nil, message)
+	if err != nil {
+		t.Fatalf("buildForwardMessage() error = %v", err)
+	}
+	audio := msg.GetAudioMessage()
+	if !audio.GetPTT() || audio.GetSeconds() != 7 || string(audio.GetWaveform()) != string([]byte{1, 2, 3}) {
+		t.Fatalf("voice note metadata lost: %+v", audio)
+	}
+	if audio.GetURL() != url || audio.GetDirectPath() != "/v/t62.7117-24/1_2_n.enc?ccb=11-4&oe=7FFFFFFF" {
+		t.Fatalf("unexpected media location %q %q", audio.GetURL(), audio.GetDirectPath())
+	}
+	if audio.GetContextInfo().GetForwardingScore() != 3 || !audio.GetContextInfo().GetIsForwarded() || audio.GetContextInfo().GetStanzaID() != "" {
+		t.Fatalf("unexpected context %+v", audio.GetContextInfo())
+	}
+}
+
+func TestForwardMIMEType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	ogg := []byte("OggS\x00\x02\x00\x00")
+
+	tests := []struct {
+		name, mediaType, filename string
+		head                      []byte
+		want                      string
+	}{
+		{"png image", "image", "image.jpg", png, "image/png"},
+		{"unknown image content", "image", "image.jpg", []byte("??"), "image/jpeg"},
+		{"voice note", "audio", "audio.ogg", ogg, "audio/ogg; codecs=opus"},
+		{"document by extension", "document", "report.pdf", []byte("??"), "application/pdf"},
+		{"document by content", "document", "scan", png, "image/png"},
+		{"unknown document", "document", "blob", []byte{0, 1, 2}, "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := forwardMIMEType(tt.mediaType, tt.filename, tt.head); got != tt.want {
+				t.Fatalf("forwardMIMEType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
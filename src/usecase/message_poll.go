@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+)
+
+// GetPollResults tallies the stored votes of a poll. Only polls created or received while gowa was running are known.
+func (service serviceMessage) GetPollResults(ctx context.Context, request domainMessage.PollResultsRequest) (response domainMessage.PollResultsResponse, err error) {
+	if err = validations.ValidatePollResults(ctx, request); err != nil {
+		return response, err
+	}
+
+	deviceID := deviceIDFromContext(ctx)
+	poll, err := service.chatStorageRepo.GetPoll(deviceID, request.MessageID)
+	if err != nil {
+		return response, err
+	}
+	if poll == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("poll %s not found", request.MessageID))
+	}
+
+	votes, err := service.chatStorageRepo.GetPollVotes(poll.DeviceID, poll.ID)
+	if err != nil {
+		return response, err
+	}
+
+	return tallyPoll(poll, votes), nil
+}
+
+// tallyPoll counts the current selection of every voter; withdrawn votes are left out
+func tallyPoll(poll *domainChatStorage.Poll, votes []*domainChatStorage.PollVote) domainMessage.PollResultsResponse {
+	response := domainMessage.PollResultsResponse{
+		MessageID:       poll.ID,
+		ChatJID:         poll.ChatJID,
+		Question:        poll.Question,
+		SelectableCount: poll.SelectableCount,
+		Options:         make([]domainMessage.PollOptionResult, 0, len(poll.Options)),
+		Voters:          []domainMessage.PollVoter{},
+	}
+
+	index := make(map[string]int, len(poll.Options))
+	for i, option := range poll.Options {
+		index[option] = i
+		response.Options = append(response.Options, domainMessage.PollOptionResult{Name: option, Voters: []string{}})
+	}
+
+	for _, vote := range votes {
+		if len(vote.Options) == 0 {
+			continue
+		}
+		for _, option := range vote.Options {
+			if i, ok := index[option]; ok {
+				response.Options[i].Votes++
+				response.Options[i].Voters = append(response.Options[i].Voters, vote.Voter)
+			}
+		}
+		response.Voters = append(response.Voters, domainMessage.PollVoter{
+			JID:       vote.Voter,
+			Name:      vote.VoterName,
+			Options:   vote.Options,
+			Timestamp: vote.Timestamp.Format(time.RFC3339),
+		})
+	}
+	response.TotalVoters = len(response.Voters)
+	return response
+}
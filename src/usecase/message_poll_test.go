@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+func TestTallyPoll(t *testing.T) {
+	poll := &domainChatStorage.Poll{
+		ID:              "POLL1",
+		ChatJID:         "120363025246125888@g.us",
+		Question:        "Lunch?",
+		Options:         []string{"Rice", "Noodles", "Soup"},
+		SelectableCount: 0,
+	}
+	at := time.Date(2026, time.March, 1, 8, 0, 0, 0, time.UTC)
+	votes := []*domainChatStorage.PollVote{
+		{Voter: "628111@s.whatsapp.net", VoterName: "Budi", Options: []string{"Rice", "Soup"}, Timestamp: at},
+		{Voter: "628222@s.whatsapp.net", Options: []string{"Rice"}, Timestamp: at.Add(time.Minute)},
+		{Voter: "628333@s.whatsapp.net", Options: []string{}, Timestamp: at.Add(2 * time.Minute)},
+	}
+
+	results := tallyPoll(poll, votes)
+	if results.TotalVoters != 2 || len(results.Voters) != 2 {
+		t.Fatalf("withdrawn votes must not count, got %d voters", results.TotalVoters)
+	}
+	if results.Options[0].Votes != 2 || results.Options[1].Votes != 0 || results.Options[2].Votes != 1 {
+		t.Fatalf("unexpected tally: %+v", results.Options)
+	}
+	if results.Options[2].Voters[0] != "628111@s.whatsapp.net" {
+		t.Fatalf("unexpected voters for Soup: %v", results.Options[2].Voters)
+	}
+	if results.Options[1].Voters == nil {
+		t.Fatal("options without votes should list no voters rather than null")
+	}
+	if results.Voters[0].Name != "Budi" || results.Voters[0].Timestamp != "2026-03-01T08:00:00Z" {
+		t.Fatalf("unexpected voter entry: %+v", results.Voters[0])
+	}
+}
@@ -1,19 +1,88 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/disintegration/imaging"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
 )
 
-type serviceNewsletter struct{}
+type serviceNewsletter struct {
+	limiter *outboundLimiter
+}
 
 func NewNewsletterService() domainNewsletter.INewsletterUsecase {
-	return &serviceNewsletter{}
+	return &serviceNewsletter{
+		limiter: sharedOutboundLimiter(),
+	}
+}
+
+func (service serviceNewsletter) Create(ctx context.Context, request domainNewsletter.CreateRequest) (response *types.NewsletterMetadata, err error) {
+	if err = validations.ValidateCreateNewsletter(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	params := whatsmeow.CreateNewsletterParams{
+		Name:        strings.TrimSpace(request.Name),
+		Description: request.Description,
+	}
+	if request.Picture != nil {
+		picture, err := utils.ProcessGroupPhoto(request.Picture)
+		if err != nil {
+			return response, pkgError.ValidationError(fmt.Sprintf("failed to process picture: %v", err))
+		}
+		params.Picture = picture.Bytes()
+	}
+
+	response, err = client.CreateNewsletter(ctx, params)
+	if err == nil && response == nil {
+		err = pkgError.InternalServerError("WhatsApp did not return the created channel")
+	}
+	return response, err
+}
+
+func (service serviceNewsletter) Follow(ctx context.Context, request domainNewsletter.FollowRequest) (response *types.NewsletterMetadata, err error) {
+	if err = validations.ValidateFollowNewsletter(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	metadata, err := newsletterMetadata(ctx, client, request.NewsletterID, request.InviteLink)
+	if err != nil {
+		return response, err
+	}
+
+	if err = client.FollowNewsletter(ctx, metadata.ID); err != nil {
+		return response, err
+	}
+	return metadata, nil
 }
 
 func (service serviceNewsletter) Unfollow(ctx context.Context, request domainNewsletter.UnfollowRequest) (err error) {
@@ -26,10 +95,361 @@ func (service serviceNewsletter) Unfollow(ctx context.Context, request domainNew
 		return pkgError.ErrWaCLI
 	}
 
-	JID, err := utils.ValidateJidWithLogin(client, request.NewsletterID)
+	JID, err := newsletterJID(client, request.NewsletterID)
 	if err != nil {
 		return err
 	}
 
 	return client.UnfollowNewsletter(ctx, JID)
 }
+
+func (service serviceNewsletter) Info(ctx context.Context, request domainNewsletter.InfoRequest) (response *types.NewsletterMetadata, err error) {
+	if err = validations.ValidateNewsletterInfo(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	return newsletterMetadata(ctx, client, request.NewsletterID, request.InviteLink)
+}
+
+func (service serviceNewsletter) Messages(ctx context.Context, request domainNewsletter.MessagesRequest) (response domainNewsletter.MessagesResponse, err error) {
+	if err = validations.ValidateNewsletterMessages(ctx, &request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	JID, err := newsletterJID(client, request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	messages, err := client.GetNewsletterMessages(ctx, JID, &whatsmeow.GetNewsletterMessagesParams{
+		Count:  request.Count,
+		Before: types.MessageServerID(request.Before),
+	})
+	if err != nil {
+		return response, err
+	}
+
+	response.NewsletterID = JID.String()
+	response.Data = newsletterMessageItems(messages)
+	return response, nil
+}
+
+func (service serviceNewsletter) MessageUpdates(ctx context.Context, request domainNewsletter.MessageUpdatesRequest) (response domainNewsletter.MessagesResponse, err error) {
+	if err = validations.ValidateNewsletterMessageUpdates(ctx, &request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	JID, err := newsletterJID(client, request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	params := &whatsmeow.GetNewsletterUpdatesParams{
+		Count: request.Count,
+		After: types.MessageServerID(request.After),
+	}
+	if request.Since > 0 {
+		params.Since = time.Unix(request.Since, 0)
+	}
+
+	messages, err := client.GetNewsletterMessageUpdates(ctx, JID, params)
+	if err != nil {
+		return response, err
+	}
+
+	response.NewsletterID = JID.String()
+	response.Data = newsletterMessageItems(messages)
+	return response, nil
+}
+
+// Post publishes to a channel. Channel posts are not end-to-end encrypted: media is uploaded in plain and the
+// upload handle is sent along with the message, which is why this does not go through the regular send flow.
+func (service serviceNewsletter) Post(ctx context.Context, request domainNewsletter.PostRequest) (response domainNewsletter.PostResponse, err error) {
+	if err = validations.ValidatePostNewsletter(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	JID, err := newsletterJID(client, request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	metadata, err := client.GetNewsletterInfo(ctx, JID)
+	if err != nil {
+		return response, err
+	}
+	if !isNewsletterAdmin(metadata) {
+		return response, pkgError.ValidationError(fmt.Sprintf("you are not an admin of channel %s", JID))
+	}
+
+	// A post counts against the outbound rate limit like any other send; check it before uploading media
+	if err = service.limiter.acquire(sendLimiterKey(ctx, client), JID); err != nil {
+		return response, err
+	}
+
+	var (
+		msg       *waE2E.Message
+		extra     whatsmeow.SendRequestExtra
+		mediaPath string
+	)
+	switch {
+	case request.Image != nil || (request.ImageURL != nil && *request.ImageURL != ""):
+		if mediaPath, err = newsletterImageFile(request); err != nil {
+			return response, err
+		}
+		defer os.Remove(mediaPath)
+		msg, extra.MediaHandle, err = newsletterImageMessage(ctx, client, mediaPath, request.Message)
+	case request.Video != nil || (request.VideoURL != nil && *request.VideoURL != ""):
+		if mediaPath, err = newsletterVideoFile(request); err != nil {
+			return response, err
+		}
+		defer os.Remove(mediaPath)
+		msg, extra.MediaHandle, err = newsletterVideoMessage(ctx, client, mediaPath, request.Message)
+	default:
+		msg = &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{Text: proto.String(request.Message)}}
+	}
+	if err != nil {
+		return response, err
+	}
+
+	sent, err := client.SendMessage(ctx, JID, msg, extra)
+	if err != nil {
+		return response, err
+	}
+
+	response.MessageID = sent.ID
+	response.ServerID = int(sent.ServerID)
+	response.Status = fmt.Sprintf("Posted to channel %s (server id: %d)", JID, sent.ServerID)
+	return response, nil
+}
+
+func (service serviceNewsletter) React(ctx context.Context, request domainNewsletter.ReactRequest) (err error) {
+	if err = validations.ValidateReactNewsletter(ctx, request); err != nil {
+		return err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return pkgError.ErrWaCLI
+	}
+
+	JID, err := newsletterJID(client, request.NewsletterID)
+	if err != nil {
+		return err
+	}
+
+	return client.NewsletterSendReaction(ctx, JID, types.MessageServerID(request.ServerID), request.Reaction, "")
+}
+
+func (service serviceNewsletter) Mute(ctx context.Context, request domainNewsletter.MuteRequest) (err error) {
+	if err = validations.ValidateMuteNewsletter(ctx, request); err != nil {
+		return err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return pkgError.ErrWaCLI
+	}
+
+	JID, err := newsletterJID(client, request.NewsletterID)
+	if err != nil {
+		return err
+	}
+
+	return client.NewsletterToggleMute(ctx, JID, request.Mute)
+}
+
+// newsletterJID parses a channel JID and rejects anything that is not a channel
+func newsletterJID(client *whatsmeow.Client, newsletterID string) (types.JID, error) {
+	JID, err := utils.ValidateJidWithLogin(client, newsletterID)
+	if err != nil {
+		return JID, err
+	}
+	if JID.Server != types.NewsletterServer {
+		return JID, pkgError.InvalidJID(fmt.Sprintf("%s is not a channel, channel IDs end with @%s", newsletterID, types.NewsletterServer))
+	}
+	return JID, nil
+}
+
+// newsletterMetadata looks a channel up by JID or by invite link, whichever is given
+func newsletterMetadata(ctx context.Context, client *whatsmeow.Client, newsletterID, inviteLink string) (metadata *types.NewsletterMetadata, err error) {
+	if inviteLink == "" {
+		JID, err := newsletterJID(client, newsletterID)
+		if err != nil {
+			return nil, err
+		}
+		metadata, err = client.GetNewsletterInfo(ctx, JID)
+	} else {
+		utils.MustLogin(client)
+		key := newsletterInviteKey(inviteLink)
+		if key == "" {
+			return nil, pkgError.ValidationError(fmt.Sprintf("invalid channel invite link: %s", inviteLink))
+		}
+		metadata, err = client.GetNewsletterInfoWithInvite(ctx, key)
+	}
+	if err == nil && metadata == nil {
+		err = pkgError.NotFoundError("channel not found")
+	}
+	return metadata, err
+}
+
+// newsletterInviteKey returns the invite code of a link such as https://whatsapp.com/channel/<code>; a bare code is
+// returned as is
+func newsletterInviteKey(link string) string {
+	link = strings.TrimSpace(link)
+	if !strings.Contains(link, "/") {
+		return link
+	}
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[len(segments)-2] != "channel" {
+		return ""
+	}
+	return segments[len(segments)-1]
+}
+
+func isNewsletterAdmin(metadata *types.NewsletterMetadata) bool {
+	if metadata == nil || metadata.ViewerMeta == nil {
+		return false
+	}
+	return metadata.ViewerMeta.Role == types.NewsletterRoleAdmin || metadata.ViewerMeta.Role == types.NewsletterRoleOwner
+}
+
+// newsletterMessageItems flattens channel posts into their text, media type and counters
+func newsletterMessageItems(messages []*types.NewsletterMessage) []domainNewsletter.MessageItem {
+	items := make([]domainNewsletter.MessageItem, 0, len(messages))
+	for _, message := range messages {
+		if message == nil {
+			continue
+		}
+		mediaType, _, _, _, _, _, _ := utils.ExtractMediaInfo(message.Message)
+		items = append(items, domainNewsletter.MessageItem{
+			ServerID:  int(message.MessageServerID),
+			MessageID: message.MessageID,
+			Type:      message.Type,
+			MediaType: mediaType,
+			Text:      utils.ExtractMessageTextFromProto(message.Message),
+			Timestamp: message.Timestamp,
+			Views:     message.ViewsCount,
+			Reactions: message.ReactionCounts,
+		})
+	}
+	return items
+}
+
+// newsletterImageFile stores the uploaded or linked image of a post in a temporary file
+func newsletterImageFile(request domainNewsletter.PostRequest) (string, error) {
+	if request.Image != nil {
+		path, err := saveUpload(request.Image, "", "newsletter_")
+		if err != nil {
+			return "", pkgError.InternalServerError(fmt.Sprintf("failed to store image: %v", err))
+		}
+		return path, nil
+	}
+
+	path, _, err := utils.DownloadImageToFile(*request.ImageURL, config.PathSendItems)
+	if err != nil {
+		return "", pkgError.InternalServerError(fmt.Sprintf("failed to download image from URL %v", err))
+	}
+	return path, nil
+}
+
+// newsletterVideoFile stores the uploaded or linked video of a post in a temporary file
+func newsletterVideoFile(request domainNewsletter.PostRequest) (string, error) {
+	if request.Video != nil {
+		path, err := saveUpload(request.Video, "", "newsletter_")
+		if err != nil {
+			return "", pkgError.InternalServerError(fmt.Sprintf("failed to store video: %v", err))
+		}
+		return path, nil
+	}
+
+	path, _, err := utils.DownloadVideoToFile(*request.VideoURL, config.PathSendItems)
+	if err != nil {
+		return "", pkgError.InternalServerError(fmt.Sprintf("failed to download video from URL %v", err))
+	}
+	return path, nil
+}
+
+func newsletterImageMessage(ctx context.Context, client *whatsmeow.Client, path, caption string) (*waE2E.Message, string, error) {
+	srcImage, err := imaging.Open(path)
+	if err != nil {
+		return nil, "", pkgError.ValidationError(fmt.Sprintf("failed to open image: %v", err))
+	}
+	var thumbnail bytes.Buffer
+	if err = imaging.Encode(&thumbnail, imaging.Resize(srcImage, 100, 0, imaging.Lanczos), imaging.JPEG); err != nil {
+		return nil, "", pkgError.InternalServerError(fmt.Sprintf("failed to create thumbnail %v", err))
+	}
+
+	uploaded, err := uploadNewsletterFile(ctx, client, whatsmeow.MediaImage, path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+		Caption:       proto.String(caption),
+		URL:           proto.String(uploaded.URL),
+		DirectPath:    proto.String(uploaded.DirectPath),
+		Mimetype:      proto.String(http.DetectContentType(fileHead(path))),
+		FileSHA256:    uploaded.FileSHA256,
+		FileLength:    proto.Uint64(uploaded.FileLength),
+		JPEGThumbnail: thumbnail.Bytes(),
+		Width:         proto.Uint32(uint32(srcImage.Bounds().Dx())),
+		Height:        proto.Uint32(uint32(srcImage.Bounds().Dy())),
+	}}, uploaded.Handle, nil
+}
+
+// newsletterVideoMessage posts the video as is; the apps render their own preview for channel videos
+func newsletterVideoMessage(ctx context.Context, client *whatsmeow.Client, path, caption string) (*waE2E.Message, string, error) {
+	uploaded, err := uploadNewsletterFile(ctx, client, whatsmeow.MediaVideo, path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+		Caption:    proto.String(caption),
+		URL:        proto.String(uploaded.URL),
+		DirectPath: proto.String(uploaded.DirectPath),
+		Mimetype:   proto.String("video/mp4"),
+		FileSHA256: uploaded.FileSHA256,
+		FileLength: proto.Uint64(uploaded.FileLength),
+	}}, uploaded.Handle, nil
+}
+
+func uploadNewsletterFile(ctx context.Context, client *whatsmeow.Client, mediaType whatsmeow.MediaType, path string) (whatsmeow.UploadResponse, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return whatsmeow.UploadResponse{}, pkgError.InternalServerError(fmt.Sprintf("failed to open media: %v", err))
+	}
+	defer file.Close()
+
+	uploaded, err := client.UploadNewsletterReader(ctx, file, mediaType)
+	if err != nil {
+		logrus.Errorf("Failed to upload channel media %s: %v", path, err)
+		return uploaded, pkgError.WaUploadMediaError(fmt.Sprintf("failed to upload media: %v", err))
+	}
+	return uploaded, nil
+}
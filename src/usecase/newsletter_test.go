@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewsletterInviteKey(t *testing.T) {
+	cases := map[string]string{
+		"https://whatsapp.com/channel/0029VaABCDEF":         "0029VaABCDEF",
+		"https://www.whatsapp.com/channel/0029VaABCDEF/":    "0029VaABCDEF",
+		"https://whatsapp.com/channel/0029VaABCDEF?lang=en": "0029VaABCDEF",
+		" 0029VaABCDEF ":                     "0029VaABCDEF",
+		"https://chat.whatsapp.com/AbCdEfGh": "",
+	}
+	for link, want := range cases {
+		if got := newsletterInviteKey(link); got != want {
+			t.Fatalf("newsletterInviteKey(%q) = %q, want %q", link, got, want)
+		}
+	}
+}
+
+func TestIsNewsletterAdmin(t *testing.T) {
+	if isNewsletterAdmin(nil) || isNewsletterAdmin(&types.NewsletterMetadata{}) {
+		t.Fatalf("a channel without viewer metadata must not count as administered")
+	}
+	for role, want := range map[types.NewsletterRole]bool{
+		types.NewsletterRoleOwner:      true,
+		types.NewsletterRoleAdmin:      true,
+		types.NewsletterRoleSubscriber: false,
+		types.NewsletterRoleGuest:      false,
+	} {
+		metadata := &types.NewsletterMetadata{ViewerMeta: &types.NewsletterViewerMetadata{Role: role}}
+		if got := isNewsletterAdmin(metadata); got != want {
+			t.Fatalf("isNewsletterAdmin(%s) = %v, want %v", role, got, want)
+		}
+	}
+}
+
+func TestNewsletterMessageItems(t *testing.T) {
+	at := time.Date(2026, time.March, 1, 8, 0, 0, 0, time.UTC)
+	items := newsletterMessageItems([]*types.NewsletterMessage{
+		{
+			MessageServerID: 101,
+			MessageID:       "MSG1",
+			Type:            "text",
+			Timestamp:       at,
+			ViewsCount:      250,
+			ReactionCounts:  map[string]int{"👍": 12},
+			Message:         &waE2E.Message{Conversation: proto.String("Launch day")},
+		},
+		nil,
+		{
+			MessageServerID: 102,
+			MessageID:       "MSG2",
+			Type:            "media",
+			Timestamp:       at.Add(time.Hour),
+			ViewsCount:      80,
+			Message:         &waE2E.Message{ImageMessage: &waE2E.ImageMessage{Caption: proto.String("New product")}},
+		},
+	})
+
+	if len(items) != 2 {
+		t.Fatalf("expected nil posts to be skipped, got %d items", len(items))
+	}
+	if items[0].ServerID != 101 || items[0].Text != "Launch day" || items[0].MediaType != "" || items[0].Views != 250 || items[0].Reactions["👍"] != 12 {
+		t.Fatalf("unexpected text post: %+v", items[0])
+	}
+	if items[1].ServerID != 102 || items[1].Text != "New product" || items[1].MediaType != "image" {
+		t.Fatalf("unexpected media post: %+v", items[1])
+	}
+}
@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	domainSchedule "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/schedule"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	schedulePollInterval = 5 * time.Second
+	scheduleBatchSize    = 20
+	scheduleSendTimeout  = 2 * time.Minute
+)
+
+type serviceSchedule struct {
+	repo        domainSchedule.IScheduleRepository
+	sendService domainSend.ISendUsecase
+	manager     *whatsapp.DeviceManager
+}
+
+func NewScheduleService(repo domainSchedule.IScheduleRepository, sendService domainSend.ISendUsecase, manager *whatsapp.DeviceManager) domainSchedule.IScheduleUsecase {
+	return &serviceSchedule{
+		repo:        repo,
+		sendService: sendService,
+		manager:     manager,
+	}
+}
+
+// jobDeviceID returns the device manager ID a background job (schedule, campaign) must run under
+func jobDeviceID(ctx context.Context) (string, error) {
+	inst, ok := whatsapp.DeviceFromContext(ctx)
+	if !ok || inst == nil {
+		return "", pkgError.ValidationError("device context is required")
+	}
+	return inst.ID(), nil
+}
+
+func (service serviceSchedule) CreateSchedule(ctx context.Context, request domainSchedule.CreateScheduleRequest) (response domainSchedule.ScheduledMessage, err error) {
+	if err = validations.ValidateCreateSchedule(ctx, &request); err != nil {
+		return response, err
+	}
+
+	payload, phone, err := normalizeSchedulePayload(request.Payload)
+	if err != nil {
+		return response, err
+	}
+
+	handler := sendPayloadHandlers[request.Type]
+	if err = handler.validate(ctx, service.sendService, payload); err != nil {
+		return response, err
+	}
+
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	sendAt, err := utils.ParseTimeInLocation(request.SendAt, request.Timezone)
+	if err != nil {
+		return response, pkgError.ValidationError(fmt.Sprintf("send_at: %v", err))
+	}
+
+	message := &domainSchedule.ScheduledMessage{
+		ID:       uuid.NewString(),
+		DeviceID: deviceID,
+		Type:     request.Type,
+		Phone:    phone,
+		Payload:  payload,
+		SendAt:   sendAt,
+		Timezone: request.Timezone,
+		Status:   domainSchedule.StatusPending,
+	}
+	if err = service.repo.Create(message); err != nil {
+		return response, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"schedule_id": message.ID,
+		"device_id":   deviceID,
+		"type":        message.Type,
+		"send_at":     message.SendAt,
+	}).Info("Scheduled message created")
+
+	return *message, nil
+}
+
+// normalizeSchedulePayload sanitizes the recipient the same way the /send/* handlers do and compacts the JSON
+func normalizeSchedulePayload(raw json.RawMessage) (json.RawMessage, string, error) {
+	var fields map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, "", pkgError.ValidationError(fmt.Sprintf("payload: %v", err))
+	}
+
+	phone, _ := fields["phone"].(string)
+	utils.SanitizePhone(&phone)
+	if phone != "" {
+		fields["phone"] = phone
+	}
+
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return nil, "", pkgError.ValidationError(fmt.Sprintf("payload: %v", err))
+	}
+	return payload, phone, nil
+}
+
+func (service serviceSchedule) ListSchedules(ctx context.Context, request domainSchedule.ListScheduleRequest) (response domainSchedule.ListScheduleResponse, err error) {
+	if err = validations.ValidateListSchedules(ctx, &request); err != nil {
+		return response, err
+	}
+
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	messages, total, err := service.repo.List(&domainSchedule.ScheduleFilter{
+		DeviceID: deviceID,
+		Status:   request.Status,
+		Limit:    request.Limit,
+		Offset:   request.Offset,
+	})
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainSchedule.ScheduledMessage, 0, len(messages))
+	for _, message := range messages {
+		response.Data = append(response.Data, *message)
+	}
+	response.Total = total
+	return response, nil
+}
+
+func (service serviceSchedule) GetSchedule(ctx context.Context, id string) (response domainSchedule.ScheduledMessage, err error) {
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	message, err := service.repo.Get(deviceID, id)
+	if err != nil {
+		return response, err
+	}
+	if message == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("scheduled message %s not found", id))
+	}
+	return *message, nil
+}
+
+func (service serviceSchedule) Reschedule(ctx context.Context, request domainSchedule.RescheduleRequest) (response domainSchedule.ScheduledMessage, err error) {
+	if err = validations.ValidateReschedule(ctx, &request); err != nil {
+		return response, err
+	}
+
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	sendAt, err := utils.ParseTimeInLocation(request.SendAt, request.Timezone)
+	if err != nil {
+		return response, pkgError.ValidationError(fmt.Sprintf("send_at: %v", err))
+	}
+
+	updated, err := service.repo.Reschedule(deviceID, request.ID, sendAt, request.Timezone)
+	if err != nil {
+		return response, err
+	}
+	if !updated {
+		return response, service.notPendingError(deviceID, request.ID)
+	}
+
+	return service.GetSchedule(ctx, request.ID)
+}
+
+func (service serviceSchedule) CancelSchedule(ctx context.Context, request domainSchedule.CancelScheduleRequest) (response domainSchedule.ScheduledMessage, err error) {
+	if err = validations.ValidateCancelSchedule(ctx, &request); err != nil {
+		return response, err
+	}
+
+	deviceID, err := jobDeviceID(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	cancelled, err := service.repo.Cancel(deviceID, request.ID)
+	if err != nil {
+		return response, err
+	}
+	if !cancelled {
+		return response, service.notPendingError(deviceID, request.ID)
+	}
+
+	return service.GetSchedule(ctx, request.ID)
+}
+
+// notPendingError explains why a reschedule/cancel did not apply
+func (service serviceSchedule) notPendingError(deviceID, id string) error {
+	message, err := service.repo.Get(deviceID, id)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return pkgError.NotFoundError(fmt.Sprintf("scheduled message %s not found", id))
+	}
+	return pkgError.ValidationError(fmt.Sprintf("scheduled message %s is %s, only pending messages can be changed", id, message.Status))
+}
+
+func (service serviceSchedule) Run(ctx context.Context) {
+	// A message claimed by a previous process may or may not have been sent; never send it twice
+	if failed, err := service.repo.FailInterrupted("interrupted by restart before completion"); err != nil {
+		logrus.Errorf("Scheduler: failed to recover interrupted messages: %v", err)
+	} else if failed > 0 {
+		logrus.Warnf("Scheduler: marked %d interrupted scheduled message(s) as failed", failed)
+	}
+
+	ticker := time.NewTicker(schedulePollInterval)
+	defer ticker.Stop()
+
+	for {
+		service.dispatchDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (service serviceSchedule) dispatchDue(ctx context.Context) {
+	due, err := service.repo.ClaimDue(time.Now(), scheduleBatchSize)
+	if err != nil {
+		logrus.Errorf("Scheduler: failed to claim due messages: %v", err)
+	}
+
+	for _, message := range due {
+		service.execute(ctx, message)
+	}
+}
+
+func (service serviceSchedule) execute(ctx context.Context, message *domainSchedule.ScheduledMessage) {
+	webhookDeviceID := message.DeviceID
+	resp, err := func() (resp domainSend.GenericResponse, err error) {
+		// Send usecases panic on login/connection problems; turn that into a failed job
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+
+		inst, ok := service.manager.GetDevice(message.DeviceID)
+		if !ok || inst == nil {
+			return resp, fmt.Errorf("device %s not found", message.DeviceID)
+		}
+		if jid := inst.JID(); jid != "" {
+			webhookDeviceID = jid
+		}
+
+		sendCtx, cancel := context.WithTimeout(whatsapp.ContextWithDevice(ctx, inst), scheduleSendTimeout)
+		defer cancel()
+
+		handler, ok := sendPayloadHandlers[message.Type]
+		if !ok {
+			return resp, fmt.Errorf("unsupported scheduled message type %s", message.Type)
+		}
+		return handler.send(sendCtx, service.sendService, message.Payload)
+	}()
+
+	var rateLimited pkgError.RateLimitError
+	if errors.As(err, &rateLimited) {
+		// Not sent yet; release the claim so the next dispatch retries once the limiter allows it
+		if finishErr := service.repo.Finish(message.ID, domainSchedule.StatusPending, "", "", time.Now()); finishErr != nil {
+			logrus.Errorf("Scheduler: failed to requeue rate limited message %s: %v", message.ID, finishErr)
+		}
+		logrus.WithField("schedule_id", message.ID).Infof("Scheduled message rate limited, retrying in %s", rateLimited.RetryAfter)
+		return
+	}
+
+	status, event, errMsg := domainSchedule.StatusSent, "schedule.sent", ""
+	if err != nil {
+		status, event, errMsg = domainSchedule.StatusFailed, "schedule.failed", err.Error()
+	}
+
+	finishedAt := time.Now()
+	if finishErr := service.repo.Finish(message.ID, status, resp.MessageID, errMsg, finishedAt); finishErr != nil {
+		logrus.Errorf("Scheduler: failed to record result of %s: %v", message.ID, finishErr)
+	}
+
+	fields := logrus.Fields{"schedule_id": message.ID, "device_id": message.DeviceID, "type": message.Type}
+	if err != nil {
+		logrus.WithFields(fields).WithError(err).Warn("Scheduled message failed")
+	} else {
+		logrus.WithFields(fields).WithField("message_id", resp.MessageID).Info("Scheduled message sent")
+	}
+
+	payload := map[string]any{
+		"id":      message.ID,
+		"type":    message.Type,
+		"phone":   message.Phone,
+		"send_at": message.SendAt.Format(time.RFC3339),
+		"status":  status,
+	}
+	if resp.MessageID != "" {
+		payload["message_id"] = resp.MessageID
+	}
+	if errMsg != "" {
+		payload["error"] = errMsg
+	}
+
+	go func() {
+		webhookCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := whatsapp.ForwardEventToWebhooks(webhookCtx, event, webhookDeviceID, payload); err != nil {
+			logrus.Errorf("Scheduler: failed to forward %s webhook: %v", event, err)
+		}
+	}()
+}
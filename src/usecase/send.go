@@ -1,12 +1,12 @@
 package usecase
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math"
 	"mime"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"os/exec"
@@ -20,15 +20,14 @@ import (
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/domains/app"
 	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	domainTemplate "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/template"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
-	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/helpers"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
 	"github.com/disintegration/imaging"
 	fiberUtils "github.com/gofiber/fiber/v2/utils"
 	"github.com/sirupsen/logrus"
-	"github.com/valyala/fasthttp"
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
@@ -41,17 +40,32 @@ var webpCanvasSizeRegex = regexp.MustCompile(`Canvas size:\s*(\d+)\s*x\s*(\d+)`)
 type serviceSend struct {
 	appService      app.IAppUsecase
 	chatStorageRepo domainChatStorage.IChatStorageRepository
+	templateRepo    domainTemplate.ITemplateRepository
+	limiter         *outboundLimiter
 }
 
-func NewSendService(appService app.IAppUsecase, chatStorageRepo domainChatStorage.IChatStorageRepository) domainSend.ISendUsecase {
+func NewSendService(appService app.IAppUsecase, chatStorageRepo domainChatStorage.IChatStorageRepository, templateRepo domainTemplate.ITemplateRepository) domainSend.ISendUsecase {
 	return &serviceSend{
 		appService:      appService,
 		chatStorageRepo: chatStorageRepo,
+		templateRepo:    templateRepo,
+		limiter:         sharedOutboundLimiter(),
 	}
 }
 
-// wrapSendMessage wraps the message sending process with message ID saving
+// wrapSendMessage wraps the message sending process with rate limiting and message ID saving
 func (service serviceSend) wrapSendMessage(ctx context.Context, client *whatsmeow.Client, recipient types.JID, msg *waE2E.Message, content string) (whatsmeow.SendResponse, error) {
+	if err := service.limiter.acquire(sendLimiterKey(ctx, client), recipient); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+
+	simulateTyping(ctx, client, recipient, msg)
+
+	return service.sendAndStore(ctx, client, recipient, msg, content)
+}
+
+// sendAndStore sends the message and records it in chat storage, without rate limiting
+func (service serviceSend) sendAndStore(ctx context.Context, client *whatsmeow.Client, recipient types.JID, msg *waE2E.Message, content string) (whatsmeow.SendResponse, error) {
 	ts, err := client.SendMessage(ctx, recipient, msg)
 	if err != nil {
 		return whatsmeow.SendResponse{}, err
@@ -82,6 +96,10 @@ func (service serviceSend) wrapSendMessage(ctx context.Context, client *whatsmeo
 }
 
 func (service serviceSend) SendText(ctx context.Context, request domainSend.MessageRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
 	err = validations.ValidateSendMessage(ctx, request)
 	if err != nil {
 		return response, err
@@ -97,90 +115,13 @@ func (service serviceSend) SendText(ctx context.Context, request domainSend.Mess
 		return response, err
 	}
 
-	// Create base message
 	msg := &waE2E.Message{
 		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
 			Text:        proto.String(request.Message),
-			ContextInfo: &waE2E.ContextInfo{},
+			ContextInfo: service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, request.Message),
 		},
 	}
 
-	// Add forwarding context if IsForwarded is true
-	if request.BaseRequest.IsForwarded {
-		msg.ExtendedTextMessage.ContextInfo.IsForwarded = proto.Bool(true)
-		msg.ExtendedTextMessage.ContextInfo.ForwardingScore = proto.Uint32(100)
-	}
-
-	// Set disappearing message duration if provided
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		msg.ExtendedTextMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	} else {
-		msg.ExtendedTextMessage.ContextInfo.Expiration = proto.Uint32(service.getDefaultEphemeralExpiration(request.BaseRequest.Phone))
-	}
-
-	// Get mentions from text (existing behavior - parses @phone from message text)
-	parsedMentions := service.getMentionFromText(ctx, request.Message)
-
-	// Add explicit mentions from request.Mentions (ghost mentions - no @ required in text)
-	if len(request.Mentions) > 0 {
-		explicitMentions := service.getMentionsFromList(ctx, request.Mentions, dataWaRecipient)
-		parsedMentions = append(parsedMentions, explicitMentions...)
-		// Deduplicate to avoid mentioning the same person twice
-		parsedMentions = utils.UniqueStrings(parsedMentions)
-	}
-
-	if len(parsedMentions) > 0 {
-		msg.ExtendedTextMessage.ContextInfo.MentionedJID = parsedMentions
-	}
-
-	// Reply message
-	if request.ReplyMessageID != nil && *request.ReplyMessageID != "" {
-		message, err := service.chatStorageRepo.GetMessageByID(*request.ReplyMessageID)
-		if err != nil {
-			logrus.Warnf("Error retrieving reply message ID %s: %v, continuing without reply context", *request.ReplyMessageID, err)
-		} else if message != nil { // Only set reply context if we found the message
-			// Ensure we use a full JID (user@server) for the Participant field
-			// Use the sender JID from storage as-is. Modern storage should already provide
-			// fully-qualified JIDs (e.g., user@s.whatsapp.net or group@g.us). Avoid mutating
-			// the JID here to prevent corrupting valid group or special JIDs.
-			participantJID := message.Sender
-
-			// Build base ContextInfo with reply details
-			ctxInfo := &waE2E.ContextInfo{
-				StanzaID:    request.ReplyMessageID,
-				Participant: proto.String(participantJID),
-				QuotedMessage: &waE2E.Message{
-					Conversation: proto.String(message.Content),
-				},
-			}
-
-			// Preserve forwarding flag if set
-			if request.BaseRequest.IsForwarded {
-				ctxInfo.IsForwarded = proto.Bool(true)
-				ctxInfo.ForwardingScore = proto.Uint32(100)
-			}
-
-			// Preserve disappearing message duration if provided
-			if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-				ctxInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-			} else {
-				ctxInfo.Expiration = proto.Uint32(service.getDefaultEphemeralExpiration(participantJID))
-			}
-
-			// Preserve mentions
-			if len(parsedMentions) > 0 {
-				ctxInfo.MentionedJID = parsedMentions
-			}
-
-			msg.ExtendedTextMessage = &waE2E.ExtendedTextMessage{
-				Text:        proto.String(request.Message),
-				ContextInfo: ctxInfo,
-			}
-		} else {
-			logrus.Warnf("Reply message ID %s not found in storage, continuing without reply context", *request.ReplyMessageID)
-		}
-	}
-
 	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, request.Message)
 	if err != nil {
 		return response, err
@@ -192,6 +133,10 @@ func (service serviceSend) SendText(ctx context.Context, request domainSend.Mess
 }
 
 func (service serviceSend) SendImage(ctx context.Context, request domainSend.ImageRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
 	err = validations.ValidateSendImage(ctx, request)
 	if err != nil {
 		return response, err
@@ -207,28 +152,67 @@ func (service serviceSend) SendImage(ctx context.Context, request domainSend.Ima
 		return response, err
 	}
 
+	imageMessage, deletedItems, err := service.prepareImageMessage(ctx, client, dataWaRecipient, request.Image, request.UploadPath, request.ImageURL, request.Caption, request.Compress, request.ViewOnce)
+	defer func() {
+		go func() {
+			errDelete := utils.RemoveFile(0, deletedItems...)
+			if errDelete != nil {
+				fmt.Println("error when deleting picture: ", errDelete)
+			}
+		}()
+	}()
+	if err != nil {
+		return response, err
+	}
+
+	msg := &waE2E.Message{ImageMessage: imageMessage}
+
+	msg.ImageMessage.ContextInfo = service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, request.Caption)
+
+	caption := "🖼️ Image"
+	if request.Caption != "" {
+		caption = "🖼️ " + request.Caption
+	}
+	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, caption)
+	if err != nil {
+		return response, err
+	}
+
+	response.MessageID = ts.ID
+	response.Status = fmt.Sprintf("Message sent to %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
+	return response, nil
+}
+
+// prepareImageMessage stores the image from an upload or URL, generates its thumbnail, optionally
+// compresses it and uploads it to WhatsApp. The returned temporary files must be removed by the caller,
+// also when an error is returned.
+func (service serviceSend) prepareImageMessage(ctx context.Context, client *whatsmeow.Client, recipient types.JID, image *multipart.FileHeader, uploadPath string, imageURL *string, caption string, compress, viewOnce bool) (_ *waE2E.ImageMessage, deletedItems []string, err error) {
 	var (
 		imagePath      string
 		imageThumbnail string
 		imageName      string
-		deletedItems   []string
 		oriImagePath   string
 	)
 
-	if request.ImageURL != nil && *request.ImageURL != "" {
+	// Prefix temporary files so concurrent sends of equally named images do not overwrite each other
+	generateUUID := fiberUtils.UUIDv4()
+
+	if imageURL != nil && *imageURL != "" {
 		// Download image from URL
-		imageData, fileName, err := utils.DownloadImageFromURL(*request.ImageURL)
+		downloadedPath, fileName, err := utils.DownloadImageToFile(*imageURL, config.PathSendItems)
 		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download image from URL %v", err))
+			return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to download image from URL %v", err))
 		}
+		deletedItems = append(deletedItems, downloadedPath)
+		imageName = generateUUID + fileName
+		oriImagePath = downloadedPath
 
 		// Check if the downloaded image is WebP and convert to PNG if needed
-		mimeType := http.DetectContentType(imageData)
-		if mimeType == "image/webp" {
+		if http.DetectContentType(fileHead(downloadedPath)) == "image/webp" {
 			// Convert WebP to PNG
-			webpImage, err := imaging.Decode(bytes.NewReader(imageData))
+			webpImage, err := imaging.Open(downloadedPath)
 			if err != nil {
-				return response, pkgError.InternalServerError(fmt.Sprintf("failed to decode WebP image %v", err))
+				return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to decode WebP image %v", err))
 			}
 
 			// Change file extension to PNG
@@ -237,57 +221,53 @@ func (service serviceSend) SendImage(ctx context.Context, request domainSend.Ima
 			} else {
 				fileName = fileName + ".png"
 			}
+			imageName = generateUUID + fileName
+			oriImagePath = fmt.Sprintf("%s/%s", config.PathSendItems, imageName)
 
 			// Convert to PNG format
-			var pngBuffer bytes.Buffer
-			err = imaging.Encode(&pngBuffer, webpImage, imaging.PNG)
-			if err != nil {
-				return response, pkgError.InternalServerError(fmt.Sprintf("failed to convert WebP to PNG %v", err))
+			if err = imaging.Save(webpImage, oriImagePath); err != nil {
+				return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to convert WebP to PNG %v", err))
 			}
-			imageData = pngBuffer.Bytes()
+			deletedItems = append(deletedItems, oriImagePath)
 		}
-
-		oriImagePath = fmt.Sprintf("%s/%s", config.PathSendItems, fileName)
-		imageName = fileName
-		err = os.WriteFile(oriImagePath, imageData, 0644)
-		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to save downloaded image %v", err))
-		}
-	} else if request.Image != nil {
+	} else if image != nil {
 		// Save image to server
-		oriImagePath = fmt.Sprintf("%s/%s", config.PathSendItems, request.Image.Filename)
-		err = fasthttp.SaveMultipartFile(request.Image, oriImagePath)
+		imageName = generateUUID + image.Filename
+		oriImagePath = fmt.Sprintf("%s/%s", config.PathSendItems, imageName)
+		err = storeUpload(image, uploadPath, oriImagePath)
 		if err != nil {
-			return response, err
+			return nil, deletedItems, err
 		}
-		imageName = request.Image.Filename
+		deletedItems = append(deletedItems, oriImagePath)
+	} else {
+		// This should not happen due to validation, but guard anyway
+		return nil, deletedItems, pkgError.ValidationError("either Image or ImageURL must be provided")
 	}
-	deletedItems = append(deletedItems, oriImagePath)
 
 	/* Generate thumbnail with smalled image size */
 	srcImage, err := imaging.Open(oriImagePath)
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to open image file '%s' for thumbnail generation: %v. Possible causes: file not found, unsupported format, or permission denied.", oriImagePath, err))
+		return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("Failed to open image file '%s' for thumbnail generation: %v. Possible causes: file not found, unsupported format, or permission denied.", oriImagePath, err))
 	}
 
 	// Resize Thumbnail
 	resizedImage := imaging.Resize(srcImage, 100, 0, imaging.Lanczos)
 	imageThumbnail = fmt.Sprintf("%s/thumbnails-%s", config.PathSendItems, imageName)
 	if err = imaging.Save(resizedImage, imageThumbnail); err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to save thumbnail %v", err))
+		return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to save thumbnail %v", err))
 	}
 	deletedItems = append(deletedItems, imageThumbnail)
 
-	if request.Compress {
+	if compress {
 		// Resize image
 		openImageBuffer, err := imaging.Open(oriImagePath)
 		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("Failed to open image file '%s' for compression: %v. Possible causes: file not found, unsupported format, or permission denied.", oriImagePath, err))
+			return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("Failed to open image file '%s' for compression: %v. Possible causes: file not found, unsupported format, or permission denied.", oriImagePath, err))
 		}
 		newImage := imaging.Resize(openImageBuffer, 600, 0, imaging.Lanczos)
 		newImagePath := fmt.Sprintf("%s/new-%s", config.PathSendItems, imageName)
 		if err = imaging.Save(newImage, newImagePath); err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to save image %v", err))
+			return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to save image %v", err))
 		}
 		deletedItems = append(deletedItems, newImagePath)
 		imagePath = newImagePath
@@ -296,70 +276,35 @@ func (service serviceSend) SendImage(ctx context.Context, request domainSend.Ima
 	}
 
 	// Send to WA server
-	dataWaCaption := request.Caption
-	dataWaImage, err := os.ReadFile(imagePath)
-	if err != nil {
-		return response, err
-	}
-	uploadedImage, err := service.uploadMedia(ctx, client, whatsmeow.MediaImage, dataWaImage, dataWaRecipient)
+	uploadedImage, err := service.uploadMediaFile(ctx, client, whatsmeow.MediaImage, imagePath, recipient)
 	if err != nil {
 		fmt.Printf("failed to upload file: %v", err)
-		return response, err
+		return nil, deletedItems, err
 	}
 	dataWaThumbnail, err := os.ReadFile(imageThumbnail)
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to read thumbnail %v", err))
+		return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to read thumbnail %v", err))
 	}
 
-	msg := &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+	return &waE2E.ImageMessage{
 		JPEGThumbnail: dataWaThumbnail,
-		Caption:       proto.String(dataWaCaption),
+		Caption:       proto.String(caption),
 		URL:           proto.String(uploadedImage.URL),
 		DirectPath:    proto.String(uploadedImage.DirectPath),
 		MediaKey:      uploadedImage.MediaKey,
-		Mimetype:      proto.String(http.DetectContentType(dataWaImage)),
+		Mimetype:      proto.String(http.DetectContentType(fileHead(imagePath))),
 		FileEncSHA256: uploadedImage.FileEncSHA256,
 		FileSHA256:    uploadedImage.FileSHA256,
-		FileLength:    proto.Uint64(uint64(len(dataWaImage))),
-		ViewOnce:      proto.Bool(request.ViewOnce),
-	}}
-
-	if request.BaseRequest.IsForwarded {
-		msg.ImageMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
-		}
-	}
-
-	// Set duration expiration
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.ImageMessage.ContextInfo == nil {
-			msg.ImageMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.ImageMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	}
+		FileLength:    proto.Uint64(uploadedImage.FileLength),
+		ViewOnce:      proto.Bool(viewOnce),
+	}, deletedItems, nil
+}
 
-	caption := "🖼️ Image"
-	if request.Caption != "" {
-		caption = "🖼️ " + request.Caption
-	}
-	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, caption)
-	go func() {
-		errDelete := utils.RemoveFile(0, deletedItems...)
-		if errDelete != nil {
-			fmt.Println("error when deleting picture: ", errDelete)
-		}
-	}()
-	if err != nil {
+func (service serviceSend) SendFile(ctx context.Context, request domainSend.FileRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
 		return response, err
 	}
 
-	response.MessageID = ts.ID
-	response.Status = fmt.Sprintf("Message sent to %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
-	return response, nil
-}
-
-func (service serviceSend) SendFile(ctx context.Context, request domainSend.FileRequest) (response domainSend.GenericResponse, err error) {
 	err = validations.ValidateSendFile(ctx, request)
 	if err != nil {
 		return response, err
@@ -376,24 +321,28 @@ func (service serviceSend) SendFile(ctx context.Context, request domainSend.File
 	}
 
 	var (
-		fileBytes []byte
-		fileName  string
+		filePath string
+		fileName string
 	)
 
 	if request.FileURL != nil && *request.FileURL != "" {
-		fileBytes, fileName, err = utils.DownloadFileFromURL(*request.FileURL)
+		filePath, fileName, err = utils.DownloadFileToFile(*request.FileURL, config.PathSendItems)
 		if err != nil {
 			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download file from URL: %v", err))
 		}
 	} else if request.File != nil {
-		fileBytes = helpers.MultipartFormFileHeaderToBytes(request.File)
+		filePath, err = saveUpload(request.File, request.UploadPath, "file_")
+		if err != nil {
+			return response, pkgError.InternalServerError(fmt.Sprintf("failed to store file: %v", err))
+		}
 		fileName = request.File.Filename
 	}
+	defer os.Remove(filePath)
 
-	fileMimeType := resolveDocumentMIME(fileName, fileBytes)
+	fileMimeType := resolveDocumentMIME(fileName, fileHead(filePath))
 
 	// Send to WA server
-	uploadedFile, err := service.uploadMedia(ctx, client, whatsmeow.MediaDocument, fileBytes, dataWaRecipient)
+	uploadedFile, err := service.uploadMediaFile(ctx, client, whatsmeow.MediaDocument, filePath, dataWaRecipient)
 	if err != nil {
 		fmt.Printf("Failed to upload file: %v", err)
 		return response, err
@@ -412,19 +361,7 @@ func (service serviceSend) SendFile(ctx context.Context, request domainSend.File
 		Caption:       proto.String(request.Caption),
 	}}
 
-	if request.BaseRequest.IsForwarded {
-		msg.DocumentMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
-		}
-	}
-
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.DocumentMessage.ContextInfo == nil {
-			msg.DocumentMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.DocumentMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	}
+	msg.DocumentMessage.ContextInfo = service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, request.Caption)
 
 	caption := "📄 Document"
 	if request.Caption != "" {
@@ -479,28 +416,22 @@ func resolveAudioMIME(filename string, audioBytes []byte) string {
 	return detectedMime
 }
 
-// runFFProbe executes ffprobe with the given arguments and returns the output.
+// runFFProbe executes ffprobe through the transcoding pool and returns the output.
 // Returns empty output and error if ffprobe is not available or fails.
-func runFFProbe(args ...string) ([]byte, error) {
-	if _, err := exec.LookPath("ffprobe"); err != nil {
-		return nil, fmt.Errorf("ffprobe not found: %w", err)
-	}
-	return exec.Command("ffprobe", args...).Output()
+func runFFProbe(ctx context.Context, args ...string) ([]byte, error) {
+	return transcode(ctx, 0, "ffprobe", args...)
 }
 
-// runFFMpeg executes ffmpeg with the given arguments and returns the output.
+// runFFMpeg executes ffmpeg through the transcoding pool and returns the output.
 // Returns empty output and error if ffmpeg is not available or fails.
-func runFFMpeg(args ...string) ([]byte, error) {
-	if _, err := exec.LookPath("ffmpeg"); err != nil {
-		return nil, fmt.Errorf("ffmpeg not found: %w", err)
-	}
-	return exec.Command("ffmpeg", args...).Output()
+func runFFMpeg(ctx context.Context, args ...string) ([]byte, error) {
+	return transcode(ctx, 0, "ffmpeg", args...)
 }
 
 // getAudioDuration returns the duration of an audio file in seconds using ffprobe.
 // If ffprobe is not available or fails, it returns 0.
-func getAudioDuration(audioPath string) uint32 {
-	output, err := runFFProbe(
+func getAudioDuration(ctx context.Context, audioPath string) uint32 {
+	output, err := runFFProbe(ctx,
 		"-hide_banner",
 		"-show_entries", "format=duration",
 		"-of", "default=noprint_wrappers=1:nokey=1",
@@ -524,10 +455,10 @@ func getAudioDuration(audioPath string) uint32 {
 
 // generateWaveform generates a waveform visualization for voice notes using ffmpeg.
 // Returns a []byte with 64 amplitude samples (0-100) for WhatsApp UI visualization.
-func generateWaveform(audioPath string) []byte {
+func generateWaveform(ctx context.Context, audioPath string) []byte {
 	// Extract audio samples as signed 8-bit PCM
 	// -ac 1: mono, -ar 8000: 8kHz sample rate, -f s8: signed 8-bit output
-	output, err := runFFMpeg(
+	output, err := runFFMpeg(ctx,
 		"-i", audioPath,
 		"-ac", "1",
 		"-ar", "8000",
@@ -609,6 +540,10 @@ func generateDefaultWaveform() []byte {
 }
 
 func (service serviceSend) SendVideo(ctx context.Context, request domainSend.VideoRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
 	err = validations.ValidateSendVideo(ctx, request)
 	if err != nil {
 		return response, err
@@ -624,12 +559,12 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 		return response, err
 	}
 
-	var (
-		videoPath      string
-		videoThumbnail string
-		deletedItems   []string
-	)
-
+	videoMessage, deletedItems, err := service.prepareVideoMessage(ctx, client, dataWaRecipient, request.Video, request.UploadPath, request.VideoURL, request.Caption, videoOptions{
+		compress:    request.Compress,
+		viewOnce:    request.ViewOnce,
+		gifPlayback: request.GifPlayback,
+		ptv:         request.PTV,
+	})
 	// Ensure temporary files are always removed, even on early returns
 	defer func() {
 		if len(deletedItems) > 0 {
@@ -637,66 +572,145 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 			go utils.RemoveFile(1, deletedItems...)
 		}
 	}()
+	if err != nil {
+		return response, err
+	}
+
+	videoMessage.ContextInfo = service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, request.Caption)
+
+	msg := &waE2E.Message{VideoMessage: videoMessage}
+	caption := "🎥 Video"
+	if request.Caption != "" {
+		caption = "🎥 " + request.Caption
+	}
+	switch {
+	case request.PTV:
+		// Video notes are the same message under another field
+		msg = &waE2E.Message{PtvMessage: videoMessage}
+		caption = "🎥 Video note"
+	case videoMessage.GetGifPlayback() && request.Caption == "":
+		caption = "🎞️ GIF"
+	}
+	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, caption)
+	if err != nil {
+		return response, err
+	}
+
+	response.MessageID = ts.ID
+	response.Status = fmt.Sprintf("Video sent to %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
+	return response, nil
+}
+
+// videoOptions selects how prepareVideoMessage encodes and flags a video
+type videoOptions struct {
+	compress    bool
+	viewOnce    bool
+	gifPlayback bool
+	// ptv crops the video to a square for a round video note; the caller sends it as PtvMessage
+	ptv bool
+}
+
+// prepareVideoMessage stores the video from an upload or URL, converts GIFs to MP4, generates its thumbnail,
+// optionally compresses or crops it and uploads it to WhatsApp. The returned temporary files must be removed by
+// the caller, also when an error is returned.
+func (service serviceSend) prepareVideoMessage(ctx context.Context, client *whatsmeow.Client, recipient types.JID, video *multipart.FileHeader, uploadPath string, videoURL *string, caption string, opts videoOptions) (_ *waE2E.VideoMessage, deletedItems []string, err error) {
+	var (
+		videoPath      string
+		videoThumbnail string
+	)
 
 	generateUUID := fiberUtils.UUIDv4()
 
 	var oriVideoPath string
 
 	// Determine source of video (URL or uploaded file)
-	if request.VideoURL != nil && *request.VideoURL != "" {
-		// Download video bytes
-		videoBytes, fileName, errDownload := utils.DownloadVideoFromURL(*request.VideoURL)
+	if videoURL != nil && *videoURL != "" {
+		// Stream the video straight to a temporary file
+		downloadedPath, _, errDownload := utils.DownloadVideoToFile(*videoURL, config.PathSendItems)
 		if errDownload != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download video from URL %v", errDownload))
+			return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to download video from URL %v", errDownload))
 		}
-		// Build file path to save the downloaded video temporarily
-		oriVideoPath = fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+fileName)
-		if errWrite := os.WriteFile(oriVideoPath, videoBytes, 0644); errWrite != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to store downloaded video in server %v", errWrite))
-		}
-	} else if request.Video != nil {
+		oriVideoPath = downloadedPath
+	} else if video != nil {
 		// Save uploaded video to server
-		oriVideoPath = fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+request.Video.Filename)
-		err = fasthttp.SaveMultipartFile(request.Video, oriVideoPath)
+		oriVideoPath = fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+video.Filename)
+		err = storeUpload(video, uploadPath, oriVideoPath)
 		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to store video in server %v", err))
+			return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to store video in server %v", err))
 		}
 	} else {
 		// This should not happen due to validation, but guard anyway
-		return response, pkgError.ValidationError("either Video or VideoURL must be provided")
+		return nil, deletedItems, pkgError.ValidationError("either Video or VideoURL must be provided")
 	}
+	deletedItems = append(deletedItems, oriVideoPath)
 
 	// Check if ffmpeg is installed
 	_, err = exec.LookPath("ffmpeg")
 	if err != nil {
-		return response, pkgError.InternalServerError("ffmpeg not installed")
+		return nil, deletedItems, pkgError.InternalServerError("ffmpeg not installed")
+	}
+
+	// WhatsApp has no GIF media type: GIFs travel as muted MP4 with GIF playback
+	thumbnailAt := "00:00:01.000"
+	if http.DetectContentType(fileHead(oriVideoPath)) == "image/gif" {
+		gifVideoPath := fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+"-gif.mp4")
+		deletedItems = append(deletedItems, gifVideoPath)
+		if _, err = runFFMpeg(ctx, "-i", oriVideoPath,
+			"-movflags", "+faststart",
+			"-pix_fmt", "yuv420p",
+			"-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2", // libx264 needs even dimensions
+			"-c:v", "libx264",
+			"-an",
+			"-y",
+			gifVideoPath); err != nil {
+			return nil, deletedItems, transcodeError("failed to convert GIF to MP4", err)
+		}
+		oriVideoPath = gifVideoPath
+		opts.gifPlayback = true
+	}
+	if opts.gifPlayback {
+		// GIFs are often shorter than a second
+		thumbnailAt = "00:00:00.000"
 	}
 
 	// Generate thumbnail using ffmpeg
 	thumbnailVideoPath := fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+".png")
-	cmdThumbnail := exec.Command("ffmpeg", "-i", oriVideoPath, "-ss", "00:00:01.000", "-vframes", "1", thumbnailVideoPath)
-	err = cmdThumbnail.Run()
-	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to create thumbnail %v", err))
+	if _, err = runFFMpeg(ctx, "-i", oriVideoPath, "-ss", thumbnailAt, "-vframes", "1", thumbnailVideoPath); err != nil {
+		return nil, deletedItems, transcodeError("failed to create thumbnail", err)
 	}
+	deletedItems = append(deletedItems, thumbnailVideoPath)
 
 	// Resize Thumbnail
 	srcImage, err := imaging.Open(thumbnailVideoPath)
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to open generated video thumbnail image '%s': %v. Possible causes: file not found, unsupported format, or permission denied.", thumbnailVideoPath, err))
+		return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("Failed to open generated video thumbnail image '%s': %v. Possible causes: file not found, unsupported format, or permission denied.", thumbnailVideoPath, err))
 	}
 	resizedImage := imaging.Resize(srcImage, 100, 0, imaging.Lanczos)
 	thumbnailResizeVideoPath := fmt.Sprintf("%s/thumbnails-%s", config.PathSendItems, generateUUID+".png")
 	if err = imaging.Save(resizedImage, thumbnailResizeVideoPath); err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to save thumbnail %v", err))
+		return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to save thumbnail %v", err))
 	}
-
-	deletedItems = append(deletedItems, thumbnailVideoPath)
 	deletedItems = append(deletedItems, thumbnailResizeVideoPath)
 	videoThumbnail = thumbnailResizeVideoPath
 
-	// Compress if requested
-	if request.Compress {
+	// Video notes are played in a circle: crop to a centered square, which also keeps them small
+	if opts.ptv {
+		ptvVideoPath := fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+"-ptv.mp4")
+		deletedItems = append(deletedItems, ptvVideoPath)
+		if _, err = runFFMpeg(ctx, "-i", oriVideoPath,
+			"-vf", "crop='min(iw,ih)':'min(iw,ih)',scale=480:480",
+			"-c:v", "libx264",
+			"-crf", "28",
+			"-preset", "fast",
+			"-c:a", "aac",
+			"-b:a", "96k",
+			"-movflags", "+faststart",
+			"-y",
+			ptvVideoPath); err != nil {
+			return nil, deletedItems, transcodeError("failed to convert video to video note", err)
+		}
+		videoPath = ptvVideoPath
+	} else if opts.compress {
 		compresVideoPath := fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+".mp4")
 
 		// Use proper compression settings to reduce file size
@@ -706,7 +720,7 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 		// -c:a aac: Use AAC codec for audio
 		// -movflags +faststart: Optimize for web streaming
 		// -vf scale=720:-2: Scale video to max width 720px, maintain aspect ratio
-		cmdCompress := exec.Command("ffmpeg", "-i", oriVideoPath,
+		_, err := runFFMpeg(ctx, "-i", oriVideoPath,
 			"-c:v", "libx264",
 			"-crf", "28",
 			"-preset", "fast",
@@ -716,12 +730,9 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 			"-movflags", "+faststart",
 			"-y", // Overwrite output file if it exists
 			compresVideoPath)
-
-		// Capture both stdout and stderr for better error reporting
-		output, err := cmdCompress.CombinedOutput()
 		if err != nil {
-			logrus.Errorf("ffmpeg compression failed: %v, output: %s", err, string(output))
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to compress video: %v", err))
+			logrus.Errorf("ffmpeg compression failed: %v", err)
+			return nil, deletedItems, transcodeError("failed to compress video", err)
 		}
 
 		videoPath = compresVideoPath
@@ -729,67 +740,40 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 	} else {
 		videoPath = oriVideoPath
 	}
-	deletedItems = append(deletedItems, oriVideoPath)
 
 	//Send to WA server
-	dataWaVideo, err := os.ReadFile(videoPath)
+	uploaded, err := service.uploadMediaFile(ctx, client, whatsmeow.MediaVideo, videoPath, recipient)
 	if err != nil {
-		return response, err
-	}
-	uploaded, err := service.uploadMedia(ctx, client, whatsmeow.MediaVideo, dataWaVideo, dataWaRecipient)
-	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to upload file: %v", err))
+		return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("Failed to upload file: %v", err))
 	}
 	dataWaThumbnail, err := os.ReadFile(videoThumbnail)
 	if err != nil {
-		return response, err
+		return nil, deletedItems, err
 	}
 
-	msg := &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+	return &waE2E.VideoMessage{
 		URL:                 proto.String(uploaded.URL),
-		Mimetype:            proto.String(http.DetectContentType(dataWaVideo)),
-		Caption:             proto.String(request.Caption),
+		Mimetype:            proto.String(http.DetectContentType(fileHead(videoPath))),
+		Caption:             proto.String(caption),
 		FileLength:          proto.Uint64(uploaded.FileLength),
 		FileSHA256:          uploaded.FileSHA256,
 		FileEncSHA256:       uploaded.FileEncSHA256,
 		MediaKey:            uploaded.MediaKey,
 		DirectPath:          proto.String(uploaded.DirectPath),
-		ViewOnce:            proto.Bool(request.ViewOnce),
+		ViewOnce:            proto.Bool(opts.viewOnce),
+		GifPlayback:         proto.Bool(opts.gifPlayback),
 		JPEGThumbnail:       dataWaThumbnail,
 		ThumbnailEncSHA256:  dataWaThumbnail,
 		ThumbnailSHA256:     dataWaThumbnail,
 		ThumbnailDirectPath: proto.String(uploaded.DirectPath),
-	}}
-
-	if request.BaseRequest.IsForwarded {
-		msg.VideoMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
-		}
-	}
-
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.VideoMessage.ContextInfo == nil {
-			msg.VideoMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.VideoMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	}
+	}, deletedItems, nil
+}
 
-	caption := "🎥 Video"
-	if request.Caption != "" {
-		caption = "🎥 " + request.Caption
-	}
-	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, caption)
-	if err != nil {
+func (service serviceSend) SendContact(ctx context.Context, request domainSend.ContactRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
 		return response, err
 	}
 
-	response.MessageID = ts.ID
-	response.Status = fmt.Sprintf("Video sent to %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
-	return response, nil
-}
-
-func (service serviceSend) SendContact(ctx context.Context, request domainSend.ContactRequest) (response domainSend.GenericResponse, err error) {
 	err = validations.ValidateSendContact(ctx, request)
 	if err != nil {
 		return response, err
@@ -805,29 +789,36 @@ func (service serviceSend) SendContact(ctx context.Context, request domainSend.C
 		return response, err
 	}
 
-	msgVCard := fmt.Sprintf("BEGIN:VCARD\nVERSION:3.0\nN:;%v;;;\nFN:%v\nTEL;type=CELL;waid=%v:+%v\nEND:VCARD",
-		request.ContactName, request.ContactName, request.ContactPhone, request.ContactPhone)
-	msg := &waE2E.Message{ContactMessage: &waE2E.ContactMessage{
-		DisplayName: proto.String(request.ContactName),
-		Vcard:       proto.String(msgVCard),
-	}}
-
-	if request.BaseRequest.IsForwarded {
-		msg.ContactMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
+	cards := contactCards(request)
+	contacts := make([]*waE2E.ContactMessage, 0, len(cards))
+	names := make([]string, 0, len(cards))
+	for _, card := range cards {
+		name := card.Name
+		if name == "" {
+			name = whatsapp.ParseVCard(card.VCard).Name
 		}
+		names = append(names, name)
+		contacts = append(contacts, &waE2E.ContactMessage{
+			DisplayName: proto.String(name),
+			Vcard:       proto.String(whatsapp.BuildVCard(card)),
+		})
 	}
 
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.ContactMessage.ContextInfo == nil {
-			msg.ContactMessage.ContextInfo = &waE2E.ContextInfo{}
+	contextInfo := service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, "")
+	msg := &waE2E.Message{}
+	content := "👤 " + strings.Join(names, ", ")
+	if len(contacts) == 1 {
+		msg.ContactMessage = contacts[0]
+		msg.ContactMessage.ContextInfo = contextInfo
+	} else {
+		msg.ContactsArrayMessage = &waE2E.ContactsArrayMessage{
+			DisplayName: proto.String(fmt.Sprintf("%d contacts", len(contacts))),
+			Contacts:    contacts,
+			ContextInfo: contextInfo,
 		}
-		msg.ContactMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
+		content = "👥 " + strings.Join(names, ", ")
 	}
 
-	content := "👤 " + request.ContactName
-
 	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, content)
 	if err != nil {
 		return response, err
@@ -838,7 +829,26 @@ func (service serviceSend) SendContact(ctx context.Context, request domainSend.C
 	return response, nil
 }
 
+// contactCards collects the contacts of a request in order: contact_name/contact_phone, contact, then contacts
+func contactCards(request domainSend.ContactRequest) []domainSend.ContactCard {
+	var cards []domainSend.ContactCard
+	if request.ContactName != "" || request.ContactPhone != "" {
+		cards = append(cards, domainSend.ContactCard{
+			Name:   request.ContactName,
+			Phones: []domainSend.ContactPhone{{Number: request.ContactPhone, Type: "CELL"}},
+		})
+	}
+	if request.Contact != nil {
+		cards = append(cards, *request.Contact)
+	}
+	return append(cards, request.Contacts...)
+}
+
 func (service serviceSend) SendLink(ctx context.Context, request domainSend.LinkRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
 	err = validations.ValidateSendLink(ctx, request)
 	if err != nil {
 		return response, err
@@ -875,19 +885,7 @@ func (service serviceSend) SendLink(ctx context.Context, request domainSend.Link
 		JPEGThumbnail: metadata.ImageThumb,
 	}}
 
-	if request.BaseRequest.IsForwarded {
-		msg.ExtendedTextMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
-		}
-	}
-
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.ExtendedTextMessage.ContextInfo == nil {
-			msg.ExtendedTextMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.ExtendedTextMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	}
+	msg.ExtendedTextMessage.ContextInfo = service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, request.Caption)
 
 	// If we have a thumbnail image, upload it to WhatsApp's servers
 	if len(metadata.ImageThumb) > 0 && metadata.Height != nil && metadata.Width != nil {
@@ -920,6 +918,10 @@ func (service serviceSend) SendLink(ctx context.Context, request domainSend.Link
 }
 
 func (service serviceSend) SendLocation(ctx context.Context, request domainSend.LocationRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
 	err = validations.ValidateSendLocation(ctx, request)
 	if err != nil {
 		return response, err
@@ -943,21 +945,19 @@ func (service serviceSend) SendLocation(ctx context.Context, request domainSend.
 		},
 	}
 
-	if request.BaseRequest.IsForwarded {
-		msg.LocationMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
-		}
+	if request.Name != "" {
+		msg.LocationMessage.Name = proto.String(request.Name)
 	}
-
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.LocationMessage.ContextInfo == nil {
-			msg.LocationMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.LocationMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
+	if request.Address != "" {
+		msg.LocationMessage.Address = proto.String(request.Address)
 	}
 
+	msg.LocationMessage.ContextInfo = service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, "")
+
 	content := "📍 " + request.Latitude + ", " + request.Longitude
+	if request.Name != "" {
+		content = "📍 " + request.Name + " (" + request.Latitude + ", " + request.Longitude + ")"
+	}
 
 	// Send WhatsApp Message Proto
 	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, content)
@@ -971,6 +971,10 @@ func (service serviceSend) SendLocation(ctx context.Context, request domainSend.
 }
 
 func (service serviceSend) SendAudio(ctx context.Context, request domainSend.AudioRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
 	// Validate request
 	err = validations.ValidateSendAudio(ctx, request)
 	if err != nil {
@@ -988,13 +992,10 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 	}
 
 	var (
-		audioBytes     []byte
-		audioMimeType  string
-		audioFilename  string
-		audioDuration  uint32
-		tempAudioPath  string
-		deleteTempFile bool
-		deletedItems   []string
+		audioPath     string
+		audioMimeType string
+		audioDuration uint32
+		deletedItems  []string
 	)
 
 	// Cleanup temporary files on exit
@@ -1006,36 +1007,23 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 		}
 	}()
 
-	// Handle audio from URL or file
+	// Handle audio from URL or file; either way it is streamed to a temporary file, never held in memory
 	if request.AudioURL != nil && *request.AudioURL != "" {
-		audioBytes, audioFilename, err = utils.DownloadAudioFromURL(*request.AudioURL)
+		var audioFilename string
+		audioPath, audioFilename, err = utils.DownloadAudioToFile(*request.AudioURL, config.PathSendItems)
 		if err != nil {
 			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download audio from URL %v", err))
 		}
-		audioMimeType = resolveAudioMIME(audioFilename, audioBytes)
-
-		// Save to temp file to get duration
-		tempAudioPath = fmt.Sprintf("%s/temp_audio_%s", config.PathSendItems, fiberUtils.UUIDv4()+filepath.Ext(audioFilename))
-		if err = os.WriteFile(tempAudioPath, audioBytes, 0644); err == nil {
-			deleteTempFile = true
-			audioDuration = getAudioDuration(tempAudioPath)
-		}
+		audioMimeType = resolveAudioMIME(audioFilename, fileHead(audioPath))
 	} else if request.Audio != nil {
-		audioBytes = helpers.MultipartFormFileHeaderToBytes(request.Audio)
-		audioMimeType = resolveAudioMIME(request.Audio.Filename, audioBytes)
-
-		// Save to temp file to get duration
-		tempAudioPath = fmt.Sprintf("%s/temp_audio_%s", config.PathSendItems, fiberUtils.UUIDv4()+filepath.Ext(request.Audio.Filename))
-		if err = os.WriteFile(tempAudioPath, audioBytes, 0644); err == nil {
-			deleteTempFile = true
-			audioDuration = getAudioDuration(tempAudioPath)
+		audioPath, err = saveUpload(request.Audio, request.UploadPath, "temp_audio_")
+		if err != nil {
+			return response, pkgError.InternalServerError(fmt.Sprintf("failed to store audio: %v", err))
 		}
+		audioMimeType = resolveAudioMIME(request.Audio.Filename, fileHead(audioPath))
 	}
-
-	// Clean up temp file
-	if deleteTempFile {
-		defer os.Remove(tempAudioPath)
-	}
+	deletedItems = append(deletedItems, audioPath)
+	audioDuration = getAudioDuration(ctx, audioPath)
 
 	// For PTT (voice notes), WhatsApp requires "audio/ogg; codecs=opus"
 	// Check if it's an OGG file and add codec info for PTT
@@ -1045,8 +1033,8 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 
 	// Generate waveform for PTT voice notes
 	var waveformData []byte
-	if request.PTT && tempAudioPath != "" {
-		waveformData = generateWaveform(tempAudioPath)
+	if request.PTT {
+		waveformData = generateWaveform(ctx, audioPath)
 	}
 
 	// If PTT is requested, convert audio to OGG Opus format for WhatsApp voice note compatibility
@@ -1071,13 +1059,6 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 
 			generateUUID := fiberUtils.UUIDv4()
 
-			// Save input audio to temporary file
-			inputPath := filepath.Join(absBaseDir, fmt.Sprintf("audio_input_%s", generateUUID))
-			if err := os.WriteFile(inputPath, audioBytes, 0644); err != nil {
-				return response, pkgError.InternalServerError(fmt.Sprintf("failed to save audio for conversion: %v", err))
-			}
-			deletedItems = append(deletedItems, inputPath)
-
 			// Output path for converted OGG Opus file
 			outputPath := filepath.Join(absBaseDir, fmt.Sprintf("audio_ptt_%s.ogg", generateUUID))
 			deletedItems = append(deletedItems, outputPath)
@@ -1090,11 +1071,8 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 			// -application voip: Optimize for voice
 			// -ar 48000: Sample rate (Opus requires 48kHz)
 			// -ac 1: Mono (WhatsApp voice notes are mono)
-			convCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
-			defer cancel()
-
-			cmdConvert := exec.CommandContext(convCtx, "ffmpeg",
-				"-i", inputPath,
+			_, err = transcode(ctx, 60*time.Second, "ffmpeg",
+				"-i", audioPath,
 				"-c:a", "libopus",
 				"-b:a", "64k",
 				"-vbr", "on",
@@ -1104,25 +1082,17 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 				"-y", // Overwrite output if exists
 				outputPath,
 			)
-
-			var stderr bytes.Buffer
-			cmdConvert.Stderr = &stderr
-
-			if err := cmdConvert.Run(); err != nil {
-				logrus.Errorf("ffmpeg PTT conversion failed: %v, stderr: %s", err, stderr.String())
-				return response, pkgError.InternalServerError(fmt.Sprintf("failed to convert audio to OGG Opus for PTT: %v", err))
-			}
-
-			// Read converted audio
-			audioBytes, err = os.ReadFile(outputPath)
 			if err != nil {
-				return response, pkgError.InternalServerError(fmt.Sprintf("failed to read converted audio: %v", err))
+				logrus.Errorf("ffmpeg PTT conversion failed: %v", err)
+				return response, transcodeError("failed to convert audio to OGG Opus for PTT", err)
 			}
 
+			audioPath = outputPath
+
 			// Update MIME type to OGG Opus
 			audioMimeType = "audio/ogg; codecs=opus"
 
-			logrus.Infof("Converted audio to OGG Opus for PTT: %d bytes", len(audioBytes))
+			logrus.Infof("Converted audio to OGG Opus for PTT: %s", outputPath)
 		} else {
 			// Already OGG format, ensure MIME type is correctly set
 			audioMimeType = "audio/ogg; codecs=opus"
@@ -1130,7 +1100,7 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 	}
 
 	// upload to WhatsApp servers
-	audioUploaded, err := service.uploadMedia(ctx, client, whatsmeow.MediaAudio, audioBytes, dataWaRecipient)
+	audioUploaded, err := service.uploadMediaFile(ctx, client, whatsmeow.MediaAudio, audioPath, dataWaRecipient)
 	if err != nil {
 		err = pkgError.WaUploadMediaError(fmt.Sprintf("Failed to upload audio: %v", err))
 		return response, err
@@ -1148,22 +1118,11 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 			PTT:           proto.Bool(request.PTT),
 			Seconds:       proto.Uint32(audioDuration),
 			Waveform:      waveformData,
+			ViewOnce:      proto.Bool(request.ViewOnce),
 		},
 	}
 
-	if request.BaseRequest.IsForwarded {
-		msg.AudioMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
-		}
-	}
-
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.AudioMessage.ContextInfo == nil {
-			msg.AudioMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.AudioMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	}
+	msg.AudioMessage.ContextInfo = service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, "")
 
 	content := "🎵 Audio"
 
@@ -1178,6 +1137,10 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 }
 
 func (service serviceSend) SendPoll(ctx context.Context, request domainSend.PollRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
 	err = validations.ValidateSendPoll(ctx, request)
 	if err != nil {
 		return response, err
@@ -1197,18 +1160,30 @@ func (service serviceSend) SendPoll(ctx context.Context, request domainSend.Poll
 
 	msg := client.BuildPollCreation(request.Question, request.Options, request.MaxAnswer)
 
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.PollCreationMessage.ContextInfo == nil {
-			msg.PollCreationMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.PollCreationMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	}
+	msg.PollCreationMessage.ContextInfo = service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, request.Question)
 
 	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, content)
 	if err != nil {
 		return response, err
 	}
 
+	// Keep the options so incoming votes can be mapped back to them
+	poll := &domainChatStorage.Poll{
+		ID:              ts.ID,
+		DeviceID:        deviceIDFromContext(ctx),
+		ChatJID:         dataWaRecipient.String(),
+		Question:        request.Question,
+		Options:         request.Options,
+		SelectableCount: int(msg.PollCreationMessage.GetSelectableOptionsCount()),
+		Timestamp:       ts.Timestamp,
+	}
+	if client.Store.ID != nil {
+		poll.Creator = client.Store.ID.ToNonAD().String()
+	}
+	if err := service.chatStorageRepo.StorePoll(poll); err != nil {
+		logrus.Warnf("Failed to store poll %s: %v", ts.ID, err)
+	}
+
 	response.MessageID = ts.ID
 	response.Status = fmt.Sprintf("Send poll success %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
 	return response, nil
@@ -1306,7 +1281,7 @@ func (service serviceSend) getMentionsFromList(ctx context.Context, mentions []s
 		// Handle @everyone keyword - fetch all group participants
 		if mention == "@everyone" {
 			if recipientJID.Server == types.GroupServer {
-				groupInfo, err := client.GetGroupInfo(ctx, recipientJID)
+				groupInfo, err := whatsapp.StoredGroupInfo(ctx, client, service.chatStorageRepo, deviceIDFromContext(ctx), recipientJID, false)
 				if err == nil && groupInfo != nil {
 					for _, participant := range groupInfo.Participants {
 						result = append(result, participant.JID.String())
@@ -1325,6 +1300,10 @@ func (service serviceSend) getMentionsFromList(ctx context.Context, mentions []s
 }
 
 func (service serviceSend) SendSticker(ctx context.Context, request domainSend.StickerRequest) (response domainSend.GenericResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
 	// Validate request
 	err = validations.ValidateSendSticker(ctx, request)
 	if err != nil {
@@ -1344,7 +1323,6 @@ func (service serviceSend) SendSticker(ctx context.Context, request domainSend.S
 	var (
 		stickerPath  string
 		deletedItems []string
-		stickerBytes []byte
 	)
 
 	// Resolve absolute base directory for send items
@@ -1364,23 +1342,11 @@ func (service serviceSend) SendSticker(ctx context.Context, request domainSend.S
 
 	// Handle sticker from URL or file
 	if request.StickerURL != nil && *request.StickerURL != "" {
-		// Download sticker from URL
-		imageData, _, err := utils.DownloadImageFromURL(*request.StickerURL)
+		// Download sticker from URL into a temporary file within base dir
+		stickerPath, _, err = utils.DownloadImageToFile(*request.StickerURL, absBaseDir)
 		if err != nil {
 			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download sticker from URL: %v", err))
 		}
-
-		// Create safe temporary file within base dir
-		f, err := os.CreateTemp(absBaseDir, "sticker_*")
-		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to create temp file: %v", err))
-		}
-		stickerPath = f.Name()
-		if _, err := f.Write(imageData); err != nil {
-			f.Close()
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to write sticker: %v", err))
-		}
-		_ = f.Close()
 		deletedItems = append(deletedItems, stickerPath)
 	} else if request.Sticker != nil {
 		// Create safe temporary file within base dir
@@ -1392,93 +1358,123 @@ func (service serviceSend) SendSticker(ctx context.Context, request domainSend.S
 		_ = f.Close()
 
 		// Save uploaded file to safe path
-		err = fasthttp.SaveMultipartFile(request.Sticker, stickerPath)
+		err = storeUpload(request.Sticker, request.UploadPath, stickerPath)
 		if err != nil {
 			return response, pkgError.InternalServerError(fmt.Sprintf("failed to save sticker: %v", err))
 		}
 		deletedItems = append(deletedItems, stickerPath)
 	}
 
+	stickerMessage, preparedItems, err := service.prepareStickerMessage(ctx, client, dataWaRecipient, stickerPath, stickerMetadata(request.StickerMetadata))
+	deletedItems = append(deletedItems, preparedItems...)
+	if err != nil {
+		return response, err
+	}
+
+	stickerMessage.ContextInfo = service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, "")
+	msg := &waE2E.Message{StickerMessage: stickerMessage}
+
+	content := "🎨 Sticker"
+	if stickerMessage.GetIsAnimated() {
+		content = "🎨 Animated Sticker"
+	}
+
+	// Send the sticker message
+	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, content)
+	if err != nil {
+		return response, err
+	}
+
+	response.MessageID = ts.ID
+	if stickerMessage.GetIsAnimated() {
+		response.Status = fmt.Sprintf("Animated sticker sent to %s (server timestamp: %s)", request.Phone, ts.Timestamp.String())
+	} else {
+		response.Status = fmt.Sprintf("Sticker sent to %s (server timestamp: %s)", request.Phone, ts.Timestamp.String())
+	}
+	return response, nil
+}
+
+// prepareStickerMessage turns the image at stickerPath into a WebP sticker: animated WebP is used as is, anything
+// else is scaled to at most 512x512 and converted. The metadata, if any, is embedded before the upload. The
+// returned temporary files must be removed by the caller, also when an error is returned.
+func (service serviceSend) prepareStickerMessage(ctx context.Context, client *whatsmeow.Client, recipient types.JID, stickerPath string, metadata *utils.StickerMetadata) (_ *waE2E.StickerMessage, deletedItems []string, err error) {
+	var (
+		stickerBytes  []byte
+		width, height int
+	)
+
+	// Resolve absolute base directory for send items
+	absBaseDir, err := filepath.Abs(config.PathSendItems)
+	if err != nil {
+		return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to resolve base directory: %v", err))
+	}
+
 	// Check if input is animated WebP - if so, handle it specially
-	infoCtx, infoCancel := context.WithTimeout(ctx, 5*time.Second)
-	defer infoCancel()
-	isAnimatedSticker, webpWidth, webpHeight := getWebPInfo(infoCtx, stickerPath)
+	isAnimatedSticker, webpWidth, webpHeight := getWebPInfo(ctx, stickerPath)
 	if isAnimatedSticker {
 		logrus.Info("Detected animated WebP sticker")
 
 		// Validate dimensions - must be exactly 512x512 for animated stickers
 		if webpWidth != 512 || webpHeight != 512 {
-			return response, pkgError.ValidationError(
+			return nil, deletedItems, pkgError.ValidationError(
 				fmt.Sprintf("animated WebP stickers must be exactly 512x512 pixels (got %dx%d). Please resize your sticker before uploading.", webpWidth, webpHeight))
 		}
 
 		// Validate file size - must be under 500KB
 		fileInfo, statErr := os.Stat(stickerPath)
 		if statErr != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to stat sticker file: %v", statErr))
+			return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to stat sticker file: %v", statErr))
 		}
 		if fileInfo.Size() > 500*1024 {
-			return response, pkgError.ValidationError(
+			return nil, deletedItems, pkgError.ValidationError(
 				fmt.Sprintf("animated WebP stickers must be under 500KB (got %d KB). Please reduce the file size.", fileInfo.Size()/1024))
 		}
 
 		// Use the animated WebP file directly
 		stickerBytes, err = os.ReadFile(stickerPath)
 		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to read animated sticker: %v", err))
+			return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to read animated sticker: %v", err))
 		}
+		width, height = webpWidth, webpHeight
 
 		logrus.Infof("Using animated WebP sticker directly: %dx%d, %d bytes", webpWidth, webpHeight, len(stickerBytes))
-
-		// Upload sticker to WhatsApp servers
-		stickerUploaded, err := service.uploadMedia(ctx, client, whatsmeow.MediaImage, stickerBytes, dataWaRecipient)
+	} else {
+		stickerBytes, width, height, deletedItems, err = convertSticker(ctx, stickerPath, absBaseDir)
 		if err != nil {
-			return response, pkgError.WaUploadMediaError(fmt.Sprintf("failed to upload sticker: %v", err))
-		}
-
-		// Create animated sticker message
-		msg := &waE2E.Message{
-			StickerMessage: &waE2E.StickerMessage{
-				URL:           proto.String(stickerUploaded.URL),
-				DirectPath:    proto.String(stickerUploaded.DirectPath),
-				Mimetype:      proto.String("image/webp"),
-				FileLength:    proto.Uint64(stickerUploaded.FileLength),
-				FileSHA256:    stickerUploaded.FileSHA256,
-				FileEncSHA256: stickerUploaded.FileEncSHA256,
-				MediaKey:      stickerUploaded.MediaKey,
-				Width:         proto.Uint32(uint32(webpWidth)),
-				Height:        proto.Uint32(uint32(webpHeight)),
-				IsAnimated:    proto.Bool(true),
-			},
+			return nil, deletedItems, err
 		}
+	}
 
-		if request.BaseRequest.IsForwarded {
-			msg.StickerMessage.ContextInfo = &waE2E.ContextInfo{
-				IsForwarded:     proto.Bool(true),
-				ForwardingScore: proto.Uint32(100),
-			}
-		}
-
-		if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-			if msg.StickerMessage.ContextInfo == nil {
-				msg.StickerMessage.ContextInfo = &waE2E.ContextInfo{}
-			}
-			msg.StickerMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-		}
-
-		content := "🎨 Animated Sticker"
-
-		// Send the animated sticker message
-		ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, content)
+	if metadata != nil {
+		stickerBytes, err = utils.EmbedStickerMetadata(stickerBytes, *metadata)
 		if err != nil {
-			return response, err
+			return nil, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to embed sticker metadata: %v", err))
 		}
-
-		response.MessageID = ts.ID
-		response.Status = fmt.Sprintf("Animated sticker sent to %s (server timestamp: %s)", request.Phone, ts.Timestamp.String())
-		return response, nil
 	}
 
+	// Upload sticker to WhatsApp servers
+	stickerUploaded, err := service.uploadMedia(ctx, client, whatsmeow.MediaImage, stickerBytes, recipient)
+	if err != nil {
+		return nil, deletedItems, pkgError.WaUploadMediaError(fmt.Sprintf("failed to upload sticker: %v", err))
+	}
+
+	return &waE2E.StickerMessage{
+		URL:           proto.String(stickerUploaded.URL),
+		DirectPath:    proto.String(stickerUploaded.DirectPath),
+		Mimetype:      proto.String("image/webp"),
+		FileLength:    proto.Uint64(stickerUploaded.FileLength),
+		FileSHA256:    stickerUploaded.FileSHA256,
+		FileEncSHA256: stickerUploaded.FileEncSHA256,
+		MediaKey:      stickerUploaded.MediaKey,
+		Width:         proto.Uint32(uint32(width)),
+		Height:        proto.Uint32(uint32(height)),
+		IsAnimated:    proto.Bool(isAnimatedSticker),
+	}, deletedItems, nil
+}
+
+// convertSticker scales a still image (or the first frame of an animated WebP the image decoder cannot read) to
+// at most 512x512 and converts it to WebP
+func convertSticker(ctx context.Context, stickerPath, absBaseDir string) (stickerBytes []byte, width, height int, deletedItems []string, err error) {
 	// Convert image to WebP format for sticker (512x512 max size)
 	srcImage, err := imaging.Open(stickerPath)
 	if err != nil {
@@ -1488,12 +1484,9 @@ func (service serviceSend) SendSticker(ctx context.Context, request domainSend.S
 		fallbackPngPath := filepath.Join(absBaseDir, fmt.Sprintf("fallback_%s.png", fiberUtils.UUIDv4()))
 		deletedItems = append(deletedItems, fallbackPngPath)
 
-		convertCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		defer cancel()
-
 		// Check if context was already cancelled before starting conversion
-		if convertCtx.Err() != nil {
-			return response, pkgError.InternalServerError("request cancelled during sticker processing")
+		if ctx.Err() != nil {
+			return nil, 0, 0, deletedItems, pkgError.InternalServerError("request cancelled during sticker processing")
 		}
 
 		conversionSuccess := false
@@ -1505,41 +1498,35 @@ func (service serviceSend) SendSticker(ctx context.Context, request domainSend.S
 				extractedFramePath := filepath.Join(absBaseDir, fmt.Sprintf("frame_%s.webp", fiberUtils.UUIDv4()))
 				deletedItems = append(deletedItems, extractedFramePath)
 
-				cmdWebpmux := exec.CommandContext(convertCtx, "webpmux", "-get", "frame", "1", stickerPath, "-o", extractedFramePath)
-				var stderrWebpmux bytes.Buffer
-				cmdWebpmux.Stderr = &stderrWebpmux
-				if errWebpmux := cmdWebpmux.Run(); errWebpmux == nil {
+				if _, errWebpmux := transcode(ctx, 30*time.Second, "webpmux", "-get", "frame", "1", stickerPath, "-o", extractedFramePath); errWebpmux == nil {
 					// Now decode the extracted frame with dwebp
-					cmdDwebp := exec.CommandContext(convertCtx, "dwebp", extractedFramePath, "-o", fallbackPngPath)
-					var stderrDwebp bytes.Buffer
-					cmdDwebp.Stderr = &stderrDwebp
-					if errDwebp := cmdDwebp.Run(); errDwebp == nil {
+					if _, errDwebp := transcode(ctx, 30*time.Second, "dwebp", extractedFramePath, "-o", fallbackPngPath); errDwebp == nil {
 						conversionSuccess = true
 						logrus.Info("webpmux + dwebp conversion successful for animated WebP")
 					} else {
-						logrus.Errorf("dwebp failed on extracted frame: %v, stderr: %s", errDwebp, stderrDwebp.String())
+						logrus.Errorf("dwebp failed on extracted frame: %v", errDwebp)
 					}
 				} else {
-					logrus.Errorf("webpmux frame extraction failed: %v, stderr: %s", errWebpmux, stderrWebpmux.String())
+					logrus.Errorf("webpmux frame extraction failed: %v", errWebpmux)
 				}
 			}
 		}
 
 		if !conversionSuccess {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to open image for sticker conversion: %v (animated WebP requires webpmux and dwebp tools)", err))
+			return nil, 0, 0, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to open image for sticker conversion: %v (animated WebP requires webpmux and dwebp tools)", err))
 		}
 
 		srcImage, err = imaging.Open(fallbackPngPath)
 		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to open fallback PNG image: %v", err))
+			return nil, 0, 0, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to open fallback PNG image: %v", err))
 		}
 		logrus.Info("Fallback conversion successful")
 	}
 
 	// Resize image to max 512x512 maintaining aspect ratio
 	bounds := srcImage.Bounds()
-	width := bounds.Dx()
-	height := bounds.Dy()
+	width = bounds.Dx()
+	height = bounds.Dy()
 
 	if width > 512 || height > 512 {
 		if width > height {
@@ -1559,88 +1546,38 @@ func (service serviceSend) SendSticker(ctx context.Context, request domainSend.S
 
 	err = imaging.Save(srcImage, pngPath)
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to save temporary PNG: %v", err))
+		return nil, 0, 0, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to save temporary PNG: %v", err))
 	}
 
 	// Try to use ffmpeg first (most common), then cwebp
-	var convertCmd *exec.Cmd
-
-	// Add execution timeout for conversion
-	convCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
-	defer cancel()
+	var convertTool string
+	var convertArgs []string
 
 	// Check if ffmpeg is available
 	if _, err := exec.LookPath("ffmpeg"); err == nil {
 		// Use ffmpeg to convert to WebP with transparency support, overwrite if exists
-		convertCmd = exec.CommandContext(convCtx, "ffmpeg", "-y", "-i", pngPath, "-vcodec", "libwebp", "-lossless", "0", "-compression_level", "6", "-q:v", "60", "-preset", "default", "-loop", "0", "-an", "-vsync", "0", webpPath)
+		convertTool = "ffmpeg"
+		convertArgs = []string{"-y", "-i", pngPath, "-vcodec", "libwebp", "-lossless", "0", "-compression_level", "6", "-q:v", "60", "-preset", "default", "-loop", "0", "-an", "-vsync", "0", webpPath}
 	} else if _, err := exec.LookPath("cwebp"); err == nil {
 		// Use cwebp as fallback
-		convertCmd = exec.CommandContext(convCtx, "cwebp", "-q", "60", "-o", webpPath, pngPath)
+		convertTool = "cwebp"
+		convertArgs = []string{"-q", "60", "-o", webpPath, pngPath}
 	} else {
 		// If neither tool is available, return error
-		return response, pkgError.InternalServerError("neither ffmpeg nor cwebp is installed for WebP conversion")
+		return nil, 0, 0, deletedItems, pkgError.InternalServerError("neither ffmpeg nor cwebp is installed for WebP conversion")
 	}
 
-	var stderr bytes.Buffer
-	convertCmd.Stderr = &stderr
-
-	if err := convertCmd.Run(); err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to convert sticker to WebP: %v, stderr: %s", err, stderr.String()))
+	if _, err := transcode(ctx, 45*time.Second, convertTool, convertArgs...); err != nil {
+		return nil, 0, 0, deletedItems, transcodeError("failed to convert sticker to WebP", err)
 	}
 
 	// Read the WebP file
 	stickerBytes, err = os.ReadFile(webpPath)
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to read WebP sticker: %v", err))
-	}
-
-	// Upload sticker to WhatsApp servers
-	stickerUploaded, err := service.uploadMedia(ctx, client, whatsmeow.MediaImage, stickerBytes, dataWaRecipient)
-	if err != nil {
-		return response, pkgError.WaUploadMediaError(fmt.Sprintf("failed to upload sticker: %v", err))
+		return nil, 0, 0, deletedItems, pkgError.InternalServerError(fmt.Sprintf("failed to read WebP sticker: %v", err))
 	}
 
-	// Create sticker message
-	msg := &waE2E.Message{
-		StickerMessage: &waE2E.StickerMessage{
-			URL:           proto.String(stickerUploaded.URL),
-			DirectPath:    proto.String(stickerUploaded.DirectPath),
-			Mimetype:      proto.String("image/webp"),
-			FileLength:    proto.Uint64(stickerUploaded.FileLength),
-			FileSHA256:    stickerUploaded.FileSHA256,
-			FileEncSHA256: stickerUploaded.FileEncSHA256,
-			MediaKey:      stickerUploaded.MediaKey,
-			Width:         proto.Uint32(uint32(srcImage.Bounds().Dx())),
-			Height:        proto.Uint32(uint32(srcImage.Bounds().Dy())),
-			IsAnimated:    proto.Bool(false),
-		},
-	}
-
-	if request.BaseRequest.IsForwarded {
-		msg.StickerMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
-		}
-	}
-
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.StickerMessage.ContextInfo == nil {
-			msg.StickerMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.StickerMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	}
-
-	content := "🎨 Sticker"
-
-	// Send the sticker message
-	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, content)
-	if err != nil {
-		return response, err
-	}
-
-	response.MessageID = ts.ID
-	response.Status = fmt.Sprintf("Sticker sent to %s (server timestamp: %s)", request.Phone, ts.Timestamp.String())
-	return response, nil
+	return stickerBytes, srcImage.Bounds().Dx(), srcImage.Bounds().Dy(), deletedItems, nil
 }
 
 func (service serviceSend) uploadMedia(ctx context.Context, client *whatsmeow.Client, mediaType whatsmeow.MediaType, media []byte, recipient types.JID) (uploaded whatsmeow.UploadResponse, err error) {
@@ -1664,8 +1601,7 @@ func getWebPInfo(ctx context.Context, filePath string) (isAnimated bool, width i
 	// Clean path to prevent path traversal
 	cleanPath := filepath.Clean(filePath)
 
-	cmd := exec.CommandContext(ctx, "webpmux", "-info", cleanPath)
-	output, err := cmd.Output()
+	output, err := transcode(ctx, 5*time.Second, "webpmux", "-info", cleanPath)
 	if err != nil {
 		return false, 0, 0
 	}
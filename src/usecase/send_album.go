@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// albumUploadConcurrency caps how many album items are prepared and uploaded at once
+const albumUploadConcurrency = 4
+
+// albumMedia is one prepared album item, ready to be attached to the album message
+type albumMedia struct {
+	msg          *waE2E.Message
+	content      string
+	deletedItems []string
+}
+
+// SendAlbum uploads the items concurrently and sends them grouped the way the phone app does: an album
+// message announcing the item counts, followed by every media message associated with it, in order.
+func (service serviceSend) SendAlbum(ctx context.Context, request domainSend.AlbumRequest) (response domainSend.AlbumResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
+	err = validations.ValidateSendAlbum(ctx, request)
+	if err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	dataWaRecipient, err := utils.ValidateJidWithLogin(client, request.Phone)
+	if err != nil {
+		return response, err
+	}
+
+	media := make([]albumMedia, len(request.Items))
+	errs := make([]error, len(request.Items))
+	defer func() {
+		var deletedItems []string
+		for _, item := range media {
+			deletedItems = append(deletedItems, item.deletedItems...)
+		}
+		if len(deletedItems) > 0 {
+			go utils.RemoveFile(1, deletedItems...)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, albumUploadConcurrency)
+	for i, item := range request.Items {
+		wg.Add(1)
+		go func(i int, item domainSend.AlbumItem) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			base := request.BaseRequest
+			if i > 0 {
+				// Only the first item carries the quote, like albums sent as a reply from the phone
+				base.ReplyMessageID = nil
+			}
+			media[i], errs[i] = service.prepareAlbumItem(ctx, client, dataWaRecipient, item, base, request.Compress)
+		}(i, item)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			logrus.Warnf("Album to %s: failed to prepare items[%d]: %v", request.Phone, i, err)
+			return response, err
+		}
+	}
+
+	var imageCount, videoCount uint32
+	for _, item := range request.Items {
+		if item.Type == domainSend.AlbumItemVideo {
+			videoCount++
+		} else {
+			imageCount++
+		}
+	}
+
+	albumMsg := &waE2E.Message{AlbumMessage: &waE2E.AlbumMessage{
+		ExpectedImageCount: proto.Uint32(imageCount),
+		ExpectedVideoCount: proto.Uint32(videoCount),
+		ContextInfo: service.buildContextInfo(ctx, domainSend.BaseRequest{
+			Duration:    request.Duration,
+			IsForwarded: request.IsForwarded,
+		}, dataWaRecipient, ""),
+	}}
+
+	// Every message of the album counts against the outbound rate limit. The album message fails fast when the
+	// limit is reached; once it is out, each item waits for its own slot so the album is not cut off halfway.
+	album, err := service.wrapSendMessage(ctx, client, dataWaRecipient, albumMsg, fmt.Sprintf("🖼️ Album (%d items)", len(request.Items)))
+	if err != nil {
+		return response, err
+	}
+	response.MessageID = album.ID
+
+	parentKey := &waCommon.MessageKey{
+		RemoteJID: proto.String(dataWaRecipient.String()),
+		FromMe:    proto.Bool(true),
+		ID:        proto.String(album.ID),
+	}
+	for i, item := range media {
+		item.msg.MessageContextInfo = &waE2E.MessageContextInfo{
+			MessageAssociation: &waE2E.MessageAssociation{
+				AssociationType:  waE2E.MessageAssociation_MEDIA_ALBUM.Enum(),
+				ParentMessageKey: parentKey,
+			},
+		}
+
+		ts, err := func() (whatsmeow.SendResponse, error) {
+			if err := service.limiter.wait(ctx, sendLimiterKey(ctx, client), dataWaRecipient); err != nil {
+				return whatsmeow.SendResponse{}, err
+			}
+			return service.sendAndStore(ctx, client, dataWaRecipient, item.msg, item.content)
+		}()
+		if err != nil {
+			logrus.Warnf("Album %s to %s stopped after %d of %d items: %v", album.ID, request.Phone, i, len(media), err)
+			return response, pkgError.InternalServerError(fmt.Sprintf("album partially sent (%d of %d items): %v", i, len(media), err))
+		}
+		response.MessageIDs = append(response.MessageIDs, ts.ID)
+	}
+
+	response.Status = fmt.Sprintf("Album of %d items sent to %s (server timestamp: %s)", len(media), request.Phone, album.Timestamp.String())
+	return response, nil
+}
+
+// prepareAlbumItem uploads one album item and wraps it in its media message
+func (service serviceSend) prepareAlbumItem(ctx context.Context, client *whatsmeow.Client, recipient types.JID, item domainSend.AlbumItem, base domainSend.BaseRequest, compress bool) (prepared albumMedia, err error) {
+	switch item.Type {
+	case domainSend.AlbumItemVideo:
+		var video *waE2E.VideoMessage
+		video, prepared.deletedItems, err = service.prepareVideoMessage(ctx, client, recipient, item.File, item.UploadPath, item.URL, item.Caption, videoOptions{compress: compress})
+		if err != nil {
+			return prepared, err
+		}
+		video.ContextInfo = service.buildContextInfo(ctx, base, recipient, item.Caption)
+		prepared.msg = &waE2E.Message{VideoMessage: video}
+		prepared.content = strings.TrimSpace("🎥 " + item.Caption)
+	default:
+		var image *waE2E.ImageMessage
+		image, prepared.deletedItems, err = service.prepareImageMessage(ctx, client, recipient, item.File, item.UploadPath, item.URL, item.Caption, compress, false)
+		if err != nil {
+			return prepared, err
+		}
+		image.ContextInfo = service.buildContextInfo(ctx, base, recipient, item.Caption)
+		prepared.msg = &waE2E.Message{ImageMessage: image}
+		prepared.content = strings.TrimSpace("🖼️ " + item.Caption)
+	}
+	return prepared, nil
+}
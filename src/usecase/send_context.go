@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// buildContextInfo assembles the ContextInfo every send type shares: the forwarded flag, the disappearing
+// timer, mentions (@phone in text plus request.Mentions) and the quoted message of a reply. It returns nil
+// when there is nothing to attach.
+func (service serviceSend) buildContextInfo(ctx context.Context, request domainSend.BaseRequest, recipient types.JID, text string) *waE2E.ContextInfo {
+	contextInfo := &waE2E.ContextInfo{}
+
+	if request.IsForwarded {
+		contextInfo.IsForwarded = proto.Bool(true)
+		contextInfo.ForwardingScore = proto.Uint32(100)
+	}
+
+	if request.Duration != nil && *request.Duration > 0 {
+		contextInfo.Expiration = proto.Uint32(uint32(*request.Duration))
+	} else if expiration := service.getDefaultEphemeralExpiration(recipient.String()); expiration > 0 {
+		contextInfo.Expiration = proto.Uint32(expiration)
+	}
+
+	mentions := service.getMentionFromText(ctx, text)
+	if len(request.Mentions) > 0 {
+		mentions = append(mentions, service.getMentionsFromList(ctx, request.Mentions, recipient)...)
+	}
+	if len(mentions) > 0 {
+		// Deduplicate to avoid mentioning the same person twice
+		contextInfo.MentionedJID = utils.UniqueStrings(mentions)
+	}
+
+	if request.ReplyMessageID != nil && *request.ReplyMessageID != "" {
+		service.applyQuote(ctx, contextInfo, *request.ReplyMessageID, recipient)
+	}
+
+	if proto.Size(contextInfo) == 0 {
+		return nil
+	}
+	return contextInfo
+}
+
+// applyQuote points the context at a message the sending device stored. A reply is still sent when the message
+// is unknown, just without the quote.
+func (service serviceSend) applyQuote(ctx context.Context, contextInfo *waE2E.ContextInfo, messageID string, recipient types.JID) {
+	message, err := service.chatStorageRepo.GetMessageByIDByDevice(deviceIDFromContext(ctx), messageID)
+	if err != nil {
+		logrus.Warnf("Error retrieving reply message ID %s: %v, continuing without reply context", messageID, err)
+		return
+	}
+	if message == nil {
+		logrus.Warnf("Reply message ID %s not found in storage, continuing without reply context", messageID)
+		return
+	}
+
+	contextInfo.StanzaID = proto.String(messageID)
+	// Storage keeps fully-qualified sender JIDs (user@s.whatsapp.net, group participants included)
+	contextInfo.Participant = proto.String(message.Sender)
+	contextInfo.QuotedMessage = quotedMessage(message)
+	if message.ChatJID != recipient.String() {
+		// Quoting a message from another chat, e.g. replying privately to a group message
+		contextInfo.RemoteJID = proto.String(message.ChatJID)
+	}
+}
+
+// quotedMessage rebuilds the preview WhatsApp shows above a reply from what chat storage kept
+func quotedMessage(message *domainChatStorage.Message) *waE2E.Message {
+	switch message.MediaType {
+	case "image":
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{Caption: proto.String(message.Content)}}
+	case "video":
+		return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{Caption: proto.String(message.Content)}}
+	case "video_note":
+		return &waE2E.Message{PtvMessage: &waE2E.VideoMessage{}}
+	case "audio":
+		return &waE2E.Message{AudioMessage: &waE2E.AudioMessage{}}
+	case "document":
+		return &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+			FileName: proto.String(message.Filename),
+			Caption:  proto.String(message.Content),
+		}}
+	case "sticker":
+		return &waE2E.Message{StickerMessage: &waE2E.StickerMessage{}}
+	default:
+		return &waE2E.Message{Conversation: proto.String(message.Content)}
+	}
+}
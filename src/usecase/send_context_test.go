@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// stubMessageRepository keeps messages per device, the way chat storage scopes them
+type stubMessageRepository struct {
+	domainChatStorage.IChatStorageRepository
+	messages map[string]map[string]*domainChatStorage.Message
+}
+
+func (r stubMessageRepository) GetMessageByIDByDevice(deviceID, id string) (*domainChatStorage.Message, error) {
+	return r.messages[deviceID][id], nil
+}
+
+func TestApplyQuoteOnlyQuotesTheSendingDevice(t *testing.T) {
+	service := serviceSend{chatStorageRepo: stubMessageRepository{messages: map[string]map[string]*domainChatStorage.Message{
+		"dev-a": {"MSG1": {ID: "MSG1", ChatJID: "628111@s.whatsapp.net", Sender: "628111@s.whatsapp.net", Content: "secret"}},
+	}}}
+	recipient := types.NewJID("628111", types.DefaultUserServer)
+
+	ownCtx := whatsapp.ContextWithDevice(context.Background(), whatsapp.NewDeviceInstance("dev-a", nil, nil))
+	contextInfo := &waE2E.ContextInfo{}
+	service.applyQuote(ownCtx, contextInfo, "MSG1", recipient)
+	if contextInfo.GetStanzaID() != "MSG1" || contextInfo.GetQuotedMessage().GetConversation() != "secret" {
+		t.Fatalf("expected the stored message to be quoted, got %+v", contextInfo)
+	}
+
+	otherCtx := whatsapp.ContextWithDevice(context.Background(), whatsapp.NewDeviceInstance("dev-b", nil, nil))
+	contextInfo = &waE2E.ContextInfo{}
+	service.applyQuote(otherCtx, contextInfo, "MSG1", recipient)
+	if contextInfo.StanzaID != nil || contextInfo.QuotedMessage != nil {
+		t.Fatalf("another device's message must not be quoted, got %+v", contextInfo)
+	}
+}
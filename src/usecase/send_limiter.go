@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+const (
+	typingDelayPerChar = 60 * time.Millisecond
+	typingMinDelay     = 800 * time.Millisecond
+)
+
+// outboundLimiter caps how fast each device sends, overall and towards a single recipient
+type outboundLimiter struct {
+	device    *utils.TokenBucketLimiter
+	recipient *utils.TokenBucketLimiter
+}
+
+var (
+	sharedLimiter     *outboundLimiter
+	sharedLimiterOnce sync.Once
+)
+
+// sharedOutboundLimiter returns the process-wide limiter so every service that sends draws from the same budget
+func sharedOutboundLimiter() *outboundLimiter {
+	sharedLimiterOnce.Do(func() {
+		sharedLimiter = newOutboundLimiter(config.WhatsappRateLimitPerMinute, config.WhatsappRateLimitBurst, config.WhatsappRateLimitPerRecipient)
+	})
+	return sharedLimiter
+}
+
+func newOutboundLimiter(perMinute, burst, perRecipient int) *outboundLimiter {
+	return &outboundLimiter{
+		device:    utils.NewTokenBucketLimiter(perMinute, burst),
+		recipient: utils.NewTokenBucketLimiter(perRecipient, 0),
+	}
+}
+
+// acquire takes a send slot for deviceID -> recipient or returns a RATE_LIMITED error with the retry delay
+func (l *outboundLimiter) acquire(deviceID string, recipient types.JID) error {
+	if ok, wait := l.device.Take(deviceID); !ok {
+		return pkgError.RateLimited(fmt.Sprintf("device %s exceeded its outbound message rate", deviceID), wait)
+	}
+
+	if ok, wait := l.recipient.Take(deviceID + "|" + recipient.ToNonAD().String()); !ok {
+		// The message is not sent, so it must not count against the device budget either
+		l.device.Refund(deviceID)
+		return pkgError.RateLimited(fmt.Sprintf("too many messages to %s", recipient.ToNonAD().String()), wait)
+	}
+	return nil
+}
+
+// wait takes a send slot like acquire, but sleeps until the limiter frees one instead of failing. It is meant for
+// messages that complete a send already under way, where giving up would leave the recipient with half of it.
+func (l *outboundLimiter) wait(ctx context.Context, deviceID string, recipient types.JID) error {
+	for {
+		err := l.acquire(deviceID, recipient)
+		var rateLimited pkgError.RateLimitError
+		if !errors.As(err, &rateLimited) {
+			return err
+		}
+		if !sleepContext(ctx, rateLimited.RetryAfter) {
+			return ctx.Err()
+		}
+	}
+}
+
+// sendLimiterKey identifies the sending device for rate limiting
+func sendLimiterKey(ctx context.Context, client *whatsmeow.Client) string {
+	if deviceID := deviceIDFromContext(ctx); deviceID != "" {
+		return deviceID
+	}
+	if client.Store != nil && client.Store.ID != nil {
+		return client.Store.ID.ToNonAD().String()
+	}
+	return ""
+}
+
+// typingDelay grows with the text length so short replies are quick and long ones look typed
+func typingDelay(text string, maxDelay time.Duration) time.Duration {
+	delay := time.Duration(utf8.RuneCountInString(text)) * typingDelayPerChar
+	if delay < typingMinDelay {
+		delay = typingMinDelay
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// simulateTyping shows "typing..." to the recipient before a text message when WhatsappTypingSimulation is on
+func simulateTyping(ctx context.Context, client *whatsmeow.Client, recipient types.JID, msg *waE2E.Message) {
+	if !config.WhatsappTypingSimulation {
+		return
+	}
+	if recipient.Server == types.NewsletterServer || recipient.Server == types.BroadcastServer {
+		return
+	}
+
+	text := msg.GetConversation()
+	if text == "" {
+		text = msg.GetExtendedTextMessage().GetText()
+	}
+	if text == "" {
+		return
+	}
+
+	if err := client.SendChatPresence(ctx, recipient, types.ChatPresenceComposing, types.ChatPresenceMediaText); err != nil {
+		logrus.Debugf("Failed to send typing presence to %s: %v", recipient.String(), err)
+		return
+	}
+
+	timer := time.NewTimer(typingDelay(text, time.Duration(config.WhatsappTypingMaxDelay)*time.Second))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
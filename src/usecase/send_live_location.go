@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// liveLocationFeedTimeout bounds one request to a position feed
+	liveLocationFeedTimeout = 10 * time.Second
+	// liveLocationStopWait bounds how long the final update of a share waits for an outbound rate limit slot
+	liveLocationStopWait = 30 * time.Second
+)
+
+var (
+	liveShares     = &liveLocationRegistry{sessions: make(map[string]*liveLocationSession)}
+	liveFeedClient = &http.Client{Timeout: liveLocationFeedTimeout}
+)
+
+// liveLocationRegistry tracks the shares that are still running. Shares live in memory only; a restart ends them.
+type liveLocationRegistry struct {
+	mu       sync.Mutex
+	sessions map[string]*liveLocationSession
+}
+
+type liveLocationSession struct {
+	mu        sync.Mutex
+	id        string
+	deviceID  string
+	recipient types.JID
+	caption   string
+	sequence  int64
+	duration  time.Duration
+	position  domainSend.LiveLocationPosition
+	feedURL   string
+	startedAt time.Time
+	expiresAt time.Time
+	cancel    context.CancelFunc
+}
+
+func (r *liveLocationRegistry) add(session *liveLocationSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.id] = session
+}
+
+// get returns a running share of the device, or nil
+func (r *liveLocationRegistry) get(deviceID, id string) *liveLocationSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := r.sessions[id]
+	if session == nil || session.deviceID != deviceID {
+		return nil
+	}
+	return session
+}
+
+func (r *liveLocationRegistry) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, id)
+}
+
+// StartLiveLocation sends the live location card and keeps the share running until it is stopped or expires
+func (service serviceSend) StartLiveLocation(ctx context.Context, request domainSend.LiveLocationRequest) (response domainSend.LiveLocationResponse, err error) {
+	if err = validations.ValidateStartLiveLocation(ctx, &request); err != nil {
+		return response, err
+	}
+
+	inst, ok := whatsapp.DeviceFromContext(ctx)
+	if !ok || inst == nil {
+		return response, pkgError.ValidationError("device context is required")
+	}
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	dataWaRecipient, err := utils.ValidateJidWithLogin(client, request.Phone)
+	if err != nil {
+		return response, err
+	}
+
+	msg := liveLocationCard(request)
+	msg.LiveLocationMessage.ContextInfo = service.buildContextInfo(ctx, request.BaseRequest, dataWaRecipient, request.Caption)
+
+	content := fmt.Sprintf("📍 Live location %f, %f", request.Latitude, request.Longitude)
+	ts, err := service.wrapSendMessage(ctx, client, dataWaRecipient, msg, content)
+	if err != nil {
+		return response, err
+	}
+
+	// The share outlives the request; it keeps the device, not the request context
+	shareCtx, cancel := context.WithCancel(whatsapp.ContextWithDevice(context.WithoutCancel(ctx), inst))
+	session := &liveLocationSession{
+		id:        ts.ID,
+		deviceID:  deviceIDFromContext(ctx),
+		recipient: dataWaRecipient,
+		caption:   request.Caption,
+		duration:  time.Duration(request.ShareDuration) * time.Second,
+		position:  request.LiveLocationPosition,
+		feedURL:   request.FeedURL,
+		startedAt: ts.Timestamp,
+		expiresAt: ts.Timestamp.Add(time.Duration(request.ShareDuration) * time.Second),
+		cancel:    cancel,
+	}
+	liveShares.add(session)
+	go service.runLiveLocation(shareCtx, session, time.Duration(request.FeedInterval)*time.Second)
+
+	response = session.response()
+	response.Status = fmt.Sprintf("Live location shared with %s until %s", request.Phone, response.ExpiresAt)
+	return response, nil
+}
+
+func (service serviceSend) UpdateLiveLocation(ctx context.Context, request domainSend.LiveLocationUpdateRequest) (response domainSend.LiveLocationResponse, err error) {
+	if err = validations.ValidateUpdateLiveLocation(ctx, request); err != nil {
+		return response, err
+	}
+
+	session := liveShares.get(deviceIDFromContext(ctx), request.ShareID)
+	if session == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("live location %s is not being shared", request.ShareID))
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	if err = service.pushLiveLocation(ctx, client, session, request.LiveLocationPosition); err != nil {
+		return response, err
+	}
+
+	response = session.response()
+	response.Status = fmt.Sprintf("Live location updated (sequence %d)", response.SequenceNumber)
+	return response, nil
+}
+
+// StopLiveLocation ends a share: a last update tells the recipient the share is over, then no more positions are
+// sent and a running feed is no longer polled. The share stops even when the last update cannot be sent.
+func (service serviceSend) StopLiveLocation(ctx context.Context, shareID string) (response domainSend.LiveLocationResponse, err error) {
+	session := liveShares.get(deviceIDFromContext(ctx), shareID)
+	if session == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("live location %s is not being shared", shareID))
+	}
+
+	session.cancel()
+	liveShares.remove(shareID)
+
+	status := "Live location sharing stopped"
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil || !client.IsLoggedIn() {
+		err = pkgError.ErrWaCLI
+	} else {
+		err = service.endLiveLocation(ctx, client, session)
+	}
+	if err != nil {
+		logrus.Warnf("Live location %s: failed to send the final update: %v", shareID, err)
+		status = fmt.Sprintf("Live location sharing stopped, but the recipient was not told: %v", err)
+	}
+
+	response = session.response()
+	response.Status = status
+	return response, nil
+}
+
+// runLiveLocation polls the position feed, if any, and ends the share when it expires or is stopped
+func (service serviceSend) runLiveLocation(ctx context.Context, session *liveLocationSession, feedInterval time.Duration) {
+	defer liveShares.remove(session.id)
+	defer session.cancel()
+
+	expiry := time.NewTimer(time.Until(session.expiresAt))
+	defer expiry.Stop()
+
+	var feed <-chan time.Time
+	if session.feedURL != "" && feedInterval > 0 {
+		ticker := time.NewTicker(feedInterval)
+		defer ticker.Stop()
+		feed = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-expiry.C:
+			logrus.Infof("Live location %s expired", session.id)
+			return
+		case <-feed:
+			service.pollLiveLocationFeed(ctx, session)
+		}
+	}
+}
+
+func (service serviceSend) pollLiveLocationFeed(ctx context.Context, session *liveLocationSession) {
+	position, err := fetchLiveLocationPosition(ctx, session.feedURL)
+	if err != nil {
+		logrus.Warnf("Live location %s: failed to read feed %s: %v", session.id, session.feedURL, err)
+		return
+	}
+
+	session.mu.Lock()
+	unchanged := session.position == position
+	session.mu.Unlock()
+	if unchanged {
+		return
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil || !client.IsLoggedIn() {
+		logrus.Warnf("Live location %s: device is not connected, skipping update", session.id)
+		return
+	}
+	if err := service.pushLiveLocation(ctx, client, session, position); err != nil {
+		logrus.Warnf("Live location %s: failed to send update: %v", session.id, err)
+	}
+}
+
+// pushLiveLocation sends the next position of a share. Every update takes an outbound rate limit slot; one that
+// finds the limit reached is skipped, and the next feed poll or API update tries again. Updates are not stored as
+// chat messages.
+func (service serviceSend) pushLiveLocation(ctx context.Context, client *whatsmeow.Client, session *liveLocationSession, position domainSend.LiveLocationPosition) error {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	if err := service.limiter.acquire(sendLimiterKey(ctx, client), session.recipient); err != nil {
+		return err
+	}
+
+	sequence := session.sequence + 1
+	offset := uint32(time.Since(session.startedAt).Seconds())
+	msg := &waE2E.Message{LiveLocationMessage: liveLocationMessage(position, session.caption, sequence, offset)}
+	if _, err := client.SendMessage(ctx, session.recipient, msg); err != nil {
+		return err
+	}
+
+	session.sequence = sequence
+	session.position = position
+	return nil
+}
+
+// endLiveLocation sends the last position once more, dated at the end of the share's duration, so the recipient
+// shows the share as ended instead of waiting for it to run out. Like the updates it takes an outbound rate limit
+// slot, but it waits a while for one because dropping it would leave the share running on the recipient's side.
+func (service serviceSend) endLiveLocation(ctx context.Context, client *whatsmeow.Client, session *liveLocationSession) error {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	waitCtx, cancel := context.WithTimeout(ctx, liveLocationStopWait)
+	defer cancel()
+	if err := service.limiter.wait(waitCtx, sendLimiterKey(ctx, client), session.recipient); err != nil {
+		return err
+	}
+
+	sequence := session.sequence + 1
+	msg := &waE2E.Message{LiveLocationMessage: liveLocationMessage(session.position, session.caption, sequence, uint32(session.duration.Seconds()))}
+	if _, err := client.SendMessage(ctx, session.recipient, msg); err != nil {
+		return err
+	}
+
+	session.sequence = sequence
+	session.expiresAt = time.Now()
+	return nil
+}
+
+func (session *liveLocationSession) response() domainSend.LiveLocationResponse {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return domainSend.LiveLocationResponse{
+		ShareID:        session.id,
+		Phone:          session.recipient.String(),
+		SequenceNumber: session.sequence,
+		Latitude:       session.position.Latitude,
+		Longitude:      session.position.Longitude,
+		FeedURL:        session.feedURL,
+		StartedAt:      session.startedAt.Format(time.RFC3339),
+		ExpiresAt:      session.expiresAt.Format(time.RFC3339),
+	}
+}
+
+// liveLocationCard is the first message of a share. The live location message has no duration of its own, so the
+// card carries the share duration as its add-on duration.
+func liveLocationCard(request domainSend.LiveLocationRequest) *waE2E.Message {
+	return &waE2E.Message{
+		LiveLocationMessage: liveLocationMessage(request.LiveLocationPosition, request.Caption, 0, 0),
+		MessageContextInfo: &waE2E.MessageContextInfo{
+			MessageAddOnDurationInSecs: proto.Uint32(uint32(request.ShareDuration)),
+			MessageAddOnExpiryType:     waE2E.MessageContextInfo_STATIC.Enum(),
+		},
+	}
+}
+
+func liveLocationMessage(position domainSend.LiveLocationPosition, caption string, sequence int64, timeOffset uint32) *waE2E.LiveLocationMessage {
+	msg := &waE2E.LiveLocationMessage{
+		DegreesLatitude:  proto.Float64(position.Latitude),
+		DegreesLongitude: proto.Float64(position.Longitude),
+		SequenceNumber:   proto.Int64(sequence),
+		TimeOffset:       proto.Uint32(timeOffset),
+	}
+	if position.AccuracyInMeters > 0 {
+		msg.AccuracyInMeters = proto.Uint32(position.AccuracyInMeters)
+	}
+	if position.SpeedInMps > 0 {
+		msg.SpeedInMps = proto.Float32(position.SpeedInMps)
+	}
+	if position.Heading > 0 {
+		msg.DegreesClockwiseFromMagneticNorth = proto.Uint32(position.Heading)
+	}
+	if caption != "" {
+		msg.Caption = proto.String(caption)
+	}
+	return msg
+}
+
+// fetchLiveLocationPosition reads one position from a feed answering with the same JSON as the update endpoint
+func fetchLiveLocationPosition(ctx context.Context, feedURL string) (position domainSend.LiveLocationPosition, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return position, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := liveFeedClient.Do(req)
+	if err != nil {
+		return position, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return position, fmt.Errorf("feed answered %s", resp.Status)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&position); err != nil {
+		return position, fmt.Errorf("invalid position: %w", err)
+	}
+	if position.Latitude < -90 || position.Latitude > 90 || position.Longitude < -180 || position.Longitude > 180 {
+		return position, fmt.Errorf("position %f, %f is out of range", position.Latitude, position.Longitude)
+	}
+	return position, nil
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestFetchLiveLocationPosition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte(`{"latitude": -7.797068, "longitude": 110.370529, "heading": 45}`))
+		case "/out-of-range":
+			_, _ = w.Write([]byte(`{"latitude": 95, "longitude": 110}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	position, err := fetchLiveLocationPosition(context.Background(), server.URL+"/ok")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if position.Latitude != -7.797068 || position.Longitude != 110.370529 || position.Heading != 45 {
+		t.Fatalf("unexpected position %+v", position)
+	}
+
+	if _, err := fetchLiveLocationPosition(context.Background(), server.URL+"/out-of-range"); err == nil {
+		t.Fatal("expected an error for an out of range position")
+	}
+	if _, err := fetchLiveLocationPosition(context.Background(), server.URL+"/missing"); err == nil {
+		t.Fatal("expected an error for a failing feed")
+	}
+}
+
+func TestLiveLocationRegistryIsScopedByDevice(t *testing.T) {
+	registry := &liveLocationRegistry{sessions: make(map[string]*liveLocationSession)}
+	registry.add(&liveLocationSession{id: "SHARE1", deviceID: "device-a"})
+
+	if registry.get("device-a", "SHARE1") == nil {
+		t.Fatal("expected the share to be found for its device")
+	}
+	if registry.get("device-b", "SHARE1") != nil {
+		t.Fatal("expected the share to be hidden from other devices")
+	}
+
+	registry.remove("SHARE1")
+	if registry.get("device-a", "SHARE1") != nil {
+		t.Fatal("expected the share to be removed")
+	}
+}
+
+func TestLiveLocationCardCarriesShareDuration(t *testing.T) {
+	msg := liveLocationCard(domainSend.LiveLocationRequest{
+		LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.797068, Longitude: 110.370529},
+		Caption:              "On my way",
+		ShareDuration:        900,
+	})
+
+	if msg.GetMessageContextInfo().GetMessageAddOnDurationInSecs() != 900 {
+		t.Fatalf("expected the share duration on the card, got %+v", msg.GetMessageContextInfo())
+	}
+	location := msg.GetLiveLocationMessage()
+	if location.GetSequenceNumber() != 0 || location.GetTimeOffset() != 0 || location.GetCaption() != "On my way" {
+		t.Fatalf("unexpected card %+v", location)
+	}
+}
+
+func TestLiveLocationUpdatesTakeRateLimitSlots(t *testing.T) {
+	service := serviceSend{limiter: newOutboundLimiter(60, 1, 0)}
+	ctx := whatsapp.ContextWithDevice(context.Background(), whatsapp.NewDeviceInstance("dev-a", nil, nil))
+	session := &liveLocationSession{id: "SHARE1", recipient: types.NewJID("628111", types.DefaultUserServer), sequence: 3}
+	if err := service.limiter.acquire("dev-a", session.recipient); err != nil {
+		t.Fatalf("first send should pass: %v", err)
+	}
+
+	// Nothing reaches the client once the limit is reached
+	var rateLimited pkgError.RateLimitError
+	if err := service.pushLiveLocation(ctx, nil, session, domainSend.LiveLocationPosition{Latitude: 1}); !errors.As(err, &rateLimited) {
+		t.Fatalf("expected the update to be rate limited, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := service.endLiveLocation(cancelled, nil, session); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the final update to wait for a slot until cancelled, got %v", err)
+	}
+	if session.sequence != 3 {
+		t.Fatalf("sequence must not move for updates that were not sent, got %d", session.sequence)
+	}
+}
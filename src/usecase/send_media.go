@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	fiberUtils "github.com/gofiber/fiber/v2/utils"
+	"github.com/valyala/fasthttp"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// sniffLength is how much of a file http.DetectContentType looks at
+const sniffLength = 512
+
+// uploadMediaFile encrypts, hashes and uploads a file from disk as a stream, so memory use stays flat whatever the
+// file size. The encrypted copy whatsmeow needs is written next to the other send items and removed afterwards.
+func (service serviceSend) uploadMediaFile(ctx context.Context, client *whatsmeow.Client, mediaType whatsmeow.MediaType, path string, recipient types.JID) (uploaded whatsmeow.UploadResponse, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return uploaded, err
+	}
+	defer file.Close()
+
+	if recipient.Server == types.NewsletterServer {
+		return client.UploadNewsletterReader(ctx, file, mediaType)
+	}
+
+	encrypted, err := os.CreateTemp(config.PathSendItems, "upload-*.enc")
+	if err != nil {
+		return uploaded, err
+	}
+	defer func() {
+		_ = encrypted.Close()
+		_ = os.Remove(encrypted.Name())
+	}()
+
+	return client.UploadReader(ctx, file, encrypted, mediaType)
+}
+
+// saveUpload stores an uploaded file under PathSendItems. Large multipart parts are already spooled to disk by the
+// form parser and are moved or copied in chunks, never read into memory as a whole. uploadPath is set when an async
+// send has already detached the upload to disk; it is copied rather than moved so a retried job still finds it.
+func saveUpload(file *multipart.FileHeader, uploadPath, prefix string) (string, error) {
+	path := filepath.Join(config.PathSendItems, prefix+fiberUtils.UUIDv4()+filepath.Ext(file.Filename))
+	if err := storeUpload(file, uploadPath, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// storeUpload writes an upload to path, from the detached copy at uploadPath when there is one
+func storeUpload(file *multipart.FileHeader, uploadPath, path string) error {
+	if uploadPath == "" {
+		return fasthttp.SaveMultipartFile(file, path)
+	}
+
+	src, err := os.Open(uploadPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// fileHead returns the first bytes of a file for content sniffing
+func fileHead(path string) []byte {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffLength)
+	n, _ := io.ReadFull(file, head)
+	return head[:n]
+}
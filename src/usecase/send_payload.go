@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainSchedule "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/schedule"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+)
+
+// sendPayloadHandler validates a stored /send/* JSON body and replays it through ISendUsecase.
+// Scheduled messages and campaigns use it to send any message type without duplicating the send logic.
+type sendPayloadHandler struct {
+	validate func(ctx context.Context, sender domainSend.ISendUsecase, payload json.RawMessage) error
+	send     func(ctx context.Context, sender domainSend.ISendUsecase, payload json.RawMessage) (domainSend.GenericResponse, error)
+}
+
+// templateApplier is the part of the send service that renders a request's template into its fields
+type templateApplier interface {
+	applyTemplate(request any) error
+}
+
+func newSendPayloadHandler[T any](
+	validate func(context.Context, T) error,
+	send func(domainSend.ISendUsecase, context.Context, T) (domainSend.GenericResponse, error),
+) sendPayloadHandler {
+	decode := func(payload json.RawMessage) (T, error) {
+		var request T
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return request, pkgError.ValidationError(fmt.Sprintf("payload: %v", err))
+		}
+		return request, nil
+	}
+
+	return sendPayloadHandler{
+		validate: func(ctx context.Context, sender domainSend.ISendUsecase, payload json.RawMessage) error {
+			request, err := decode(payload)
+			if err != nil {
+				return err
+			}
+			// Validate the message as it will be sent, with its template rendered in
+			if applier, ok := sender.(templateApplier); ok {
+				if err = applier.applyTemplate(&request); err != nil {
+					return err
+				}
+			}
+			return validate(ctx, request)
+		},
+		send: func(ctx context.Context, sender domainSend.ISendUsecase, payload json.RawMessage) (domainSend.GenericResponse, error) {
+			request, err := decode(payload)
+			if err != nil {
+				return domainSend.GenericResponse{}, err
+			}
+			return send(sender, ctx, request)
+		},
+	}
+}
+
+var sendPayloadHandlers = map[string]sendPayloadHandler{
+	domainSchedule.TypeText:     newSendPayloadHandler(validations.ValidateSendMessage, domainSend.ISendUsecase.SendText),
+	domainSchedule.TypeImage:    newSendPayloadHandler(validations.ValidateSendImage, domainSend.ISendUsecase.SendImage),
+	domainSchedule.TypeFile:     newSendPayloadHandler(validations.ValidateSendFile, domainSend.ISendUsecase.SendFile),
+	domainSchedule.TypeVideo:    newSendPayloadHandler(validations.ValidateSendVideo, domainSend.ISendUsecase.SendVideo),
+	domainSchedule.TypeAudio:    newSendPayloadHandler(validations.ValidateSendAudio, domainSend.ISendUsecase.SendAudio),
+	domainSchedule.TypeSticker:  newSendPayloadHandler(validations.ValidateSendSticker, domainSend.ISendUsecase.SendSticker),
+	domainSchedule.TypeContact:  newSendPayloadHandler(validations.ValidateSendContact, domainSend.ISendUsecase.SendContact),
+	domainSchedule.TypeLink:     newSendPayloadHandler(validations.ValidateSendLink, domainSend.ISendUsecase.SendLink),
+	domainSchedule.TypeLocation: newSendPayloadHandler(validations.ValidateSendLocation, domainSend.ISendUsecase.SendLocation),
+	domainSchedule.TypePoll:     newSendPayloadHandler(validations.ValidateSendPoll, domainSend.ISendUsecase.SendPoll),
+}
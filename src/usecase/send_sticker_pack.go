@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// stickerPackImageExtensions are the archive entries imported as stickers; everything else is ignored
+var stickerPackImageExtensions = map[string]bool{
+	".png":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".webp": true,
+	".gif":  true,
+}
+
+// SendStickerPack sends every image of a zip archive as a sticker, in file name order. All stickers carry the same
+// pack metadata, so WhatsApp groups them as one pack. A sticker that fails is reported and the rest still go out.
+func (service serviceSend) SendStickerPack(ctx context.Context, request domainSend.StickerPackRequest) (response domainSend.StickerPackResponse, err error) {
+	if err = service.applyTemplate(&request); err != nil {
+		return response, err
+	}
+
+	if err = validations.ValidateSendStickerPack(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+
+	dataWaRecipient, err := utils.ValidateJidWithLogin(client, request.Phone)
+	if err != nil {
+		return response, err
+	}
+
+	var packPath string
+	if request.PackURL != nil && *request.PackURL != "" {
+		packPath, _, err = utils.DownloadFileToFile(*request.PackURL, config.PathSendItems)
+		if err != nil {
+			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download sticker pack: %v", err))
+		}
+	} else {
+		packPath, err = saveUpload(request.Pack, request.UploadPath, "pack_")
+		if err != nil {
+			return response, pkgError.InternalServerError(fmt.Sprintf("failed to store sticker pack: %v", err))
+		}
+	}
+	defer os.Remove(packPath)
+
+	archive, err := zip.OpenReader(packPath)
+	if err != nil {
+		return response, pkgError.ValidationError(fmt.Sprintf("pack is not a valid zip archive: %v", err))
+	}
+	defer archive.Close()
+
+	entries, err := stickerPackEntries(archive.File)
+	if err != nil {
+		return response, err
+	}
+
+	metadata := stickerMetadata(request.StickerMetadata)
+	response.PackID = metadata.PackID
+	response.PackName = metadata.PackName
+
+	for i, entry := range entries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		base := request.BaseRequest
+		if i > 0 {
+			// Only the first sticker carries the quote
+			base.ReplyMessageID = nil
+		}
+
+		item := domainSend.StickerPackItem{File: entry.Name}
+		messageID, err := service.sendStickerPackEntry(ctx, client, dataWaRecipient, entry, base, metadata)
+		if err != nil {
+			logrus.Warnf("Sticker pack %q to %s: %s failed: %v", metadata.PackName, request.Phone, entry.Name, err)
+			item.Error = err.Error()
+			response.Failed++
+		} else {
+			item.MessageID = messageID
+			response.Sent++
+		}
+		response.Stickers = append(response.Stickers, item)
+	}
+
+	if response.Sent == 0 {
+		return response, pkgError.InternalServerError(fmt.Sprintf("no sticker of pack %q could be sent to %s", metadata.PackName, dataWaRecipient))
+	}
+
+	response.Status = fmt.Sprintf("Sticker pack %q: %d of %d stickers sent to %s", metadata.PackName, response.Sent, len(entries), request.Phone)
+	return response, nil
+}
+
+// sendStickerPackEntry extracts one archive entry, converts it and sends it as a sticker
+func (service serviceSend) sendStickerPackEntry(ctx context.Context, client *whatsmeow.Client, recipient types.JID, entry *zip.File, base domainSend.BaseRequest, metadata *utils.StickerMetadata) (string, error) {
+	stickerPath, err := extractStickerPackEntry(entry)
+	if err != nil {
+		return "", err
+	}
+	deletedItems := []string{stickerPath}
+	defer func() {
+		for _, path := range deletedItems {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				logrus.Warnf("Failed to cleanup temporary file %s: %v", path, err)
+			}
+		}
+	}()
+
+	stickerMessage, preparedItems, err := service.prepareStickerMessage(ctx, client, recipient, stickerPath, metadata)
+	deletedItems = append(deletedItems, preparedItems...)
+	if err != nil {
+		return "", err
+	}
+
+	stickerMessage.ContextInfo = service.buildContextInfo(ctx, base, recipient, "")
+	ts, err := service.wrapSendMessage(ctx, client, recipient, &waE2E.Message{StickerMessage: stickerMessage}, "🎨 Sticker")
+	if err != nil {
+		return "", err
+	}
+	return ts.ID, nil
+}
+
+// stickerPackEntries returns the images of the archive sorted by name, skipping folders, hidden files and
+// macOS resource forks
+func stickerPackEntries(files []*zip.File) ([]*zip.File, error) {
+	var entries []*zip.File
+	for _, file := range files {
+		name := path.Base(file.Name)
+		if file.FileInfo().IsDir() || strings.HasPrefix(name, ".") || strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+		if !stickerPackImageExtensions[strings.ToLower(path.Ext(name))] {
+			continue
+		}
+		entries = append(entries, file)
+	}
+
+	if len(entries) == 0 {
+		return nil, pkgError.ValidationError("the pack contains no images (png, jpg, jpeg, webp, gif)")
+	}
+	if len(entries) > domainSend.StickerPackMaxStickers {
+		return nil, pkgError.ValidationError(fmt.Sprintf("a sticker pack holds at most %d stickers, the archive has %d images", domainSend.StickerPackMaxStickers, len(entries)))
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+// extractStickerPackEntry copies one archive entry into a temporary file. The announced size is not trusted: the
+// copy stops past the image size limit, so a crafted archive cannot fill the disk.
+func extractStickerPackEntry(entry *zip.File) (string, error) {
+	reader, err := entry.Open()
+	if err != nil {
+		return "", pkgError.ValidationError(fmt.Sprintf("%s: cannot read from the archive: %v", entry.Name, err))
+	}
+	defer reader.Close()
+
+	file, err := os.CreateTemp(config.PathSendItems, "sticker_*"+strings.ToLower(filepath.Ext(entry.Name)))
+	if err != nil {
+		return "", pkgError.InternalServerError(fmt.Sprintf("failed to create temp file: %v", err))
+	}
+
+	written, err := io.Copy(file, io.LimitReader(reader, config.WhatsappSettingMaxImageSize+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && written > config.WhatsappSettingMaxImageSize {
+		err = pkgError.ValidationError(fmt.Sprintf("%s: image is larger than %d bytes", entry.Name, config.WhatsappSettingMaxImageSize))
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// stickerMetadata returns the metadata to embed, or nil when the request sets none
+func stickerMetadata(request domainSend.StickerMetadata) *utils.StickerMetadata {
+	if request.PackName == "" && request.PackPublisher == "" && len(request.Emojis) == 0 {
+		return nil
+	}
+	return &utils.StickerMetadata{
+		PackID:    stickerPackID(request.PackName, request.PackPublisher),
+		PackName:  request.PackName,
+		Publisher: request.PackPublisher,
+		Emojis:    request.Emojis,
+	}
+}
+
+// stickerPackID derives the pack ID from name and publisher, so stickers sent separately still group as one pack
+func stickerPackID(name, publisher string) string {
+	sum := sha256.Sum256([]byte(publisher + "\x00" + name))
+	return hex.EncodeToString(sum[:16])
+}
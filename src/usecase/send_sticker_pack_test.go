@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+)
+
+func buildZip(t *testing.T, names ...string) *zip.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, name := range names {
+		entry, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		if strings.HasSuffix(name, "/") {
+			continue
+		}
+		if _, err := entry.Write([]byte("image")); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	return reader
+}
+
+func TestStickerPackEntriesFiltersAndSorts(t *testing.T) {
+	archive := buildZip(t,
+		"pack/03.webp",
+		"pack/01.PNG",
+		"pack/",
+		"pack/readme.txt",
+		"pack/.hidden.png",
+		"__MACOSX/pack/._01.png",
+		"pack/02.jpg",
+	)
+
+	entries, err := stickerPackEntries(archive.File)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	want := []string{"pack/01.PNG", "pack/02.jpg", "pack/03.webp"}
+	if fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+}
+
+func TestStickerPackEntriesLimits(t *testing.T) {
+	if _, err := stickerPackEntries(buildZip(t, "notes.txt").File); err == nil {
+		t.Fatal("expected an error for an archive without images")
+	}
+
+	var names []string
+	for i := 0; i <= domainSend.StickerPackMaxStickers; i++ {
+		names = append(names, fmt.Sprintf("%02d.png", i))
+	}
+	if _, err := stickerPackEntries(buildZip(t, names...).File); err == nil {
+		t.Fatalf("expected an error for more than %d images", domainSend.StickerPackMaxStickers)
+	}
+}
+
+func TestStickerMetadataPackID(t *testing.T) {
+	if stickerMetadata(domainSend.StickerMetadata{}) != nil {
+		t.Fatal("expected no metadata when none is set")
+	}
+
+	first := stickerMetadata(domainSend.StickerMetadata{PackName: "Cats", PackPublisher: "Kemal", Emojis: []string{"😺"}})
+	second := stickerMetadata(domainSend.StickerMetadata{PackName: "Cats", PackPublisher: "Kemal"})
+	other := stickerMetadata(domainSend.StickerMetadata{PackName: "Dogs", PackPublisher: "Kemal"})
+	if first.PackID != second.PackID {
+		t.Fatalf("expected stickers of one pack to share the pack ID, got %s and %s", first.PackID, second.PackID)
+	}
+	if first.PackID == other.PackID {
+		t.Fatal("expected different packs to get different pack IDs")
+	}
+}
@@ -1,6 +1,19 @@
 package usecase
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"go.mau.fi/whatsmeow/types"
+)
 
 func TestResolveDocumentMIME(t *testing.T) {
 	tests := []struct {
@@ -39,3 +52,91 @@ func TestResolveDocumentMIME(t *testing.T) {
 		})
 	}
 }
+
+func TestTypingDelay(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		max  time.Duration
+		want time.Duration
+	}{
+		{name: "short text uses minimum", text: "ok", max: 5 * time.Second, want: typingMinDelay},
+		{name: "proportional to runes", text: strings.Repeat("é", 20), max: 5 * time.Second, want: 20 * typingDelayPerChar},
+		{name: "capped by max", text: strings.Repeat("a", 1000), max: 5 * time.Second, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := typingDelay(tt.text, tt.max); got != tt.want {
+				t.Fatalf("typingDelay() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutboundLimiter(t *testing.T) {
+	limiter := newOutboundLimiter(60, 2, 1)
+	alice := types.NewJID("628111", types.DefaultUserServer)
+	bob := types.NewJID("628222", types.DefaultUserServer)
+
+	if err := limiter.acquire("device", alice); err != nil {
+		t.Fatalf("first send should pass: %v", err)
+	}
+
+	// Second message to the same recipient trips the per-recipient limit and must not use a device token
+	err := limiter.acquire("device", alice)
+	var rateLimited pkgError.RateLimitError
+	if !errors.As(err, &rateLimited) || rateLimited.ErrCode() != "RATE_LIMITED" || rateLimited.RetryAfter <= 0 {
+		t.Fatalf("expected RATE_LIMITED error, got %v", err)
+	}
+
+	if err := limiter.acquire("device", bob); err != nil {
+		t.Fatalf("device token should have been refunded: %v", err)
+	}
+	if err := limiter.acquire("device", types.NewJID("628333", types.DefaultUserServer)); !errors.As(err, &rateLimited) {
+		t.Fatalf("expected device limit after burst, got %v", err)
+	}
+}
+
+func TestOutboundLimiterWait(t *testing.T) {
+	alice := types.NewJID("628111", types.DefaultUserServer)
+
+	// A token every 10ms: waiting gets the next slot instead of failing
+	limiter := newOutboundLimiter(6000, 1, 0)
+	if err := limiter.acquire("device", alice); err != nil {
+		t.Fatalf("first send should pass: %v", err)
+	}
+	if err := limiter.wait(context.Background(), "device", alice); err != nil {
+		t.Fatalf("wait should get a slot once the bucket refills: %v", err)
+	}
+
+	// A token every second: a cancelled context stops the wait
+	limiter = newOutboundLimiter(60, 1, 0)
+	if err := limiter.acquire("device", alice); err != nil {
+		t.Fatalf("first send should pass: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.wait(ctx, "device", alice); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled context to stop the wait, got %v", err)
+	}
+}
+
+func TestStoreUploadCopiesDetachedUpload(t *testing.T) {
+	dir := t.TempDir()
+	detached := filepath.Join(dir, "async_upload.jpg")
+	if err := os.WriteFile(detached, []byte("image bytes"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	// A rate limited job is retried, so every attempt must find the detached upload again
+	for attempt := 0; attempt < 2; attempt++ {
+		path := filepath.Join(dir, fmt.Sprintf("attempt_%d.jpg", attempt))
+		if err := storeUpload(&multipart.FileHeader{Filename: "photo.jpg"}, detached, path); err != nil {
+			t.Fatalf("storeUpload() error = %v", err)
+		}
+		if content, err := os.ReadFile(path); err != nil || string(content) != "image bytes" {
+			t.Fatalf("unexpected stored upload %q, %v", content, err)
+		}
+	}
+}
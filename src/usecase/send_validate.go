@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+)
+
+// ValidateSend checks a send request the way the matching Send method does, template applied, without sending it.
+// Queued sends use it so a bad request is rejected before it is accepted.
+func (service serviceSend) ValidateSend(ctx context.Context, request any) error {
+	switch req := request.(type) {
+	case domainSend.MessageRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendMessage)
+	case domainSend.ImageRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendImage)
+	case domainSend.FileRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendFile)
+	case domainSend.VideoRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendVideo)
+	case domainSend.AudioRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendAudio)
+	case domainSend.StickerRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendSticker)
+	case domainSend.StickerPackRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendStickerPack)
+	case domainSend.AlbumRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendAlbum)
+	case domainSend.ContactRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendContact)
+	case domainSend.LinkRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendLink)
+	case domainSend.LocationRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendLocation)
+	case domainSend.PollRequest:
+		return validateSendRequest(ctx, service, req, validations.ValidateSendPoll)
+	}
+	return pkgError.InternalServerError(fmt.Sprintf("no validation for %T", request))
+}
+
+// validateSendRequest renders the request's template into a copy of it and validates the result
+func validateSendRequest[T any](ctx context.Context, service serviceSend, request T, validate func(context.Context, T) error) error {
+	if err := service.applyTemplate(&request); err != nil {
+		return err
+	}
+	return validate(ctx, request)
+}
@@ -0,0 +1,337 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultStatusBackground is used for text statuses posted without a background colour
+const defaultStatusBackground = "#25D366"
+
+type serviceStatus struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+	// send provides media preparation and the rate-limited send path shared with /send/*
+	send serviceSend
+}
+
+func NewStatusService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainStatus.IStatusUsecase {
+	return &serviceStatus{
+		chatStorageRepo: chatStorageRepo,
+		send: serviceSend{
+			chatStorageRepo: chatStorageRepo,
+			limiter:         sharedOutboundLimiter(),
+		},
+	}
+}
+
+func (service serviceStatus) PostText(ctx context.Context, request domainStatus.PostTextRequest) (response domainStatus.PostResponse, err error) {
+	if err = validations.ValidatePostTextStatus(ctx, &request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	if request.BackgroundColor == "" {
+		request.BackgroundColor = defaultStatusBackground
+	}
+	background, _ := utils.ParseARGBColor(request.BackgroundColor)
+
+	text := &waE2E.ExtendedTextMessage{
+		Text:           proto.String(request.Text),
+		BackgroundArgb: proto.Uint32(background),
+	}
+	if request.TextColor != "" {
+		textColor, _ := utils.ParseARGBColor(request.TextColor)
+		text.TextArgb = proto.Uint32(textColor)
+	}
+	if request.Font != "" {
+		text.Font = waE2E.ExtendedTextMessage_FontType(waE2E.ExtendedTextMessage_FontType_value[request.Font]).Enum()
+	}
+
+	return service.post(ctx, client, &waE2E.Message{ExtendedTextMessage: text}, &domainChatStorage.Status{
+		Type:            domainStatus.TypeText,
+		Text:            request.Text,
+		BackgroundColor: utils.FormatARGBColor(background),
+		TextColor:       request.TextColor,
+		Font:            request.Font,
+	})
+}
+
+func (service serviceStatus) PostImage(ctx context.Context, request domainStatus.PostImageRequest) (response domainStatus.PostResponse, err error) {
+	if err = validations.ValidatePostImageStatus(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	image, deletedItems, err := service.send.prepareImageMessage(ctx, client, types.StatusBroadcastJID, request.Image, "", request.ImageURL, request.Caption, request.Compress, false)
+	defer func() {
+		if len(deletedItems) > 0 {
+			go utils.RemoveFile(1, deletedItems...)
+		}
+	}()
+	if err != nil {
+		return response, err
+	}
+
+	return service.post(ctx, client, &waE2E.Message{ImageMessage: image}, &domainChatStorage.Status{
+		Type:      domainStatus.TypeImage,
+		Text:      request.Caption,
+		MediaType: "image",
+	})
+}
+
+func (service serviceStatus) PostVideo(ctx context.Context, request domainStatus.PostVideoRequest) (response domainStatus.PostResponse, err error) {
+	if err = validations.ValidatePostVideoStatus(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	video, deletedItems, err := service.send.prepareVideoMessage(ctx, client, types.StatusBroadcastJID, request.Video, "", request.VideoURL, request.Caption, videoOptions{compress: request.Compress})
+	defer func() {
+		if len(deletedItems) > 0 {
+			go utils.RemoveFile(1, deletedItems...)
+		}
+	}()
+	if err != nil {
+		return response, err
+	}
+
+	return service.post(ctx, client, &waE2E.Message{VideoMessage: video}, &domainChatStorage.Status{
+		Type:      domainStatus.TypeVideo,
+		Text:      request.Caption,
+		MediaType: "video",
+	})
+}
+
+// post sends the status to status@broadcast and records it so it shows up in ListStatuses. WhatsApp
+// delivers it to the audience chosen in the account's status privacy settings.
+func (service serviceStatus) post(ctx context.Context, client *whatsmeow.Client, msg *waE2E.Message, status *domainChatStorage.Status) (response domainStatus.PostResponse, err error) {
+	content := status.Text
+	if content == "" {
+		content = fmt.Sprintf("%s status", status.Type)
+	}
+
+	ts, err := service.send.wrapSendMessage(ctx, client, types.StatusBroadcastJID, msg, content)
+	if err != nil {
+		return response, err
+	}
+
+	status.ID = ts.ID
+	status.DeviceID = deviceIDFromContext(ctx)
+	status.IsFromMe = true
+	status.Timestamp = ts.Timestamp
+	status.ExpiresAt = ts.Timestamp.Add(domainStatus.Lifetime)
+	if client.Store.ID != nil {
+		status.Sender = client.Store.ID.ToNonAD().String()
+	}
+	if err := service.chatStorageRepo.StoreStatus(status); err != nil {
+		logrus.Warnf("Failed to store posted status %s: %v", ts.ID, err)
+	}
+
+	response.StatusID = ts.ID
+	response.ExpiresAt = status.ExpiresAt.Format(time.RFC3339)
+	response.Status = fmt.Sprintf("Status posted (server timestamp: %s)", ts.Timestamp.String())
+	return response, nil
+}
+
+func (service serviceStatus) DeleteStatus(ctx context.Context, statusID string) (response domainStatus.StatusActionResponse, err error) {
+	status, err := service.findStatus(ctx, statusID)
+	if err != nil {
+		return response, err
+	}
+	if !status.IsFromMe {
+		return response, pkgError.ValidationError("only your own statuses can be deleted")
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	if err = service.send.limiter.acquire(sendLimiterKey(ctx, client), types.StatusBroadcastJID); err != nil {
+		return response, err
+	}
+	if _, err = client.SendMessage(ctx, types.StatusBroadcastJID, client.BuildRevoke(types.StatusBroadcastJID, types.EmptyJID, statusID)); err != nil {
+		return response, err
+	}
+
+	if err = service.chatStorageRepo.DeleteStatus(status.DeviceID, statusID); err != nil {
+		logrus.Warnf("Failed to remove deleted status %s from storage: %v", statusID, err)
+	}
+
+	response.StatusID = statusID
+	response.Status = "Status deleted"
+	return response, nil
+}
+
+func (service serviceStatus) ListStatuses(ctx context.Context, request domainStatus.ListStatusesRequest) (response domainStatus.ListStatusesResponse, err error) {
+	if err = validations.ValidateListStatuses(ctx, &request); err != nil {
+		return response, err
+	}
+
+	now := time.Now()
+	if _, err := service.chatStorageRepo.DeleteExpiredStatuses(now); err != nil {
+		logrus.Warnf("Failed to remove expired statuses: %v", err)
+	}
+
+	filter := &domainChatStorage.StatusFilter{
+		DeviceID:     deviceIDFromContext(ctx),
+		UnviewedOnly: request.UnviewedOnly,
+		Now:          now,
+		Limit:        request.Limit,
+		Offset:       request.Offset,
+	}
+	if request.Sender != "" {
+		sender, err := utils.ParseJID(request.Sender)
+		if err != nil {
+			return response, err
+		}
+		filter.Sender = sender.ToNonAD().String()
+	}
+	if request.Mine {
+		filter.IsFromMe = proto.Bool(true)
+	}
+
+	statuses, err := service.chatStorageRepo.GetStatuses(filter)
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainStatus.StatusInfo, 0, len(statuses))
+	for _, status := range statuses {
+		response.Data = append(response.Data, toStatusInfo(status))
+	}
+	return response, nil
+}
+
+func (service serviceStatus) GetStatus(ctx context.Context, statusID string) (response domainStatus.StatusInfo, err error) {
+	status, err := service.findStatus(ctx, statusID)
+	if err != nil {
+		return response, err
+	}
+	return toStatusInfo(status), nil
+}
+
+// MarkViewed sends the read receipt that tells the contact we saw their status
+func (service serviceStatus) MarkViewed(ctx context.Context, statusID string) (response domainStatus.StatusActionResponse, err error) {
+	status, err := service.findStatus(ctx, statusID)
+	if err != nil {
+		return response, err
+	}
+	if status.IsFromMe {
+		return response, pkgError.ValidationError("your own statuses cannot be marked as viewed")
+	}
+
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	sender, err := types.ParseJID(status.Sender)
+	if err != nil {
+		return response, err
+	}
+
+	now := time.Now()
+	if err = client.MarkRead(ctx, []types.MessageID{statusID}, now, types.StatusBroadcastJID, sender); err != nil {
+		return response, err
+	}
+	if err = service.chatStorageRepo.MarkStatusViewed(status.DeviceID, statusID, now); err != nil {
+		logrus.Warnf("Failed to record status %s as viewed: %v", statusID, err)
+	}
+
+	response.StatusID = statusID
+	response.Status = "Status marked as viewed"
+	return response, nil
+}
+
+func (service serviceStatus) GetPrivacy(ctx context.Context) (response domainStatus.PrivacyResponse, err error) {
+	client := whatsapp.ClientFromContext(ctx)
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	utils.MustLogin(client)
+
+	settings, err := client.GetStatusPrivacy(ctx)
+	if err != nil {
+		return response, err
+	}
+
+	// The first entry is always the default audience
+	response.Type = string(types.StatusPrivacyTypeContacts)
+	response.List = []string{}
+	if len(settings) > 0 {
+		response.Type = string(settings[0].Type)
+		for _, jid := range settings[0].List {
+			response.List = append(response.List, jid.String())
+		}
+	}
+	return response, nil
+}
+
+func (service serviceStatus) findStatus(ctx context.Context, statusID string) (*domainChatStorage.Status, error) {
+	if statusID == "" {
+		return nil, pkgError.ValidationError("status_id: cannot be blank.")
+	}
+
+	status, err := service.chatStorageRepo.GetStatus(deviceIDFromContext(ctx), statusID)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil || !status.ExpiresAt.After(time.Now()) {
+		return nil, pkgError.NotFoundError(fmt.Sprintf("status %s not found", statusID))
+	}
+	return status, nil
+}
+
+func toStatusInfo(status *domainChatStorage.Status) domainStatus.StatusInfo {
+	info := domainStatus.StatusInfo{
+		ID:              status.ID,
+		Sender:          status.Sender,
+		SenderName:      status.PushName,
+		IsFromMe:        status.IsFromMe,
+		Type:            status.Type,
+		Text:            status.Text,
+		BackgroundColor: status.BackgroundColor,
+		TextColor:       status.TextColor,
+		Font:            status.Font,
+		MediaType:       status.MediaType,
+		Viewed:          status.ViewedAt != nil,
+		Timestamp:       status.Timestamp.Format(time.RFC3339),
+		ExpiresAt:       status.ExpiresAt.Format(time.RFC3339),
+	}
+	if status.ViewedAt != nil {
+		viewedAt := status.ViewedAt.Format(time.RFC3339)
+		info.ViewedAt = &viewedAt
+	}
+	return info
+}
@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	domainTemplate "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/template"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type serviceTemplate struct {
+	repo domainTemplate.ITemplateRepository
+}
+
+func NewTemplateService(repo domainTemplate.ITemplateRepository) domainTemplate.ITemplateUsecase {
+	return &serviceTemplate{
+		repo: repo,
+	}
+}
+
+func (service serviceTemplate) CreateTemplate(ctx context.Context, request domainTemplate.CreateTemplateRequest) (response domainTemplate.Template, err error) {
+	if err = validations.ValidateCreateTemplate(ctx, &request); err != nil {
+		return response, err
+	}
+
+	template := &domainTemplate.Template{
+		ID:        uuid.NewString(),
+		Name:      request.Name,
+		Body:      request.Body,
+		MediaType: request.MediaType,
+		MediaURL:  request.MediaURL,
+		Caption:   request.Caption,
+	}
+	if err = service.repo.Create(template); err != nil {
+		return response, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"template_id": template.ID,
+		"name":        template.Name,
+	}).Info("Message template created")
+
+	return withTemplateVariables(*template), nil
+}
+
+func (service serviceTemplate) ListTemplates(_ context.Context, request domainTemplate.ListTemplatesRequest) (response domainTemplate.ListTemplatesResponse, err error) {
+	templates, total, err := service.repo.List(&domainTemplate.TemplateFilter{
+		Search: strings.TrimSpace(request.Search),
+		Limit:  request.Limit,
+		Offset: request.Offset,
+	})
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainTemplate.Template, 0, len(templates))
+	for _, template := range templates {
+		response.Data = append(response.Data, withTemplateVariables(*template))
+	}
+	response.Total = total
+	return response, nil
+}
+
+func (service serviceTemplate) GetTemplate(_ context.Context, id string) (response domainTemplate.Template, err error) {
+	template, err := service.repo.Get(id)
+	if err != nil {
+		return response, err
+	}
+	if template == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("template %s not found", id))
+	}
+	return withTemplateVariables(*template), nil
+}
+
+func (service serviceTemplate) UpdateTemplate(ctx context.Context, request domainTemplate.UpdateTemplateRequest) (response domainTemplate.Template, err error) {
+	if err = validations.ValidateUpdateTemplate(ctx, &request); err != nil {
+		return response, err
+	}
+
+	existing, err := service.repo.Get(request.ID)
+	if err != nil {
+		return response, err
+	}
+	if existing == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("template %s not found", request.ID))
+	}
+
+	existing.Name = request.Name
+	existing.Body = request.Body
+	existing.MediaType = request.MediaType
+	existing.MediaURL = request.MediaURL
+	existing.Caption = request.Caption
+	updated, err := service.repo.Update(existing)
+	if err != nil {
+		return response, err
+	}
+	if !updated {
+		return response, pkgError.NotFoundError(fmt.Sprintf("template %s not found", request.ID))
+	}
+	return withTemplateVariables(*existing), nil
+}
+
+func (service serviceTemplate) DeleteTemplate(_ context.Context, id string) error {
+	deleted, err := service.repo.Delete(id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return pkgError.NotFoundError(fmt.Sprintf("template %s not found", id))
+	}
+	return nil
+}
+
+// withTemplateVariables fills in the placeholder names so clients know which variables a send must supply
+func withTemplateVariables(template domainTemplate.Template) domainTemplate.Template {
+	template.Variables = templateVariableNames(template)
+	if template.Variables == nil {
+		template.Variables = []string{}
+	}
+	return template
+}
+
+func templateVariableNames(template domainTemplate.Template) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, text := range []string{template.Body, template.Caption, template.MediaURL} {
+		for _, name := range utils.TemplateVariables(text) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// renderSendTemplate loads the template referenced by a send request and substitutes its variables.
+// It returns nil when the request does not use a template and a validation error listing every
+// variable the request did not supply, so nothing is sent with raw {{placeholders}}.
+func renderSendTemplate(repo domainTemplate.ITemplateRepository, request domainSend.BaseRequest) (*domainTemplate.RenderedTemplate, error) {
+	templateID := strings.TrimSpace(request.TemplateID)
+	if templateID == "" {
+		return nil, nil
+	}
+	if repo == nil {
+		return nil, pkgError.ValidationError("template_id: templates are not available")
+	}
+
+	template, err := repo.Get(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, pkgError.NotFoundError(fmt.Sprintf("template %s not found", templateID))
+	}
+
+	var missing []string
+	for _, name := range templateVariableNames(*template) {
+		if _, ok := request.Variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, pkgError.ValidationError(fmt.Sprintf("variables: missing values for %s", strings.Join(missing, ", ")))
+	}
+
+	return &domainTemplate.RenderedTemplate{
+		Body:      utils.RenderTemplate(template.Body, request.Variables),
+		Caption:   utils.RenderTemplate(template.Caption, request.Variables),
+		MediaType: template.MediaType,
+		MediaURL:  utils.RenderTemplate(template.MediaURL, request.Variables),
+	}, nil
+}
+
+// templateCaption prefers the template's default caption and falls back to its body
+func templateCaption(rendered *domainTemplate.RenderedTemplate) string {
+	if rendered.Caption != "" {
+		return rendered.Caption
+	}
+	return rendered.Body
+}
+
+// templateMediaURL returns the template attachment when it fits the endpoint, nil otherwise.
+// Any attachment can go out as a document, so /send/file accepts every media type.
+func templateMediaURL(rendered *domainTemplate.RenderedTemplate, mediaType string) *string {
+	if rendered.MediaURL == "" {
+		return nil
+	}
+	if rendered.MediaType != mediaType && mediaType != domainTemplate.MediaFile {
+		return nil
+	}
+	url := rendered.MediaURL
+	return &url
+}
+
+// applyTemplate renders the template referenced by a send request into the request fields. Fields the
+// caller set explicitly always win over the template so a template can be overridden per message.
+func (service serviceSend) applyTemplate(request any) error {
+	switch req := request.(type) {
+	case *domainSend.MessageRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		if req.Message == "" {
+			req.Message = rendered.Body
+		}
+	case *domainSend.ImageRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		if req.Caption == "" {
+			req.Caption = templateCaption(rendered)
+		}
+		if req.Image == nil && req.ImageURL == nil {
+			req.ImageURL = templateMediaURL(rendered, domainTemplate.MediaImage)
+		}
+	case *domainSend.VideoRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		if req.Caption == "" {
+			req.Caption = templateCaption(rendered)
+		}
+		if req.Video == nil && req.VideoURL == nil {
+			req.VideoURL = templateMediaURL(rendered, domainTemplate.MediaVideo)
+		}
+	case *domainSend.FileRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		if req.Caption == "" {
+			req.Caption = templateCaption(rendered)
+		}
+		if req.File == nil && req.FileURL == nil {
+			req.FileURL = templateMediaURL(rendered, domainTemplate.MediaFile)
+		}
+	case *domainSend.AudioRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		if req.Audio == nil && req.AudioURL == nil {
+			req.AudioURL = templateMediaURL(rendered, domainTemplate.MediaAudio)
+		}
+	case *domainSend.StickerRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		if req.Sticker == nil && req.StickerURL == nil {
+			req.StickerURL = templateMediaURL(rendered, domainTemplate.MediaSticker)
+		}
+	case *domainSend.LinkRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		if req.Caption == "" {
+			req.Caption = templateCaption(rendered)
+		}
+	case *domainSend.PollRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		if req.Question == "" {
+			req.Question = rendered.Body
+		}
+	case *domainSend.ContactRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		// The template names the shared contact
+		switch {
+		case req.Contact != nil:
+			if req.Contact.Name == "" {
+				req.Contact.Name = rendered.Body
+			}
+		case len(req.Contacts) == 0 && req.ContactName == "":
+			req.ContactName = rendered.Body
+		}
+	case *domainSend.LocationRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		// The template labels the pin: its body is the place name and its caption the address
+		if req.Name == "" {
+			req.Name = rendered.Body
+		}
+		if req.Address == "" {
+			req.Address = rendered.Caption
+		}
+	case *domainSend.AlbumRequest:
+		rendered, err := renderSendTemplate(service.templateRepo, req.BaseRequest)
+		if err != nil || rendered == nil {
+			return err
+		}
+		for i := range req.Items {
+			if req.Items[i].Caption == "" {
+				req.Items[i].Caption = templateCaption(rendered)
+			}
+		}
+	case *domainSend.StickerPackRequest:
+		// A pack is a zip of stickers; a template has nothing to put into it
+		if req.TemplateID != "" {
+			return pkgError.ValidationError("template_id: sticker packs cannot be sent from a template")
+		}
+	default:
+		return pkgError.ValidationError(fmt.Sprintf("template_id: templates are not supported for %T", request))
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	domainSchedule "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/schedule"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	domainTemplate "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/template"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+)
+
+type stubTemplateRepository struct {
+	domainTemplate.ITemplateRepository
+	templates map[string]*domainTemplate.Template
+}
+
+func (r stubTemplateRepository) Get(id string) (*domainTemplate.Template, error) {
+	return r.templates[id], nil
+}
+
+func TestApplyTemplate(t *testing.T) {
+	service := serviceSend{templateRepo: stubTemplateRepository{templates: map[string]*domainTemplate.Template{
+		"greeting": {ID: "greeting", Body: "Hi {{name}}, order {{order}} shipped"},
+		"promo":    {ID: "promo", Body: "Promo", MediaType: domainTemplate.MediaImage, MediaURL: "https://example.com/{{sku}}.jpg", Caption: "Deal for {{name}}"},
+	}}}
+	vars := map[string]string{"name": "Budi", "order": "INV-42", "sku": "A1"}
+
+	text := domainSend.MessageRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "greeting", Variables: vars}}
+	if err := service.applyTemplate(&text); err != nil {
+		t.Fatalf("applyTemplate(text) error = %v", err)
+	}
+	if text.Message != "Hi Budi, order INV-42 shipped" {
+		t.Fatalf("unexpected message %q", text.Message)
+	}
+
+	explicit := domainSend.MessageRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "greeting", Variables: vars}, Message: "custom"}
+	if err := service.applyTemplate(&explicit); err != nil || explicit.Message != "custom" {
+		t.Fatalf("explicit message should win, got %q (err %v)", explicit.Message, err)
+	}
+
+	image := domainSend.ImageRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "promo", Variables: vars}}
+	if err := service.applyTemplate(&image); err != nil {
+		t.Fatalf("applyTemplate(image) error = %v", err)
+	}
+	if image.Caption != "Deal for Budi" || image.ImageURL == nil || *image.ImageURL != "https://example.com/A1.jpg" {
+		t.Fatalf("unexpected image request: caption %q url %v", image.Caption, image.ImageURL)
+	}
+
+	video := domainSend.VideoRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "promo", Variables: vars}}
+	if err := service.applyTemplate(&video); err != nil || video.VideoURL != nil {
+		t.Fatalf("image attachment must not be used for video, got %v (err %v)", video.VideoURL, err)
+	}
+
+	missing := domainSend.MessageRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "greeting", Variables: map[string]string{"name": "Budi"}}}
+	err := service.applyTemplate(&missing)
+	if err != pkgError.ValidationError("variables: missing values for order") {
+		t.Fatalf("expected missing variable error, got %v", err)
+	}
+
+	unknown := domainSend.MessageRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "nope"}}
+	var notFound pkgError.NotFoundError
+	if err := service.applyTemplate(&unknown); !errors.As(err, &notFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	contact := domainSend.ContactRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "greeting", Variables: vars}, ContactPhone: "62811"}
+	if err := service.applyTemplate(&contact); err != nil || contact.ContactName != "Hi Budi, order INV-42 shipped" {
+		t.Fatalf("unexpected contact name %q (err %v)", contact.ContactName, err)
+	}
+
+	location := domainSend.LocationRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "promo", Variables: vars}, Address: "Jl. Merdeka 1"}
+	if err := service.applyTemplate(&location); err != nil {
+		t.Fatalf("applyTemplate(location) error = %v", err)
+	}
+	if location.Name != "Promo" || location.Address != "Jl. Merdeka 1" {
+		t.Fatalf("unexpected location labels %q, %q", location.Name, location.Address)
+	}
+
+	album := domainSend.AlbumRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "promo", Variables: vars}, Items: []domainSend.AlbumItem{
+		{Type: domainSend.AlbumItemImage},
+		{Type: domainSend.AlbumItemImage, Caption: "Back side"},
+	}}
+	if err := service.applyTemplate(&album); err != nil {
+		t.Fatalf("applyTemplate(album) error = %v", err)
+	}
+	if album.Items[0].Caption != "Deal for Budi" || album.Items[1].Caption != "Back side" {
+		t.Fatalf("unexpected album captions %q, %q", album.Items[0].Caption, album.Items[1].Caption)
+	}
+
+	if err := service.applyTemplate(&domainSend.GenericResponse{}); err == nil {
+		t.Fatal("expected an error for a request type without template support")
+	}
+
+	pack := domainSend.StickerPackRequest{BaseRequest: domainSend.BaseRequest{TemplateID: "greeting", Variables: vars}}
+	if err := service.applyTemplate(&pack); err != pkgError.ValidationError("template_id: sticker packs cannot be sent from a template") {
+		t.Fatalf("expected sticker pack template to be rejected, got %v", err)
+	}
+}
+
+func TestSendPayloadValidationAppliesTemplate(t *testing.T) {
+	sender := &serviceSend{templateRepo: stubTemplateRepository{templates: map[string]*domainTemplate.Template{
+		"greeting": {ID: "greeting", Body: "Hi {{name}}"},
+	}}}
+	handler := sendPayloadHandlers[domainSchedule.TypeText]
+
+	payload := json.RawMessage(`{"phone":"6281234567890","template_id":"greeting","variables":{"name":"Budi"}}`)
+	if err := handler.validate(context.Background(), sender, payload); err != nil {
+		t.Fatalf("templated payload without a message should be valid, got %v", err)
+	}
+
+	missing := json.RawMessage(`{"phone":"6281234567890","template_id":"greeting"}`)
+	if err := handler.validate(context.Background(), sender, missing); err != pkgError.ValidationError("variables: missing values for name") {
+		t.Fatalf("expected missing variable error, got %v", err)
+	}
+}
+
+func TestValidateSendAppliesTemplate(t *testing.T) {
+	service := serviceSend{templateRepo: stubTemplateRepository{templates: map[string]*domainTemplate.Template{
+		"greeting": {ID: "greeting", Body: "Hi {{name}}"},
+	}}}
+
+	templated := domainSend.MessageRequest{BaseRequest: domainSend.BaseRequest{Phone: "6281234567890", TemplateID: "greeting", Variables: map[string]string{"name": "Budi"}}}
+	if err := service.ValidateSend(context.Background(), templated); err != nil {
+		t.Fatalf("templated message should be valid, got %v", err)
+	}
+
+	empty := domainSend.MessageRequest{BaseRequest: domainSend.BaseRequest{Phone: "6281234567890"}}
+	if err := service.ValidateSend(context.Background(), empty); err == nil {
+		t.Fatal("expected a message without text or template to be rejected")
+	}
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+)
+
+var (
+	sharedTranscoder     *utils.Transcoder
+	sharedTranscoderOnce sync.Once
+)
+
+// mediaTranscoder returns the process-wide pool every media conversion goes through, so a burst of uploads cannot
+// start more tool processes than configured
+func mediaTranscoder() *utils.Transcoder {
+	sharedTranscoderOnce.Do(func() {
+		sharedTranscoder = utils.NewTranscoder(config.WhatsappTranscodeWorkers, config.WhatsappTranscodeQueueSize,
+			time.Duration(config.WhatsappTranscodeTimeout)*time.Second)
+	})
+	return sharedTranscoder
+}
+
+// transcode runs one media tool through the shared pool; timeout <= 0 uses the configured default
+func transcode(ctx context.Context, timeout time.Duration, name string, args ...string) ([]byte, error) {
+	return mediaTranscoder().Run(ctx, timeout, name, args...)
+}
+
+// transcodeError keeps QUEUE_FULL and timeout errors from the pool so callers see 503/504, and reports anything
+// else as an internal error
+func transcodeError(message string, err error) error {
+	var genericErr pkgError.GenericError
+	if errors.As(err, &genericErr) {
+		return genericErr
+	}
+	return pkgError.InternalServerError(fmt.Sprintf("%s: %v", message, err))
+}
@@ -183,7 +183,8 @@ func (service serviceUser) Avatar(ctx context.Context, request domainUser.Avatar
 	return response, nil
 }
 
-// MyListGroups returns all groups the user has joined.
+// MyListGroups returns all groups the user has joined. The first call lists them from WhatsApp and stores them;
+// later calls are served from the stored group metadata, which group events keep current, unless refresh is set.
 //
 // ⚠️ KNOWN LIMITATION: This endpoint returns a maximum of 500 groups due to a WhatsApp protocol limitation.
 // The underlying whatsmeow library's GetJoinedGroups() function sends a single "participating" IQ query
@@ -192,14 +193,14 @@ func (service serviceUser) Avatar(ctx context.Context, request domainUser.Avatar
 //
 // For more details, see: https://github.com/tulir/whatsmeow/blob/main/group.go
 // Related issue: https://github.com/aldinokemal/go-whatsapp-web-multidevice/issues/553
-func (service serviceUser) MyListGroups(ctx context.Context) (response domainUser.MyListGroupsResponse, err error) {
+func (service serviceUser) MyListGroups(ctx context.Context, request domainUser.MyListGroupsRequest) (response domainUser.MyListGroupsResponse, err error) {
 	client := whatsapp.ClientFromContext(ctx)
 	if client == nil {
 		return response, pkgError.ErrWaCLI
 	}
 	utils.MustLogin(client)
 
-	groups, err := client.GetJoinedGroups(ctx)
+	groups, err := whatsapp.StoredJoinedGroups(ctx, client, service.chatStorageRepo, deviceIDFromContext(ctx), request.Refresh)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,119 @@
+package validations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainCampaign "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/campaign"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	defaultCampaignRatePerMinute = 10
+	maxCampaignRatePerMinute     = 60
+	maxCampaignJitterSeconds     = 600
+)
+
+var campaignStatuses = []any{
+	domainCampaign.StatusRunning, domainCampaign.StatusPaused, domainCampaign.StatusCompleted, domainCampaign.StatusCancelled,
+}
+
+var campaignRecipientStatuses = []any{
+	domainCampaign.RecipientPending, domainCampaign.RecipientSending, domainCampaign.RecipientSent,
+	domainCampaign.RecipientFailed, domainCampaign.RecipientSkipped, domainCampaign.RecipientCancelled,
+}
+
+// validateClock accepts an HH:MM wall-clock time
+func validateClock(value any) error {
+	s, _ := value.(string)
+	if s == "" {
+		return nil
+	}
+	if _, err := time.Parse("15:04", s); err != nil {
+		return fmt.Errorf("must be in HH:MM format")
+	}
+	return nil
+}
+
+func ValidateCreateCampaign(ctx context.Context, request *domainCampaign.CreateCampaignRequest) error {
+	if request.RatePerMinute == 0 {
+		request.RatePerMinute = defaultCampaignRatePerMinute
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Name, validation.Length(0, 255)),
+		validation.Field(&request.Type, validation.Required, validation.In(sendPayloadTypes...)),
+		validation.Field(&request.Payload, validation.Required),
+		validation.Field(&request.RatePerMinute, validation.Min(1), validation.Max(maxCampaignRatePerMinute)),
+		validation.Field(&request.JitterSeconds, validation.Min(0), validation.Max(maxCampaignJitterSeconds)),
+		validation.Field(&request.QuietHoursStart, validation.By(validateClock), validation.When(request.QuietHoursEnd != "", validation.Required)),
+		validation.Field(&request.QuietHoursEnd, validation.By(validateClock), validation.When(request.QuietHoursStart != "", validation.Required)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if len(request.Recipients) == 0 && request.RecipientsCSV == "" {
+		return pkgError.ValidationError("recipients: cannot be blank.")
+	}
+
+	if request.Timezone != "" {
+		if _, err := time.LoadLocation(request.Timezone); err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("timezone: invalid timezone %q.", request.Timezone))
+		}
+	}
+
+	return nil
+}
+
+func ValidateListCampaigns(ctx context.Context, request *domainCampaign.ListCampaignsRequest) error {
+	if request.Limit == 0 {
+		request.Limit = 25
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Status, validation.In(campaignStatuses...)),
+		validation.Field(&request.Limit, validation.Min(1), validation.Max(100)),
+		validation.Field(&request.Offset, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateListCampaignRecipients(ctx context.Context, request *domainCampaign.ListRecipientsRequest) error {
+	if request.Limit == 0 {
+		request.Limit = 100
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.CampaignID, validation.Required),
+		validation.Field(&request.Status, validation.In(campaignRecipientStatuses...)),
+		validation.Field(&request.Limit, validation.Min(1), validation.Max(1000)),
+		validation.Field(&request.Offset, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateCampaignAction(ctx context.Context, request *domainCampaign.CampaignActionRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
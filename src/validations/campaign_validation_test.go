@@ -0,0 +1,88 @@
+package validations
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	domainCampaign "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/campaign"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCreateCampaign(t *testing.T) {
+	payload := json.RawMessage(`{"message":"Hi {{name}}"}`)
+	recipients := []domainCampaign.RecipientInput{{Phone: "6289685028129", Variables: map[string]string{"name": "Budi"}}}
+
+	tests := []struct {
+		name    string
+		request domainCampaign.CreateCampaignRequest
+		err     any
+	}{
+		{
+			name:    "should success with json recipients",
+			request: domainCampaign.CreateCampaignRequest{Type: "text", Payload: payload, Recipients: recipients},
+			err:     nil,
+		},
+		{
+			name: "should success with csv recipients and quiet hours",
+			request: domainCampaign.CreateCampaignRequest{
+				Type: "text", Payload: payload, RecipientsCSV: "phone,name\n6289685028129,Budi",
+				QuietHoursStart: "21:00", QuietHoursEnd: "08:00", Timezone: "Asia/Jakarta",
+			},
+			err: nil,
+		},
+		{
+			name:    "should error without recipients",
+			request: domainCampaign.CreateCampaignRequest{Type: "text", Payload: payload},
+			err:     pkgError.ValidationError("recipients: cannot be blank."),
+		},
+		{
+			name:    "should error with unknown type",
+			request: domainCampaign.CreateCampaignRequest{Type: "fax", Payload: payload, Recipients: recipients},
+			err:     pkgError.ValidationError("type: must be a valid value."),
+		},
+		{
+			name:    "should error with rate above limit",
+			request: domainCampaign.CreateCampaignRequest{Type: "text", Payload: payload, Recipients: recipients, RatePerMinute: 500},
+			err:     pkgError.ValidationError("rate_per_minute: must be no greater than 60."),
+		},
+		{
+			name:    "should error with only one quiet hours bound",
+			request: domainCampaign.CreateCampaignRequest{Type: "text", Payload: payload, Recipients: recipients, QuietHoursStart: "21:00"},
+			err:     pkgError.ValidationError("quiet_hours_end: cannot be blank."),
+		},
+		{
+			name:    "should error with malformed quiet hours",
+			request: domainCampaign.CreateCampaignRequest{Type: "text", Payload: payload, Recipients: recipients, QuietHoursStart: "9pm", QuietHoursEnd: "08:00"},
+			err:     pkgError.ValidationError("quiet_hours_start: must be in HH:MM format."),
+		},
+		{
+			name:    "should error with invalid timezone",
+			request: domainCampaign.CreateCampaignRequest{Type: "text", Payload: payload, Recipients: recipients, Timezone: "Mars/Olympus"},
+			err:     pkgError.ValidationError(`timezone: invalid timezone "Mars/Olympus".`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateCampaign(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateCreateCampaignDefaultsRate(t *testing.T) {
+	request := domainCampaign.CreateCampaignRequest{
+		Type:       "text",
+		Payload:    json.RawMessage(`{"message":"hello"}`),
+		Recipients: []domainCampaign.RecipientInput{{Phone: "6289685028129"}},
+	}
+	assert.NoError(t, ValidateCreateCampaign(context.Background(), &request))
+	assert.Equal(t, 10, request.RatePerMinute)
+}
+
+func TestValidateCampaignAction(t *testing.T) {
+	assert.NoError(t, ValidateCampaignAction(context.Background(), &domainCampaign.CampaignActionRequest{ID: "abc"}))
+	assert.Equal(t, pkgError.ValidationError("id: cannot be blank."),
+		ValidateCampaignAction(context.Background(), &domainCampaign.CampaignActionRequest{}))
+}
@@ -102,3 +102,24 @@ func ValidateArchiveChat(ctx context.Context, request *domainChat.ArchiveChatReq
 
 	return nil
 }
+
+// DefaultHistoryRequestCount is the batch size recommended by whatsmeow for on-demand history sync
+const DefaultHistoryRequestCount = 50
+
+func ValidateRequestHistory(ctx context.Context, request *domainChat.RequestHistoryRequest) error {
+	// Set default count if not provided
+	if request.Count == 0 {
+		request.Count = DefaultHistoryRequestCount
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ChatJID, validation.Required),
+		validation.Field(&request.Count, validation.Min(1), validation.Max(500)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
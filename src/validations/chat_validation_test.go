@@ -250,3 +250,59 @@ func TestValidateSetDisappearingTimer(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRequestHistory(t *testing.T) {
+	type args struct {
+		request domainChat.RequestHistoryRequest
+	}
+	tests := []struct {
+		name      string
+		args      args
+		err       any
+		wantCount int
+	}{
+		{
+			name: "should success with valid request",
+			args: args{request: domainChat.RequestHistoryRequest{
+				ChatJID: "6289685028129@s.whatsapp.net",
+				Count:   100,
+			}},
+			err:       nil,
+			wantCount: 100,
+		},
+		{
+			name: "should success with zero count (auto set to default)",
+			args: args{request: domainChat.RequestHistoryRequest{
+				ChatJID:   "120363024512399999@g.us",
+				MessageID: "3EB0C127D7BACC83D6A1",
+			}},
+			err:       nil,
+			wantCount: DefaultHistoryRequestCount,
+		},
+		{
+			name: "should error with empty chat_jid",
+			args: args{request: domainChat.RequestHistoryRequest{
+				Count: 50,
+			}},
+			err:       pkgError.ValidationError("chat_jid: cannot be blank."),
+			wantCount: 50,
+		},
+		{
+			name: "should error with count too high",
+			args: args{request: domainChat.RequestHistoryRequest{
+				ChatJID: "6289685028129@s.whatsapp.net",
+				Count:   501,
+			}},
+			err:       pkgError.ValidationError("count: must be no greater than 500."),
+			wantCount: 501,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRequestHistory(context.Background(), &tt.args.request)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.wantCount, tt.args.request.Count)
+		})
+	}
+}
@@ -2,11 +2,27 @@ package validations
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// groupDescriptionMaxLength is the limit the WhatsApp apps enforce for group descriptions
+const groupDescriptionMaxLength = 2048
+
+const (
+	defaultParticipantJobBatchSize    = 10
+	maxParticipantJobBatchSize        = 50
+	defaultParticipantJobBatchDelay   = 10
+	maxParticipantJobBatchDelay       = 600
+	participantJobInviteMessageLength = 1024
 )
 
 func ValidateJoinGroupWithLink(ctx context.Context, request domainGroup.JoinGroupWithLinkRequest) error {
@@ -205,6 +221,71 @@ func ValidateSetGroupTopic(ctx context.Context, request domainGroup.SetGroupTopi
 	return nil
 }
 
+func ValidateSetGroupJoinApproval(ctx context.Context, request domainGroup.SetGroupJoinApprovalRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateSetGroupMemberAddMode(ctx context.Context, request domainGroup.SetGroupMemberAddModeRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.Mode, validation.Required,
+			validation.In(types.GroupMemberAddModeAdmin, types.GroupMemberAddModeAllMember).Error("must be admin_add or all_member_add")),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateSetGroupDisappearing(ctx context.Context, request domainGroup.SetGroupDisappearingRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.TimerSeconds, validation.By(validateTimerValue)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateSetGroupDescription(ctx context.Context, request domainGroup.SetGroupDescriptionRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		// Description can be empty to remove it
+		validation.Field(&request.Description, validation.RuneLength(0, groupDescriptionMaxLength)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateRevokeGroupInviteLink(ctx context.Context, request domainGroup.RevokeGroupInviteLinkRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
 func ValidateGroupInfo(ctx context.Context, request domainGroup.GroupInfoRequest) error {
 	err := validation.ValidateStructWithContext(ctx, &request,
 		validation.Field(&request.GroupID, validation.Required),
@@ -228,3 +309,156 @@ func ValidateGetGroupInviteLink(ctx context.Context, request domainGroup.GetGrou
 
 	return nil
 }
+
+func ValidateCreateCommunity(ctx context.Context, request domainGroup.CreateCommunityRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Name, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateCommunity(ctx context.Context, request domainGroup.CommunityRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateCommunityGroups(ctx context.Context, request domainGroup.CommunityGroupsRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+		validation.Field(&request.GroupIDs, validation.Required),
+		validation.Field(&request.GroupIDs, validation.Each(validation.Required)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateCreateCommunityGroup(ctx context.Context, request domainGroup.CreateCommunityGroupRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+		validation.Field(&request.Title, validation.Required),
+		validation.Field(&request.Participants, validation.Each(validation.Required)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateCreateParticipantJob(ctx context.Context, request *domainGroup.CreateParticipantJobRequest) error {
+	if request.BatchSize == 0 {
+		request.BatchSize = defaultParticipantJobBatchSize
+	}
+	if request.BatchDelaySeconds == 0 {
+		request.BatchDelaySeconds = defaultParticipantJobBatchDelay
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.Action, validation.Required, validation.In(
+			whatsmeow.ParticipantChangeAdd, whatsmeow.ParticipantChangeRemove,
+			whatsmeow.ParticipantChangePromote, whatsmeow.ParticipantChangeDemote,
+		)),
+		validation.Field(&request.Participants, validation.Each(validation.Required)),
+		validation.Field(&request.BatchSize, validation.Min(1), validation.Max(maxParticipantJobBatchSize)),
+		validation.Field(&request.BatchDelaySeconds, validation.Min(0), validation.Max(maxParticipantJobBatchDelay)),
+		validation.Field(&request.InviteMessage, validation.RuneLength(0, participantJobInviteMessageLength)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if len(request.Participants) == 0 && request.ParticipantsCSV == "" {
+		return pkgError.ValidationError("participants: cannot be blank.")
+	}
+
+	// Only adding can fall back to an invite
+	if request.InviteFallback == nil {
+		fallback := request.Action == whatsmeow.ParticipantChangeAdd
+		request.InviteFallback = &fallback
+	} else if *request.InviteFallback && request.Action != whatsmeow.ParticipantChangeAdd {
+		return pkgError.ValidationError("invite_fallback: only applies to the add action.")
+	}
+
+	return nil
+}
+
+func ValidateParticipantJob(ctx context.Context, request domainGroup.ParticipantJobRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.ID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+const (
+	defaultGroupHistoryLimit = 50
+	maxGroupHistoryLimit     = 500
+)
+
+// validateHistoryTypes accepts a comma separated list of group history event types
+func validateHistoryTypes(value any) error {
+	s, _ := value.(string)
+	for _, eventType := range strings.Split(s, ",") {
+		eventType = strings.TrimSpace(eventType)
+		if eventType != "" && !slices.Contains(domainGroup.HistoryTypes, eventType) {
+			return fmt.Errorf("unknown type %q", eventType)
+		}
+	}
+	return nil
+}
+
+// validateRFC3339 accepts an empty value or an RFC3339 timestamp
+func validateRFC3339(value any) error {
+	s, _ := value.(string)
+	if s == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, s); err != nil {
+		return fmt.Errorf("must be an RFC3339 time")
+	}
+	return nil
+}
+
+func ValidateGroupHistory(ctx context.Context, request *domainGroup.GroupHistoryRequest) error {
+	if request.Limit == 0 {
+		request.Limit = defaultGroupHistoryLimit
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.Types, validation.By(validateHistoryTypes)),
+		validation.Field(&request.StartTime, validation.By(validateRFC3339)),
+		validation.Field(&request.EndTime, validation.By(validateRFC3339)),
+		validation.Field(&request.Limit, validation.Min(1), validation.Max(maxGroupHistoryLimit)),
+		validation.Field(&request.Offset, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
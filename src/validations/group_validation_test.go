@@ -2,12 +2,14 @@ package validations
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/stretchr/testify/assert"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
 )
 
 func TestValidateJoinGroupWithLink(t *testing.T) {
@@ -706,3 +708,309 @@ func TestValidateGroupInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreateCommunity(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainGroup.CreateCommunityRequest
+		err     any
+	}{
+		{
+			name:    "should success with name",
+			request: domainGroup.CreateCommunityRequest{Name: "Neighbourhood"},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty name",
+			request: domainGroup.CreateCommunityRequest{Description: "All streets"},
+			err:     pkgError.ValidationError("name: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateCommunity(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateCommunityGroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainGroup.CommunityGroupsRequest
+		err     any
+	}{
+		{
+			name: "should success with groups",
+			request: domainGroup.CommunityGroupsRequest{
+				CommunityID: "120363000000000001@g.us",
+				GroupIDs:    []string{"120363000000000002@g.us", "120363000000000003@g.us"},
+			},
+			err: nil,
+		},
+		{
+			name:    "should error without groups",
+			request: domainGroup.CommunityGroupsRequest{CommunityID: "120363000000000001@g.us"},
+			err:     pkgError.ValidationError("group_ids: cannot be blank."),
+		},
+		{
+			name: "should error with empty group id",
+			request: domainGroup.CommunityGroupsRequest{
+				CommunityID: "120363000000000001@g.us",
+				GroupIDs:    []string{"120363000000000002@g.us", ""},
+			},
+			err: pkgError.ValidationError("group_ids: (1: cannot be blank.)."),
+		},
+		{
+			name:    "should error without community id",
+			request: domainGroup.CommunityGroupsRequest{GroupIDs: []string{"120363000000000002@g.us"}},
+			err:     pkgError.ValidationError("community_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommunityGroups(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateCreateCommunityGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainGroup.CreateCommunityGroupRequest
+		err     any
+	}{
+		{
+			name:    "should success without participants",
+			request: domainGroup.CreateCommunityGroupRequest{CommunityID: "120363000000000001@g.us", Title: "Parents"},
+			err:     nil,
+		},
+		{
+			name:    "should error without title",
+			request: domainGroup.CreateCommunityGroupRequest{CommunityID: "120363000000000001@g.us"},
+			err:     pkgError.ValidationError("title: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateCommunityGroup(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSetGroupMemberAddMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainGroup.SetGroupMemberAddModeRequest
+		err     any
+	}{
+		{
+			name:    "should success with admin only",
+			request: domainGroup.SetGroupMemberAddModeRequest{GroupID: "123456789@g.us", Mode: types.GroupMemberAddModeAdmin},
+			err:     nil,
+		},
+		{
+			name:    "should success with all members",
+			request: domainGroup.SetGroupMemberAddModeRequest{GroupID: "123456789@g.us", Mode: types.GroupMemberAddModeAllMember},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty mode",
+			request: domainGroup.SetGroupMemberAddModeRequest{GroupID: "123456789@g.us"},
+			err:     pkgError.ValidationError("mode: cannot be blank."),
+		},
+		{
+			name:    "should error with unknown mode",
+			request: domainGroup.SetGroupMemberAddModeRequest{GroupID: "123456789@g.us", Mode: "everyone"},
+			err:     pkgError.ValidationError("mode: must be admin_add or all_member_add."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetGroupMemberAddMode(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSetGroupDisappearing(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainGroup.SetGroupDisappearingRequest
+		err     any
+	}{
+		{
+			name:    "should success turning the timer off",
+			request: domainGroup.SetGroupDisappearingRequest{GroupID: "123456789@g.us", TimerSeconds: 0},
+			err:     nil,
+		},
+		{
+			name:    "should success with 7 days",
+			request: domainGroup.SetGroupDisappearingRequest{GroupID: "123456789@g.us", TimerSeconds: 604800},
+			err:     nil,
+		},
+		{
+			name:    "should error with a duration WhatsApp does not offer",
+			request: domainGroup.SetGroupDisappearingRequest{GroupID: "123456789@g.us", TimerSeconds: 3600},
+			err:     pkgError.ValidationError("timer_seconds: timer_seconds must be one of: 0 (off), 86400 (24h), 604800 (7d), 7776000 (90d)."),
+		},
+		{
+			name:    "should error with empty group id",
+			request: domainGroup.SetGroupDisappearingRequest{TimerSeconds: 86400},
+			err:     pkgError.ValidationError("group_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetGroupDisappearing(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSetGroupDescription(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainGroup.SetGroupDescriptionRequest
+		err     any
+	}{
+		{
+			name:    "should success with description and previous id",
+			request: domainGroup.SetGroupDescriptionRequest{GroupID: "123456789@g.us", Description: "Rules", PreviousID: "3EB0ABCDEF"},
+			err:     nil,
+		},
+		{
+			name:    "should success removing the description",
+			request: domainGroup.SetGroupDescriptionRequest{GroupID: "123456789@g.us"},
+			err:     nil,
+		},
+		{
+			name:    "should error with a description that is too long",
+			request: domainGroup.SetGroupDescriptionRequest{GroupID: "123456789@g.us", Description: strings.Repeat("a", 2049)},
+			err:     pkgError.ValidationError("description: the length must be no more than 2048."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetGroupDescription(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateCreateParticipantJob(t *testing.T) {
+	t.Run("should fill defaults and enable the invite fallback for add", func(t *testing.T) {
+		request := domainGroup.CreateParticipantJobRequest{
+			GroupID:      "123456789@g.us",
+			Action:       whatsmeow.ParticipantChangeAdd,
+			Participants: []string{"6289685028129"},
+		}
+		assert.NoError(t, ValidateCreateParticipantJob(context.Background(), &request))
+		assert.Equal(t, 10, request.BatchSize)
+		assert.Equal(t, 10, request.BatchDelaySeconds)
+		if assert.NotNil(t, request.InviteFallback) {
+			assert.True(t, *request.InviteFallback)
+		}
+	})
+
+	t.Run("should leave the invite fallback off for remove", func(t *testing.T) {
+		request := domainGroup.CreateParticipantJobRequest{
+			GroupID:         "123456789@g.us",
+			Action:          whatsmeow.ParticipantChangeRemove,
+			ParticipantsCSV: "phone\n6289685028129",
+		}
+		assert.NoError(t, ValidateCreateParticipantJob(context.Background(), &request))
+		if assert.NotNil(t, request.InviteFallback) {
+			assert.False(t, *request.InviteFallback)
+		}
+	})
+
+	fallback := true
+	tests := []struct {
+		name    string
+		request domainGroup.CreateParticipantJobRequest
+		err     any
+	}{
+		{
+			name:    "should error without participants",
+			request: domainGroup.CreateParticipantJobRequest{GroupID: "123456789@g.us", Action: whatsmeow.ParticipantChangeAdd},
+			err:     pkgError.ValidationError("participants: cannot be blank."),
+		},
+		{
+			name: "should error with a batch that is too large",
+			request: domainGroup.CreateParticipantJobRequest{
+				GroupID: "123456789@g.us", Action: whatsmeow.ParticipantChangeAdd, Participants: []string{"628123"}, BatchSize: 51,
+			},
+			err: pkgError.ValidationError("batch_size: must be no greater than 50."),
+		},
+		{
+			name: "should error when asking for invites on promote",
+			request: domainGroup.CreateParticipantJobRequest{
+				GroupID: "123456789@g.us", Action: whatsmeow.ParticipantChangePromote, Participants: []string{"628123"}, InviteFallback: &fallback,
+			},
+			err: pkgError.ValidationError("invite_fallback: only applies to the add action."),
+		},
+		{
+			name:    "should error with an unknown action",
+			request: domainGroup.CreateParticipantJobRequest{GroupID: "123456789@g.us", Action: "kick", Participants: []string{"628123"}},
+			err:     pkgError.ValidationError("action: must be a valid value."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateParticipantJob(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateGroupHistory(t *testing.T) {
+	t.Run("should default the limit", func(t *testing.T) {
+		request := domainGroup.GroupHistoryRequest{GroupID: "123456789@g.us", Types: "joined, removed"}
+		assert.NoError(t, ValidateGroupHistory(context.Background(), &request))
+		assert.Equal(t, 50, request.Limit)
+	})
+
+	tests := []struct {
+		name    string
+		request domainGroup.GroupHistoryRequest
+		err     any
+	}{
+		{
+			name:    "should error without group id",
+			request: domainGroup.GroupHistoryRequest{},
+			err:     pkgError.ValidationError("group_id: cannot be blank."),
+		},
+		{
+			name:    "should error with an unknown type",
+			request: domainGroup.GroupHistoryRequest{GroupID: "123456789@g.us", Types: "joined,kicked"},
+			err:     pkgError.ValidationError(`types: unknown type "kicked".`),
+		},
+		{
+			name:    "should error with a malformed start time",
+			request: domainGroup.GroupHistoryRequest{GroupID: "123456789@g.us", StartTime: "2026-10-01"},
+			err:     pkgError.ValidationError("start_time: must be an RFC3339 time."),
+		},
+		{
+			name:    "should error with a limit that is too large",
+			request: domainGroup.GroupHistoryRequest{GroupID: "123456789@g.us", Limit: 501},
+			err:     pkgError.ValidationError("limit: must be no greater than 500."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGroupHistory(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package validations
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -102,3 +104,41 @@ func ValidateDownloadMedia(ctx context.Context, request domainMessage.DownloadMe
 
 	return nil
 }
+
+func ValidatePollResults(ctx context.Context, request domainMessage.PollResultsRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.MessageID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+// MaxForwardRecipients caps how many chats a message can be forwarded to in one request
+const MaxForwardRecipients = 50
+
+func ValidateForwardMessage(ctx context.Context, request domainMessage.ForwardRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Phone, validation.Required),
+		validation.Field(&request.MessageID, validation.Required),
+		validation.Field(&request.Recipients,
+			validation.Required,
+			validation.Length(1, MaxForwardRecipients),
+			validation.Each(validation.Required, validation.By(func(value any) error {
+				if strings.HasSuffix(value.(string), "@newsletter") {
+					return errors.New("forwarding to newsletters is not supported")
+				}
+				return nil
+			})),
+		),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
@@ -291,3 +291,70 @@ func TestValidateStarMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateForwardMessage(t *testing.T) {
+	tooMany := make([]string, MaxForwardRecipients+1)
+	for i := range tooMany {
+		tooMany[i] = "6281234567890@s.whatsapp.net"
+	}
+
+	tests := []struct {
+		name        string
+		request     domainMessage.ForwardRequest
+		errContains []string
+	}{
+		{
+			name: "should success with valid request",
+			request: domainMessage.ForwardRequest{
+				MessageID:  "3EB0789ABC123456",
+				Phone:      "6281234567890@s.whatsapp.net",
+				Recipients: []string{"6289876543210", "120363025982934543@g.us"},
+			},
+		},
+		{
+			name: "should error without recipients",
+			request: domainMessage.ForwardRequest{
+				MessageID: "3EB0789ABC123456",
+				Phone:     "6281234567890@s.whatsapp.net",
+			},
+			errContains: []string{"recipients: cannot be blank"},
+		},
+		{
+			name: "should error with too many recipients",
+			request: domainMessage.ForwardRequest{
+				MessageID:  "3EB0789ABC123456",
+				Phone:      "6281234567890@s.whatsapp.net",
+				Recipients: tooMany,
+			},
+			errContains: []string{"recipients: the length must be between 1 and 50"},
+		},
+		{
+			name: "should error with empty or newsletter recipient",
+			request: domainMessage.ForwardRequest{
+				MessageID:  "3EB0789ABC123456",
+				Phone:      "6281234567890@s.whatsapp.net",
+				Recipients: []string{"", "120363024512399999@newsletter"},
+			},
+			errContains: []string{"0: cannot be blank", "1: forwarding to newsletters is not supported"},
+		},
+		{
+			name:        "should error with empty message id and phone",
+			request:     domainMessage.ForwardRequest{Recipients: []string{"6289876543210"}},
+			errContains: []string{"message_id: cannot be blank", "phone: cannot be blank"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateForwardMessage(context.Background(), tt.request)
+			if len(tt.errContains) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, msg := range tt.errContains {
+				assert.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
@@ -2,11 +2,51 @@ package validations
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/dustin/go-humanize"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// newsletterNameMaxLength and newsletterDescriptionMaxLength are the limits the WhatsApp apps enforce for channels
+const (
+	newsletterNameMaxLength        = 100
+	newsletterDescriptionMaxLength = 2048
 )
 
+func ValidateCreateNewsletter(ctx context.Context, request domainNewsletter.CreateRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Name, validation.Required, validation.RuneLength(1, newsletterNameMaxLength)),
+		validation.Field(&request.Description, validation.RuneLength(0, newsletterDescriptionMaxLength)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Picture != nil {
+		availableMimes := map[string]bool{
+			"image/jpeg": true,
+			"image/jpg":  true,
+			"image/png":  true,
+		}
+		if !availableMimes[request.Picture.Header.Get("Content-Type")] {
+			return pkgError.ValidationError("your picture is not allowed. please use jpg/jpeg/png")
+		}
+	}
+
+	return nil
+}
+
+func ValidateFollowNewsletter(ctx context.Context, request domainNewsletter.FollowRequest) error {
+	return validateNewsletterTarget(request.NewsletterID, request.InviteLink)
+}
+
 func ValidateUnfollowNewsletter(ctx context.Context, request domainNewsletter.UnfollowRequest) error {
 	err := validation.ValidateStructWithContext(ctx, &request,
 		validation.Field(&request.NewsletterID, validation.Required),
@@ -18,3 +58,140 @@ func ValidateUnfollowNewsletter(ctx context.Context, request domainNewsletter.Un
 
 	return nil
 }
+
+func ValidateNewsletterInfo(ctx context.Context, request domainNewsletter.InfoRequest) error {
+	return validateNewsletterTarget(request.NewsletterID, request.InviteLink)
+}
+
+func ValidateNewsletterMessages(ctx context.Context, request *domainNewsletter.MessagesRequest) error {
+	// Set default count if not provided
+	if request.Count == 0 {
+		request.Count = 25
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.Count, validation.Min(1), validation.Max(domainNewsletter.MessagesMaxCount)),
+		validation.Field(&request.Before, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateNewsletterMessageUpdates(ctx context.Context, request *domainNewsletter.MessageUpdatesRequest) error {
+	// Set default count if not provided
+	if request.Count == 0 {
+		request.Count = 25
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.Count, validation.Min(1), validation.Max(domainNewsletter.MessagesMaxCount)),
+		validation.Field(&request.Since, validation.Min(int64(0))),
+		validation.Field(&request.After, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidatePostNewsletter(ctx context.Context, request domainNewsletter.PostRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	hasImage := request.Image != nil || (request.ImageURL != nil && *request.ImageURL != "")
+	hasVideo := request.Video != nil || (request.VideoURL != nil && *request.VideoURL != "")
+	if hasImage && hasVideo {
+		return pkgError.ValidationError("a post carries either an image or a video, not both")
+	}
+	if !hasImage && !hasVideo && strings.TrimSpace(request.Message) == "" {
+		return pkgError.ValidationError("message cannot be blank for a text post")
+	}
+
+	if request.Image != nil {
+		availableMimes := map[string]bool{
+			"image/jpeg": true,
+			"image/jpg":  true,
+			"image/png":  true,
+		}
+		if !availableMimes[request.Image.Header.Get("Content-Type")] {
+			return pkgError.ValidationError("your image is not allowed. please use jpg/jpeg/png")
+		}
+		if request.Image.Size > config.WhatsappSettingMaxImageSize {
+			maxSizeString := humanize.Bytes(uint64(config.WhatsappSettingMaxImageSize))
+			return pkgError.ValidationError(fmt.Sprintf("max image upload is %s", maxSizeString))
+		}
+	}
+
+	if request.Video != nil {
+		if request.Video.Header.Get("Content-Type") != "video/mp4" {
+			return pkgError.ValidationError("your video type is not allowed. please use mp4")
+		}
+		if request.Video.Size > config.WhatsappSettingMaxVideoSize {
+			maxSizeString := humanize.Bytes(uint64(config.WhatsappSettingMaxVideoSize))
+			return pkgError.ValidationError(fmt.Sprintf("max video upload is %s", maxSizeString))
+		}
+	}
+
+	if request.ImageURL != nil && *request.ImageURL != "" {
+		if err := validation.Validate(*request.ImageURL, is.URL); err != nil {
+			return pkgError.ValidationError("ImageURL must be a valid URL")
+		}
+	}
+
+	if request.VideoURL != nil && *request.VideoURL != "" {
+		if err := validation.Validate(*request.VideoURL, is.URL); err != nil {
+			return pkgError.ValidationError("VideoURL must be a valid URL")
+		}
+	}
+
+	return nil
+}
+
+func ValidateReactNewsletter(ctx context.Context, request domainNewsletter.ReactRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.ServerID, validation.Required, validation.Min(1)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateMuteNewsletter(ctx context.Context, request domainNewsletter.MuteRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+// validateNewsletterTarget requires exactly one way of naming the channel
+func validateNewsletterTarget(newsletterID, inviteLink string) error {
+	switch {
+	case newsletterID == "" && inviteLink == "":
+		return pkgError.ValidationError("either newsletter_id or invite_link must be provided")
+	case newsletterID != "" && inviteLink != "":
+		return pkgError.ValidationError("provide newsletter_id or invite_link, not both")
+	}
+	return nil
+}
@@ -2,8 +2,12 @@ package validations
 
 import (
 	"context"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
 	"testing"
 
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/stretchr/testify/assert"
@@ -48,3 +52,181 @@ func TestValidateUnfollowNewsletter(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreateNewsletter(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainNewsletter.CreateRequest
+		err     any
+	}{
+		{
+			name:    "should success with name only",
+			request: domainNewsletter.CreateRequest{Name: "Brand updates"},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty name",
+			request: domainNewsletter.CreateRequest{Description: "About us"},
+			err:     pkgError.ValidationError("name: cannot be blank."),
+		},
+		{
+			name:    "should error with too long name",
+			request: domainNewsletter.CreateRequest{Name: strings.Repeat("a", 101)},
+			err:     pkgError.ValidationError("name: the length must be between 1 and 100."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateNewsletter(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateFollowNewsletter(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainNewsletter.FollowRequest
+		err     any
+	}{
+		{
+			name:    "should success with newsletter id",
+			request: domainNewsletter.FollowRequest{NewsletterID: "120363123456789@newsletter"},
+			err:     nil,
+		},
+		{
+			name:    "should success with invite link",
+			request: domainNewsletter.FollowRequest{InviteLink: "https://whatsapp.com/channel/0029VaABCDEF"},
+			err:     nil,
+		},
+		{
+			name:    "should error without newsletter id and invite link",
+			request: domainNewsletter.FollowRequest{},
+			err:     pkgError.ValidationError("either newsletter_id or invite_link must be provided"),
+		},
+		{
+			name: "should error with both newsletter id and invite link",
+			request: domainNewsletter.FollowRequest{
+				NewsletterID: "120363123456789@newsletter",
+				InviteLink:   "https://whatsapp.com/channel/0029VaABCDEF",
+			},
+			err: pkgError.ValidationError("provide newsletter_id or invite_link, not both"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFollowNewsletter(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateNewsletterMessages(t *testing.T) {
+	t.Run("should default count", func(t *testing.T) {
+		request := domainNewsletter.MessagesRequest{NewsletterID: "120363123456789@newsletter"}
+		assert.NoError(t, ValidateNewsletterMessages(context.Background(), &request))
+		assert.Equal(t, 25, request.Count)
+	})
+
+	t.Run("should error with count above the maximum", func(t *testing.T) {
+		request := domainNewsletter.MessagesRequest{NewsletterID: "120363123456789@newsletter", Count: 101}
+		err := ValidateNewsletterMessages(context.Background(), &request)
+		assert.Equal(t, pkgError.ValidationError("count: must be no greater than 100."), err)
+	})
+}
+
+func TestValidatePostNewsletter(t *testing.T) {
+	imageURL := "https://example.com/a.png"
+	videoURL := "https://example.com/a.mp4"
+	badURL := "not a url"
+	pngHeader := textproto.MIMEHeader{"Content-Type": []string{"image/png"}}
+	image := &multipart.FileHeader{Filename: "a.png", Header: pngHeader, Size: 1024}
+	largeImage := &multipart.FileHeader{Filename: "a.png", Header: pngHeader, Size: config.WhatsappSettingMaxImageSize + 1}
+
+	tests := []struct {
+		name    string
+		request domainNewsletter.PostRequest
+		err     any
+	}{
+		{
+			name:    "should success with text post",
+			request: domainNewsletter.PostRequest{NewsletterID: "120363123456789@newsletter", Message: "hello"},
+			err:     nil,
+		},
+		{
+			name:    "should success with image url and no caption",
+			request: domainNewsletter.PostRequest{NewsletterID: "120363123456789@newsletter", ImageURL: &imageURL},
+			err:     nil,
+		},
+		{
+			name:    "should success with uploaded image",
+			request: domainNewsletter.PostRequest{NewsletterID: "120363123456789@newsletter", Image: image},
+			err:     nil,
+		},
+		{
+			name:    "should error with image over the size limit",
+			request: domainNewsletter.PostRequest{NewsletterID: "120363123456789@newsletter", Image: largeImage},
+			err:     pkgError.ValidationError("max image upload is 20 MB"),
+		},
+		{
+			name:    "should error with blank text post",
+			request: domainNewsletter.PostRequest{NewsletterID: "120363123456789@newsletter", Message: "  "},
+			err:     pkgError.ValidationError("message cannot be blank for a text post"),
+		},
+		{
+			name:    "should error with image and video",
+			request: domainNewsletter.PostRequest{NewsletterID: "120363123456789@newsletter", ImageURL: &imageURL, VideoURL: &videoURL},
+			err:     pkgError.ValidationError("a post carries either an image or a video, not both"),
+		},
+		{
+			name:    "should error with invalid video url",
+			request: domainNewsletter.PostRequest{NewsletterID: "120363123456789@newsletter", VideoURL: &badURL},
+			err:     pkgError.ValidationError("VideoURL must be a valid URL"),
+		},
+		{
+			name:    "should error without newsletter id",
+			request: domainNewsletter.PostRequest{Message: "hello"},
+			err:     pkgError.ValidationError("newsletter_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePostNewsletter(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateReactNewsletter(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainNewsletter.ReactRequest
+		err     any
+	}{
+		{
+			name:    "should success with reaction",
+			request: domainNewsletter.ReactRequest{NewsletterID: "120363123456789@newsletter", ServerID: 42, Reaction: "👍"},
+			err:     nil,
+		},
+		{
+			name:    "should success removing a reaction",
+			request: domainNewsletter.ReactRequest{NewsletterID: "120363123456789@newsletter", ServerID: 42},
+			err:     nil,
+		},
+		{
+			name:    "should error without server id",
+			request: domainNewsletter.ReactRequest{NewsletterID: "120363123456789@newsletter", Reaction: "👍"},
+			err:     pkgError.ValidationError("server_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReactNewsletter(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package validations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainSchedule "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/schedule"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// scheduleGracePeriod tolerates clock skew for send_at values that are just in the past
+const scheduleGracePeriod = time.Minute
+
+// sendPayloadTypes are the /send/* endpoints that can be stored and replayed later
+var sendPayloadTypes = []any{
+	domainSchedule.TypeText, domainSchedule.TypeImage, domainSchedule.TypeFile, domainSchedule.TypeVideo,
+	domainSchedule.TypeAudio, domainSchedule.TypeSticker, domainSchedule.TypeContact, domainSchedule.TypeLink,
+	domainSchedule.TypeLocation, domainSchedule.TypePoll,
+}
+
+var scheduleStatuses = []any{
+	domainSchedule.StatusPending, domainSchedule.StatusSending, domainSchedule.StatusSent,
+	domainSchedule.StatusFailed, domainSchedule.StatusCancelled,
+}
+
+// validateSendAt parses send_at in the requested timezone and rejects times in the past
+func validateSendAt(sendAt, timezone string) error {
+	t, err := utils.ParseTimeInLocation(sendAt, timezone)
+	if err != nil {
+		return pkgError.ValidationError(fmt.Sprintf("send_at: %v", err))
+	}
+	if t.Before(time.Now().Add(-scheduleGracePeriod)) {
+		return pkgError.ValidationError("send_at: must be in the future")
+	}
+	return nil
+}
+
+func ValidateCreateSchedule(ctx context.Context, request *domainSchedule.CreateScheduleRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Type, validation.Required, validation.In(sendPayloadTypes...)),
+		validation.Field(&request.SendAt, validation.Required),
+		validation.Field(&request.Payload, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return validateSendAt(request.SendAt, request.Timezone)
+}
+
+func ValidateListSchedules(ctx context.Context, request *domainSchedule.ListScheduleRequest) error {
+	if request.Limit == 0 {
+		request.Limit = 25
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Status, validation.In(scheduleStatuses...)),
+		validation.Field(&request.Limit, validation.Min(1), validation.Max(100)),
+		validation.Field(&request.Offset, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateReschedule(ctx context.Context, request *domainSchedule.RescheduleRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ID, validation.Required),
+		validation.Field(&request.SendAt, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return validateSendAt(request.SendAt, request.Timezone)
+}
+
+func ValidateCancelSchedule(ctx context.Context, request *domainSchedule.CancelScheduleRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
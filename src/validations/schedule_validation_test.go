@@ -0,0 +1,133 @@
+package validations
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	domainSchedule "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/schedule"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCreateSchedule(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	payload := json.RawMessage(`{"phone":"6289685028129","message":"hello"}`)
+
+	tests := []struct {
+		name    string
+		request domainSchedule.CreateScheduleRequest
+		err     any
+	}{
+		{
+			name:    "should success with valid request",
+			request: domainSchedule.CreateScheduleRequest{Type: "text", SendAt: future, Payload: payload},
+			err:     nil,
+		},
+		{
+			name:    "should success with local time and timezone",
+			request: domainSchedule.CreateScheduleRequest{Type: "image", SendAt: "2099-03-01T09:00:00", Timezone: "Asia/Jakarta", Payload: payload},
+			err:     nil,
+		},
+		{
+			name:    "should error with unknown type",
+			request: domainSchedule.CreateScheduleRequest{Type: "fax", SendAt: future, Payload: payload},
+			err:     pkgError.ValidationError("type: must be a valid value."),
+		},
+		{
+			name:    "should error with empty payload",
+			request: domainSchedule.CreateScheduleRequest{Type: "text", SendAt: future},
+			err:     pkgError.ValidationError("payload: cannot be blank."),
+		},
+		{
+			name:    "should error with past send_at",
+			request: domainSchedule.CreateScheduleRequest{Type: "text", SendAt: "2020-01-01T00:00:00Z", Payload: payload},
+			err:     pkgError.ValidationError("send_at: must be in the future"),
+		},
+		{
+			name:    "should error with invalid timezone",
+			request: domainSchedule.CreateScheduleRequest{Type: "text", SendAt: "2099-03-01T09:00:00", Timezone: "Mars/Olympus", Payload: payload},
+			err:     pkgError.ValidationError(`send_at: invalid timezone "Mars/Olympus": unknown time zone Mars/Olympus`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateSchedule(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateListSchedules(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainSchedule.ListScheduleRequest
+		err     any
+	}{
+		{
+			name:    "should success with defaults",
+			request: domainSchedule.ListScheduleRequest{},
+			err:     nil,
+		},
+		{
+			name:    "should success with status filter",
+			request: domainSchedule.ListScheduleRequest{Status: "pending", Limit: 10},
+			err:     nil,
+		},
+		{
+			name:    "should error with unknown status",
+			request: domainSchedule.ListScheduleRequest{Status: "queued"},
+			err:     pkgError.ValidationError("status: must be a valid value."),
+		},
+		{
+			name:    "should error with limit too high",
+			request: domainSchedule.ListScheduleRequest{Limit: 101},
+			err:     pkgError.ValidationError("limit: must be no greater than 100."),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateListSchedules(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateReschedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainSchedule.RescheduleRequest
+		err     any
+	}{
+		{
+			name:    "should success with valid request",
+			request: domainSchedule.RescheduleRequest{ID: "abc", SendAt: "2099-01-01T10:00:00Z"},
+			err:     nil,
+		},
+		{
+			name:    "should error without id",
+			request: domainSchedule.RescheduleRequest{SendAt: "2099-01-01T10:00:00Z"},
+			err:     pkgError.ValidationError("id: cannot be blank."),
+		},
+		{
+			name:    "should error with invalid send_at",
+			request: domainSchedule.RescheduleRequest{ID: "abc", SendAt: "tomorrow"},
+			err:     pkgError.ValidationError(`send_at: invalid time "tomorrow": use RFC3339 or YYYY-MM-DDTHH:MM:SS`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReschedule(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateCancelSchedule(t *testing.T) {
+	err := ValidateCancelSchedule(context.Background(), &domainSchedule.CancelScheduleRequest{})
+	assert.Equal(t, pkgError.ValidationError("id: cannot be blank."), err)
+
+	err = ValidateCancelSchedule(context.Background(), &domainSchedule.CancelScheduleRequest{ID: "abc"})
+	assert.Nil(t, err)
+}
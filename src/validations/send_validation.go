@@ -3,7 +3,10 @@ package validations
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
@@ -37,6 +40,72 @@ func validateDuration(dur *int) error {
 	)
 }
 
+// validateMentions checks that every mention is a phone number in international format or @everyone
+func validateMentions(mentions []string) error {
+	for _, mention := range mentions {
+		if mention == "@everyone" {
+			continue
+		}
+		if err := validatePhoneNumber(mention); err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("mention %s: phone number must be in international format", mention))
+		}
+	}
+	return nil
+}
+
+// validateContactCard checks a structured contact, or only that a raw vCard looks like one
+// vcardTypePattern is the token a vCard type parameter such as CELL or WORK is written as
+var vcardTypePattern = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// vcardTypeRule accepts an empty type or a single vCard token
+var vcardTypeRule = validation.Match(vcardTypePattern).Error("must be a single word such as CELL, HOME or WORK")
+
+func validateContactCard(field string, card domainSend.ContactCard) error {
+	if card.VCard != "" {
+		if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(card.VCard)), "BEGIN:VCARD") {
+			return pkgError.ValidationError(field + ".vcard: must be a vCard starting with BEGIN:VCARD")
+		}
+		return nil
+	}
+
+	if strings.TrimSpace(card.Name) == "" {
+		return pkgError.ValidationError(field + ".name: cannot be blank.")
+	}
+	if len(card.Phones) == 0 && len(card.Emails) == 0 {
+		return pkgError.ValidationError(field + ": at least one phone or email is required")
+	}
+	for i, phone := range card.Phones {
+		if err := validatePhoneNumber(strings.TrimSpace(phone.Number)); err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("%s.phones[%d]: %s", field, i, err.Error()))
+		}
+		if err := validation.Validate(phone.Type, vcardTypeRule); err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("%s.phones[%d].type: %s", field, i, err.Error()))
+		}
+		if err := validation.Validate(phone.WaID, is.Digit); err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("%s.phones[%d].wa_id: %s", field, i, err.Error()))
+		}
+	}
+	for i, email := range card.Emails {
+		if err := validation.Validate(email.Address, validation.Required, is.EmailFormat); err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("%s.emails[%d]: %s", field, i, err.Error()))
+		}
+		if err := validation.Validate(email.Type, vcardTypeRule); err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("%s.emails[%d].type: %s", field, i, err.Error()))
+		}
+	}
+	if card.Address != nil {
+		if err := validation.Validate(card.Address.Type, vcardTypeRule); err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("%s.address.type: %s", field, err.Error()))
+		}
+	}
+	if card.URL != "" {
+		if err := validation.Validate(card.URL, is.URL); err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("%s.url: %s", field, err.Error()))
+		}
+	}
+	return nil
+}
+
 // validatePhoneNumber validates that the phone number is in international format (not starting with 0)
 func validatePhoneNumber(phone string) error {
 	if phone == "" {
@@ -77,15 +146,8 @@ func ValidateSendMessage(ctx context.Context, request domainSend.MessageRequest)
 		return err
 	}
 
-	// Validate mentions if provided
-	for _, mention := range request.Mentions {
-		// Skip validation for special @everyone keyword
-		if mention == "@everyone" {
-			continue
-		}
-		if err := validatePhoneNumber(mention); err != nil {
-			return pkgError.ValidationError(fmt.Sprintf("mention %s: phone number must be in international format", mention))
-		}
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
 	}
 
 	return nil
@@ -137,6 +199,10 @@ func ValidateSendImage(ctx context.Context, request domainSend.ImageRequest) err
 		return err
 	}
 
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -187,11 +253,82 @@ func ValidateSendSticker(ctx context.Context, request domainSend.StickerRequest)
 		}
 	}
 
+	if err := validateStickerMetadata(request.StickerMetadata); err != nil {
+		return err
+	}
+
 	// Validate duration
 	if err := validateDuration(request.Duration); err != nil {
 		return err
 	}
 
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateSendStickerPack(ctx context.Context, request domainSend.StickerPackRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Phone, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if err := validatePhoneNumber(request.Phone); err != nil {
+		return err
+	}
+
+	hasURL := request.PackURL != nil && *request.PackURL != ""
+	if hasURL == (request.Pack != nil) {
+		return pkgError.ValidationError("provide either a pack zip file or a pack_url")
+	}
+
+	if request.Pack != nil {
+		if !strings.EqualFold(filepath.Ext(request.Pack.Filename), ".zip") {
+			return pkgError.ValidationError("pack must be a .zip archive of images")
+		}
+		if request.Pack.Size > config.WhatsappSettingMaxFileSize {
+			return pkgError.ValidationError(fmt.Sprintf("max pack upload is %s", humanize.Bytes(uint64(config.WhatsappSettingMaxFileSize))))
+		}
+	}
+
+	if hasURL {
+		if err := validation.Validate(*request.PackURL, is.URL); err != nil {
+			return pkgError.ValidationError("pack_url must be a valid URL")
+		}
+	}
+
+	if strings.TrimSpace(request.PackName) == "" {
+		return pkgError.ValidationError("pack_name is required for a sticker pack")
+	}
+
+	if err := validateStickerMetadata(request.StickerMetadata); err != nil {
+		return err
+	}
+
+	if err := validateDuration(request.Duration); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// stickerMetadataMaxLength bounds the pack name and publisher shown under a sticker
+const stickerMetadataMaxLength = 128
+
+func validateStickerMetadata(metadata domainSend.StickerMetadata) error {
+	err := validation.ValidateStruct(&metadata,
+		validation.Field(&metadata.PackName, validation.RuneLength(0, stickerMetadataMaxLength)),
+		validation.Field(&metadata.PackPublisher, validation.RuneLength(0, stickerMetadataMaxLength)),
+		validation.Field(&metadata.Emojis, validation.Length(0, domainSend.StickerMaxEmojis), validation.Each(validation.Required, validation.RuneLength(1, 16))),
+	)
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
 	return nil
 }
 
@@ -234,6 +371,10 @@ func ValidateSendFile(ctx context.Context, request domainSend.FileRequest) error
 		return err
 	}
 
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -264,10 +405,11 @@ func ValidateSendVideo(ctx context.Context, request domainSend.VideoRequest) err
 			"video/x-matroska": true,
 			"video/avi":        true,
 			"video/x-msvideo":  true,
+			"image/gif":        true, // converted to MP4 and sent as GIF
 		}
 
 		if !availableMimes[request.Video.Header.Get("Content-Type")] {
-			return pkgError.ValidationError("your video type is not allowed. please use mp4/mkv/avi/x-msvideo")
+			return pkgError.ValidationError("your video type is not allowed. please use mp4/mkv/avi/x-msvideo/gif")
 		}
 
 		if request.Video.Size > config.WhatsappSettingMaxVideoSize { // 30MB
@@ -287,18 +429,73 @@ func ValidateSendVideo(ctx context.Context, request domainSend.VideoRequest) err
 		}
 	}
 
+	if request.PTV && request.GifPlayback {
+		return pkgError.ValidationError("a video can be sent either as GIF (gif_playback) or as video note (ptv), not both")
+	}
+
+	if request.PTV && request.Caption != "" {
+		return pkgError.ValidationError("video notes (ptv) cannot have a caption")
+	}
+
 	if err := validateDuration(request.Duration); err != nil {
 		return err
 	}
 
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateSendAlbum checks the album size and validates every item with the single image/video rules
+func ValidateSendAlbum(ctx context.Context, request domainSend.AlbumRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Phone, validation.Required),
+		validation.Field(&request.Items, validation.Required, validation.Length(domainSend.AlbumMinItems, domainSend.AlbumMaxItems)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if err := validatePhoneNumber(request.Phone); err != nil {
+		return err
+	}
+
+	for i, item := range request.Items {
+		hasURL := item.URL != nil && *item.URL != ""
+		if hasURL == (item.File != nil) {
+			return pkgError.ValidationError(fmt.Sprintf("items[%d]: provide either a file or a url", i))
+		}
+
+		switch item.Type {
+		case domainSend.AlbumItemImage:
+			err = ValidateSendImage(ctx, domainSend.ImageRequest{BaseRequest: request.BaseRequest, Image: item.File, ImageURL: item.URL})
+		case domainSend.AlbumItemVideo:
+			err = ValidateSendVideo(ctx, domainSend.VideoRequest{BaseRequest: request.BaseRequest, Video: item.File, VideoURL: item.URL})
+		default:
+			err = fmt.Errorf("type must be %s or %s", domainSend.AlbumItemImage, domainSend.AlbumItemVideo)
+		}
+		if err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("items[%d]: %s", i, err.Error()))
+		}
+	}
+
 	return nil
 }
 
+// MaxContactsPerMessage caps how many contacts one contacts-array message carries
+const MaxContactsPerMessage = 50
+
 func ValidateSendContact(ctx context.Context, request domainSend.ContactRequest) error {
+	structured := request.Contact != nil || len(request.Contacts) > 0
+	legacy := request.ContactName != "" || request.ContactPhone != "" || !structured
+
 	err := validation.ValidateStructWithContext(ctx, &request,
 		validation.Field(&request.Phone, validation.Required),
-		validation.Field(&request.ContactPhone, validation.Required),
-		validation.Field(&request.ContactName, validation.Required),
+		validation.Field(&request.ContactPhone, validation.When(legacy, validation.Required)),
+		validation.Field(&request.ContactName, validation.When(legacy, validation.Required)),
 	)
 
 	if err != nil {
@@ -311,14 +508,39 @@ func ValidateSendContact(ctx context.Context, request domainSend.ContactRequest)
 	}
 
 	// Custom validation for contact phone number format
-	if err := validatePhoneNumber(request.ContactPhone); err != nil {
-		return pkgError.ValidationError("contact " + err.Error())
+	if legacy {
+		if err := validatePhoneNumber(request.ContactPhone); err != nil {
+			return pkgError.ValidationError("contact " + err.Error())
+		}
+	}
+
+	total := len(request.Contacts)
+	if request.Contact != nil {
+		total++
+		if err := validateContactCard("contact", *request.Contact); err != nil {
+			return err
+		}
+	}
+	if legacy {
+		total++
+	}
+	if total > MaxContactsPerMessage {
+		return pkgError.ValidationError(fmt.Sprintf("contacts: at most %d contacts can be sent in one message", MaxContactsPerMessage))
+	}
+	for i, card := range request.Contacts {
+		if err := validateContactCard(fmt.Sprintf("contacts[%d]", i), card); err != nil {
+			return err
+		}
 	}
 
 	if err := validateDuration(request.Duration); err != nil {
 		return err
 	}
 
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -342,6 +564,10 @@ func ValidateSendLink(ctx context.Context, request domainSend.LinkRequest) error
 		return err
 	}
 
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -365,6 +591,75 @@ func ValidateSendLocation(ctx context.Context, request domainSend.LocationReques
 		return err
 	}
 
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateStartLiveLocation(ctx context.Context, request *domainSend.LiveLocationRequest) error {
+	if request.ShareDuration == 0 {
+		request.ShareDuration = domainSend.LiveLocationDefaultDuration
+	}
+	if request.FeedURL != "" && request.FeedInterval == 0 {
+		request.FeedInterval = domainSend.LiveLocationDefaultFeedInterval
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Phone, validation.Required),
+		validation.Field(&request.ShareDuration, validation.Min(domainSend.LiveLocationMinDuration), validation.Max(domainSend.LiveLocationMaxDuration)),
+		validation.Field(&request.FeedURL, is.URL),
+		validation.Field(&request.FeedInterval, validation.When(request.FeedURL != "",
+			validation.Min(domainSend.LiveLocationMinFeedInterval), validation.Max(request.ShareDuration))),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if err := validatePhoneNumber(request.Phone); err != nil {
+		return err
+	}
+
+	if err := validateLiveLocationPosition(request.LiveLocationPosition); err != nil {
+		return err
+	}
+
+	if err := validateDuration(request.Duration); err != nil {
+		return err
+	}
+
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateUpdateLiveLocation(ctx context.Context, request domainSend.LiveLocationUpdateRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.ShareID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return validateLiveLocationPosition(request.LiveLocationPosition)
+}
+
+func validateLiveLocationPosition(position domainSend.LiveLocationPosition) error {
+	err := validation.ValidateStruct(&position,
+		validation.Field(&position.Latitude, validation.Min(-90.0), validation.Max(90.0)),
+		validation.Field(&position.Longitude, validation.Min(-180.0), validation.Max(180.0)),
+		validation.Field(&position.Heading, validation.Max(uint32(359))),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
 	return nil
 }
 
@@ -440,6 +735,10 @@ func ValidateSendAudio(ctx context.Context, request domainSend.AudioRequest) err
 		return err
 	}
 
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -473,6 +772,10 @@ func ValidateSendPoll(ctx context.Context, request domainSend.PollRequest) error
 		return err
 	}
 
+	if err := validateMentions(request.Mentions); err != nil {
+		return err
+	}
+
 	// validate options should be unique each other
 	uniqueOptions := make(map[string]bool)
 	for _, option := range request.Options {
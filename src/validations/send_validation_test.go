@@ -256,7 +256,7 @@ func TestValidateSendVideo(t *testing.T) {
 				ViewOnce: false,
 				Compress: false,
 			}},
-			err: pkgError.ValidationError("your video type is not allowed. please use mp4/mkv/avi/x-msvideo"),
+			err: pkgError.ValidationError("your video type is not allowed. please use mp4/mkv/avi/x-msvideo/gif"),
 		},
 		{
 			name: "should error with empty video and video_url",
@@ -272,6 +272,44 @@ func TestValidateSendVideo(t *testing.T) {
 			}},
 			err: pkgError.ValidationError("either Video or VideoURL must be provided"),
 		},
+		{
+			name: "should success with gif upload",
+			args: args{request: domainSend.VideoRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Video: &multipart.FileHeader{
+					Filename: "funny.gif",
+					Size:     100,
+					Header:   map[string][]string{"Content-Type": {"image/gif"}},
+				},
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with gif playback and ptv",
+			args: args{request: domainSend.VideoRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Video:       file,
+				GifPlayback: true,
+				PTV:         true,
+			}},
+			err: pkgError.ValidationError("a video can be sent either as GIF (gif_playback) or as video note (ptv), not both"),
+		},
+		{
+			name: "should error with ptv caption",
+			args: args{request: domainSend.VideoRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Caption: "simple caption",
+				Video:   file,
+				PTV:     true,
+			}},
+			err: pkgError.ValidationError("video notes (ptv) cannot have a caption"),
+		},
 		{
 			name: "should success with video_url provided",
 			args: args{request: domainSend.VideoRequest{
@@ -510,6 +548,119 @@ func TestValidateSendContact(t *testing.T) {
 			}},
 			err: pkgError.ValidationError("contact_name: cannot be blank."),
 		},
+		{
+			name: "should success with reply and mentions",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone:    "1728937129312@s.whatsapp.net",
+					Mentions: []string{"628123456789", "@everyone"},
+				},
+				ContactName:  "Aldino",
+				ContactPhone: "62788712738123",
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with local format mention",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone:    "1728937129312@s.whatsapp.net",
+					Mentions: []string{"08123456789"},
+				},
+				ContactName:  "Aldino",
+				ContactPhone: "62788712738123",
+			}},
+			err: pkgError.ValidationError("mention 08123456789: phone number must be in international format"),
+		},
+		{
+			name: "should success with contact cards only",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Contacts: []domainSend.ContactCard{
+					{
+						Name:   "Aldino",
+						Phones: []domainSend.ContactPhone{{Number: "+62788712738123", Type: "WORK"}},
+						Emails: []domainSend.ContactEmail{{Address: "aldino@example.com"}},
+						URL:    "https://example.com",
+					},
+					{VCard: "BEGIN:VCARD\nVERSION:3.0\nFN:Budi\nEND:VCARD"},
+				},
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with contact card without name",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Contact: &domainSend.ContactCard{Phones: []domainSend.ContactPhone{{Number: "62788712738123"}}},
+			}},
+			err: pkgError.ValidationError("contact.name: cannot be blank."),
+		},
+		{
+			name: "should error with local format phone in contact card",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Contacts: []domainSend.ContactCard{{Name: "Aldino", Phones: []domainSend.ContactPhone{{Number: "0812345678"}}}},
+			}},
+			err: pkgError.ValidationError("contacts[0].phones[0]: phone number must be in international format (should not start with 0). For Indonesian numbers, use 62xxx format instead of 08xxx"),
+		},
+		{
+			name: "should error with invalid email in contact card",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Contacts: []domainSend.ContactCard{{Name: "Aldino", Emails: []domainSend.ContactEmail{{Address: "not-an-email"}}}},
+			}},
+			err: pkgError.ValidationError("contacts[0].emails[0]: must be a valid email address"),
+		},
+		{
+			name: "should error with a phone type that is not a vcard token",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Contacts: []domainSend.ContactCard{{Name: "Aldino", Phones: []domainSend.ContactPhone{{Number: "62788712738123", Type: "CELL:+1\nFN:x"}}}},
+			}},
+			err: pkgError.ValidationError("contacts[0].phones[0].type: must be a single word such as CELL, HOME or WORK"),
+		},
+		{
+			name: "should error with a wa_id that is not digits",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Contacts: []domainSend.ContactCard{{Name: "Aldino", Phones: []domainSend.ContactPhone{{Number: "62788712738123", WaID: "627;x"}}}},
+			}},
+			err: pkgError.ValidationError("contacts[0].phones[0].wa_id: must contain digits only"),
+		},
+		{
+			name: "should error with an address type that is not a vcard token",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Contact: &domainSend.ContactCard{Name: "Aldino", Phones: []domainSend.ContactPhone{{Number: "62788712738123"}},
+					Address: &domainSend.ContactAddress{City: "Jakarta", Type: "home work"}},
+			}},
+			err: pkgError.ValidationError("contact.address.type: must be a single word such as CELL, HOME or WORK"),
+		},
+		{
+			name: "should error with raw vcard that is not a vcard",
+			args: args{request: domainSend.ContactRequest{
+				BaseRequest: domainSend.BaseRequest{
+					Phone: "1728937129312@s.whatsapp.net",
+				},
+				Contacts: []domainSend.ContactCard{{VCard: "hello"}},
+			}},
+			err: pkgError.ValidationError("contacts[0].vcard: must be a vCard starting with BEGIN:VCARD"),
+		},
 		{
 			name: "should error with empty contact phone",
 			args: args{request: domainSend.ContactRequest{
@@ -616,6 +767,147 @@ func TestValidateSendLocation(t *testing.T) {
 	}
 }
 
+func TestValidateStartLiveLocation(t *testing.T) {
+	type args struct {
+		request domainSend.LiveLocationRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with default duration",
+			args: args{request: domainSend.LiveLocationRequest{
+				BaseRequest:          domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.797068, Longitude: 110.370529},
+			}},
+			err: nil,
+		},
+		{
+			name: "should success with feed",
+			args: args{request: domainSend.LiveLocationRequest{
+				BaseRequest:          domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.797068, Longitude: 110.370529},
+				FeedURL:              "https://tracker.example.com/position",
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty phone",
+			args: args{request: domainSend.LiveLocationRequest{
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.797068, Longitude: 110.370529},
+			}},
+			err: pkgError.ValidationError("phone: cannot be blank."),
+		},
+		{
+			name: "should error with latitude out of range",
+			args: args{request: domainSend.LiveLocationRequest{
+				BaseRequest:          domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: 91, Longitude: 110.370529},
+			}},
+			err: pkgError.ValidationError("latitude: must be no greater than 90."),
+		},
+		{
+			name: "should error with too long share duration",
+			args: args{request: domainSend.LiveLocationRequest{
+				BaseRequest:          domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.797068, Longitude: 110.370529},
+				ShareDuration:        domainSend.LiveLocationMaxDuration + 1,
+			}},
+			err: pkgError.ValidationError("share_duration: must be no greater than 28800."),
+		},
+		{
+			name: "should error with too short feed interval",
+			args: args{request: domainSend.LiveLocationRequest{
+				BaseRequest:          domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.797068, Longitude: 110.370529},
+				FeedURL:              "https://tracker.example.com/position",
+				FeedInterval:         1,
+			}},
+			err: pkgError.ValidationError("feed_interval: must be no less than 5."),
+		},
+		{
+			name: "should error with invalid feed url",
+			args: args{request: domainSend.LiveLocationRequest{
+				BaseRequest:          domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.797068, Longitude: 110.370529},
+				FeedURL:              "not a url",
+			}},
+			err: pkgError.ValidationError("feed_url: must be a valid URL."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStartLiveLocation(context.Background(), &tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateStartLiveLocationDefaults(t *testing.T) {
+	request := domainSend.LiveLocationRequest{
+		BaseRequest: domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+		FeedURL:     "https://tracker.example.com/position",
+	}
+
+	err := ValidateStartLiveLocation(context.Background(), &request)
+	assert.NoError(t, err)
+	assert.Equal(t, domainSend.LiveLocationDefaultDuration, request.ShareDuration)
+	assert.Equal(t, domainSend.LiveLocationDefaultFeedInterval, request.FeedInterval)
+}
+
+func TestValidateUpdateLiveLocation(t *testing.T) {
+	type args struct {
+		request domainSend.LiveLocationUpdateRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success normal condition",
+			args: args{request: domainSend.LiveLocationUpdateRequest{
+				ShareID:              "3EB0C127D7BACC83D6A3",
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.8, Longitude: 110.37, Heading: 180},
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty share id",
+			args: args{request: domainSend.LiveLocationUpdateRequest{
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.8, Longitude: 110.37},
+			}},
+			err: pkgError.ValidationError("share_id: cannot be blank."),
+		},
+		{
+			name: "should error with invalid heading",
+			args: args{request: domainSend.LiveLocationUpdateRequest{
+				ShareID:              "3EB0C127D7BACC83D6A3",
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.8, Longitude: 110.37, Heading: 360},
+			}},
+			err: pkgError.ValidationError("heading: must be no greater than 359."),
+		},
+		{
+			name: "should error with longitude out of range",
+			args: args{request: domainSend.LiveLocationUpdateRequest{
+				ShareID:              "3EB0C127D7BACC83D6A3",
+				LiveLocationPosition: domainSend.LiveLocationPosition{Latitude: -7.8, Longitude: -181},
+			}},
+			err: pkgError.ValidationError("longitude: must be no less than -180."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUpdateLiveLocation(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
 func TestValidateSendAudio(t *testing.T) {
 	audio := &multipart.FileHeader{
 		Filename: "sample-audio.mp3",
@@ -1111,3 +1403,156 @@ func TestValidateSendAudio_WithDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSendAlbum(t *testing.T) {
+	image := &multipart.FileHeader{
+		Filename: "sample-image.png",
+		Size:     100,
+		Header:   map[string][]string{"Content-Type": {"image/png"}},
+	}
+	videoURL := "https://example.com/sample.mp4"
+	invalidURL := "not a url"
+
+	tests := []struct {
+		name    string
+		request domainSend.AlbumRequest
+		err     any
+	}{
+		{
+			name: "should success with mixed uploads and urls",
+			request: domainSend.AlbumRequest{
+				BaseRequest: domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				Items: []domainSend.AlbumItem{
+					{Type: domainSend.AlbumItemImage, File: image, Caption: "first"},
+					{Type: domainSend.AlbumItemVideo, URL: &videoURL},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "should error with a single item",
+			request: domainSend.AlbumRequest{
+				BaseRequest: domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				Items:       []domainSend.AlbumItem{{Type: domainSend.AlbumItemImage, File: image}},
+			},
+			err: pkgError.ValidationError("items: the length must be between 2 and 30."),
+		},
+		{
+			name: "should error with unknown item type",
+			request: domainSend.AlbumRequest{
+				BaseRequest: domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				Items: []domainSend.AlbumItem{
+					{Type: domainSend.AlbumItemImage, File: image},
+					{Type: "audio", URL: &videoURL},
+				},
+			},
+			err: pkgError.ValidationError("items[1]: type must be image or video"),
+		},
+		{
+			name: "should error when item has both file and url",
+			request: domainSend.AlbumRequest{
+				BaseRequest: domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				Items: []domainSend.AlbumItem{
+					{Type: domainSend.AlbumItemImage, File: image, URL: &videoURL},
+					{Type: domainSend.AlbumItemImage, File: image},
+				},
+			},
+			err: pkgError.ValidationError("items[0]: provide either a file or a url"),
+		},
+		{
+			name: "should error with invalid item url",
+			request: domainSend.AlbumRequest{
+				BaseRequest: domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				Items: []domainSend.AlbumItem{
+					{Type: domainSend.AlbumItemImage, File: image},
+					{Type: domainSend.AlbumItemVideo, URL: &invalidURL},
+				},
+			},
+			err: pkgError.ValidationError("items[1]: VideoURL must be a valid URL"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSendAlbum(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSendStickerPack(t *testing.T) {
+	pack := &multipart.FileHeader{
+		Filename: "cats.zip",
+		Size:     100,
+		Header:   map[string][]string{"Content-Type": {"application/zip"}},
+	}
+	packURL := "https://example.com/cats.zip"
+
+	tests := []struct {
+		name    string
+		request domainSend.StickerPackRequest
+		err     any
+	}{
+		{
+			name: "should success with pack file",
+			request: domainSend.StickerPackRequest{
+				BaseRequest:     domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				StickerMetadata: domainSend.StickerMetadata{PackName: "Cats", PackPublisher: "Kemal", Emojis: []string{"😺"}},
+				Pack:            pack,
+			},
+			err: nil,
+		},
+		{
+			name: "should success with pack url",
+			request: domainSend.StickerPackRequest{
+				BaseRequest:     domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				StickerMetadata: domainSend.StickerMetadata{PackName: "Cats"},
+				PackURL:         &packURL,
+			},
+			err: nil,
+		},
+		{
+			name: "should error with both file and url",
+			request: domainSend.StickerPackRequest{
+				BaseRequest:     domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				StickerMetadata: domainSend.StickerMetadata{PackName: "Cats"},
+				Pack:            pack,
+				PackURL:         &packURL,
+			},
+			err: pkgError.ValidationError("provide either a pack zip file or a pack_url"),
+		},
+		{
+			name: "should error with a non zip file",
+			request: domainSend.StickerPackRequest{
+				BaseRequest:     domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				StickerMetadata: domainSend.StickerMetadata{PackName: "Cats"},
+				Pack:            &multipart.FileHeader{Filename: "cats.rar", Size: 100},
+			},
+			err: pkgError.ValidationError("pack must be a .zip archive of images"),
+		},
+		{
+			name: "should error without pack name",
+			request: domainSend.StickerPackRequest{
+				BaseRequest: domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				Pack:        pack,
+			},
+			err: pkgError.ValidationError("pack_name is required for a sticker pack"),
+		},
+		{
+			name: "should error with too many emojis",
+			request: domainSend.StickerPackRequest{
+				BaseRequest:     domainSend.BaseRequest{Phone: "1728937129312@s.whatsapp.net"},
+				StickerMetadata: domainSend.StickerMetadata{PackName: "Cats", Emojis: []string{"😺", "🐱", "😸", "😹"}},
+				Pack:            pack,
+			},
+			err: pkgError.ValidationError("emojis: the length must be no more than 3."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSendStickerPack(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package validations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// maxStatusTextLength is the longest text status the phone app lets you type
+const maxStatusTextLength = 700
+
+func validateStatusColor(value any) error {
+	s, _ := value.(string)
+	if s == "" {
+		return nil
+	}
+	if _, err := utils.ParseARGBColor(s); err != nil {
+		return fmt.Errorf("must be a hex colour like #25D366")
+	}
+	return nil
+}
+
+func validateStatusFont(value any) error {
+	s, _ := value.(string)
+	if s == "" {
+		return nil
+	}
+	if _, ok := waE2E.ExtendedTextMessage_FontType_value[s]; !ok {
+		return fmt.Errorf("must be one of SYSTEM, SYSTEM_TEXT, FB_SCRIPT, SYSTEM_BOLD, MORNINGBREEZE_REGULAR, CALISTOGA_REGULAR, EXO2_EXTRABOLD, COURIERPRIME_BOLD")
+	}
+	return nil
+}
+
+func ValidatePostTextStatus(ctx context.Context, request *domainStatus.PostTextRequest) error {
+	request.Font = strings.ToUpper(strings.TrimSpace(request.Font))
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Text, validation.Required, validation.RuneLength(1, maxStatusTextLength)),
+		validation.Field(&request.BackgroundColor, validation.By(validateStatusColor)),
+		validation.Field(&request.TextColor, validation.By(validateStatusColor)),
+		validation.Field(&request.Font, validation.By(validateStatusFont)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+// ValidatePostImageStatus applies the /send/image rules to a status image
+func ValidatePostImageStatus(ctx context.Context, request domainStatus.PostImageRequest) error {
+	return ValidateSendImage(ctx, domainSend.ImageRequest{
+		BaseRequest: domainSend.BaseRequest{Phone: types.StatusBroadcastJID.String()},
+		Caption:     request.Caption,
+		Image:       request.Image,
+		ImageURL:    request.ImageURL,
+	})
+}
+
+// ValidatePostVideoStatus applies the /send/video rules to a status video
+func ValidatePostVideoStatus(ctx context.Context, request domainStatus.PostVideoRequest) error {
+	return ValidateSendVideo(ctx, domainSend.VideoRequest{
+		BaseRequest: domainSend.BaseRequest{Phone: types.StatusBroadcastJID.String()},
+		Caption:     request.Caption,
+		Video:       request.Video,
+		VideoURL:    request.VideoURL,
+	})
+}
+
+func ValidateListStatuses(ctx context.Context, request *domainStatus.ListStatusesRequest) error {
+	if request.Limit == 0 {
+		request.Limit = 50
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Limit, validation.Min(1), validation.Max(200)),
+		validation.Field(&request.Offset, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
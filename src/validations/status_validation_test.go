@@ -0,0 +1,60 @@
+package validations
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePostTextStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainStatus.PostTextRequest
+		err     any
+	}{
+		{
+			name:    "should success with colours and font",
+			request: domainStatus.PostTextRequest{Text: "Open today", BackgroundColor: "#25D366", TextColor: "#FFFFFFFF", Font: "system_bold"},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty text",
+			request: domainStatus.PostTextRequest{},
+			err:     pkgError.ValidationError("text: cannot be blank."),
+		},
+		{
+			name:    "should error with too long text",
+			request: domainStatus.PostTextRequest{Text: strings.Repeat("a", 701)},
+			err:     pkgError.ValidationError("text: the length must be between 1 and 700."),
+		},
+		{
+			name:    "should error with invalid colour",
+			request: domainStatus.PostTextRequest{Text: "Hi", BackgroundColor: "green"},
+			err:     pkgError.ValidationError("background_color: must be a hex colour like #25D366."),
+		},
+		{
+			name:    "should error with unknown font",
+			request: domainStatus.PostTextRequest{Text: "Hi", Font: "comic"},
+			err:     pkgError.ValidationError("font: must be one of SYSTEM, SYSTEM_TEXT, FB_SCRIPT, SYSTEM_BOLD, MORNINGBREEZE_REGULAR, CALISTOGA_REGULAR, EXO2_EXTRABOLD, COURIERPRIME_BOLD."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePostTextStatus(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidatePostImageStatus(t *testing.T) {
+	err := ValidatePostImageStatus(context.Background(), domainStatus.PostImageRequest{})
+	assert.Equal(t, pkgError.ValidationError("either Image or ImageURL must be provided"), err)
+
+	imageURL := "https://example.com/menu.jpg"
+	assert.NoError(t, ValidatePostImageStatus(context.Background(), domainStatus.PostImageRequest{ImageURL: &imageURL}))
+}
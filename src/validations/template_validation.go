@@ -0,0 +1,51 @@
+package validations
+
+import (
+	"context"
+	"strings"
+
+	domainTemplate "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/template"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+var templateMediaTypes = []any{
+	domainTemplate.MediaImage, domainTemplate.MediaVideo, domainTemplate.MediaFile,
+	domainTemplate.MediaAudio, domainTemplate.MediaSticker,
+}
+
+func ValidateCreateTemplate(ctx context.Context, request *domainTemplate.CreateTemplateRequest) error {
+	request.Name = strings.TrimSpace(request.Name)
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&request.Body, validation.When(request.MediaURL == "", validation.Required)),
+		validation.Field(&request.MediaType, validation.In(templateMediaTypes...), validation.When(request.MediaURL != "", validation.Required)),
+		validation.Field(&request.MediaURL, is.URL, validation.When(request.MediaType != "", validation.Required)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateUpdateTemplate(ctx context.Context, request *domainTemplate.UpdateTemplateRequest) error {
+	request.Name = strings.TrimSpace(request.Name)
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ID, validation.Required),
+		validation.Field(&request.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&request.Body, validation.When(request.MediaURL == "", validation.Required)),
+		validation.Field(&request.MediaType, validation.In(templateMediaTypes...), validation.When(request.MediaURL != "", validation.Required)),
+		validation.Field(&request.MediaURL, is.URL, validation.When(request.MediaType != "", validation.Required)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package validations
+
+import (
+	"context"
+	"testing"
+
+	domainTemplate "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/template"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCreateTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainTemplate.CreateTemplateRequest
+		err     any
+	}{
+		{
+			name:    "should success with text body",
+			request: domainTemplate.CreateTemplateRequest{Name: "greeting", Body: "Hi {{name}}"},
+			err:     nil,
+		},
+		{
+			name: "should success with media and no body",
+			request: domainTemplate.CreateTemplateRequest{
+				Name: "promo", MediaType: "image", MediaURL: "https://example.com/promo.jpg", Caption: "Promo for {{name}}",
+			},
+			err: nil,
+		},
+		{
+			name:    "should error without name",
+			request: domainTemplate.CreateTemplateRequest{Name: "  ", Body: "Hi"},
+			err:     pkgError.ValidationError("name: cannot be blank."),
+		},
+		{
+			name:    "should error without body or media",
+			request: domainTemplate.CreateTemplateRequest{Name: "empty"},
+			err:     pkgError.ValidationError("body: cannot be blank."),
+		},
+		{
+			name:    "should error with media url but no media type",
+			request: domainTemplate.CreateTemplateRequest{Name: "promo", MediaURL: "https://example.com/promo.jpg"},
+			err:     pkgError.ValidationError("media_type: cannot be blank."),
+		},
+		{
+			name:    "should error with unknown media type",
+			request: domainTemplate.CreateTemplateRequest{Name: "promo", MediaType: "gif", MediaURL: "https://example.com/promo.gif"},
+			err:     pkgError.ValidationError("media_type: must be a valid value."),
+		},
+		{
+			name:    "should error with invalid media url",
+			request: domainTemplate.CreateTemplateRequest{Name: "promo", MediaType: "image", MediaURL: "not a url"},
+			err:     pkgError.ValidationError("media_url: must be a valid URL."),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateTemplate(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateUpdateTemplate(t *testing.T) {
+	assert.NoError(t, ValidateUpdateTemplate(context.Background(), &domainTemplate.UpdateTemplateRequest{ID: "abc", Name: "greeting", Body: "Hi"}))
+	assert.Equal(t, pkgError.ValidationError("id: cannot be blank."),
+		ValidateUpdateTemplate(context.Background(), &domainTemplate.UpdateTemplateRequest{Name: "greeting", Body: "Hi"}))
+}
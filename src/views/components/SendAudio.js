@@ -15,6 +15,7 @@ export default {
             audio_url: null,
             duration: 0,
             ptt: false,
+            view_once: false,
         }
     },
     computed: {
@@ -61,6 +62,7 @@ export default {
                 payload.append("phone", this.phone_id)
                 payload.append("is_forwarded", this.is_forwarded)
                 payload.append("ptt", this.ptt)
+                payload.append("view_once", this.view_once)
                 if (this.duration && this.duration > 0) {
                     payload.append("duration", this.duration)
                 }
@@ -92,6 +94,7 @@ export default {
             this.is_forwarded = false;
             this.duration = 0;
             this.ptt = false;
+            this.view_once = false;
             $("#file_audio").val('');
             this.selectedFileName = null;
             this.audio_url = null;
@@ -137,6 +140,13 @@ export default {
                         <label>Send as voice note (required for OGG/Opus files)</label>
                     </div>
                 </div>
+                <div class="field">
+                    <label>View Once</label>
+                    <div class="ui toggle checkbox">
+                        <input type="checkbox" aria-label="view once" v-model="view_once">
+                        <label>Check for enable one time play</label>
+                    </div>
+                </div>
                 <div class="field">
                     <label>Disappearing Duration (seconds)</label>
                     <input v-model.number="duration" type="number" min="0" placeholder="0 (no expiry)" aria-label="duration"/>
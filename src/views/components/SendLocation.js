@@ -11,6 +11,8 @@ export default {
             phone: '',
             latitude: '',
             longitude: '',
+            name: '',
+            address: '',
             loading: false,
             is_forwarded: false,
             duration: 0
@@ -64,6 +66,8 @@ export default {
                     phone: this.phone_id,
                     latitude: this.latitude,
                     longitude: this.longitude,
+                    ...(this.name.trim() ? {name: this.name.trim()} : {}),
+                    ...(this.address.trim() ? {address: this.address.trim()} : {}),
                     is_forwarded: this.is_forwarded,
                     ...(this.duration && this.duration > 0 ? {duration: this.duration} : {})
                 };
@@ -84,6 +88,8 @@ export default {
             this.phone = '';
             this.latitude = '';
             this.longitude = '';
+            this.name = '';
+            this.address = '';
             this.type = window.TYPEUSER;
             this.is_forwarded = false;
             this.duration = 0;
@@ -120,6 +126,16 @@ export default {
                     <input v-model="longitude" type="text" placeholder="Please enter longitude (-180 to 180)"
                            aria-label="longitude">
                 </div>
+                <div class="field">
+                    <label>Place Name (optional)</label>
+                    <input v-model="name" type="text" placeholder="e.g. Tugu Yogyakarta"
+                           aria-label="place name">
+                </div>
+                <div class="field">
+                    <label>Address (optional)</label>
+                    <input v-model="address" type="text" placeholder="Street address shown under the name"
+                           aria-label="address">
+                </div>
                 <div class="field" v-if="isShowAttributes()">
                     <label>Is Forwarded</label>
                     <div class="ui toggle checkbox">
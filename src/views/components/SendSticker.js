@@ -14,7 +14,10 @@ export default {
             sticker_url: null,
             preview_url: null,
             is_forwarded: false,
-            duration: 0
+            duration: 0,
+            pack_name: '',
+            pack_publisher: '',
+            emojis: ''
         }
     },
     computed: {
@@ -75,6 +78,14 @@ export default {
                 if (this.sticker_url) {
                     payload.append('sticker_url', this.sticker_url)
                 }
+                if (this.pack_name.trim()) {
+                    payload.append('pack_name', this.pack_name.trim())
+                }
+                if (this.pack_publisher.trim()) {
+                    payload.append('pack_publisher', this.pack_publisher.trim())
+                }
+                // Emojis are typed separated by spaces and sent as repeated fields
+                this.emojis.split(/\s+/).filter(emoji => emoji).forEach(emoji => payload.append('emojis', emoji))
                 
                 let response = await window.http.post(`/send/sticker`, payload)
                 this.handleReset();
@@ -95,6 +106,9 @@ export default {
             this.sticker_url = null;
             this.is_forwarded = false;
             this.duration = 0;
+            this.pack_name = '';
+            this.pack_publisher = '';
+            this.emojis = '';
             $("#file_sticker").val('');
         },
         handleStickerChange(event) {
@@ -151,6 +165,20 @@ export default {
                         <label>Mark sticker as forwarded</label>
                     </div>
                 </div>
+                <div class="two fields">
+                    <div class="field">
+                        <label>Pack Name</label>
+                        <input type="text" v-model="pack_name" placeholder="Shown under the sticker (optional)" aria-label="pack name">
+                    </div>
+                    <div class="field">
+                        <label>Pack Publisher</label>
+                        <input type="text" v-model="pack_publisher" placeholder="Optional" aria-label="pack publisher">
+                    </div>
+                </div>
+                <div class="field">
+                    <label>Emojis</label>
+                    <input type="text" v-model="emojis" placeholder="Up to 3, separated by spaces (optional)" aria-label="emojis">
+                </div>
                 <div class="field">
                     <label>Disappearing Duration (seconds)</label>
                     <input v-model.number="duration" type="number" min="0" placeholder="0 (no expiry)" aria-label="duration"/>
@@ -16,6 +16,8 @@ export default {
             caption: '',
             view_once: false,
             compress: false,
+            gif_playback: false,
+            ptv: false,
             type: window.TYPEUSER,
             phone: '',
             loading: false,
@@ -94,6 +96,8 @@ export default {
                 payload.append("caption", this.caption.trim())
                 payload.append("view_once", this.view_once)
                 payload.append("compress", this.compress)
+                payload.append("gif_playback", this.gif_playback)
+                payload.append("ptv", this.ptv)
                 payload.append("is_forwarded", this.is_forwarded)
                 if (this.duration && this.duration > 0) {
                     payload.append("duration", this.duration)
@@ -123,6 +127,8 @@ export default {
             this.caption = '';
             this.view_once = false;
             this.compress = false;
+            this.gif_playback = false;
+            this.ptv = false;
             this.phone = '';
             this.selectedFileName = null;
             this.video_url = null;
@@ -180,6 +186,20 @@ export default {
                         <label>Check for compressing video to smaller size</label>
                     </div>
                 </div>
+                <div class="field" v-if="isShowAttributes() && !ptv">
+                    <label>GIF</label>
+                    <div class="ui toggle checkbox">
+                        <input type="checkbox" aria-label="gif playback" v-model="gif_playback">
+                        <label>Send as looping GIF without sound (.gif files always are)</label>
+                    </div>
+                </div>
+                <div class="field" v-if="isShowAttributes() && !gif_playback">
+                    <label>Video Note</label>
+                    <div class="ui toggle checkbox">
+                        <input type="checkbox" aria-label="video note" v-model="ptv">
+                        <label>Send as round video note (no caption)</label>
+                    </div>
+                </div>
                 <div class="field" v-if="isShowAttributes() && !view_once">
                     <label>Is Forwarded</label>
                     <div class="ui toggle checkbox">
@@ -199,7 +219,7 @@ export default {
                 <div style="text-align: left; font-weight: bold; margin: 10px 0;" v-if="!video_url">or you can upload video from your device</div>
                 <div class="field" style="padding-bottom: 30px" v-if="!video_url">
                     <label>Video</label>
-                    <input type="file" style="display: none" accept="video/*" id="file_video" @change="handleFileChange">
+                    <input type="file" style="display: none" accept="video/*,image/gif" id="file_video" @change="handleFileChange">
                     <label for="file_video" class="ui positive medium green left floated button" style="color: white">
                         <i class="ui upload icon"></i>
                         Upload video